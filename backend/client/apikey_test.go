@@ -0,0 +1,68 @@
+package client_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/client"
+)
+
+func TestAPIKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	session := mustClient(t)
+	account := newAccount(t, session)
+
+	key, err := session.CreateAPIKey(ctx, client.CreateAPIKeyRequest{
+		Name: "ci", Scopes: []string{client.APIKeyScopeRead}, ExpiresInDays: 30,
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key.Key, "mig_"))
+	assert.True(t, strings.HasPrefix(key.Key, key.Prefix))
+
+	script := mustClient(t, client.WithAPIKey(key.Key))
+
+	t.Run("should authenticate with the key", func(t *testing.T) {
+		me, err := script.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, account.Email, me.Email)
+	})
+
+	t.Run("should list the key with its last use but without the key", func(t *testing.T) {
+		keys, err := session.ListAPIKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, key.ID, keys[0].ID)
+		assert.Equal(t, key.Prefix, keys[0].Prefix)
+		assert.NotNil(t, keys[0].LastUsedAt)
+	})
+
+	t.Run("should limit a read key to safe methods", func(t *testing.T) {
+		_, err := script.UpdateProfile(ctx, client.UpdateProfileRequest{Name: "Renamed"})
+		assert.True(t, client.IsProblem(err, client.ProblemInsufficientScope), err)
+	})
+
+	t.Run("should not mint keys with a key", func(t *testing.T) {
+		writer, err := session.CreateAPIKey(ctx, client.CreateAPIKeyRequest{
+			Name: "writer", Scopes: []string{client.APIKeyScopeWrite}, ExpiresInDays: 1,
+		})
+		require.NoError(t, err)
+
+		_, err = mustClient(t, client.WithAPIKey(writer.Key)).CreateAPIKey(ctx, client.CreateAPIKeyRequest{
+			Name: "escalated", Scopes: []string{client.APIKeyScopeWrite}, ExpiresInDays: 365,
+		})
+		assert.True(t, client.IsProblem(err, client.ProblemSessionRequired), err)
+	})
+
+	t.Run("should reject the key once revoked", func(t *testing.T) {
+		require.NoError(t, session.RevokeAPIKey(ctx, key.ID))
+
+		_, err := script.Me(ctx)
+		assert.True(t, client.IsProblem(err, client.ProblemUnauthorized), err)
+	})
+}
@@ -0,0 +1,409 @@
+// Package client is a typed Go client for the migos API.
+//
+// By default it keeps the session in a cookie jar, like a browser, and
+// sends the CSRF token of the session along. With WithBearerAuth it sends the access token in the Authorization header
+// instead. In both modes a request rejected with 401 is retried once after
+// refreshing the session. With WithAPIKey it authenticates with an API key
+// and never refreshes. Service accounts sign in with ClientCredentials in
+// bearer mode.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	csrfCookie = "csrf_token"
+	csrfHeader = "X-CSRF-Token"
+)
+
+// Client calls the migos API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	bearer     bool
+	apiKey     string
+	onTokens   func(Tokens)
+
+	mu     sync.Mutex
+	tokens Tokens
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the underlying HTTP client. In cookie mode a jar is
+// added to a copy of it when it has none.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBearerAuth authenticates with "Authorization: Bearer" instead of
+// cookies, for services that store the tokens themselves.
+func WithBearerAuth() Option {
+	return func(c *Client) {
+		c.bearer = true
+	}
+}
+
+// WithAPIKey authenticates with an API key ("mig_..."), for scripts and CI.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.bearer = true
+		c.apiKey = key
+	}
+}
+
+// WithTokens starts the client with previously issued tokens, for bearer
+// mode.
+func WithTokens(tokens Tokens) Option {
+	return func(c *Client) {
+		c.tokens = tokens
+	}
+}
+
+// WithTokenCallback calls fn whenever the client receives new tokens, so
+// they can be persisted.
+func WithTokenCallback(fn func(Tokens)) Option {
+	return func(c *Client) {
+		c.onTokens = fn
+	}
+}
+
+// New returns a client for the API at baseURL, e.g. "https://auth.example.com".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if !c.bearer && c.httpClient.Jar == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cookie jar: %w", err)
+		}
+		httpClient := *c.httpClient
+		httpClient.Jar = jar
+		c.httpClient = &httpClient
+	}
+
+	return c, nil
+}
+
+// Tokens returns the tokens of the current session, if any.
+func (c *Client) Tokens() Tokens {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens
+}
+
+func (c *Client) CreateAccount(ctx context.Context, req CreateAccountRequest) (*Tokens, error) {
+	return c.startSession(ctx, http.MethodPost, "/v1/user/create-account", req)
+}
+
+func (c *Client) Login(ctx context.Context, req LoginRequest) (*Tokens, error) {
+	return c.startSession(ctx, http.MethodPost, "/v1/auth/login", req)
+}
+
+// ChangeExpiredPassword sets a new password with the challenge token of a
+// ProblemPasswordChangeRequired returned by Login, and signs in.
+func (c *Client) ChangeExpiredPassword(ctx context.Context, req ChangeExpiredPasswordRequest) (*Tokens, error) {
+	return c.startSession(ctx, http.MethodPost, "/v1/auth/password-change", req)
+}
+
+// Reactivate reactivates a deactivated account and signs in.
+func (c *Client) Reactivate(ctx context.Context, req LoginRequest) (*Tokens, error) {
+	return c.startSession(ctx, http.MethodPatch, "/v1/user/reactivate", req)
+}
+
+// Refresh rotates the session tokens.
+func (c *Client) Refresh(ctx context.Context) (*Tokens, error) {
+	var body any
+	if c.bearer {
+		body = refreshRequest{RefreshToken: c.Tokens().RefreshToken}
+	}
+	return c.startSession(ctx, http.MethodPost, "/v1/auth/refresh", body)
+}
+
+func (c *Client) Logout(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodPost, "/v1/auth/logout", nil, nil, true); err != nil {
+		return err
+	}
+	c.setTokens(Tokens{})
+	return nil
+}
+
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.do(ctx, http.MethodGet, "/v1/auth/me", nil, &user, true); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (c *Client) UpdateProfile(ctx context.Context, req UpdateProfileRequest) (*User, error) {
+	var user User
+	if err := c.do(ctx, http.MethodPatch, "/v1/user/profile", req, &user, true); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (c *Client) UpdatePassword(ctx context.Context, req UpdatePasswordRequest) error {
+	return c.do(ctx, http.MethodPatch, "/v1/user/password", req, nil, true)
+}
+
+// DeleteAccount deactivates the signed in account and ends the session.
+func (c *Client) DeleteAccount(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodDelete, "/v1/user", nil, nil, true); err != nil {
+		return err
+	}
+	c.setTokens(Tokens{})
+	return nil
+}
+
+// ListSessions lists the sessions of the signed in user; Current marks the
+// one this client holds.
+func (c *Client) ListSessions(ctx context.Context) ([]Session, error) {
+	var response struct {
+		Sessions []Session `json:"sessions"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v1/auth/sessions", nil, &response, true); err != nil {
+		return nil, err
+	}
+	return response.Sessions, nil
+}
+
+// RevokeSession signs out the browser or device holding the session. Use
+// Logout for the current one.
+func (c *Client) RevokeSession(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v1/auth/sessions/"+url.PathEscape(id), nil, nil, true)
+}
+
+// CreateAPIKey mints an API key for the signed in user. The key is only
+// returned here; it needs a session, not another API key.
+func (c *Client) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreatedAPIKey, error) {
+	var key CreatedAPIKey
+	if err := c.do(ctx, http.MethodPost, "/v1/auth/api-keys", req, &key, true); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (c *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var response struct {
+		APIKeys []APIKey `json:"api_keys"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v1/auth/api-keys", nil, &response, true); err != nil {
+		return nil, err
+	}
+	return response.APIKeys, nil
+}
+
+func (c *Client) RevokeAPIKey(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v1/auth/api-keys/"+url.PathEscape(id), nil, nil, true)
+}
+
+// ClientCredentials signs in a service account with the client_credentials
+// grant, so later requests carry its token; use it with WithBearerAuth. The
+// token cannot be refreshed: call it again once the token expires. An empty
+// scope requests every scope of the account.
+func (c *Client) ClientCredentials(ctx context.Context, clientID, clientSecret, scope string) (*ServiceAccountToken, error) {
+	req := clientCredentialsRequest{
+		GrantType:    "client_credentials",
+		Scope:        scope,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+
+	var token ServiceAccountToken
+	if err := c.do(ctx, http.MethodPost, "/oauth/token", req, &token, false); err != nil {
+		return nil, err
+	}
+	c.setTokens(Tokens{AccessToken: token.AccessToken})
+	return &token, nil
+}
+
+// CreateServiceAccount needs the admin role or scope. The first client
+// secret is only returned here.
+func (c *Client) CreateServiceAccount(ctx context.Context, req CreateServiceAccountRequest) (*CreatedServiceAccount, error) {
+	var account CreatedServiceAccount
+	if err := c.do(ctx, http.MethodPost, "/v1/admin/service-accounts", req, &account, true); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (c *Client) ListServiceAccounts(ctx context.Context) ([]ServiceAccount, error) {
+	var response struct {
+		ServiceAccounts []ServiceAccount `json:"service_accounts"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v1/admin/service-accounts", nil, &response, true); err != nil {
+		return nil, err
+	}
+	return response.ServiceAccounts, nil
+}
+
+func (c *Client) DeleteServiceAccount(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v1/admin/service-accounts/"+url.PathEscape(id), nil, nil, true)
+}
+
+// CreateServiceAccountSecret adds a secret to rotate to; the account's other
+// secrets keep working until they are deleted.
+func (c *Client) CreateServiceAccountSecret(ctx context.Context, id string) (*CreatedServiceAccountSecret, error) {
+	var secret CreatedServiceAccountSecret
+	if err := c.do(ctx, http.MethodPost, "/v1/admin/service-accounts/"+url.PathEscape(id)+"/secrets", nil, &secret, true); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+func (c *Client) DeleteServiceAccountSecret(ctx context.Context, id, secretID string) error {
+	path := "/v1/admin/service-accounts/" + url.PathEscape(id) + "/secrets/" + url.PathEscape(secretID)
+	return c.do(ctx, http.MethodDelete, path, nil, nil, true)
+}
+
+func (c *Client) startSession(ctx context.Context, method, path string, body any) (*Tokens, error) {
+	var tokens Tokens
+	if err := c.do(ctx, method, path, body, &tokens, false); err != nil {
+		return nil, err
+	}
+	c.setTokens(tokens)
+	return &tokens, nil
+}
+
+func (c *Client) setTokens(tokens Tokens) {
+	c.mu.Lock()
+	c.tokens = tokens
+	c.mu.Unlock()
+
+	if c.onTokens != nil {
+		c.onTokens(tokens)
+	}
+}
+
+// do sends the request and decodes a 2xx body into out. Authenticated
+// requests rejected with 401 are retried once after a refresh.
+func (c *Client) do(ctx context.Context, method, path string, body, out any, authenticated bool) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	err := c.send(ctx, method, path, payload, out, authenticated)
+	if !authenticated || !IsProblem(err, ProblemUnauthorized) || !c.canRefresh() {
+		return err
+	}
+
+	if _, refreshErr := c.Refresh(ctx); refreshErr != nil {
+		return errors.Join(err, refreshErr)
+	}
+	return c.send(ctx, method, path, payload, out, authenticated)
+}
+
+func (c *Client) canRefresh() bool {
+	if c.apiKey != "" {
+		return false
+	}
+	return !c.bearer || c.Tokens().RefreshToken != ""
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, out any, authenticated bool) error {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authenticated && c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	} else if authenticated && c.bearer {
+		if accessToken := c.Tokens().AccessToken; accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+	} else if authenticated {
+		c.setCSRFHeader(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // nothing to do about a failed close
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeProblem(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+// setCSRFHeader copies the csrf_token cookie into the X-CSRF-Token header,
+// as pages of the API's origin do. The name may carry the prefixes of the
+// API's cookie policy, e.g. __Host-.
+func (c *Client) setCSRFHeader(req *http.Request) {
+	for _, cookie := range c.httpClient.Jar.Cookies(req.URL) {
+		if strings.HasSuffix(cookie.Name, csrfCookie) {
+			req.Header.Set(csrfHeader, cookie.Value)
+			return
+		}
+	}
+}
+
+// decodeProblem reads an error response, falling back to the status line
+// when the body is not problem+json (e.g. from a proxy).
+func decodeProblem(resp *http.Response) error {
+	problem := &ProblemDetails{
+		Status: resp.StatusCode,
+		Title:  http.StatusText(resp.StatusCode),
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "application/problem+json" || mediaType == "application/json" {
+		if err := json.NewDecoder(resp.Body).Decode(problem); err != nil {
+			return fmt.Errorf("failed to decode error response (status %d): %w", resp.StatusCode, err)
+		}
+	}
+	if problem.Status == 0 {
+		problem.Status = resp.StatusCode
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		problem.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return problem
+}
@@ -0,0 +1,566 @@
+package client_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/SergioLNeves/migos/client"
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/container"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/security"
+	"github.com/SergioLNeves/migos/internal/server"
+	"github.com/SergioLNeves/migos/internal/social/socialtest"
+	"github.com/SergioLNeves/migos/internal/storage"
+	"github.com/SergioLNeves/migos/pkg/authverify"
+)
+
+const (
+	password = "s3cret-password"
+	// breachedPassword is the one entry of the test breached password corpus.
+	breachedPassword = "correct-horse-battery"
+)
+
+var (
+	baseURL string
+	emails  atomic.Int64
+
+	oauthClientID, oauthClientSecret string
+	oidcClientID                     string
+
+	idp *socialtest.Server
+
+	adminService   domain.AdminService
+	authRepository domain.AuthRepository
+	store          storage.Storage
+
+	// routes are the routes the server registered.
+	routes []*echo.Route
+)
+
+const oidcRedirectURI = "http://127.0.0.1/callback"
+
+// TestMain runs the real server, wired like cmd/api, over a temporary
+// database and key pair.
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	dir, err := os.MkdirTemp("", "migos-client")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck // best effort cleanup
+
+	idp = socialtest.NewServer()
+	defer idp.Close()
+	providersFile := filepath.Join(dir, "providers.json")
+	providers, err := json.Marshal([]domain.SocialProviderConfig{idp.OIDCConfig("idp")})
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(providersFile, providers, 0o600); err != nil {
+		panic(err)
+	}
+
+	pepperKey, err := security.GenerateKey("pepper-1", 32)
+	if err != nil {
+		panic(err)
+	}
+	encryptionKey, err := security.GenerateKey("enc-1", security.EncryptionKeySize)
+	if err != nil {
+		panic(err)
+	}
+
+	// The issuer is the URL the server listens on, so it is known before
+	// the tokens are configured.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	baseURL = "http://" + listener.Addr().String()
+
+	breachedCorpus := filepath.Join(dir, "pwned")
+	if err := writeBreachedCorpus(breachedCorpus, breachedPassword); err != nil {
+		panic(err)
+	}
+
+	config.Env = domain.Config{
+		Env:      "test",
+		LogLevel: "error",
+		HTTP:     domain.HTTPConfig{BodyLimit: "64K"},
+		Keys: domain.KeysConfig{
+			PrivateKeyPath: filepath.Join(dir, "private.pem"),
+			PublicKeyPath:  filepath.Join(dir, "public.pem"),
+		},
+		Token: domain.TokenConfig{AccessTokenExpiry: 60, RefreshTokenExpiry: 10080, Issuer: baseURL, Audience: "migos-test"},
+		// Cheap parameters keep the many parallel sign ups fast.
+		Password:   domain.PasswordConfig{HashAlgorithm: domain.PasswordAlgorithmArgon2id, Argon2Memory: 1024, Argon2Iterations: 1, Argon2Parallelism: 1, HashQueueTimeout: 5 * time.Second, PepperKeys: pepperKey},
+		Policy:     domain.PasswordPolicyConfig{MinLength: 8, MaxLength: 128, ForbidPersonalInfo: true, BreachedCorpus: breachedCorpus, BreachedMinCount: 1, HistorySize: 3, MaxAge: time.Hour, ChangeChallengeTTL: time.Minute},
+		Encryption: domain.EncryptionConfig{Keys: encryptionKey, PII: true},
+		SQL:        domain.SQLConfig{DBPath: filepath.Join(dir, "auth.db"), MaxConn: 1, MaxIdle: 1},
+		OIDC:       domain.OIDCConfig{LoginURL: "/login", CodeTTL: time.Minute, DeviceCodeTTL: time.Minute, DevicePollInterval: time.Second},
+		Social:     domain.SocialConfig{ProvidersFile: providersFile, StateTTL: time.Minute},
+		OpenAPI:    domain.OpenAPIConfig{DocsUI: true},
+	}
+	if err := security.GenerateRSAKeyPair(config.Env.Keys.PrivateKeyPath, config.Env.Keys.PublicKeyPath, security.DefaultKeySize); err != nil {
+		panic(err)
+	}
+
+	injector := container.New(logging.NewLogger(&config.Env))
+	defer injector.Shutdown() //nolint:errcheck // best effort cleanup
+	store = do.MustInvoke[storage.Storage](injector)
+	if err := store.Migrate(context.Background()); err != nil {
+		panic(err)
+	}
+
+	e, err := server.New(injector, &config.Env)
+	if err != nil {
+		panic(err)
+	}
+	routes = e.Routes()
+
+	oauthClient, secret, err := do.MustInvoke[domain.OAuthService](injector).CreateClient(context.Background(), domain.NewOAuthClientRequest{Name: "client-test"})
+	if err != nil {
+		panic(err)
+	}
+	oauthClientID, oauthClientSecret = oauthClient.ID.String(), secret
+
+	oidcClient, _, err := do.MustInvoke[domain.OAuthService](injector).CreateClient(context.Background(), domain.NewOAuthClientRequest{
+		Name:         "oidc-test",
+		RedirectURIs: []string{oidcRedirectURI},
+		Public:       true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	oidcClientID = oidcClient.ID.String()
+	adminService = do.MustInvoke[domain.AdminService](injector)
+	authRepository = do.MustInvoke[domain.AuthRepository](injector)
+	srv := httptest.NewUnstartedServer(e)
+	srv.Listener.Close() //nolint:errcheck,gosec // replaced by the listener of the issuer
+	srv.Listener = listener
+	srv.Start()
+	defer srv.Close()
+
+	return m.Run()
+}
+
+// writeBreachedCorpus writes the HIBP range file holding password.
+func writeBreachedCorpus(dir, password string) error {
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		return err
+	}
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // the HIBP corpus is keyed by SHA-1
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(hash[5:]+":42\n"), 0o600)
+}
+
+func newAccount(t *testing.T, c *client.Client) client.CreateAccountRequest {
+	t.Helper()
+
+	req := client.CreateAccountRequest{
+		Name:     "Client Test",
+		Email:    fmt.Sprintf("client-%d@example.com", emails.Add(1)),
+		Password: password,
+	}
+	_, err := c.CreateAccount(context.Background(), req)
+	require.NoError(t, err)
+	return req
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	modes := map[string][]client.Option{
+		"cookies": nil,
+		"bearer":  {client.WithBearerAuth()},
+	}
+
+	for mode, opts := range modes {
+		t.Run("should run the account lifecycle with "+mode, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			c, err := client.New(baseURL, opts...)
+			require.NoError(t, err)
+			account := newAccount(t, c)
+
+			me, err := c.Me(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, account.Email, me.Email)
+
+			updated, err := c.UpdateProfile(ctx, client.UpdateProfileRequest{Name: "Renamed User"})
+			require.NoError(t, err)
+			assert.Equal(t, "Renamed User", updated.Name)
+
+			require.NoError(t, c.UpdatePassword(ctx, client.UpdatePasswordRequest{
+				CurrentPassword: password,
+				NewPassword:     "an0ther-password",
+			}))
+
+			refreshed, err := c.Refresh(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, *refreshed, c.Tokens())
+
+			require.NoError(t, c.Logout(ctx))
+			assert.Empty(t, c.Tokens())
+			_, err = c.Me(ctx)
+			assert.True(t, client.IsProblem(err, client.ProblemUnauthorized))
+
+			_, err = c.Login(ctx, client.LoginRequest{Email: account.Email, Password: "an0ther-password"})
+			require.NoError(t, err)
+			require.NoError(t, c.DeleteAccount(ctx))
+
+			_, err = c.Login(ctx, client.LoginRequest{Email: account.Email, Password: "an0ther-password"})
+			assert.True(t, client.IsProblem(err, client.ProblemUserDeactivated))
+
+			_, err = c.Reactivate(ctx, client.LoginRequest{Email: account.Email, Password: "an0ther-password"})
+			require.NoError(t, err)
+			_, err = c.Me(ctx)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("should decode problem details", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := client.New(baseURL)
+		require.NoError(t, err)
+		account := newAccount(t, c)
+
+		_, err = c.CreateAccount(context.Background(), account)
+
+		var problem *client.ProblemDetails
+		require.ErrorAs(t, err, &problem)
+		assert.Equal(t, http.StatusConflict, problem.Status)
+		assert.Equal(t, client.ProblemEmailAlreadyExists, problem.Code())
+		assert.NotEmpty(t, problem.RequestID)
+	})
+
+	t.Run("should decode validation errors", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := client.New(baseURL)
+		require.NoError(t, err)
+
+		_, err = c.Login(context.Background(), client.LoginRequest{Email: "not-an-email"})
+
+		var problem *client.ProblemDetails
+		require.ErrorAs(t, err, &problem)
+		assert.Equal(t, client.ProblemValidation, problem.Code())
+		assert.NotEmpty(t, problem.Errors)
+	})
+
+	t.Run("should reject passwords that break the policy as field errors", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := client.New(baseURL)
+		require.NoError(t, err)
+
+		for name, tc := range map[string]struct {
+			password string
+			message  string
+		}{
+			"short":    {"short", "password must be at least 8 characters long"},
+			"personal": {"policy-owner-2024", "password must not contain your email or name"},
+			"breached": {breachedPassword, "password has appeared in a data breach and can't be used"},
+		} {
+			_, err = c.CreateAccount(context.Background(), client.CreateAccountRequest{
+				Name: "Policy Owner", Email: fmt.Sprintf("policy-%s@example.com", name), Password: tc.password,
+			})
+
+			var problem *client.ProblemDetails
+			require.ErrorAs(t, err, &problem, name)
+			assert.Equal(t, client.ProblemWeakPassword, problem.Code(), name)
+			assert.Equal(t, []client.FieldError{{Field: "password", Message: tc.message}}, problem.Errors, name)
+		}
+	})
+
+	t.Run("should reject reused passwords and challenge passwords past the maximum age", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		c, err := client.New(baseURL)
+		require.NoError(t, err)
+		account := newAccount(t, c)
+
+		require.NoError(t, c.UpdatePassword(ctx, client.UpdatePasswordRequest{CurrentPassword: password, NewPassword: "an0ther-password"}))
+		err = c.UpdatePassword(ctx, client.UpdatePasswordRequest{CurrentPassword: "an0ther-password", NewPassword: password})
+		var problem *client.ProblemDetails
+		require.ErrorAs(t, err, &problem)
+		assert.Equal(t, client.ProblemWeakPassword, problem.Code())
+		assert.Equal(t, []client.FieldError{{Field: "new_password", Message: "new_password must differ from your last 3 passwords"}}, problem.Errors)
+
+		user, err := authRepository.FindUserByEmail(ctx, account.Email)
+		require.NoError(t, err)
+		changedAt := time.Now().Add(-2 * time.Hour)
+		user.PasswordChangedAt = &changedAt
+		require.NoError(t, authRepository.UpdateUser(ctx, user))
+
+		_, err = c.Login(ctx, client.LoginRequest{Email: account.Email, Password: "an0ther-password"})
+		require.ErrorAs(t, err, &problem)
+		assert.Equal(t, client.ProblemPasswordChangeRequired, problem.Code())
+		require.NotEmpty(t, problem.ChallengeToken)
+		challenge := problem.ChallengeToken
+
+		_, err = c.ChangeExpiredPassword(ctx, client.ChangeExpiredPasswordRequest{ChallengeToken: challenge, NewPassword: password})
+		assert.True(t, client.IsProblem(err, client.ProblemWeakPassword))
+
+		_, err = c.ChangeExpiredPassword(ctx, client.ChangeExpiredPasswordRequest{ChallengeToken: challenge, NewPassword: "th1rd-password"})
+		require.NoError(t, err)
+		_, err = c.Me(ctx)
+		require.NoError(t, err)
+
+		_, err = c.ChangeExpiredPassword(ctx, client.ChangeExpiredPasswordRequest{ChallengeToken: challenge, NewPassword: "f0urth-password"})
+		assert.True(t, client.IsProblem(err, client.ProblemInvalidPasswordChallenge))
+
+		_, err = c.Login(ctx, client.LoginRequest{Email: account.Email, Password: "th1rd-password"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should pepper passwords, encrypt PII at rest and re-encrypt older rows", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		c, err := client.New(baseURL)
+		require.NoError(t, err)
+		account := newAccount(t, c)
+
+		db := store.GetDB().(*gorm.DB)
+		var raw struct{ Name, Password string }
+		require.NoError(t, db.Table("user").Select("name, password").Where("email = ?", account.Email).Scan(&raw).Error)
+		assert.True(t, strings.HasPrefix(raw.Name, "enc:v2:enc-1:"), raw.Name)
+		assert.True(t, strings.HasPrefix(raw.Password, "$pepper$pepper-1$argon2id$"), raw.Password)
+
+		// A sealed value is bound to its row, so it can't be moved to another.
+		other := newAccount(t, mustClient(t))
+		var otherName string
+		require.NoError(t, db.Table("user").Select("name").Where("email = ?", other.Email).Scan(&otherName).Error)
+		require.NoError(t, db.Table("user").Where("email = ?", other.Email).Update("name", raw.Name).Error)
+		_, err = authRepository.FindUserByEmail(ctx, other.Email)
+		assert.Error(t, err)
+		require.NoError(t, db.Table("user").Where("email = ?", other.Email).Update("name", otherName).Error)
+
+		// Simulate a row written before encryption was enabled.
+		require.NoError(t, db.Table("user").Where("email = ?", account.Email).Update("name", "Plain Name").Error)
+		user, err := authRepository.FindUserByEmail(ctx, account.Email)
+		require.NoError(t, err)
+		assert.Equal(t, "Plain Name", user.Name)
+
+		columns, err := store.ReencryptColumns(ctx)
+		require.NoError(t, err)
+		rewritten := map[string]int{}
+		for _, column := range columns {
+			rewritten[column.Column] = column.Rows
+		}
+		assert.GreaterOrEqual(t, rewritten["user.name"], 1)
+		assert.Contains(t, rewritten, "social_login_state.code_verifier")
+
+		require.NoError(t, db.Table("user").Select("name, password").Where("email = ?", account.Email).Scan(&raw).Error)
+		assert.True(t, strings.HasPrefix(raw.Name, "enc:v2:enc-1:"), raw.Name)
+		user, err = authRepository.FindUserByEmail(ctx, account.Email)
+		require.NoError(t, err)
+		assert.Equal(t, "Plain Name", user.Name)
+	})
+
+	t.Run("should refresh and retry when the bearer token is rejected", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		seed, err := client.New(baseURL, client.WithBearerAuth())
+		require.NoError(t, err)
+		account := newAccount(t, seed)
+
+		var saved client.Tokens
+		c, err := client.New(baseURL,
+			client.WithBearerAuth(),
+			client.WithTokens(client.Tokens{AccessToken: "invalid", RefreshToken: seed.Tokens().RefreshToken}),
+			client.WithTokenCallback(func(tokens client.Tokens) { saved = tokens }),
+		)
+		require.NoError(t, err)
+
+		me, err := c.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, account.Email, me.Email)
+		assert.Equal(t, c.Tokens(), saved)
+		assert.NotEqual(t, "invalid", saved.AccessToken)
+	})
+
+	t.Run("should refresh and retry when the access cookie is rejected", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		c, err := client.New(baseURL, client.WithHTTPClient(&http.Client{Jar: jar}))
+		require.NoError(t, err)
+		newAccount(t, c)
+
+		u, err := url.Parse(baseURL)
+		require.NoError(t, err)
+		jar.SetCookies(u, []*http.Cookie{{Name: "access_token", Value: "invalid", Path: "/"}})
+
+		_, err = c.Me(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not retry when there is no session to refresh", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := client.New(baseURL, client.WithBearerAuth())
+		require.NoError(t, err)
+
+		_, err = c.Me(context.Background())
+		assert.True(t, client.IsProblem(err, client.ProblemUnauthorized))
+	})
+
+	t.Run("should refuse cookie requests without the CSRF token or from other origins", func(t *testing.T) {
+		t.Parallel()
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		httpClient := &http.Client{Jar: jar}
+		newAccount(t, mustClient(t, client.WithHTTPClient(httpClient)))
+
+		patch := func(headers map[string]string) *http.Response {
+			req, err := http.NewRequest(http.MethodPatch, baseURL+"/v1/user/profile", strings.NewReader(`{"name":"Other Name"}`))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			for name, value := range headers {
+				req.Header.Set(name, value)
+			}
+			resp, err := httpClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			return resp
+		}
+
+		assert.Equal(t, http.StatusForbidden, patch(nil).StatusCode)
+		token := csrfToken(t, jar)
+		assert.Equal(t, http.StatusForbidden, patch(map[string]string{"X-CSRF-Token": token, "Origin": "https://evil.example.com"}).StatusCode)
+		assert.Equal(t, http.StatusOK, patch(map[string]string{"X-CSRF-Token": token, "Origin": baseURL}).StatusCode)
+	})
+
+	t.Run("should issue access tokens that authverify accepts", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		c, err := client.New(baseURL, client.WithBearerAuth())
+		require.NoError(t, err)
+		newAccount(t, c)
+		me, err := c.Me(ctx)
+		require.NoError(t, err)
+
+		verifier, err := authverify.New(authverify.Config{
+			JWKSURL:  baseURL + "/.well-known/jwks.json",
+			Issuer:   baseURL,
+			Audience: "migos-test",
+		})
+		require.NoError(t, err)
+
+		claims, err := verifier.Verify(ctx, c.Tokens().AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, me.ID, claims.UserID)
+
+		_, err = verifier.Verify(ctx, c.Tokens().RefreshToken)
+		assert.ErrorIs(t, err, authverify.ErrInvalidToken)
+	})
+	t.Run("should introspect tokens for registered clients and revoke only their own", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		c, err := client.New(baseURL, client.WithBearerAuth())
+		require.NoError(t, err)
+		newAccount(t, c)
+		tokens := c.Tokens()
+
+		revoke := func(token string) *http.Response {
+			form := url.Values{"token": {token}, "token_type_hint": {"refresh_token"}}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/oauth/revoke", strings.NewReader(form.Encode()))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetBasicAuth(oauthClientID, oauthClientSecret)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			t.Cleanup(func() { resp.Body.Close() }) //nolint:errcheck // best effort cleanup
+			return resp
+		}
+
+		verifier, err := authverify.New(authverify.Config{
+			JWKSURL:  baseURL + "/.well-known/jwks.json",
+			Issuer:   baseURL,
+			Audience: "migos-test",
+			Introspection: &authverify.IntrospectionConfig{
+				URL:          baseURL + "/oauth/introspect",
+				ClientID:     oauthClientID,
+				ClientSecret: oauthClientSecret,
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(ctx, tokens.AccessToken)
+		require.NoError(t, err)
+
+		// First-party sessions were not issued to the client.
+		resp := revoke(tokens.RefreshToken)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, "unauthorized_client", decode[map[string]any](t, resp)["error"])
+		_, err = verifier.Verify(ctx, tokens.AccessToken)
+		require.NoError(t, err)
+
+		// A session the client started through the device flow is its own.
+		resp = postForm(t, http.DefaultClient, "/oauth/device/code", url.Values{
+			"client_id": {oauthClientID}, "client_secret": {oauthClientSecret}, "scope": {"openid email"},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		device := decode[domain.DeviceCodeResponse](t, resp)
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		newAccount(t, mustClient(t, client.WithHTTPClient(&http.Client{Jar: jar})))
+		resp = postForm(t, browser(t, jar), "/device", url.Values{
+			"user_code": {device.UserCode}, "consent": {"approve"}, "csrf_token": {csrfToken(t, jar)},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp = postForm(t, http.DefaultClient, "/token", url.Values{
+			"grant_type": {domain.GrantTypeDeviceCode}, "device_code": {device.DeviceCode},
+			"client_id": {oauthClientID}, "client_secret": {oauthClientSecret},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		clientTokens := decode[domain.TokenResponse](t, resp)
+
+		resp = postForm(t, http.DefaultClient, "/oauth/introspect", url.Values{
+			"token": {clientTokens.AccessToken}, "client_id": {oauthClientID}, "client_secret": {oauthClientSecret},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		introspection := decode[domain.IntrospectionResponse](t, resp)
+		assert.True(t, introspection.Active)
+		assert.Equal(t, oauthClientID, introspection.ClientID)
+		assert.Equal(t, "openid email", introspection.Scope)
+
+		require.Equal(t, http.StatusOK, revoke(clientTokens.RefreshToken).StatusCode)
+		assert.Equal(t, http.StatusUnauthorized, userInfo(t, clientTokens.AccessToken).StatusCode)
+	})
+}
@@ -0,0 +1,141 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/client"
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+func startDeviceFlow(t *testing.T) domain.DeviceCodeResponse {
+	t.Helper()
+	resp := postForm(t, http.DefaultClient, "/oauth/device/code", url.Values{
+		"client_id": {oidcClientID},
+		"scope":     {"openid profile"},
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	return decode[domain.DeviceCodeResponse](t, resp)
+}
+
+func pollDevice(t *testing.T, deviceCode string) *http.Response {
+	t.Helper()
+	return postForm(t, http.DefaultClient, "/token", url.Values{
+		"grant_type":  {domain.GrantTypeDeviceCode},
+		"device_code": {deviceCode},
+		"client_id":   {oidcClientID},
+	})
+}
+
+func oauthError(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	return decode[map[string]any](t, resp)["error"].(string)
+}
+
+func TestDeviceFlow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should send users without a session to the login page", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := browser(t, nil).Get(baseURL + "/device?user_code=WDJB-MJHT")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/login?return_to="+url.QueryEscape("/device?user_code=WDJB-MJHT"), resp.Header.Get("Location"))
+	})
+
+	t.Run("should turn an approved device into a session the user can revoke", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		device := startDeviceFlow(t)
+		assert.Equal(t, baseURL+"/device", device.VerificationURI)
+		assert.Equal(t, 1, device.Interval)
+
+		// Polls before the user answers are pending, and too fast ones are
+		// told to slow down.
+		assert.Equal(t, "authorization_pending", oauthError(t, pollDevice(t, device.DeviceCode)))
+		assert.Equal(t, "slow_down", oauthError(t, pollDevice(t, device.DeviceCode)))
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		session := mustClient(t, client.WithHTTPClient(&http.Client{Jar: jar}))
+		newAccount(t, session)
+		b := browser(t, jar)
+
+		resp, err := b.Get(device.VerificationURIComplete)
+		require.NoError(t, err)
+		page, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(page), "oidc-test wants to access your account")
+
+		resp = postForm(t, b, "/device", url.Values{
+			"user_code":  {strings.ToLower(device.UserCode)},
+			"consent":    {"approve"},
+			"csrf_token": {formCSRFToken(t, page)},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = pollDevice(t, device.DeviceCode)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		tokens := decode[domain.TokenResponse](t, resp)
+		assert.Equal(t, "openid profile", tokens.Scope)
+		assert.NotEmpty(t, tokens.IDToken)
+		assert.Equal(t, http.StatusOK, userInfo(t, tokens.AccessToken).StatusCode)
+
+		// The device code is redeemed once.
+		assert.Equal(t, "invalid_grant", oauthError(t, pollDevice(t, device.DeviceCode)))
+
+		sessions, err := session.ListSessions(ctx)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		var deviceSession client.Session
+		for _, s := range sessions {
+			if !s.Current {
+				deviceSession = s
+			}
+		}
+		assert.Equal(t, oidcClientID, deviceSession.ClientID)
+		assert.Equal(t, "oidc-test", deviceSession.ClientName)
+
+		require.NoError(t, session.RevokeSession(ctx, deviceSession.ID))
+		assert.Equal(t, http.StatusUnauthorized, userInfo(t, tokens.AccessToken).StatusCode)
+
+		err = session.RevokeSession(ctx, deviceSession.ID)
+		assert.True(t, client.IsProblem(err, client.ProblemSessionNotFound), err)
+	})
+
+	t.Run("should return access_denied when the user denies the device", func(t *testing.T) {
+		t.Parallel()
+
+		device := startDeviceFlow(t)
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		newAccount(t, mustClient(t, client.WithHTTPClient(&http.Client{Jar: jar})))
+
+		resp := postForm(t, browser(t, jar), "/device", url.Values{
+			"user_code":  {device.UserCode},
+			"consent":    {"deny"},
+			"csrf_token": {csrfToken(t, jar)},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = pollDevice(t, device.DeviceCode)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, "access_denied", decode[map[string]any](t, resp)["error"])
+	})
+}
@@ -0,0 +1,338 @@
+package client_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/client"
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/pkg/authverify"
+)
+
+const codeVerifier = "M25iVXpKU3puUjFaYWg3T1NDTDQtcW1ROUY5YXlwalNoc0hhakxifmZHag"
+
+// browser follows no redirects, so each step of the flow can be inspected.
+func browser(t *testing.T, jar http.CookieJar) *http.Client {
+	t.Helper()
+	return &http.Client{
+		Jar: jar,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func authorizeParams(state string) url.Values {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return url.Values{
+		"response_type":         {"code"},
+		"client_id":             {oidcClientID},
+		"redirect_uri":          {oidcRedirectURI},
+		"scope":                 {"openid email"},
+		"state":                 {state},
+		"nonce":                 {"n-" + state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}
+}
+
+// redirectParams returns the query of the redirect back to the client.
+func redirectParams(t *testing.T, resp *http.Response) url.Values {
+	t.Helper()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, oidcRedirectURI, location.Scheme+"://"+location.Host+location.Path)
+	return location.Query()
+}
+
+// csrfToken returns the CSRF token the server set in jar.
+func csrfToken(t *testing.T, jar http.CookieJar) string {
+	t.Helper()
+	u, err := url.Parse(baseURL)
+	require.NoError(t, err)
+	for _, cookie := range jar.Cookies(u) {
+		if cookie.Name == "csrf_token" {
+			return cookie.Value
+		}
+	}
+	require.Fail(t, "no csrf_token cookie")
+	return ""
+}
+
+// formCSRFToken returns the csrf_token field of a page's form.
+func formCSRFToken(t *testing.T, page []byte) string {
+	t.Helper()
+	match := regexp.MustCompile(`name="csrf_token" value="([^"]+)"`).FindSubmatch(page)
+	require.NotNil(t, match, "no csrf_token field")
+	return string(match[1])
+}
+
+func postForm(t *testing.T, c *http.Client, path string, form url.Values) *http.Response {
+	t.Helper()
+	resp, err := c.Post(baseURL+path, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() }) //nolint:errcheck // best effort cleanup
+	return resp
+}
+
+func decode[T any](t *testing.T, resp *http.Response) T {
+	t.Helper()
+	var v T
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&v))
+	return v
+}
+
+func TestOpenIDConnect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish the provider metadata", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := http.Get(baseURL + "/.well-known/openid-configuration")
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // best effort cleanup
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		doc := decode[domain.DiscoveryDocument](t, resp)
+		assert.Equal(t, baseURL, doc.Issuer)
+		assert.Equal(t, baseURL+"/authorize", doc.AuthorizationEndpoint)
+		assert.Equal(t, baseURL+"/token", doc.TokenEndpoint)
+		assert.Equal(t, baseURL+"/oauth/device/code", doc.DeviceAuthorizationEndpoint)
+		assert.Equal(t, baseURL+"/.well-known/jwks.json", doc.JWKSURI)
+		assert.Equal(t, []string{"S256"}, doc.CodeChallengeMethodsSupported)
+	})
+
+	t.Run("should send users without a session to the login page", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := browser(t, nil).Get(baseURL + "/authorize?" + authorizeParams("anonymous").Encode())
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.True(t, strings.HasPrefix(resp.Header.Get("Location"), "/login?return_to=%2Fauthorize%3F"))
+	})
+
+	t.Run("should run the authorization code flow with PKCE", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		account := newAccount(t, mustClient(t, client.WithHTTPClient(&http.Client{Jar: jar})))
+		b := browser(t, jar)
+
+		// The first request asks for consent.
+		params := authorizeParams("first")
+		resp, err := b.Get(baseURL + "/authorize?" + params.Encode())
+		require.NoError(t, err)
+		page, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(page), "oidc-test wants to access your account")
+
+		consent := url.Values{"consent": {"approve"}}
+		for key, values := range params {
+			consent[key] = values
+		}
+		// The answer must come from the page, which carries the CSRF token.
+		assert.Equal(t, http.StatusForbidden, postForm(t, b, "/authorize", consent).StatusCode)
+		consent.Set("csrf_token", formCSRFToken(t, page))
+		redirect := redirectParams(t, postForm(t, b, "/authorize", consent))
+		assert.Equal(t, "first", redirect.Get("state"))
+		code := redirect.Get("code")
+		require.NotEmpty(t, code)
+
+		// The code is redeemed by the public client with the verifier.
+		exchange := url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {code},
+			"redirect_uri":  {oidcRedirectURI},
+			"code_verifier": {codeVerifier},
+			"client_id":     {oidcClientID},
+		}
+		resp = postForm(t, http.DefaultClient, "/token", exchange)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		tokens := decode[domain.TokenResponse](t, resp)
+		assert.Equal(t, "Bearer", tokens.TokenType)
+		assert.Equal(t, "openid email", tokens.Scope)
+
+		publicPEM, err := os.ReadFile(config.Env.Keys.PublicKeyPath)
+		require.NoError(t, err)
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+		require.NoError(t, err)
+		// Relying parties check iss against the issuer they discovered.
+		resp, err = http.Get(baseURL + "/.well-known/openid-configuration")
+		require.NoError(t, err)
+		discovered := decode[domain.DiscoveryDocument](t, resp)
+		require.NoError(t, resp.Body.Close())
+		idClaims := jwt.MapClaims{}
+		_, err = jwt.ParseWithClaims(tokens.IDToken, idClaims, func(*jwt.Token) (any, error) { return publicKey, nil },
+			jwt.WithValidMethods([]string{"RS256"}),
+			jwt.WithIssuer(discovered.Issuer),
+			jwt.WithAudience(oidcClientID),
+			jwt.WithExpirationRequired(),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "n-first", idClaims["nonce"])
+		assert.Equal(t, account.Email, idClaims["email"])
+		assert.NotContains(t, idClaims, "name")
+
+		// A code is redeemed at most once.
+		resp = postForm(t, http.DefaultClient, "/token", exchange)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "invalid_grant", decode[map[string]any](t, resp)["error"])
+
+		// The access token reads the claims its scopes allow; the ID token
+		// is not an access token.
+		info := userInfo(t, tokens.AccessToken)
+		require.Equal(t, http.StatusOK, info.StatusCode)
+		assert.Equal(t, domain.UserInfo{Subject: idClaims["sub"].(string), Email: account.Email}, decode[domain.UserInfo](t, info))
+		assert.Equal(t, http.StatusUnauthorized, userInfo(t, tokens.IDToken).StatusCode)
+
+		// It is meant for the client: the first-party API and services
+		// expecting the audience of the API refuse it.
+		for _, route := range []struct{ method, path string }{
+			{http.MethodGet, "/v1/auth/me"},
+			{http.MethodDelete, "/v1/user"},
+			{http.MethodPost, "/v1/auth/api-keys"},
+			{http.MethodGet, "/v1/admin/service-accounts"},
+		} {
+			req, err := http.NewRequest(route.method, baseURL+route.path, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, route.method+" "+route.path)
+		}
+		resp, err = http.Post(baseURL+"/v1/auth/refresh", "application/json",
+			strings.NewReader(`{"refresh_token":"`+tokens.RefreshToken+`"}`))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		for audience, valid := range map[string]bool{"migos-test": false, oidcClientID: true} {
+			verifier, err := authverify.New(authverify.Config{
+				JWKSURL:  baseURL + "/.well-known/jwks.json",
+				Issuer:   baseURL,
+				Audience: audience,
+			})
+			require.NoError(t, err)
+			claims, err := verifier.Verify(ctx, tokens.AccessToken)
+			if valid {
+				require.NoError(t, err)
+				assert.Equal(t, oidcClientID, claims.ClientID)
+			} else {
+				assert.Error(t, err)
+			}
+		}
+
+		// The refresh token is bound to the client that received it.
+		resp = postForm(t, http.DefaultClient, "/token", url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {tokens.RefreshToken},
+			"client_id":     {oidcClientID},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		refreshed := decode[domain.TokenResponse](t, resp)
+		assert.NotEmpty(t, refreshed.AccessToken)
+		assert.Empty(t, refreshed.IDToken)
+		assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+
+		resp = postForm(t, http.DefaultClient, "/token", url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {refreshed.RefreshToken},
+			"client_id":     {oauthClientID},
+			"client_secret": {oauthClientSecret},
+		})
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		// Every redemption rotates the refresh token; replaying a spent one
+		// revokes the session, so the current tokens stop working too.
+		resp = postForm(t, http.DefaultClient, "/token", url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {refreshed.RefreshToken},
+			"client_id":     {oidcClientID},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		rotated := decode[domain.TokenResponse](t, resp)
+		assert.Equal(t, http.StatusOK, userInfo(t, rotated.AccessToken).StatusCode)
+
+		resp = postForm(t, http.DefaultClient, "/token", url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {tokens.RefreshToken},
+			"client_id":     {oidcClientID},
+		})
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, "invalid_grant", decode[map[string]any](t, resp)["error"])
+
+		resp = postForm(t, http.DefaultClient, "/token", url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {rotated.RefreshToken},
+			"client_id":     {oidcClientID},
+		})
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, http.StatusUnauthorized, userInfo(t, rotated.AccessToken).StatusCode)
+
+		// Consent is remembered, so the next request redirects right away.
+		resp, err = b.Get(baseURL + "/authorize?" + authorizeParams("second").Encode())
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		redirect = redirectParams(t, resp)
+		assert.Equal(t, "second", redirect.Get("state"))
+		assert.NotEmpty(t, redirect.Get("code"))
+	})
+
+	t.Run("should redirect access_denied when the user denies consent", func(t *testing.T) {
+		t.Parallel()
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		newAccount(t, mustClient(t, client.WithHTTPClient(&http.Client{Jar: jar})))
+
+		consent := authorizeParams("denied")
+		consent.Set("consent", "deny")
+		consent.Set("csrf_token", csrfToken(t, jar))
+		redirect := redirectParams(t, postForm(t, browser(t, jar), "/authorize", consent))
+
+		assert.Equal(t, "access_denied", redirect.Get("error"))
+		assert.Equal(t, "denied", redirect.Get("state"))
+		assert.Empty(t, redirect.Get("code"))
+	})
+}
+
+func mustClient(t *testing.T, opts ...client.Option) *client.Client {
+	t.Helper()
+	c, err := client.New(baseURL, opts...)
+	require.NoError(t, err)
+	return c
+}
+
+func userInfo(t *testing.T, accessToken string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, baseURL+"/userinfo", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() }) //nolint:errcheck // best effort cleanup
+	return resp
+}
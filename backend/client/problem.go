@@ -0,0 +1,81 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Problem types returned by the API, as "scope/code". The full list is in
+// .github/ERRORS.md.
+const (
+	ProblemValidation               = "request/validation-error"
+	ProblemOriginNotAllowed         = "request/origin-not-allowed"
+	ProblemUnauthorized             = "auth/unauthorized"
+	ProblemInsufficientScope        = "auth/insufficient-scope"
+	ProblemSessionRequired          = "auth/session-required"
+	ProblemInvalidCSRFToken         = "auth/invalid-csrf-token"
+	ProblemInvalidRefreshToken      = "auth/invalid-refresh-token"
+	ProblemInvalidCredentials       = "auth/invalid-credentials"
+	ProblemUserDeactivated          = "auth/user-deactivated"
+	ProblemPasswordExpired          = "auth/password-expired"
+	ProblemPasswordChangeRequired   = "auth/password-change-required"
+	ProblemInvalidPasswordChallenge = "auth/invalid-password-challenge"
+	ProblemUserNotDeactivated       = "auth/user-not-deactivated"
+	ProblemEmailAlreadyExists       = "user/email-already-exists"
+	ProblemInvalidPassword          = "user/invalid-current-password"
+	ProblemWeakPassword             = "user/weak-password"
+	ProblemSessionNotFound          = "session/not-found"
+	ProblemServerBusy               = "server/busy"
+)
+
+const typePrefix = "urn:auth-session-api/"
+
+// FieldError is a request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ProblemDetails is an RFC 7807 error returned by the API. Every non-2xx
+// response is decoded into one, so callers can errors.As on it.
+type ProblemDetails struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	TraceID   string       `json:"trace_id,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	// ChallengeToken is set on ProblemPasswordChangeRequired, for
+	// Client.ChangeExpiredPassword.
+	ChallengeToken string `json:"challenge_token,omitempty"`
+	// RetryAfter is how long the server asked to wait before retrying,
+	// from the Retry-After header, or zero when it did not say.
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (p *ProblemDetails) Error() string {
+	message := fmt.Sprintf("migos: %d %s", p.Status, p.Title)
+	if p.Detail != "" {
+		message += ": " + p.Detail
+	}
+	if p.RequestID != "" {
+		message += " (request " + p.RequestID + ")"
+	}
+	return message
+}
+
+// Code returns the problem type without its URN prefix, e.g.
+// "auth/invalid-credentials".
+func (p *ProblemDetails) Code() string {
+	return strings.TrimPrefix(p.Type, typePrefix)
+}
+
+// IsProblem reports whether err is a ProblemDetails with the given code.
+func IsProblem(err error, code string) bool {
+	var problem *ProblemDetails
+	return errors.As(err, &problem) && problem.Code() == code
+}
@@ -0,0 +1,75 @@
+package client_test
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/client"
+)
+
+// pathParam matches the parameters of a route path, such as :provider.
+var pathParam = regexp.MustCompile(`:[a-z_]+`)
+
+func TestSecurityHeaders(t *testing.T) {
+	t.Run("should set the security headers on every route", func(t *testing.T) {
+		t.Parallel()
+
+		c := browser(t, nil)
+		require.NotEmpty(t, routes)
+		for _, route := range routes {
+			path := pathParam.ReplaceAllString(route.Path, "x")
+			req, err := http.NewRequest(route.Method, baseURL+path, nil)
+			require.NoError(t, err)
+
+			resp, err := c.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close() //nolint:errcheck,gosec // only the headers are read
+
+			name := route.Method + " " + route.Path
+			assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"), name)
+			assert.Equal(t, "no-referrer", resp.Header.Get("Referrer-Policy"), name)
+			assert.Contains(t, resp.Header.Get("Permissions-Policy"), "camera=()", name)
+			assert.Equal(t, "DENY", resp.Header.Get("X-Frame-Options"), name)
+			assert.Empty(t, resp.Header.Get("Strict-Transport-Security"), "HSTS is only sent in production: %s", name)
+
+			csp := resp.Header.Get("Content-Security-Policy")
+			assert.True(t, strings.HasPrefix(csp, "default-src 'none'"), name)
+			assert.Contains(t, csp, "frame-ancestors 'none'", name)
+			if route.Path == "/docs" {
+				assert.Contains(t, csp, "script-src https://cdn.jsdelivr.net", name)
+			} else {
+				assert.NotContains(t, csp, "script-src", name)
+			}
+		}
+	})
+
+	t.Run("should set them on unknown paths and on the pages", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := http.Get(baseURL + "/does-not-exist")
+		require.NoError(t, err)
+		resp.Body.Close() //nolint:errcheck,gosec // only the headers are read
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		newAccount(t, mustClient(t, client.WithHTTPClient(&http.Client{Jar: jar})))
+
+		resp, err = browser(t, jar).Get(baseURL + "/device")
+		require.NoError(t, err)
+		resp.Body.Close() //nolint:errcheck,gosec // only the headers are read
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/html; charset=UTF-8", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "default-src 'none'; base-uri 'none'; frame-ancestors 'none'", resp.Header.Get("Content-Security-Policy"))
+		assert.Equal(t, "DENY", resp.Header.Get("X-Frame-Options"))
+	})
+}
@@ -0,0 +1,91 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/client"
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+func TestServiceAccounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	admin := mustClient(t)
+	account := newAccount(t, admin)
+	require.NoError(t, adminService.GrantRole(ctx, account.Email, domain.RoleAdmin))
+
+	created, err := admin.CreateServiceAccount(ctx, client.CreateServiceAccountRequest{
+		Name: "billing-worker", Scopes: []string{domain.RoleAdmin, "orders:read"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ClientSecret)
+	require.Len(t, created.Secrets, 1)
+
+	t.Run("should issue a token with every scope by default", func(t *testing.T) {
+		worker := mustClient(t, client.WithBearerAuth())
+
+		token, err := worker.ClientCredentials(ctx, created.ID, created.ClientSecret, "")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer", token.TokenType)
+		assert.Equal(t, "admin orders:read", token.Scope)
+
+		accounts, err := worker.ListServiceAccounts(ctx)
+		require.NoError(t, err)
+		assert.NotEmpty(t, accounts)
+	})
+
+	t.Run("should not let a narrowed token manage service accounts", func(t *testing.T) {
+		worker := mustClient(t, client.WithBearerAuth())
+
+		_, err := worker.ClientCredentials(ctx, created.ID, created.ClientSecret, "orders:read")
+		require.NoError(t, err)
+
+		_, err = worker.ListServiceAccounts(ctx)
+		assert.True(t, client.IsProblem(err, client.ProblemInsufficientScope), err)
+	})
+
+	t.Run("should reject scopes the account was not granted", func(t *testing.T) {
+		_, err := mustClient(t, client.WithBearerAuth()).ClientCredentials(ctx, created.ID, created.ClientSecret, "orders:write")
+		assert.True(t, client.IsProblem(err, "oauth/invalid-scope"), err)
+	})
+
+	t.Run("should rotate secrets", func(t *testing.T) {
+		secret, err := admin.CreateServiceAccountSecret(ctx, created.ID)
+		require.NoError(t, err)
+
+		_, err = mustClient(t, client.WithBearerAuth()).ClientCredentials(ctx, created.ID, secret.ClientSecret, "")
+		require.NoError(t, err)
+
+		require.NoError(t, admin.DeleteServiceAccountSecret(ctx, created.ID, created.Secrets[0].ID))
+		_, err = mustClient(t, client.WithBearerAuth()).ClientCredentials(ctx, created.ID, created.ClientSecret, "")
+		assert.True(t, client.IsProblem(err, "oauth/invalid-client"), err)
+	})
+
+	t.Run("should forbid users without the admin role", func(t *testing.T) {
+		user := mustClient(t)
+		newAccount(t, user)
+
+		_, err := user.ListServiceAccounts(ctx)
+		assert.True(t, client.IsProblem(err, client.ProblemInsufficientScope), err)
+	})
+
+	t.Run("should reject the tokens of a deleted account", func(t *testing.T) {
+		other, err := admin.CreateServiceAccount(ctx, client.CreateServiceAccountRequest{
+			Name: "short-lived", Scopes: []string{domain.RoleAdmin},
+		})
+		require.NoError(t, err)
+		worker := mustClient(t, client.WithBearerAuth())
+		_, err = worker.ClientCredentials(ctx, other.ID, other.ClientSecret, "")
+		require.NoError(t, err)
+
+		require.NoError(t, admin.DeleteServiceAccount(ctx, other.ID))
+
+		_, err = worker.ListServiceAccounts(ctx)
+		assert.True(t, client.IsProblem(err, client.ProblemUnauthorized), err)
+	})
+}
@@ -0,0 +1,138 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/client"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/social/socialtest"
+)
+
+// socialLogin signs user in through the fake provider with a browser using
+// jar and returns the page the callback answered with.
+func socialLogin(t *testing.T, jar http.CookieJar, user socialtest.User) (*http.Response, string) {
+	t.Helper()
+	b := browser(t, jar)
+
+	resp, err := b.Get(baseURL + "/v1/auth/social/idp?return_to=%2Fwelcome")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := socialtest.SignIn(resp.Header.Get("Location"), user)
+	require.NoError(t, err)
+
+	resp, err = b.Get(callback)
+	require.NoError(t, err)
+	page, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp, string(page)
+}
+
+func TestSocialLogin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the configured providers", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := http.Get(baseURL + "/v1/auth/providers")
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // best effort cleanup
+
+		assert.Equal(t, domain.SocialProvidersResponse{Providers: []domain.SocialProviderInfo{{Name: "idp", DisplayName: "idp"}}},
+			decode[domain.SocialProvidersResponse](t, resp))
+	})
+
+	t.Run("should provision a user and sign in again as the same user", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		user := socialtest.User{
+			Subject: fmt.Sprintf("idp-%d", emails.Add(1)), Email: fmt.Sprintf("social-%d@example.com", emails.Add(1)),
+			EmailVerified: true, Name: "Social User",
+		}
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		resp, page := socialLogin(t, jar, user)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, page, `href="/welcome"`)
+
+		me, err := mustClient(t, client.WithHTTPClient(&http.Client{Jar: jar})).Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, user.Email, me.Email)
+		assert.Equal(t, "Social User", me.Name)
+
+		// The subject stays linked even when the provider email changes.
+		user.Email = "changed-" + user.Email
+		other, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		resp, _ = socialLogin(t, other, user)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		again, err := mustClient(t, client.WithHTTPClient(&http.Client{Jar: other})).Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, me.ID, again.ID)
+	})
+
+	t.Run("should link the account with the same verified email", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+
+		account := newAccount(t, mustClient(t))
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+
+		resp, _ := socialLogin(t, jar, socialtest.User{
+			Subject: fmt.Sprintf("idp-%d", emails.Add(1)), Email: account.Email, EmailVerified: true, Name: "Someone Else",
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		me, err := mustClient(t, client.WithHTTPClient(&http.Client{Jar: jar})).Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, account.Email, me.Email)
+		assert.Equal(t, account.Name, me.Name)
+	})
+
+	t.Run("should not link an account to an unverified email", func(t *testing.T) {
+		t.Parallel()
+
+		account := newAccount(t, mustClient(t))
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+
+		resp, page := socialLogin(t, jar, socialtest.User{
+			Subject: fmt.Sprintf("idp-%d", emails.Add(1)), Email: account.Email, Name: "Impostor",
+		})
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Contains(t, page, "social/email-not-verified")
+	})
+
+	t.Run("should reject a callback in a browser that did not start the login", func(t *testing.T) {
+		t.Parallel()
+
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+		resp, err := browser(t, jar).Get(baseURL + "/v1/auth/social/idp")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		callback, err := socialtest.SignIn(resp.Header.Get("Location"), socialtest.User{Subject: "victim", Email: "victim@example.com", EmailVerified: true})
+		require.NoError(t, err)
+
+		resp, err = browser(t, nil).Get(callback)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,144 @@
+package client
+
+import "time"
+
+// Tokens are the session tokens issued by login, account creation,
+// reactivation and refresh.
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type CreateAccountRequest struct {
+	Name     string `json:"name"`
+	Avatar   string `json:"avatar,omitempty"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// ChangeExpiredPasswordRequest redeems the challenge token of a
+// ProblemPasswordChangeRequired returned by Login.
+type ChangeExpiredPasswordRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	NewPassword    string `json:"new_password"`
+}
+
+type UpdatePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// UpdateProfileRequest changes only the fields that are set.
+type UpdateProfileRequest struct {
+	Name   string `json:"name,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+type User struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Avatar string `json:"avatar"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Session is a signed in browser or device. Sessions started by an OAuth
+// client, such as devices approved through the device flow, name it.
+type Session struct {
+	ID         string    `json:"id"`
+	ClientID   string    `json:"client_id,omitempty"`
+	ClientName string    `json:"client_name,omitempty"`
+	Scope      string    `json:"scope,omitempty"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// API key scopes: read allows GET, HEAD and OPTIONS, write every method.
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeWrite = "write"
+)
+
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// APIKey describes a key without the key itself; Prefix tells keys apart.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKey carries the key, which the API shows only once.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type clientCredentialsRequest struct {
+	GrantType    string `json:"grant_type"`
+	Scope        string `json:"scope,omitempty"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// ServiceAccountToken is issued by the client_credentials grant. Scope lists
+// the granted scopes, separated by spaces.
+type ServiceAccountToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+type CreateServiceAccountRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// ServiceAccountSecret describes a secret without the secret itself.
+type ServiceAccountSecret struct {
+	ID         string     `json:"id"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedServiceAccountSecret carries the secret, which the API shows only
+// once.
+type CreatedServiceAccountSecret struct {
+	ServiceAccountSecret
+	ClientSecret string `json:"client_secret"`
+}
+
+// ServiceAccount is a machine identity; its ID is the OAuth client_id.
+type ServiceAccount struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Scopes    []string               `json:"scopes"`
+	Secrets   []ServiceAccountSecret `json:"secrets"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// CreatedServiceAccount carries the first client secret, which the API shows
+// only once.
+type CreatedServiceAccount struct {
+	ServiceAccount
+	ClientSecret string `json:"client_secret"`
+}
@@ -5,17 +5,14 @@ import (
 	"time"
 
 	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/container"
 	"github.com/SergioLNeves/migos/internal/domain"
-	"github.com/SergioLNeves/migos/internal/handler"
-	authmiddleware "github.com/SergioLNeves/migos/internal/middleware"
 	"github.com/SergioLNeves/migos/internal/pkg/logging"
-	validator "github.com/SergioLNeves/migos/internal/pkg/validator"
-	"github.com/SergioLNeves/migos/internal/repository"
-	"github.com/SergioLNeves/migos/internal/security"
-	"github.com/SergioLNeves/migos/internal/service"
-	"github.com/SergioLNeves/migos/internal/storage/sqlite"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+	"github.com/SergioLNeves/migos/internal/server"
+	"github.com/SergioLNeves/migos/internal/storage"
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 	"github.com/samber/do"
 	"go.uber.org/zap"
 )
@@ -33,113 +30,63 @@ func main() {
 	logger = logging.NewLogger(&config.Env)
 	defer logger.Sync()
 
-	e := echo.New()
-	e.Use(middleware.RequestLogger())
-	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
-	e.Validator = validator.NewValidator()
+	shutdownTracing, err := tracing.Init(context.Background(), &config.Env.Tracing)
+	if err != nil {
+		logger.Fatal("init tracing", zap.Error(err))
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("shutdown tracing", zap.Error(err))
+		}
+	}()
 
 	initDependencies(logger)
 	defer func() {
 		if err := injector.Shutdown(); err != nil {
-			e.Logger.Errorf("shutdown injector: %w", err)
+			logger.Error("shutdown injector", zap.Error(err))
 		}
 	}()
 
-	configureHealthcheckRoute(e)
-	configureAuthRoute(e)
-
-	sessionRepo := do.MustInvoke[domain.SessionRepository](injector)
-	startSessionCleanup(sessionRepo)
-
-	authRepo := do.MustInvoke[domain.AuthRepository](injector)
-	startUserCleanup(authRepo)
-
-	api := config.NewAPI(e, config.Env.Port, 10*time.Second)
-	api.Start()
-}
-
-func configureHealthcheckRoute(e *echo.Echo) {
-	healthCheckHandler, err := do.Invoke[domain.HealthCheckHandler](injector)
-	if err != nil {
-		logger.Fatal("invoke healthcheck handler", zap.Error(err))
+	if config.Env.SQL.AutoMigrate {
+		if err := do.MustInvoke[storage.Storage](injector).Migrate(context.Background()); err != nil {
+			logger.Fatal("migrate database", zap.Error(err))
+		}
 	}
 
-	e.GET("/health", healthCheckHandler.Check)
-}
-
-func configureAuthRoute(e *echo.Echo) {
-	tokenProvider := do.MustInvoke[domain.TokenProvider](injector)
-	sessionRepo := do.MustInvoke[domain.SessionRepository](injector)
-	authRepo := do.MustInvoke[domain.AuthRepository](injector)
-	authHandler, err := do.Invoke[domain.AuthHandler](injector)
+	e, err := server.New(injector, &config.Env)
 	if err != nil {
-		logger.Fatal("invoke auth handler", zap.Error(err))
+		logger.Fatal("configure server", zap.Error(err))
 	}
-	sessionAuth := authmiddleware.SessionAuth(tokenProvider, sessionRepo, authRepo)
 
-	v1 := e.Group("/v1")
-	userGroup := v1.Group("/user")
-	userGroup.POST("/create-account", authHandler.CreateAccount)
-	userGroup.PATCH("/password", authHandler.UpdatePassword, sessionAuth)
-	userGroup.PATCH("/profile", authHandler.UpdateUser, sessionAuth)
-	userGroup.DELETE("", authHandler.DeleteUser, sessionAuth)
-	userGroup.PATCH("/reactivate", authHandler.ReactivateAccount)
+	scheduler := do.MustInvoke[domain.JobScheduler](injector)
+	scheduler.Start()
 
-	authGroup := v1.Group("/auth")
-	authGroup.POST("/login", authHandler.Login)
-	authGroup.POST("/logout", authHandler.Logout, sessionAuth)
-	authGroup.GET("/me", authHandler.Me, sessionAuth)
+	api := config.NewAPI(e, config.Env.Port, 10*time.Second)
+	api.OnShutdown(drainHealthCheck)
+	if config.Env.Metrics.Port != 0 {
+		api.AddListener(newMetricsServer(), config.Env.Metrics.Port)
+	}
+	api.Start()
 }
 
-func startSessionCleanup(sessionRepo domain.SessionRepository) {
-	ticker := time.NewTicker(12 * time.Hour)
-	go func() {
-		for range ticker.C {
-			deleted, err := sessionRepo.DeleteExpiredSessions(context.Background())
-			if err != nil {
-				logger.Error("session cleanup failed", zap.Error(err))
-				continue
-			}
-			if deleted > 0 {
-				logger.Info("expired sessions cleaned up", zap.Int64("deleted", deleted))
-			}
-		}
-	}()
+// newMetricsServer exposes /metrics on its own listener so it stays off the
+// public API port.
+func newMetricsServer() *echo.Echo {
+	e := echo.New()
+	e.HidePort = true
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+	return e
 }
 
-func startUserCleanup(authRepo domain.AuthRepository) {
-	ticker := time.NewTicker(24 * time.Hour)
-	go func() {
-		for range ticker.C {
-			deleted, err := authRepo.DeleteDeactivatedUsers(context.Background())
-			if err != nil {
-				logger.Error("user cleanup failed", zap.Error(err))
-				continue
-			}
-			if deleted > 0 {
-				logger.Info("deactivated users cleaned up", zap.Int64("deleted", deleted))
-			}
-		}
-	}()
+// drainHealthCheck flips readiness to not-ready and waits HEALTH_DRAIN_DELAY
+// so load balancers stop sending traffic before the listener closes.
+func drainHealthCheck() {
+	do.MustInvoke[domain.HealthCheckerService](injector).Drain()
+	time.Sleep(config.Env.Health.DrainDelay)
 }
 
 func initDependencies(logger *zap.Logger) {
-	injector = do.New()
-
-	do.ProvideValue(injector, logger)
-
-	do.Provide(injector, sqlite.NewSQLite)
-
-	do.Provide(injector, repository.NewAuthRepository)
-	do.Provide(injector, repository.NewSessionRepository)
-
-	do.Provide(injector, security.NewJWTProvider)
-	do.Provide(injector, security.NewBcryptHasher)
-
-	do.Provide(injector, service.NewHealthCheckService)
-	do.Provide(injector, service.NewAuthService)
-
-	do.Provide(injector, handler.NewHealthCheckHandler)
-	do.Provide(injector, handler.NewAuthHandler)
+	injector = container.New(logger)
 }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+// stringList is a flag that may be repeated.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// clientCreate prints the client ID and secret. The secret cannot be
+// recovered afterwards; delete the client and create a new one instead.
+func clientCreate(ctx context.Context, args []string) error {
+	fs := newFlagSet("client create")
+	name := fs.String("name", "", "client name, such as the service calling the API")
+	public := fs.Bool("public", false, "create a client without a secret, authenticated by PKCE only")
+	var redirectURIs stringList
+	fs.Var(&redirectURIs, "redirect-uri", "OpenID Connect redirect URI (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return errors.New("--name is required")
+	}
+
+	request := domain.NewOAuthClientRequest{Name: *name, RedirectURIs: redirectURIs, Public: *public}
+
+	return withInjector(func(injector *do.Injector) error {
+		oauthService := do.MustInvoke[domain.OAuthService](injector)
+		client, secret, err := oauthService.CreateClient(ctx, request)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("client_id=%s\n", client.ID)
+		if secret != "" {
+			fmt.Printf("client_secret=%s\n", secret)
+		}
+		return nil
+	})
+}
+
+func clientList(ctx context.Context, args []string) error {
+	fs := newFlagSet("client list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		oauthService := do.MustInvoke[domain.OAuthService](injector)
+		clients, err := oauthService.ListClients(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tPUBLIC\tREDIRECT URIS\tCREATED")
+		for _, client := range clients {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\n",
+				client.ID,
+				client.Name,
+				client.Public(),
+				strings.Join(client.RedirectURIs, " "),
+				client.CreatedAt.Format(time.RFC3339),
+			)
+		}
+		return w.Flush()
+	})
+}
+
+func clientDelete(ctx context.Context, args []string) error {
+	fs := newFlagSet("client delete")
+	id := fs.String("id", "", "client ID to delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	clientID, err := uuid.Parse(*id)
+	if err != nil {
+		return fmt.Errorf("invalid client ID: %w", err)
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		oauthService := do.MustInvoke[domain.OAuthService](injector)
+		return oauthService.DeleteClient(ctx, clientID)
+	})
+}
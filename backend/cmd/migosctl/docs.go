@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/SergioLNeves/migos/internal/handler"
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+	"github.com/SergioLNeves/migos/internal/pkg/openapi"
+	"github.com/SergioLNeves/migos/internal/router"
+)
+
+func docsErrors(_ context.Context, args []string) error {
+	fs := newFlagSet("docs errors")
+	out := fs.String("out", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return writeDoc(*out, writeErrorsDoc)
+}
+
+func docsOpenAPI(_ context.Context, args []string) error {
+	fs := newFlagSet("docs openapi")
+	out := fs.String("out", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return writeDoc(*out, writeOpenAPIDoc)
+}
+
+// writeDoc runs write against stdout, or against the file at out when set.
+func writeDoc(out string, write func(io.Writer) error) error {
+	if out == "" {
+		return write(os.Stdout)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", out, err)
+	}
+	defer f.Close() //nolint:errcheck // close error is irrelevant after a failed write
+
+	if err := write(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// writeErrorsDoc renders errorpkg.Errors as a markdown table, so the error
+// catalogue can't drift from what the API actually returns.
+func writeErrorsDoc(w io.Writer) error {
+	lines := []string{
+		"# Erros da API",
+		"",
+		"<!-- Gerado por `migosctl docs errors`; nao edite manualmente. -->",
+		"",
+		"Todas as falhas sao respondidas como `application/problem+json` (RFC 7807), com `instance`, `trace_id` e `request_id` preenchidos por requisicao. Erros de validacao incluem a lista `errors` com os campos invalidos. Erros do escopo `oauth` trazem tambem o membro `error` com o codigo do RFC 6749 (por exemplo `invalid_grant`), lido pelas bibliotecas de cliente OAuth.",
+		"",
+		"| Tipo | Status | Titulo | Detalhe |",
+		"|---|---|---|---|",
+	}
+	for _, entry := range errorpkg.Errors.Entries() {
+		lines = append(lines, fmt.Sprintf("| `%s` | %d | %s | %s |",
+			entry.Problem().Type, entry.Status, entry.Title, entry.Detail))
+	}
+	lines = append(lines, "",
+		"Erros gerados pelo proprio Echo (rota inexistente, metodo nao permitido) usam o tipo `urn:auth-session-api/http/<status>`, por exemplo `urn:auth-session-api/http/not-found`.")
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeOpenAPIDoc renders the OpenAPI document of the route table. Handlers
+// are never called, so zero values stand in for the wired ones.
+func writeOpenAPIDoc(w io.Writer) error {
+	routes := router.Routes(router.Handlers{
+		Health: handler.HealthCheckHandlerImpl{},
+		Auth:   handler.AuthHandlerImpl{},
+		Keys:   handler.KeysHandlerImpl{},
+		OAuth:  handler.OAuthHandlerImpl{},
+		OIDC:   handler.OIDCHandlerImpl{},
+		Social: handler.SocialHandlerImpl{},
+		APIKey: handler.APIKeyHandlerImpl{},
+
+		ServiceAccount: handler.ServiceAccountHandlerImpl{},
+	})
+	body, err := openapi.Encode(router.Document(routes))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
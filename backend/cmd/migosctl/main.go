@@ -0,0 +1,140 @@
+// Command migosctl performs operational tasks against the auth service
+// database and key material using the same wiring as the API server.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/container"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, args []string) error
+}
+
+var commands = map[string]map[string]command{
+	"user": {
+		"create":          {usage: "--name NAME --email EMAIL [--password PASSWORD]", run: userCreate},
+		"reset-password":  {usage: "--email EMAIL [--password PASSWORD]", run: userResetPassword},
+		"expire-password": {usage: "--email EMAIL", run: userExpirePassword},
+		"grant-role":      {usage: "--email EMAIL --role ROLE", run: userGrantRole},
+		"revoke-role":     {usage: "--email EMAIL --role ROLE", run: userRevokeRole},
+	},
+	"session": {
+		"list":   {usage: "--email EMAIL", run: sessionList},
+		"revoke": {usage: "--id SESSION_ID | --email EMAIL", run: sessionRevoke},
+	},
+	"client": {
+		"create": {usage: "--name NAME [--redirect-uri URI...] [--public]", run: clientCreate},
+		"list":   {usage: "", run: clientList},
+		"delete": {usage: "--id CLIENT_ID", run: clientDelete},
+	},
+	"db": {
+		"migrate": {usage: "", run: dbMigrate},
+	},
+	"jobs": {
+		"list": {usage: "", run: jobsList},
+		"run":  {usage: "[JOB...]", run: jobsRun},
+	},
+	"keys": {
+		"generate": {usage: "[--private PATH] [--public PATH] [--bits N]", run: keysGenerate},
+		"rotate":   {usage: "[--private PATH] [--public PATH] [--bits N]", run: keysRotate},
+	},
+	"secrets": {
+		"generate-key": {usage: "--id KEY_ID", run: secretsGenerateKey},
+		"reencrypt":    {usage: "", run: secretsReencrypt},
+	},
+	"config": {
+		"dump": {usage: "", run: configDump},
+	},
+	"docs": {
+		"errors":  {usage: "[--out PATH]", run: docsErrors},
+		"openapi": {usage: "[--out PATH]", run: docsOpenAPI},
+	},
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "migosctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) < 2 {
+		printUsage()
+		return errors.New("missing command")
+	}
+
+	group, ok := commands[args[0]]
+	if !ok {
+		printUsage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	cmd, ok := group[args[1]]
+	if !ok {
+		printUsage()
+		return fmt.Errorf("unknown command %q", args[0]+" "+args[1])
+	}
+
+	return cmd.run(context.Background(), args[2:])
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "usage: migosctl <command> <subcommand> [flags]")
+	fmt.Fprintln(os.Stderr)
+
+	groups := make([]string, 0, len(commands))
+	for name := range commands {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		subs := make([]string, 0, len(commands[group]))
+		for name := range commands[group] {
+			subs = append(subs, name)
+		}
+		sort.Strings(subs)
+		for _, sub := range subs {
+			fmt.Fprintf(os.Stderr, "  %s %s %s\n", group, sub, commands[group][sub].usage)
+		}
+	}
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting.
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// withInjector loads the environment, builds the shared container and tears
+// it down once fn returns.
+func withInjector(fn func(injector *do.Injector) error) error {
+	if err := config.LoadEnv(); err != nil {
+		return err
+	}
+
+	logger := logging.NewLogger(&config.Env)
+	defer logger.Sync() //nolint:errcheck // best-effort flush on exit
+
+	injector := container.New(logger)
+	defer func() {
+		if err := injector.Shutdown(); err != nil {
+			logger.Error("shutdown injector", zap.Error(err))
+		}
+	}()
+
+	return fn(injector)
+}
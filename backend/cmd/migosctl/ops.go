@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/security"
+	"github.com/SergioLNeves/migos/internal/storage"
+)
+
+// dbMigrate brings the schema up to date. Building the storage doesn't
+// migrate, so this is the only command that changes the schema; run it
+// before the others on a new database or with DB_AUTO_MIGRATE=false.
+func dbMigrate(ctx context.Context, args []string) error {
+	if err := newFlagSet("db migrate").Parse(args); err != nil {
+		return err
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		db := do.MustInvoke[storage.Storage](injector)
+		return db.Migrate(ctx)
+	})
+}
+
+func jobsList(_ context.Context, args []string) error {
+	if err := newFlagSet("jobs list").Parse(args); err != nil {
+		return err
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		scheduler := do.MustInvoke[domain.JobScheduler](injector)
+		for _, name := range scheduler.Jobs() {
+			fmt.Println(name)
+		}
+		return nil
+	})
+}
+
+func jobsRun(ctx context.Context, args []string) error {
+	fs := newFlagSet("jobs run")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		scheduler := do.MustInvoke[domain.JobScheduler](injector)
+
+		names := fs.Args()
+		if len(names) == 0 {
+			names = scheduler.Jobs()
+		}
+
+		for _, name := range names {
+			deleted, err := scheduler.RunNow(ctx, name)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			fmt.Printf("%s\t%d\n", name, deleted)
+		}
+		return nil
+	})
+}
+
+func keysGenerate(_ context.Context, args []string) error {
+	privatePath, publicPath, bits, err := parseKeyFlags("keys generate", args)
+	if err != nil {
+		return err
+	}
+
+	if err := security.GenerateRSAKeyPair(privatePath, publicPath, bits); err != nil {
+		return err
+	}
+
+	fmt.Printf("generated %s and %s\n", privatePath, publicPath)
+	return nil
+}
+
+func keysRotate(_ context.Context, args []string) error {
+	privatePath, publicPath, bits, err := parseKeyFlags("keys rotate", args)
+	if err != nil {
+		return err
+	}
+
+	privBackup, pubBackup, err := security.RotateRSAKeyPair(privatePath, publicPath, bits)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("previous keys moved to %s and %s\n", privBackup, pubBackup)
+	fmt.Printf("generated %s and %s; restart the server to start signing with them\n", privatePath, publicPath)
+	return nil
+}
+
+// parseKeyFlags defaults the key paths to the configured ones when the
+// environment can be loaded, so the command also works before a .env exists.
+func parseKeyFlags(name string, args []string) (string, string, int, error) {
+	defaultPrivate, defaultPublic := "private-key.pem", "public-key.pem"
+	if err := config.LoadEnv(); err == nil {
+		defaultPrivate = config.Env.Keys.PrivateKeyPath
+		defaultPublic = config.Env.Keys.PublicKeyPath
+	}
+
+	fs := newFlagSet(name)
+	privatePath := fs.String("private", defaultPrivate, "private key path")
+	publicPath := fs.String("public", defaultPublic, "public key path")
+	bits := fs.Int("bits", security.DefaultKeySize, "RSA key size in bits")
+	if err := fs.Parse(args); err != nil {
+		return "", "", 0, err
+	}
+
+	return *privatePath, *publicPath, *bits, nil
+}
+
+// secretsGenerateKey prints a new key entry, valid both as a pepper key and
+// as an encryption key, for the key settings or files.
+func secretsGenerateKey(_ context.Context, args []string) error {
+	fs := newFlagSet("secrets generate-key")
+	id := fs.String("id", "", "key ID, e.g. the date it was created")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	entry, err := security.GenerateKey(*id, security.EncryptionKeySize)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(entry)
+	return nil
+}
+
+// secretsReencrypt seals the encrypted columns of existing rows under the
+// current primary key, e.g. after adding a key or enabling ENCRYPTION_PII.
+func secretsReencrypt(ctx context.Context, args []string) error {
+	if err := newFlagSet("secrets reencrypt").Parse(args); err != nil {
+		return err
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		db := do.MustInvoke[storage.Storage](injector)
+		columns, err := db.ReencryptColumns(ctx)
+		for _, column := range columns {
+			fmt.Printf("%s\t%d\n", column.Column, column.Rows)
+		}
+		return err
+	})
+}
+
+func configDump(_ context.Context, args []string) error {
+	if err := newFlagSet("config dump").Parse(args); err != nil {
+		return err
+	}
+
+	if err := config.LoadEnv(); err != nil {
+		return err
+	}
+
+	for _, line := range config.Dump(&config.Env) {
+		fmt.Println(line)
+	}
+	return nil
+}
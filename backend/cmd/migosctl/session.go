@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+func sessionList(ctx context.Context, args []string) error {
+	fs := newFlagSet("session list")
+	email := fs.String("email", "", "email address")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("--email is required")
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		adminService := do.MustInvoke[domain.AdminService](injector)
+		sessions, err := adminService.ListSessions(ctx, *email)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tCREATED\tEXPIRES")
+		for _, session := range sessions {
+			fmt.Fprintf(w, "%s\t%s\t%s\n",
+				session.ID,
+				session.CreatedAt.Format(time.RFC3339),
+				session.ExpiresAt.Format(time.RFC3339),
+			)
+		}
+		return w.Flush()
+	})
+}
+
+func sessionRevoke(ctx context.Context, args []string) error {
+	fs := newFlagSet("session revoke")
+	id := fs.String("id", "", "session ID to revoke")
+	email := fs.String("email", "", "revoke every session of this user")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if (*id == "") == (*email == "") {
+		return errors.New("exactly one of --id or --email is required")
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		adminService := do.MustInvoke[domain.AdminService](injector)
+		if *email != "" {
+			return adminService.RevokeUserSessions(ctx, *email)
+		}
+
+		sessionID, err := uuid.Parse(*id)
+		if err != nil {
+			return fmt.Errorf("invalid session ID: %w", err)
+		}
+		return adminService.RevokeSession(ctx, sessionID)
+	})
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	validatorpkg "github.com/SergioLNeves/migos/internal/pkg/validator"
+)
+
+func userCreate(ctx context.Context, args []string) error {
+	fs := newFlagSet("user create")
+	name := fs.String("name", "", "display name")
+	email := fs.String("email", "", "email address")
+	password := fs.String("password", "", "initial password (read from stdin when omitted)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	pw, err := passwordOrStdin(*password)
+	if err != nil {
+		return err
+	}
+
+	request := domain.CreateAccountRequest{Name: *name, Email: *email, Password: pw}
+	if err := validatorpkg.NewValidator().Validate(request); err != nil {
+		return err
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		adminService := do.MustInvoke[domain.AdminService](injector)
+		user, err := adminService.CreateUser(ctx, request)
+		if err != nil {
+			return err
+		}
+		fmt.Println(user.ID)
+		return nil
+	})
+}
+
+func userResetPassword(ctx context.Context, args []string) error {
+	fs := newFlagSet("user reset-password")
+	email := fs.String("email", "", "email address")
+	password := fs.String("password", "", "new password (read from stdin when omitted)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	pw, err := passwordOrStdin(*password)
+	if err != nil {
+		return err
+	}
+
+	request := domain.ResetPasswordRequest{Email: *email, Password: pw}
+	if err := validatorpkg.NewValidator().Validate(request); err != nil {
+		return err
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		adminService := do.MustInvoke[domain.AdminService](injector)
+		return adminService.ResetPassword(ctx, request)
+	})
+}
+
+func userExpirePassword(ctx context.Context, args []string) error {
+	fs := newFlagSet("user expire-password")
+	email := fs.String("email", "", "email address")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("--email is required")
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		adminService := do.MustInvoke[domain.AdminService](injector)
+		return adminService.ExpirePassword(ctx, *email)
+	})
+}
+
+func userGrantRole(ctx context.Context, args []string) error {
+	email, role, err := parseRoleFlags("user grant-role", args)
+	if err != nil {
+		return err
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		adminService := do.MustInvoke[domain.AdminService](injector)
+		return adminService.GrantRole(ctx, email, role)
+	})
+}
+
+func userRevokeRole(ctx context.Context, args []string) error {
+	email, role, err := parseRoleFlags("user revoke-role", args)
+	if err != nil {
+		return err
+	}
+
+	return withInjector(func(injector *do.Injector) error {
+		adminService := do.MustInvoke[domain.AdminService](injector)
+		return adminService.RevokeRole(ctx, email, role)
+	})
+}
+
+func parseRoleFlags(name string, args []string) (string, string, error) {
+	fs := newFlagSet(name)
+	email := fs.String("email", "", "email address")
+	role := fs.String("role", "", "role name ("+strings.Join(domain.ValidRoles, ", ")+")")
+	if err := fs.Parse(args); err != nil {
+		return "", "", err
+	}
+	if *email == "" || *role == "" {
+		return "", "", errors.New("--email and --role are required")
+	}
+	return *email, *role, nil
+}
+
+// passwordOrStdin keeps passwords out of shell history by reading a single
+// line from stdin when no flag value was given.
+func passwordOrStdin(password string) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+
+	fmt.Fprint(os.Stderr, "password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
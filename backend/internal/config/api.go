@@ -15,6 +15,13 @@ type API struct {
 	echo            *echo.Echo
 	port            int
 	shutdownTimeout time.Duration
+	auxiliary       []listener
+	onShutdown      []func()
+}
+
+type listener struct {
+	echo *echo.Echo
+	port int
 }
 
 func NewAPI(e *echo.Echo, port int, shutdownTimeout time.Duration) *API {
@@ -25,6 +32,19 @@ func NewAPI(e *echo.Echo, port int, shutdownTimeout time.Duration) *API {
 	}
 }
 
+// OnShutdown registers a hook that runs after the stop signal is received and
+// before the servers stop accepting connections, e.g. to start draining.
+func (s *API) OnShutdown(fn func()) {
+	s.onShutdown = append(s.onShutdown, fn)
+}
+
+// AddListener registers an auxiliary server, such as the metrics endpoint,
+// that is started and shut down together with the main one.
+func (s *API) AddListener(e *echo.Echo, port int) {
+	e.HideBanner = true
+	s.auxiliary = append(s.auxiliary, listener{echo: e, port: port})
+}
+
 func (s *API) Start() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -36,9 +56,22 @@ func (s *API) Start() {
 		}
 	}()
 
+	for _, aux := range s.auxiliary {
+		go func() {
+			address := fmt.Sprintf(":%d", aux.port)
+			if err := aux.echo.Start(address); err != nil {
+				aux.echo.Logger.Info("Shutting down auxiliary listener on ", address)
+			}
+		}()
+	}
+
 	<-quit
 	s.echo.Logger.Info("Server is shutting down...")
 
+	for _, fn := range s.onShutdown {
+		fn()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
@@ -46,5 +79,11 @@ func (s *API) Start() {
 		s.echo.Logger.Error("Server forced to shutdown, err:", err)
 	}
 
+	for _, aux := range s.auxiliary {
+		if err := aux.echo.Shutdown(ctx); err != nil {
+			aux.echo.Logger.Error("Auxiliary listener forced to shutdown, err:", err)
+		}
+	}
+
 	s.echo.Logger.Info("Server exited gracefully")
 }
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+// Redacted replaces the value of any config field tagged `secret:"true"`.
+const Redacted = "[REDACTED]"
+
+// Dump renders the configuration as KEY=value lines in declaration order.
+// Fields tagged `secret:"true"` are redacted when set.
+func Dump(cfg *domain.Config) []string {
+	var lines []string
+	dumpStruct(reflect.ValueOf(cfg).Elem(), &lines)
+	return lines
+}
+
+func dumpStruct(v reflect.Value, lines *[]string) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		tag, ok := field.Tag.Lookup("env")
+		if !ok {
+			if value.Kind() == reflect.Struct {
+				dumpStruct(value, lines)
+			}
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		rendered := fmt.Sprint(value.Interface())
+		if field.Tag.Get("secret") == "true" && !value.IsZero() {
+			rendered = Redacted
+		}
+		*lines = append(*lines, name+"="+rendered)
+	}
+}
@@ -0,0 +1,63 @@
+package container
+
+import (
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/handler"
+	"github.com/SergioLNeves/migos/internal/jobs"
+	"github.com/SergioLNeves/migos/internal/pkg/cookie"
+	"github.com/SergioLNeves/migos/internal/repository"
+	"github.com/SergioLNeves/migos/internal/security"
+	"github.com/SergioLNeves/migos/internal/service"
+	"github.com/SergioLNeves/migos/internal/social"
+	"github.com/SergioLNeves/migos/internal/storage/sqlite"
+)
+
+// New registers every application dependency. It is shared by the API server
+// and migosctl so both operate on the same wiring.
+func New(logger *zap.Logger) *do.Injector {
+	injector := do.New()
+
+	do.ProvideValue(injector, logger)
+
+	do.Provide(injector, sqlite.NewSQLite)
+
+	do.Provide(injector, repository.NewAuthRepository)
+	do.Provide(injector, repository.NewSessionRepository)
+	do.Provide(injector, repository.NewOAuthClientRepository)
+	do.Provide(injector, repository.NewAuthorizationRepository)
+	do.Provide(injector, repository.NewIdentityRepository)
+	do.Provide(injector, repository.NewAPIKeyRepository)
+	do.Provide(injector, repository.NewServiceAccountRepository)
+
+	do.Provide(injector, security.NewJWTProvider)
+	do.Provide(injector, security.NewPasswordHasher)
+	do.Provide(injector, security.NewPasswordPolicy)
+	do.Provide(injector, security.NewFieldCipher)
+
+	do.Provide(injector, social.NewProviders)
+	do.Provide(injector, cookie.NewManager)
+
+	do.Provide(injector, service.NewHealthCheckService)
+	do.Provide(injector, service.NewAuthService)
+	do.Provide(injector, service.NewAdminService)
+	do.Provide(injector, service.NewOAuthService)
+	do.Provide(injector, service.NewOIDCService)
+	do.Provide(injector, service.NewSocialService)
+	do.Provide(injector, service.NewAPIKeyService)
+	do.Provide(injector, service.NewServiceAccountService)
+
+	do.Provide(injector, jobs.NewScheduler)
+
+	do.Provide(injector, handler.NewHealthCheckHandler)
+	do.Provide(injector, handler.NewAuthHandler)
+	do.Provide(injector, handler.NewKeysHandler)
+	do.Provide(injector, handler.NewOAuthHandler)
+	do.Provide(injector, handler.NewOIDCHandler)
+	do.Provide(injector, handler.NewSocialHandler)
+	do.Provide(injector, handler.NewAPIKeyHandler)
+	do.Provide(injector, handler.NewServiceAccountHandler)
+
+	return injector
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoleAdmin = "admin"
+)
+
+var ErrInvalidRole = fmt.Errorf("Error Invalid Role")
+
+// ValidRoles lists every role that can be granted to a user.
+var ValidRoles = []string{RoleAdmin}
+
+type UserRole struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Role      string    `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+type ResetPasswordRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type AdminService interface {
+	CreateUser(ctx context.Context, req CreateAccountRequest) (*User, error)
+	ResetPassword(ctx context.Context, req ResetPasswordRequest) error
+	ExpirePassword(ctx context.Context, email string) error
+	ListSessions(ctx context.Context, email string) ([]Session, error)
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
+	RevokeUserSessions(ctx context.Context, email string) error
+	GrantRole(ctx context.Context, email, role string) error
+	RevokeRole(ctx context.Context, email, role string) error
+}
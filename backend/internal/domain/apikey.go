@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrAPIKeyNotFound     = fmt.Errorf("Error API Key Not Found")
+	ErrInsufficientScope  = fmt.Errorf("Error Insufficient Scope")
+	ErrSessionRequired    = fmt.Errorf("Error Session Required")
+	ErrAPIKeyNameConflict = fmt.Errorf("Error API Key Name Conflict")
+)
+
+// APIKeyPrefix starts every API key, so keys are recognizable in the
+// Authorization header and by secret scanners.
+const APIKeyPrefix = "mig_"
+
+const (
+	// APIKeyScopeRead allows safe methods (GET, HEAD, OPTIONS).
+	APIKeyScopeRead = "read"
+	// APIKeyScopeWrite also allows the methods that change state.
+	APIKeyScopeWrite = "write"
+)
+
+// APIKey is a personal access token. Only the SHA-256 hash of the key is
+// stored; Prefix keeps its first characters so users can tell keys apart.
+type APIKey struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_api_key_user_name"`
+	Name       string    `gorm:"not null;uniqueIndex:idx_api_key_user_name"`
+	Prefix     string    `gorm:"not null"`
+	KeyHash    string    `gorm:"not null;uniqueIndex"`
+	Scopes     string    `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" form:"name" validate:"required,max=100" example:"ci-deploy"`
+	Scopes        []string `json:"scopes" form:"scopes" validate:"required,min=1,dive,oneof=read write"`
+	ExpiresInDays int      `json:"expires_in_days" form:"expires_in_days" validate:"required,min=1,max=365" example:"90"`
+}
+
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name" example:"ci-deploy"`
+	Prefix     string     `json:"prefix" example:"mig_Xk3v9QaB"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse carries the key itself, which is only ever shown in
+// the response that creates it.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"mig_Xk3v9QaB..."`
+}
+
+type APIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
+
+type APIKeyHandler interface {
+	Create(c echo.Context) error
+	List(c echo.Context) error
+	Revoke(c echo.Context) error
+}
+
+type APIKeyService interface {
+	Create(ctx context.Context, userID string, req CreateAPIKeyRequest) (*CreatedAPIKeyResponse, error)
+	List(ctx context.Context, userID string) ([]APIKeyResponse, error)
+	Revoke(ctx context.Context, userID, keyID string) error
+}
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *APIKey) error
+	FindAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	ListAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]APIKey, error)
+	// DeleteAPIKey deletes the key of userID, returning ErrAPIKeyNotFound
+	// for keys of other users.
+	DeleteAPIKey(ctx context.Context, userID, id uuid.UUID) error
+	TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+	DeleteExpiredAPIKeys(ctx context.Context) (int64, error)
+}
@@ -16,40 +16,48 @@ var (
 	ErrInvalidCurrentPassword = fmt.Errorf("Error Invalid Current Password")
 	ErrUserDeactivated        = fmt.Errorf("Error User Deactivated")
 	ErrUserNotDeactivated     = fmt.Errorf("Error User Not Deactivated")
+	ErrPasswordExpired        = fmt.Errorf("Error Password Expired")
+	ErrUnauthorized           = fmt.Errorf("Error Unauthorized")
+	ErrInvalidRefreshToken    = fmt.Errorf("Error Invalid Refresh Token")
+	ErrInvalidCSRFToken       = fmt.Errorf("Error Invalid CSRF Token")
 )
 
 type CreateAccountRequest struct {
-	Name     string `form:"name" validate:"required,name"`
-	Avatar   string
-	Email    string `form:"email" validate:"required,email"`
-	Password string `form:"password" validate:"required,min=8"`
+	Name     string `json:"name" form:"name" validate:"required,name"`
+	Avatar   string `json:"avatar" form:"avatar"`
+	Email    string `json:"email" form:"email" validate:"required,email"`
+	Password string `json:"password" form:"password" validate:"required"`
 }
 
 type User struct {
-	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
-	Name      string
-	Email     string `gorm:"type:varchar(100);uniqueIndex;not null"`
-	Password  string `gorm:"not null"`
-	Avatar    string
-	DeletedAt *time.Time
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;"`
+	Name              string    `gorm:"serializer:pii"`
+	Email             string    `gorm:"type:varchar(100);uniqueIndex;not null"`
+	Password          string    `gorm:"not null"`
+	Avatar            string    `gorm:"serializer:pii"`
+	PasswordExpiresAt *time.Time
+	// PasswordChangedAt is nil for passwords set before it was recorded,
+	// which count from CreatedAt.
+	PasswordChangedAt *time.Time
+	DeletedAt         *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 type LoginRequest struct {
-	Email    string `form:"email" validate:"required,email"`
-	Password string `form:"password" validate:"required"`
+	Email    string `json:"email" form:"email" validate:"required,email"`
+	Password string `json:"password" form:"password" validate:"required"`
 }
 
 type UpdatePasswordRequest struct {
-	CurrentPassword string `form:"current_password" validate:"required"`
-	NewPassword     string `form:"new_password" validate:"required,min=8"`
+	CurrentPassword string `json:"current_password" form:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" form:"new_password" validate:"required"`
 }
 
 type UpdateUserRequest struct {
-	Name   string `form:"name" validate:"omitempty,name"`
-	Email  string `form:"email" validate:"omitempty,email"`
-	Avatar string `form:"avatar"`
+	Name   string `json:"name" form:"name" validate:"omitempty,name"`
+	Email  string `json:"email" form:"email" validate:"omitempty,email"`
+	Avatar string `json:"avatar" form:"avatar"`
 }
 
 type UserResponse struct {
@@ -68,6 +76,10 @@ type AuthHandler interface {
 	Me(c echo.Context) error
 	DeleteUser(c echo.Context) error
 	ReactivateAccount(c echo.Context) error
+	ChangeExpiredPassword(c echo.Context) error
+	Refresh(c echo.Context) error
+	ListSessions(c echo.Context) error
+	RevokeSession(c echo.Context) error
 }
 
 type AuthService interface {
@@ -78,6 +90,14 @@ type AuthService interface {
 	UpdateUser(ctx context.Context, userID string, req UpdateUserRequest) (*UserResponse, error)
 	DeleteUser(ctx context.Context, userID string) error
 	ReactivateAccount(ctx context.Context, req LoginRequest) (*AuthResponse, error)
+	// ChangeExpiredPassword redeems the challenge Login returned for a
+	// password past its maximum age, setting the new password and opening
+	// a session.
+	ChangeExpiredPassword(ctx context.Context, req ChangeExpiredPasswordRequest) (*AuthResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*AuthResponse, error)
+	// ListSessions marks currentSessionID, which is empty for API keys.
+	ListSessions(ctx context.Context, userID, currentSessionID string) ([]SessionResponse, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
 }
 
 type AuthRepository interface {
@@ -85,6 +105,24 @@ type AuthRepository interface {
 	FindUserByEmail(ctx context.Context, email string) (*User, error)
 	FindUserByID(ctx context.Context, id uuid.UUID) (*User, error)
 	UpdateUser(ctx context.Context, user *User) error
+	// UpdatePasswordHash replaces the password hash of the user only while
+	// it is still oldHash, so a rehash never overwrites a password change.
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 	DeleteDeactivatedUsers(ctx context.Context) (int64, error)
+	GrantRole(ctx context.Context, userID uuid.UUID, role string) error
+	RevokeRole(ctx context.Context, userID uuid.UUID, role string) error
+	FindRolesByUserID(ctx context.Context, userID uuid.UUID) ([]string, error)
+	// AddPasswordHistory stores entry and drops all but the newest keep
+	// entries of the user.
+	AddPasswordHistory(ctx context.Context, entry *PasswordHistory, keep int) error
+	// ListPasswordHistory returns up to limit entries of the user, newest
+	// first.
+	ListPasswordHistory(ctx context.Context, userID uuid.UUID, limit int) ([]PasswordHistory, error)
+	CreatePasswordChallenge(ctx context.Context, challenge *PasswordChallenge) error
+	FindPasswordChallenge(ctx context.Context, id string) (*PasswordChallenge, error)
+	// DeletePasswordChallenge returns ErrInvalidPasswordChallenge when the
+	// challenge is already gone, so it is redeemed once.
+	DeletePasswordChallenge(ctx context.Context, id string) error
+	DeleteExpiredPasswordChallenges(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,36 @@
+package domain
+
+import "github.com/labstack/echo/v4"
+
+// Names of the cookies the API sets, before the configured prefixes.
+const (
+	CookieAccessToken  = "access_token"
+	CookieRefreshToken = "refresh_token"
+	CookieCSRFToken    = "csrf_token"
+	CookieSocialState  = "social_state"
+)
+
+// CSRFHeader carries the CSRF token of a cookie session: in requests that
+// change state and, whenever the session cookies are set, in the response,
+// for pages on other allowed origins, which can't read the cookie.
+const CSRFHeader = "X-CSRF-Token"
+
+// CookieManager sets and reads the cookies of the API, applying the
+// configured names, domain, SameSite mode, partitioning and sealing.
+type CookieManager interface {
+	// SetSession sets the access, refresh and CSRF cookies of tokens.
+	SetSession(c echo.Context, tokens *AuthResponse)
+	ClearSession(c echo.Context)
+	// SetSocialState sets the cookie binding a social login to the browser
+	// that started it, sent back to path only.
+	SetSocialState(c echo.Context, state, path string)
+	ClearSocialState(c echo.Context, path string)
+	// Get returns the value of the cookie named name, opened when sealed.
+	// It reports false when the cookie is missing, empty or doesn't open.
+	Get(c echo.Context, name string) (string, bool)
+	// CSRFToken returns the CSRF token of a session.
+	CSRFToken(sessionID string) string
+	// RefreshScoped reports whether the refresh cookie is only sent to the
+	// refresh endpoint, so other requests can't rotate the session.
+	RefreshScoped() bool
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// GrantTypeDeviceCode is the grant type of the device authorization
+	// grant (RFC 8628).
+	GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	DeviceStatusPending  = "pending"
+	DeviceStatusApproved = "approved"
+	DeviceStatusDenied   = "denied"
+)
+
+// DeviceAuthorization is a pending device authorization request. ID holds a
+// SHA-256 hash of the device code and UserCode one of the normalized user
+// code, never the codes themselves.
+type DeviceAuthorization struct {
+	ID       string    `gorm:"primary_key"`
+	UserCode string    `gorm:"not null;uniqueIndex"`
+	ClientID uuid.UUID `gorm:"type:uuid;not null"`
+	Scope    string
+	Status   string `gorm:"not null"`
+	// UserID and ApprovedAt are set once a user answers the request.
+	UserID     *uuid.UUID `gorm:"type:uuid"`
+	ApprovedAt *time.Time
+	// Interval is the minimum number of seconds between polls; it grows
+	// every time the client polls too fast.
+	Interval     int `gorm:"not null"`
+	LastPolledAt *time.Time
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+// DeviceCodeRequest starts the device flow (RFC 8628 section 3.1).
+type DeviceCodeRequest struct {
+	Scope string `json:"scope,omitempty" form:"scope" example:"openid profile"`
+	ClientCredentials
+}
+
+// DeviceCodeResponse follows RFC 8628 section 3.2.
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code" example:"WDJB-MJHT"`
+	VerificationURI         string `json:"verification_uri" example:"https://auth.example.com/device"`
+	VerificationURIComplete string `json:"verification_uri_complete" example:"https://auth.example.com/device?user_code=WDJB-MJHT"`
+	ExpiresIn               int    `json:"expires_in" example:"600"`
+	Interval                int    `json:"interval" example:"5"`
+}
+
+// DeviceRequest is the query of the device verification page.
+type DeviceRequest struct {
+	UserCode string `json:"user_code,omitempty" query:"user_code"`
+}
+
+// DeviceApprovalRequest is submitted by the device verification page.
+type DeviceApprovalRequest struct {
+	UserCode string `json:"user_code" form:"user_code" validate:"required,max=20" example:"WDJB-MJHT"`
+	Consent  string `json:"consent" form:"consent" validate:"required,oneof=approve deny"`
+	// CSRFToken is the CSRF token of the session, checked by SessionAuth.
+	CSRFToken string `json:"csrf_token,omitempty" form:"csrf_token"`
+}
@@ -0,0 +1,36 @@
+package domain
+
+// SealedField locates a sealed value. A value only opens in the table,
+// column and row it was sealed for, so it can't be copied into another row.
+type SealedField struct {
+	Table  string
+	Column string
+	// Row is the primary key of the row, one value per key column.
+	Row []string
+}
+
+func (f SealedField) String() string {
+	return f.Table + "." + f.Column
+}
+
+// FieldCipher seals sensitive column values with AES-GCM envelope
+// encryption: each value gets its own data key, wrapped by a named key
+// encryption key so the keys can be rotated.
+type FieldCipher interface {
+	// Seal returns what to store for plaintext in field: an envelope under
+	// the primary key when encrypt is set and keys are configured, or the
+	// plaintext itself.
+	Seal(plaintext string, field SealedField, encrypt bool) (string, error)
+	// Open returns the plaintext of a stored value, sealed or not, so rows
+	// written before encryption was enabled still read.
+	Open(value string, field SealedField) (string, error)
+	// Current reports whether value is stored the way Seal would store it
+	// now under keyID: sealed in the current envelope format under that
+	// key, or plaintext when keyID is "".
+	Current(value, keyID string) bool
+	// PrimaryKeyID returns the ID of the key sealing new values, or "" when
+	// no keys are configured.
+	PrimaryKeyID() string
+	// EncryptPII reports whether PII columns are sealed too.
+	EncryptPII() bool
+}
@@ -3,12 +3,37 @@ package domain
 import "time"
 
 type Config struct {
-	Env      string `env:"ENV,default=development"`
-	Port     int    `env:"PORT,default=8080"`
-	LogLevel string `env:"LOG_LEVEL,default:debug"`
-	Keys     KeysConfig
-	Token    TokenConfig
-	SQL      SQLConfig
+	Env        string `env:"ENV,default=development"`
+	Port       int    `env:"PORT,default=8080"`
+	LogLevel   string `env:"LOG_LEVEL,default:debug"`
+	HTTP       HTTPConfig
+	Keys       KeysConfig
+	Token      TokenConfig
+	Password   PasswordConfig
+	Policy     PasswordPolicyConfig
+	Encryption EncryptionConfig
+	Cookie     CookieConfig
+	Headers    HeadersConfig
+	SQL        SQLConfig
+	Metrics    MetricsConfig
+	Tracing    TracingConfig
+	Health     HealthConfig
+	OpenAPI    OpenAPIConfig
+	OIDC       OIDCConfig
+	Social     SocialConfig
+}
+
+type HTTPConfig struct {
+	BodyLimit string `env:"HTTP_BODY_LIMIT,default=64K"`
+	// AllowedOrigins lists, comma separated, the origins other than the
+	// API's own ("https://app.example.com") allowed to call it from a
+	// browser. CORS is answered for them only, and state changing requests
+	// sent from any other origin are refused.
+	AllowedOrigins string `env:"HTTP_ALLOWED_ORIGINS"`
+	// CSRFKey signs the CSRF tokens of cookie sessions. When empty a random
+	// key is made at start, so tokens change on restart and differ between
+	// replicas; set it when running more than one.
+	CSRFKey string `env:"HTTP_CSRF_KEY" secret:"true"`
 }
 
 type KeysConfig struct {
@@ -17,8 +42,121 @@ type KeysConfig struct {
 }
 
 type TokenConfig struct {
-	AccessTokenExpiry  int `env:"ACCESS_TOKEN_EXPIRY,default=60"`
-	RefreshTokenExpiry int `env:"REFRESH_TOKEN_EXPIRY,default=10080"`
+	AccessTokenExpiry  int    `env:"ACCESS_TOKEN_EXPIRY,default=60"`
+	RefreshTokenExpiry int    `env:"REFRESH_TOKEN_EXPIRY,default=10080"`
+	Issuer             string `env:"TOKEN_ISSUER,default=http://localhost:8080"`
+	Audience           string `env:"TOKEN_AUDIENCE,default=migos"`
+}
+
+type PasswordConfig struct {
+	// HashAlgorithm hashes new passwords: argon2id or bcrypt. Hashes of
+	// the other algorithm, or with other parameters, are replaced when
+	// their users sign in.
+	HashAlgorithm string `env:"PASSWORD_HASH_ALGORITHM,default=argon2id"`
+	// Argon2Memory is in KiB.
+	Argon2Memory      uint32 `env:"PASSWORD_ARGON2_MEMORY,default=65536"`
+	Argon2Iterations  uint32 `env:"PASSWORD_ARGON2_ITERATIONS,default=3"`
+	Argon2Parallelism uint8  `env:"PASSWORD_ARGON2_PARALLELISM,default=2"`
+	BcryptCost        int    `env:"PASSWORD_BCRYPT_COST,default=12"`
+	// HashWorkers bounds how many hashes run at once; 0 means one per CPU.
+	// Requests beyond it wait up to HashQueueTimeout for a worker and then
+	// get a 503.
+	HashWorkers      int           `env:"PASSWORD_HASH_WORKERS,default=0"`
+	HashQueueTimeout time.Duration `env:"PASSWORD_HASH_QUEUE_TIMEOUT,default=2s"`
+	// PepperKeys are HMAC-SHA256 keys mixed into passwords before hashing,
+	// as comma separated "id:base64" entries; PepperKeysFile holds more, one
+	// per line. PepperKeyID picks the key of new hashes, by default the
+	// first one. Hashes under other keys are redone at sign in, so retired
+	// keys must stay until no hash uses them.
+	PepperKeys     string `env:"PASSWORD_PEPPER_KEYS" secret:"true"`
+	PepperKeysFile string `env:"PASSWORD_PEPPER_KEYS_FILE"`
+	PepperKeyID    string `env:"PASSWORD_PEPPER_KEY_ID"`
+}
+
+type PasswordPolicyConfig struct {
+	// MinLength and MaxLength count characters, not bytes.
+	MinLength        int  `env:"PASSWORD_MIN_LENGTH,default=8"`
+	MaxLength        int  `env:"PASSWORD_MAX_LENGTH,default=128"`
+	RequireLowercase bool `env:"PASSWORD_REQUIRE_LOWERCASE,default=false"`
+	RequireUppercase bool `env:"PASSWORD_REQUIRE_UPPERCASE,default=false"`
+	RequireDigit     bool `env:"PASSWORD_REQUIRE_DIGIT,default=false"`
+	RequireSymbol    bool `env:"PASSWORD_REQUIRE_SYMBOL,default=false"`
+	// ForbidPersonalInfo rejects passwords containing the email, its local
+	// part or a word of the name.
+	ForbidPersonalInfo bool `env:"PASSWORD_FORBID_PERSONAL_INFO,default=true"`
+	// BreachedCorpus is a directory of Have I Been Pwned range files, one
+	// per SHA-1 prefix ("21BD1.txt" holding "SUFFIX:COUNT" lines), as
+	// written by the HIBP downloader. Empty disables the check.
+	BreachedCorpus   string `env:"PASSWORD_BREACHED_CORPUS"`
+	BreachedMinCount int    `env:"PASSWORD_BREACHED_MIN_COUNT,default=1"`
+	// HistorySize is how many of the latest passwords, the current one
+	// included, can't be set again. 0 disables the check.
+	HistorySize int `env:"PASSWORD_HISTORY_SIZE,default=5"`
+	// MaxAge makes Login ask for a new password instead of opening a
+	// session once the password is older. 0 disables it.
+	MaxAge             time.Duration `env:"PASSWORD_MAX_AGE,default=0s"`
+	ChangeChallengeTTL time.Duration `env:"PASSWORD_CHANGE_CHALLENGE_TTL,default=5m"`
+}
+
+// EncryptionConfig holds the AES-256 keys sealing sensitive columns, in the
+// same formats as the pepper keys. KeyID picks the key of new values, by
+// default the first one. PII also encrypts names, avatars and the emails of
+// social identities; account emails stay readable for lookups.
+type EncryptionConfig struct {
+	Keys     string `env:"ENCRYPTION_KEYS" secret:"true"`
+	KeysFile string `env:"ENCRYPTION_KEYS_FILE"`
+	KeyID    string `env:"ENCRYPTION_KEY_ID"`
+	PII      bool   `env:"ENCRYPTION_PII,default=false"`
+}
+
+// CookieConfig shapes the cookies set in browsers. They are Secure in
+// production and whenever an option below needs it.
+type CookieConfig struct {
+	// Domain shares the cookies with its subdomains; empty keeps them on
+	// the API host.
+	Domain string `env:"COOKIE_DOMAIN"`
+	// NamePrefix goes before every name, e.g. "staging_", so deployments
+	// sharing a domain don't overwrite each other's cookies.
+	NamePrefix string `env:"COOKIE_NAME_PREFIX"`
+	// SecurePrefix adds the __Host- prefix, or __Secure- to cookies with a
+	// Domain or a narrower Path, so browsers only accept them from HTTPS.
+	SecurePrefix bool `env:"COOKIE_SECURE_PREFIX,default=false"`
+	// SameSite is strict, lax or none. The social login state cookie is
+	// always lax, as the provider sends the browser back cross-site.
+	SameSite string `env:"COOKIE_SAMESITE,default=strict"`
+	// Partitioned keeps the cookies in a jar per top-level site (CHIPS),
+	// for pages embedded in other sites.
+	Partitioned bool `env:"COOKIE_PARTITIONED,default=false"`
+	// RefreshPath is where the refresh cookie is sent. With the refresh
+	// endpoint, other requests authenticate with the access cookie until
+	// it expires and clients then refresh.
+	RefreshPath string `env:"COOKIE_REFRESH_PATH,default=/"`
+	// SealKeys are AES-256 keys encrypting the HttpOnly cookies, in the
+	// same formats as the encryption keys; empty leaves them in clear.
+	SealKeys     string `env:"COOKIE_SEAL_KEYS" secret:"true"`
+	SealKeysFile string `env:"COOKIE_SEAL_KEYS_FILE"`
+	SealKeyID    string `env:"COOKIE_SEAL_KEY_ID"`
+}
+
+// HeadersConfig sets the security headers of every response. Empty values
+// keep the defaults of the server and "off" leaves a header out.
+type HeadersConfig struct {
+	// HSTS is the Strict-Transport-Security header, sent in production
+	// only.
+	HSTS string `env:"HEADERS_HSTS"`
+	// CSP is the Content-Security-Policy of the API responses.
+	CSP string `env:"HEADERS_CSP"`
+	// FrameAncestors is the frame-ancestors directive added to CSP, also
+	// sent as X-Frame-Options when 'none' or 'self'. The HTML pages are
+	// never framed, whatever it is.
+	FrameAncestors     string `env:"HEADERS_FRAME_ANCESTORS"`
+	ContentTypeOptions string `env:"HEADERS_CONTENT_TYPE_OPTIONS"`
+	ReferrerPolicy     string `env:"HEADERS_REFERRER_POLICY"`
+	PermissionsPolicy  string `env:"HEADERS_PERMISSIONS_POLICY"`
+	// PageCSP replaces CSP on the consent, device and social login pages,
+	// and DocsCSP on the /docs reference, which loads its script from a CDN.
+	PageCSP string `env:"HEADERS_PAGE_CSP"`
+	DocsCSP string `env:"HEADERS_DOCS_CSP"`
 }
 
 type SQLConfig struct {
@@ -26,4 +164,46 @@ type SQLConfig struct {
 	MaxConn     int           `env:"DB_MAX_CONN,default=10"`
 	MaxIdle     int           `env:"DB_MAX_IDLE,default=5"`
 	MaxLifeTime time.Duration `env:"DB_MAX_LIFETIME,default=1h"`
+	// AutoMigrate makes the API migrate the schema on startup. migosctl
+	// never does; it migrates only with "db migrate".
+	AutoMigrate bool `env:"DB_AUTO_MIGRATE,default=true"`
+}
+
+type MetricsConfig struct {
+	Port int `env:"METRICS_PORT,default=9090"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `env:"TRACING_EXPORTER,default=none"`
+	ServiceName string  `env:"TRACING_SERVICE_NAME,default=migos"`
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO,default=1"`
+}
+
+type HealthConfig struct {
+	CheckTimeout time.Duration `env:"HEALTH_CHECK_TIMEOUT,default=2s"`
+	DrainDelay   time.Duration `env:"HEALTH_DRAIN_DELAY,default=0s"`
+}
+
+type OpenAPIConfig struct {
+	DocsUI           bool `env:"OPENAPI_DOCS_UI,default=false"`
+	ValidateRequests bool `env:"OPENAPI_VALIDATE_REQUESTS,default=false"`
+}
+
+type OIDCConfig struct {
+	LoginURL string        `env:"OIDC_LOGIN_URL,default=/login"`
+	CodeTTL  time.Duration `env:"OIDC_CODE_TTL,default=1m"`
+	// DeviceCodeTTL is how long a device has to be approved and
+	// DevicePollInterval the initial minimum time between its polls.
+	DeviceCodeTTL      time.Duration `env:"OIDC_DEVICE_CODE_TTL,default=10m"`
+	DevicePollInterval time.Duration `env:"OIDC_DEVICE_POLL_INTERVAL,default=5s"`
+}
+
+type SocialConfig struct {
+	// ProvidersFile is a JSON array of SocialProviderConfig. ${VAR}
+	// references in it are expanded from the environment.
+	ProvidersFile string `env:"SOCIAL_PROVIDERS_FILE"`
+	// RedirectBaseURL is the public origin the providers send users back
+	// to; when empty it is taken from the login request.
+	RedirectBaseURL string        `env:"SOCIAL_REDIRECT_BASE_URL"`
+	StateTTL        time.Duration `env:"SOCIAL_STATE_TTL,default=10m"`
 }
@@ -1,16 +1,36 @@
 package domain
 
-import "github.com/labstack/echo/v4"
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDown     = "down"
+	HealthStatusDraining = "draining"
+)
+
+type ComponentHealth struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
 
 type HealthCheck struct {
-	Status   string `json:"status"`
-	Database string `json:"database"`
+	Status     string            `json:"status"`
+	Components []ComponentHealth `json:"components,omitempty"`
 }
 
 type HealthCheckerService interface {
-	Check() (HealthCheck, []error)
+	Live(ctx context.Context) HealthCheck
+	Ready(ctx context.Context) HealthCheck
+	Drain()
 }
 
 type HealthCheckHandler interface {
-	Check(ctx echo.Context) error
+	Live(ctx echo.Context) error
+	Ready(ctx echo.Context) error
 }
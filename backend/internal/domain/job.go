@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+var ErrJobNotFound = fmt.Errorf("job not found")
+
+type JobScheduler interface {
+	Start()
+	RunNow(ctx context.Context, name string) (int64, error)
+	Jobs() []string
+	Running() bool
+	Shutdown() error
+}
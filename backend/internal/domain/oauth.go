@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrInvalidClient      = fmt.Errorf("Error Invalid Client")
+	ErrClientNotFound     = fmt.Errorf("Error Client Not Found")
+	ErrUnauthorizedClient = fmt.Errorf("Error Unauthorized Client")
+)
+
+const (
+	TokenTypeAccess  = "access_token"
+	TokenTypeRefresh = "refresh_token"
+)
+
+// OAuthClient is a registered application. Confidential clients, such as
+// resource servers, may introspect tokens and revoke the ones issued to
+// them; clients with redirect
+// URIs may sign users in through OpenID Connect. Its ID is the client_id;
+// only a SHA-256 hash of the generated secret is stored, and public clients
+// have none.
+type OAuthClient struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name         string    `gorm:"not null"`
+	SecretHash   string
+	RedirectURIs []string `gorm:"serializer:json"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Public reports whether the client has no secret, as for single-page and
+// native apps, which are then authenticated by PKCE alone.
+func (c OAuthClient) Public() bool {
+	return c.SecretHash == ""
+}
+
+// NewOAuthClientRequest describes a client to register.
+type NewOAuthClientRequest struct {
+	Name         string
+	RedirectURIs []string
+	Public       bool
+}
+
+// ClientCredentials may also be sent in the body (client_secret_post)
+// instead of HTTP Basic authentication.
+type ClientCredentials struct {
+	ClientID     string `json:"client_id,omitempty" form:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty" form:"client_secret"`
+}
+
+type IntrospectRequest struct {
+	Token         string `json:"token" form:"token" validate:"required"`
+	TokenTypeHint string `json:"token_type_hint,omitempty" form:"token_type_hint"`
+	ClientCredentials
+}
+
+type RevokeRequest struct {
+	Token         string `json:"token" form:"token" validate:"required"`
+	TokenTypeHint string `json:"token_type_hint,omitempty" form:"token_type_hint"`
+	ClientCredentials
+}
+
+// IntrospectionResponse follows RFC 7662. Inactive tokens carry only
+// Active, so nothing is disclosed about them.
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	TokenType string `json:"token_type,omitempty" example:"access_token"`
+	Subject   string `json:"sub,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Scope     string `json:"scope,omitempty" example:"admin"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+type OAuthHandler interface {
+	Introspect(c echo.Context) error
+	Revoke(c echo.Context) error
+	Token(c echo.Context) error
+}
+
+type OAuthService interface {
+	CreateClient(ctx context.Context, req NewOAuthClientRequest) (client *OAuthClient, secret string, err error)
+	ListClients(ctx context.Context) ([]OAuthClient, error)
+	DeleteClient(ctx context.Context, id uuid.UUID) error
+	AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*OAuthClient, error)
+	Introspect(ctx context.Context, client *OAuthClient, req IntrospectRequest) (*IntrospectionResponse, error)
+	Revoke(ctx context.Context, client *OAuthClient, req RevokeRequest) error
+}
+
+type OAuthClientRepository interface {
+	CreateClient(ctx context.Context, client *OAuthClient) error
+	FindClientByID(ctx context.Context, id uuid.UUID) (*OAuthClient, error)
+	ListClients(ctx context.Context) ([]OAuthClient, error)
+	DeleteClient(ctx context.Context, id uuid.UUID) error
+}
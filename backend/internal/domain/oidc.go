@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrInvalidRedirectURI        = fmt.Errorf("Error Invalid Redirect URI")
+	ErrInvalidAuthorizeRequest   = fmt.Errorf("Error Invalid Authorize Request")
+	ErrUnsupportedResponseType   = fmt.Errorf("Error Unsupported Response Type")
+	ErrInvalidScope              = fmt.Errorf("Error Invalid Scope")
+	ErrAccessDenied              = fmt.Errorf("Error Access Denied")
+	ErrInvalidGrant              = fmt.Errorf("Error Invalid Grant")
+	ErrUnsupportedGrantType      = fmt.Errorf("Error Unsupported Grant Type")
+	ErrAuthorizationCodeNotFound = fmt.Errorf("Error Authorization Code Not Found")
+	ErrGrantNotFound             = fmt.Errorf("Error Grant Not Found")
+	ErrAuthorizationPending      = fmt.Errorf("Error Authorization Pending")
+	ErrSlowDown                  = fmt.Errorf("Error Slow Down")
+	ErrExpiredToken              = fmt.Errorf("Error Expired Token")
+	ErrInvalidUserCode           = fmt.Errorf("Error Invalid User Code")
+
+	ErrDeviceAuthorizationNotFound = fmt.Errorf("Error Device Authorization Not Found")
+)
+
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+
+	ResponseTypeCode        = "code"
+	CodeChallengeMethodS256 = "S256"
+
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+
+	ConsentApprove = "approve"
+	ConsentDeny    = "deny"
+)
+
+// SupportedScopes lists every scope a client may request.
+var SupportedScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail}
+
+// AuthorizationCode is issued by /authorize and redeemed once at /token.
+// ID holds a SHA-256 hash of the code, never the code itself.
+type AuthorizationCode struct {
+	ID            string    `gorm:"primary_key"`
+	ClientID      uuid.UUID `gorm:"type:uuid;not null"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null"`
+	RedirectURI   string    `gorm:"not null"`
+	Scope         string
+	Nonce         string
+	CodeChallenge string    `gorm:"not null"`
+	AuthTime      time.Time `gorm:"not null"`
+	ExpiresAt     time.Time `gorm:"not null;index"`
+	CreatedAt     time.Time
+}
+
+// OAuthGrant records the scopes a user consented to give a client, so the
+// consent page is only shown again when a client asks for more.
+type OAuthGrant struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ClientID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Scope     string    `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AuthorizeRequest holds the authorization request parameters, read from
+// the query string of GET /authorize and from the consent form.
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type" query:"response_type" form:"response_type"`
+	ClientID            string `json:"client_id" query:"client_id" form:"client_id"`
+	RedirectURI         string `json:"redirect_uri" query:"redirect_uri" form:"redirect_uri"`
+	Scope               string `json:"scope" query:"scope" form:"scope"`
+	State               string `json:"state,omitempty" query:"state" form:"state"`
+	Nonce               string `json:"nonce,omitempty" query:"nonce" form:"nonce"`
+	CodeChallenge       string `json:"code_challenge" query:"code_challenge" form:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method" query:"code_challenge_method" form:"code_challenge_method"`
+}
+
+// ConsentRequest is submitted by the consent page.
+type ConsentRequest struct {
+	AuthorizeRequest
+	Consent string `json:"consent" form:"consent" validate:"required,oneof=approve deny"`
+	// CSRFToken is the CSRF token of the session, checked by SessionAuth.
+	CSRFToken string `json:"csrf_token,omitempty" form:"csrf_token"`
+}
+
+// Authorization is the outcome of an authorization request: either the code
+// to send back to the client, or the consent the user still has to give.
+type Authorization struct {
+	Code    string
+	Consent *ConsentPrompt
+}
+
+type ConsentPrompt struct {
+	ClientName string
+	Scopes     []string
+}
+
+type TokenRequest struct {
+	GrantType    string `json:"grant_type" form:"grant_type" validate:"required"`
+	Code         string `json:"code,omitempty" form:"code"`
+	RedirectURI  string `json:"redirect_uri,omitempty" form:"redirect_uri"`
+	CodeVerifier string `json:"code_verifier,omitempty" form:"code_verifier"`
+	RefreshToken string `json:"refresh_token,omitempty" form:"refresh_token"`
+	DeviceCode   string `json:"device_code,omitempty" form:"device_code"`
+	ClientCredentials
+}
+
+// TokenResponse follows RFC 6749 section 5.1, with the OpenID Connect
+// id_token.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int    `json:"expires_in" example:"3600"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty" example:"openid profile email"`
+}
+
+// IDTokenClaims are the user claims of an OpenID Connect ID token. The
+// token provider adds the issuer and the validity period.
+type IDTokenClaims struct {
+	Subject   string
+	Audience  string
+	SessionID string
+	Nonce     string
+	AuthTime  time.Time
+	Name      string
+	Email     string
+	Picture   string
+}
+
+// UserInfo is the response of /userinfo. Profile and email claims are only
+// present when the session was granted those scopes.
+type UserInfo struct {
+	Subject string `json:"sub"`
+	Name    string `json:"name,omitempty"`
+	Picture string `json:"picture,omitempty"`
+	Email   string `json:"email,omitempty"`
+}
+
+// DiscoveryDocument is the OpenID Provider metadata published at
+// /.well-known/openid-configuration.
+type DiscoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	RevocationEndpoint                string   `json:"revocation_endpoint"`
+	DeviceAuthorizationEndpoint       string   `json:"device_authorization_endpoint"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+type OIDCHandler interface {
+	Discovery(c echo.Context) error
+	Authorize(c echo.Context) error
+	Consent(c echo.Context) error
+	Token(c echo.Context) error
+	UserInfo(c echo.Context) error
+	DeviceCode(c echo.Context) error
+	Device(c echo.Context) error
+	ApproveDevice(c echo.Context) error
+}
+
+type OIDCService interface {
+	Discovery() DiscoveryDocument
+	Authorize(ctx context.Context, userID string, req AuthorizeRequest) (*Authorization, error)
+	Consent(ctx context.Context, userID string, req ConsentRequest) (*Authorization, error)
+	Token(ctx context.Context, req TokenRequest) (*TokenResponse, error)
+	UserInfo(ctx context.Context, userID, sessionID string) (*UserInfo, error)
+	DeviceAuthorization(ctx context.Context, req DeviceCodeRequest) (*DeviceCodeResponse, error)
+	DevicePrompt(ctx context.Context, userCode string) (*ConsentPrompt, error)
+	ApproveDevice(ctx context.Context, userID string, req DeviceApprovalRequest) error
+}
+
+type AuthorizationRepository interface {
+	CreateAuthorizationCode(ctx context.Context, code *AuthorizationCode) error
+	// ConsumeAuthorizationCode deletes and returns the code, so concurrent
+	// redemptions of the same code succeed at most once.
+	ConsumeAuthorizationCode(ctx context.Context, id string) (*AuthorizationCode, error)
+	DeleteExpiredAuthorizationCodes(ctx context.Context) (int64, error)
+	FindGrant(ctx context.Context, userID, clientID uuid.UUID) (*OAuthGrant, error)
+	SaveGrant(ctx context.Context, grant *OAuthGrant) error
+	CreateDeviceAuthorization(ctx context.Context, device *DeviceAuthorization) error
+	FindDeviceAuthorization(ctx context.Context, id string) (*DeviceAuthorization, error)
+	FindDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*DeviceAuthorization, error)
+	// ResolveDeviceAuthorization records the user's answer. It only changes
+	// pending requests, so a request is answered at most once.
+	ResolveDeviceAuthorization(ctx context.Context, id string, userID uuid.UUID, status string, at time.Time) error
+	RecordDevicePoll(ctx context.Context, id string, polledAt time.Time, interval int) error
+	// DeleteDeviceAuthorization returns ErrDeviceAuthorizationNotFound when
+	// the row is already gone, so concurrent polls redeem it at most once.
+	DeleteDeviceAuthorization(ctx context.Context, id string) error
+	DeleteExpiredDeviceAuthorizations(ctx context.Context) (int64, error)
+}
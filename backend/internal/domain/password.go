@@ -1,6 +1,141 @@
 package domain
 
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrServerBusy is returned when every password hashing worker stayed
+	// busy for the whole queue timeout, so the request is shed instead of
+	// piling up. It is matched by ServerBusyError.
+	ErrServerBusy = fmt.Errorf("Error Server Busy")
+	// ErrWeakPassword is matched by PasswordPolicyError.
+	ErrWeakPassword = fmt.Errorf("Error Weak Password")
+	// ErrPasswordChangeRequired is matched by PasswordChangeRequiredError.
+	ErrPasswordChangeRequired   = fmt.Errorf("Error Password Change Required")
+	ErrInvalidPasswordChallenge = fmt.Errorf("Error Invalid Password Challenge")
+)
+
+// Password hashing algorithms. New passwords are hashed with the configured
+// one; hashes of every algorithm are still verified.
+const (
+	PasswordAlgorithmArgon2id = "argon2id"
+	PasswordAlgorithmBcrypt   = "bcrypt"
+)
+
 type PasswordHasher interface {
-	Hash(password string) (string, error)
-	Check(password, hash string) error
+	Hash(ctx context.Context, password string) (string, error)
+	Check(ctx context.Context, password, hash string) error
+	// NeedsRehash reports whether hash was made with another algorithm or
+	// other parameters than new hashes, so it should be replaced the next
+	// time the password is known.
+	NeedsRehash(hash string) bool
+}
+
+// Password policy rules reported in PasswordViolation.Rule.
+const (
+	PasswordRuleMinLength    = "min_length"
+	PasswordRuleMaxLength    = "max_length"
+	PasswordRuleLowercase    = "lowercase"
+	PasswordRuleUppercase    = "uppercase"
+	PasswordRuleDigit        = "digit"
+	PasswordRuleSymbol       = "symbol"
+	PasswordRulePersonalInfo = "personal_info"
+	PasswordRuleBreached     = "breached"
+	PasswordRuleReused       = "reused"
+)
+
+// PasswordViolation is a password policy rule a password breaks. Limit is
+// the configured length of the min_length and max_length rules and the
+// number of remembered passwords of the reused rule.
+type PasswordViolation struct {
+	Rule  string
+	Limit int
+}
+
+// PasswordOwner is who a password belongs to, so the policy can reject
+// passwords made of their email or name.
+type PasswordOwner struct {
+	Email string
+	Name  string
+}
+
+// PasswordPolicy decides which passwords may be set. Check returns every
+// rule password breaks, and an error only when the policy itself failed,
+// such as when the breached password corpus can't be read.
+type PasswordPolicy interface {
+	Check(ctx context.Context, password string, owner PasswordOwner) ([]PasswordViolation, error)
+}
+
+// PasswordPolicyError is returned when a new password breaks the policy. Field
+// is the request field holding the password, so the violations are rendered
+// as field errors. It matches ErrWeakPassword under errors.Is.
+type PasswordPolicyError struct {
+	Field      string
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	rules := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		rules = append(rules, v.Rule)
+	}
+	return fmt.Sprintf("%s: %s breaks %s", ErrWeakPassword, e.Field, strings.Join(rules, ", "))
+}
+
+func (e *PasswordPolicyError) Unwrap() error { return ErrWeakPassword }
+
+// PasswordHistory is a password hash the user had before, kept so it can't
+// be set again.
+type PasswordHistory struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Hash      string    `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// PasswordChallenge lets a user whose password is past its maximum age,
+// and who just proved they know it, set a new one. ID is the SHA-256 of the
+// challenge token.
+type PasswordChallenge struct {
+	ID        string    `gorm:"primary_key"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// PasswordChangeRequiredError is returned by Login instead of a session
+// when the password is past its maximum age. ChallengeToken redeems
+// ChangeExpiredPassword. It matches ErrPasswordChangeRequired under
+// errors.Is.
+type PasswordChangeRequiredError struct {
+	ChallengeToken string
+}
+
+func (e *PasswordChangeRequiredError) Error() string { return ErrPasswordChangeRequired.Error() }
+
+func (e *PasswordChangeRequiredError) Unwrap() error { return ErrPasswordChangeRequired }
+
+// ServerBusyError sheds a request that waited too long for a password
+// hashing worker and tells the client when to try again. It matches
+// ErrServerBusy under errors.Is.
+type ServerBusyError struct {
+	After time.Duration
+}
+
+func (e *ServerBusyError) Error() string { return ErrServerBusy.Error() }
+
+func (e *ServerBusyError) Unwrap() error { return ErrServerBusy }
+
+// RetryAfter is how long the client should wait before retrying.
+func (e *ServerBusyError) RetryAfter() time.Duration { return e.After }
+
+type ChangeExpiredPasswordRequest struct {
+	ChallengeToken string `json:"challenge_token" form:"challenge_token" validate:"required"`
+	NewPassword    string `json:"new_password" form:"new_password" validate:"required"`
 }
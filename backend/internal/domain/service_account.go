@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrServiceAccountNotFound       = fmt.Errorf("Error Service Account Not Found")
+	ErrServiceAccountSecretNotFound = fmt.Errorf("Error Service Account Secret Not Found")
+)
+
+const GrantTypeClientCredentials = "client_credentials"
+
+// ServiceAccount is a machine identity. Its ID is the client_id of the
+// client_credentials grant and Scopes, separated by spaces, are the scopes
+// its tokens may carry.
+type ServiceAccount struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name      string    `gorm:"not null"`
+	Scopes    string    `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ServiceAccountSecret is one of the secrets of a service account. Accounts
+// may hold several, so a new secret can be rolled out before the old one is
+// deleted; only the SHA-256 hash is stored.
+type ServiceAccountSecret struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key"`
+	ServiceAccountID uuid.UUID `gorm:"type:uuid;not null;index"`
+	SecretHash       string    `gorm:"not null;uniqueIndex"`
+	LastUsedAt       *time.Time
+	CreatedAt        time.Time
+}
+
+type CreateServiceAccountRequest struct {
+	Name   string   `json:"name" form:"name" validate:"required,max=100" example:"billing-worker"`
+	Scopes []string `json:"scopes" form:"scopes" validate:"required,min=1,dive,required,max=100"`
+}
+
+// ClientCredentialsRequest is the client_credentials grant of RFC 6749
+// section 4.4. Scope defaults to every scope of the account.
+type ClientCredentialsRequest struct {
+	GrantType string `json:"grant_type" form:"grant_type" validate:"required"`
+	Scope     string `json:"scope,omitempty" form:"scope" example:"admin"`
+	ClientCredentials
+}
+
+// ClientCredentialsResponse follows RFC 6749 section 5.1; no refresh token
+// is issued, the client asks for a new token instead.
+type ClientCredentialsResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type" example:"Bearer"`
+	ExpiresIn   int    `json:"expires_in" example:"3600"`
+	Scope       string `json:"scope" example:"admin"`
+}
+
+type ServiceAccountSecretResponse struct {
+	ID         string     `json:"id"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedServiceAccountSecretResponse carries the secret, which is only
+// ever shown in the response that creates it.
+type CreatedServiceAccountSecretResponse struct {
+	ServiceAccountSecretResponse
+	ClientSecret string `json:"client_secret"`
+}
+
+type ServiceAccountResponse struct {
+	ID        string                         `json:"id"`
+	Name      string                         `json:"name" example:"billing-worker"`
+	Scopes    []string                       `json:"scopes"`
+	Secrets   []ServiceAccountSecretResponse `json:"secrets"`
+	CreatedAt time.Time                      `json:"created_at"`
+}
+
+// CreatedServiceAccountResponse carries the secret of a new account.
+type CreatedServiceAccountResponse struct {
+	ServiceAccountResponse
+	ClientSecret string `json:"client_secret"`
+}
+
+type ServiceAccountsResponse struct {
+	ServiceAccounts []ServiceAccountResponse `json:"service_accounts"`
+}
+
+type ServiceAccountHandler interface {
+	Create(c echo.Context) error
+	List(c echo.Context) error
+	Delete(c echo.Context) error
+	CreateSecret(c echo.Context) error
+	DeleteSecret(c echo.Context) error
+}
+
+type ServiceAccountService interface {
+	Create(ctx context.Context, req CreateServiceAccountRequest) (*CreatedServiceAccountResponse, error)
+	List(ctx context.Context) ([]ServiceAccountResponse, error)
+	Delete(ctx context.Context, id string) error
+	CreateSecret(ctx context.Context, id string) (*CreatedServiceAccountSecretResponse, error)
+	DeleteSecret(ctx context.Context, id, secretID string) error
+	// Authenticate checks the credentials of a service account, reporting
+	// unknown accounts and wrong secrets as ErrInvalidClient.
+	Authenticate(ctx context.Context, clientID, clientSecret string) (*ServiceAccount, error)
+	Token(ctx context.Context, account *ServiceAccount, req ClientCredentialsRequest) (*ClientCredentialsResponse, error)
+}
+
+type ServiceAccountRepository interface {
+	CreateServiceAccount(ctx context.Context, account *ServiceAccount) error
+	FindServiceAccountByID(ctx context.Context, id uuid.UUID) (*ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context) ([]ServiceAccount, error)
+	// DeleteServiceAccount deletes the account together with its secrets.
+	DeleteServiceAccount(ctx context.Context, id uuid.UUID) error
+	CreateSecret(ctx context.Context, secret *ServiceAccountSecret) error
+	ListSecrets(ctx context.Context, serviceAccountID uuid.UUID) ([]ServiceAccountSecret, error)
+	// DeleteSecret deletes a secret of serviceAccountID, returning
+	// ErrServiceAccountSecretNotFound for secrets of other accounts.
+	DeleteSecret(ctx context.Context, serviceAccountID, id uuid.UUID) error
+	TouchSecret(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
@@ -11,18 +11,47 @@ import (
 var ErrSessionNotFound = fmt.Errorf("session not found")
 
 type Session struct {
-	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
-	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	ExpiresAt time.Time `gorm:"not null;index"`
+	ID     uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	// ClientID and Scope are set on sessions started through the OpenID
+	// Connect token endpoint; first-party logins leave them empty.
+	ClientID *uuid.UUID `gorm:"type:uuid"`
+	Scope    string
+	// RefreshTokenHash is the SHA-256 of the only refresh token of an OpenID
+	// Connect session that may still be redeemed; each redemption replaces
+	// it.
+	RefreshTokenHash string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	ExpiresAt        time.Time `gorm:"not null;index"`
+}
+
+// SessionResponse describes a session of the signed in user. Sessions
+// started by an OAuth client, such as an approved device, carry the client.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	ClientID   string    `json:"client_id,omitempty"`
+	ClientName string    `json:"client_name,omitempty" example:"migos-cli"`
+	Scope      string    `json:"scope,omitempty" example:"openid profile"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type SessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
 }
 
 type SessionRepository interface {
 	CreateSession(ctx context.Context, session *Session) error
 	FindSessionByID(ctx context.Context, sessionID uuid.UUID) (*Session, error)
+	FindSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]Session, error)
 	DeleteSession(ctx context.Context, sessionID uuid.UUID) (*Session, error)
 	UpdateSessionExpiry(ctx context.Context, sessionID uuid.UUID, expiresAt time.Time) error
+	// RotateRefreshToken replaces the refresh token hash of a session and
+	// extends it, only while the stored hash is still current. It returns
+	// ErrSessionNotFound when another redemption got there first.
+	RotateRefreshToken(ctx context.Context, sessionID uuid.UUID, current, next string, expiresAt time.Time) error
 	DeleteExpiredSessions(ctx context.Context) (int64, error)
-	DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) error
+	DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 }
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrSocialProviderNotFound  = fmt.Errorf("Error Social Provider Not Found")
+	ErrSocialLoginFailed       = fmt.Errorf("Error Social Login Failed")
+	ErrSocialEmailNotVerified  = fmt.Errorf("Error Social Email Not Verified")
+	ErrSocialLoginStateInvalid = fmt.Errorf("Error Social Login State Invalid")
+	ErrIdentityNotFound        = fmt.Errorf("Error Identity Not Found")
+)
+
+// SocialProviderConfig configures an upstream identity provider. Providers
+// with an Issuer are OpenID Connect providers whose endpoints come from
+// discovery; the others are plain OAuth2 providers and need the endpoint
+// URLs and Claims to read the user from UserInfoURL.
+type SocialProviderConfig struct {
+	Name             string       `json:"name"`
+	DisplayName      string       `json:"display_name,omitempty"`
+	Issuer           string       `json:"issuer,omitempty"`
+	AuthorizationURL string       `json:"authorization_url,omitempty"`
+	TokenURL         string       `json:"token_url,omitempty"`
+	UserInfoURL      string       `json:"userinfo_url,omitempty"`
+	EmailsURL        string       `json:"emails_url,omitempty"`
+	ClientID         string       `json:"client_id"`
+	ClientSecret     string       `json:"client_secret"`
+	Scopes           []string     `json:"scopes,omitempty"`
+	Claims           SocialClaims `json:"claims,omitempty"`
+}
+
+// SocialClaims names the user info fields of an OAuth2 provider. Empty
+// names fall back to the OpenID Connect claim names.
+type SocialClaims struct {
+	Subject       string `json:"subject,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified string `json:"email_verified,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Picture       string `json:"picture,omitempty"`
+}
+
+// SocialIdentity is the user as described by an upstream provider.
+type SocialIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// Identity links the subject of an upstream provider to a user.
+type Identity struct {
+	Provider  string    `gorm:"primaryKey"`
+	Subject   string    `gorm:"primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Email     string    `gorm:"serializer:pii"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SocialLoginState carries what the callback needs to finish a login
+// started by the same browser. ID holds a SHA-256 hash of the state.
+type SocialLoginState struct {
+	ID           string `gorm:"primary_key"`
+	Provider     string `gorm:"not null"`
+	Nonce        string `gorm:"not null"`
+	CodeVerifier string `gorm:"not null;serializer:secret"`
+	RedirectURI  string `gorm:"not null"`
+	ReturnTo     string
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+type SocialProviderInfo struct {
+	Name        string `json:"name" example:"google"`
+	DisplayName string `json:"display_name" example:"Google"`
+}
+
+type SocialProvidersResponse struct {
+	Providers []SocialProviderInfo `json:"providers"`
+}
+
+type SocialLoginRequest struct {
+	ReturnTo string `query:"return_to"`
+}
+
+type SocialCallbackRequest struct {
+	Code             string `query:"code"`
+	State            string `query:"state"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
+// SocialLogin is a login started with an upstream provider: the browser is
+// sent to AuthorizationURL and keeps State in a cookie for the callback.
+type SocialLogin struct {
+	AuthorizationURL string
+	State            string
+}
+
+// SocialLoginResult is a completed login and where to send the user next.
+type SocialLoginResult struct {
+	Tokens   *AuthResponse
+	ReturnTo string
+}
+
+// SocialProvider talks to one upstream identity provider.
+type SocialProvider interface {
+	Config() SocialProviderConfig
+	AuthorizationURL(ctx context.Context, redirectURI, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems the code and returns the verified identity. Nonce is
+	// checked against the ID token of OpenID Connect providers.
+	Exchange(ctx context.Context, redirectURI, code, codeVerifier, nonce string) (*SocialIdentity, error)
+}
+
+// SocialProviders are the configured providers, in configuration order.
+type SocialProviders []SocialProvider
+
+type SocialHandler interface {
+	Providers(c echo.Context) error
+	Login(c echo.Context) error
+	Callback(c echo.Context) error
+}
+
+type SocialService interface {
+	Providers() []SocialProviderInfo
+	Begin(ctx context.Context, provider, redirectURI, returnTo string) (*SocialLogin, error)
+	Complete(ctx context.Context, provider string, req SocialCallbackRequest) (*SocialLoginResult, error)
+}
+
+type IdentityRepository interface {
+	FindIdentity(ctx context.Context, provider, subject string) (*Identity, error)
+	// SaveIdentity links the identity to its user, replacing a previous link
+	// of the same subject.
+	SaveIdentity(ctx context.Context, identity *Identity) error
+	CreateLoginState(ctx context.Context, state *SocialLoginState) error
+	// ConsumeLoginState deletes and returns the state, so a callback can be
+	// completed at most once.
+	ConsumeLoginState(ctx context.Context, id string) (*SocialLoginState, error)
+	DeleteExpiredLoginStates(ctx context.Context) (int64, error)
+}
@@ -1,22 +1,70 @@
 package domain
 
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
 type AuthResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
+	// SessionID lets the handlers tie the CSRF cookie to the session.
+	SessionID string `json:"-"`
 }
 
+// AccessTokenClaims are the claims of a user access token or, when
+// ClientID is set, of a service account token, which has no user or
+// session. OIDCClientID is set on the tokens of sessions started by an
+// OpenID Connect client, with the scopes granted to it.
 type AccessTokenClaims struct {
-	SessionID string
+	UserID       string
+	SessionID    string
+	ClientID     string
+	OIDCClientID string
+	Scopes       []string
+	IssuedAt     time.Time
+	ExpiresAt    time.Time
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token" validate:"required"`
 }
 
 type RefreshTokenClaims struct {
 	UserID    string
 	SessionID string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// JWK is an RSA public key in JSON Web Key form (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty" example:"RSA"`
+	Use string `json:"use" example:"sig"`
+	Alg string `json:"alg" example:"RS256"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e" example:"AQAB"`
+}
+
+// JWKS is the set of keys that verify the tokens this service signs.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type KeysHandler interface {
+	JWKS(c echo.Context) error
 }
 
 type TokenProvider interface {
-	GenerateAccessToken(sessionID string) (string, error)
-	GenerateRefreshToken(userID, sessionID string) (string, error)
-	ParseAccessToken(tokenString string) (*AccessTokenClaims, error)
-	ParseRefreshToken(tokenString string) (*RefreshTokenClaims, error)
+	GenerateAccessToken(ctx context.Context, userID, sessionID string) (string, error)
+	GenerateClientAccessToken(ctx context.Context, userID, sessionID, clientID, scope string) (string, error)
+	GenerateRefreshToken(ctx context.Context, userID, sessionID string) (string, error)
+	GenerateIDToken(ctx context.Context, claims IDTokenClaims) (string, error)
+	GenerateServiceAccountToken(ctx context.Context, serviceAccountID string, scopes []string) (string, error)
+	ParseAccessToken(ctx context.Context, tokenString string) (*AccessTokenClaims, error)
+	ParseRefreshToken(ctx context.Context, tokenString string) (*RefreshTokenClaims, error)
+	JWKS() JWKS
 }
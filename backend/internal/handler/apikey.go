@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+type APIKeyHandlerImpl struct {
+	APIKeyService domain.APIKeyService
+}
+
+func NewAPIKeyHandler(i *do.Injector) (domain.APIKeyHandler, error) {
+	apiKeyService := do.MustInvoke[domain.APIKeyService](i)
+
+	return &APIKeyHandlerImpl{
+		APIKeyService: apiKeyService,
+	}, nil
+}
+
+// Create mints a key. It needs a session, so a key can't mint keys with
+// wider scopes or a later expiry than its own.
+func (h APIKeyHandlerImpl) Create(c echo.Context) error {
+	if _, err := requireSession(c); err != nil {
+		return err
+	}
+
+	var request domain.CreateAPIKeyRequest
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
+	}
+
+	response, err := h.APIKeyService.Create(c.Request().Context(), c.Get("user_id").(string), request)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (h APIKeyHandlerImpl) List(c echo.Context) error {
+	keys, err := h.APIKeyService.List(c.Request().Context(), c.Get("user_id").(string))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.APIKeysResponse{APIKeys: keys})
+}
+
+func (h APIKeyHandlerImpl) Revoke(c echo.Context) error {
+	if err := h.APIKeyService.Revoke(c.Request().Context(), c.Get("user_id").(string), c.Param("id")); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+func newAPIKeyHandler(t *testing.T) (*APIKeyHandlerImpl, *mockpkg.MockAPIKeyService) {
+	t.Helper()
+	apiKeyService := mockpkg.NewMockAPIKeyService(t)
+	return &APIKeyHandlerImpl{APIKeyService: apiKeyService}, apiKeyService
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	const body = `{"name":"ci","scopes":["read"],"expires_in_days":30}`
+
+	t.Run("should return 201 with the key and disable caching", func(t *testing.T) {
+		t.Parallel()
+
+		h, apiKeyService := newAPIKeyHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/api-keys", body)
+		c.Set("user_id", "user-1")
+		c.Set("session_id", "session-1")
+
+		apiKeyService.On("Create", mock.Anything, "user-1", domain.CreateAPIKeyRequest{
+			Name: "ci", Scopes: []string{"read"}, ExpiresInDays: 30,
+		}).Return(&domain.CreatedAPIKeyResponse{Key: "mig_secret"}, nil)
+
+		err := serve(c, h.Create)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.Contains(t, rec.Body.String(), `"key":"mig_secret"`)
+	})
+
+	t.Run("should return 403 when authenticated with an api key", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newAPIKeyHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/api-keys", body)
+		c.Set("user_id", "user-1")
+		c.Set("api_key_id", "key-1")
+
+		err := serve(c, h.Create)
+
+		assert.ErrorIs(t, err, domain.ErrSessionRequired)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should return 400 for an unknown scope", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newAPIKeyHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/api-keys",
+			`{"name":"ci","scopes":["admin"],"expires_in_days":30}`)
+		c.Set("user_id", "user-1")
+		c.Set("session_id", "session-1")
+
+		err := serve(c, h.Create)
+
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	t.Run("should return 404 for keys of other users", func(t *testing.T) {
+		t.Parallel()
+
+		h, apiKeyService := newAPIKeyHandler(t)
+		c, rec := newJSONContext(http.MethodDelete, "/v1/auth/api-keys/key-1", "")
+		c.Set("user_id", "user-1")
+		c.SetParamNames("id")
+		c.SetParamValues("key-1")
+
+		apiKeyService.On("Revoke", mock.Anything, "user-1", "key-1").Return(domain.ErrAPIKeyNotFound)
+
+		err := serve(c, h.Revoke)
+
+		assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
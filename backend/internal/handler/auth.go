@@ -1,15 +1,10 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 
-	"github.com/SergioLNeves/migos/internal/config"
 	"github.com/SergioLNeves/migos/internal/domain"
-	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
 	"github.com/SergioLNeves/migos/internal/pkg/logging"
-	validatorpkg "github.com/SergioLNeves/migos/internal/pkg/validator"
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/do"
 	"go.uber.org/zap"
@@ -17,261 +12,139 @@ import (
 
 type AuthHandlerImpl struct {
 	AuthService domain.AuthService
+	Cookies     domain.CookieManager
 }
 
 func NewAuthHandler(i *do.Injector) (domain.AuthHandler, error) {
 	authService := do.MustInvoke[domain.AuthService](i)
+	cookies := do.MustInvoke[domain.CookieManager](i)
 
 	return &AuthHandlerImpl{
 		AuthService: authService,
+		Cookies:     cookies,
 	}, nil
 }
 
 func (e AuthHandlerImpl) CreateAccount(c echo.Context) error {
-	logger := logging.With(zap.String("handler", "AuthHandler.CreateAccount"))
-
 	var request domain.CreateAccountRequest
-	if err := c.Bind(&request); err != nil {
-		logger.Error("failed to bind request", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("auth", "invalid-request").
-			WithTitle("Invalid Request").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("Failed to parse request body").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusBadRequest, problemDetails)
-	}
-
-	if err := validatorpkg.NewValidator().Validate(request); err != nil {
-		logger.Error("validation failed", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("auth", "validation-error").
-			WithTitle("Validation Failed").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("One or more fields failed validation").
-			WithInstance(c.Request().URL.Path).
-			AddFieldErrors(
-				errorpkg.NewProblemDetailsFromStructValidation(err.(validator.ValidationErrors)),
-			)
-		return c.JSON(http.StatusBadRequest, problemDetails)
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
 	}
 
 	response, err := e.AuthService.CreateAccount(c.Request().Context(), request)
 	if err != nil {
-		if errors.Is(err, domain.ErrEmailAlreadyExists) {
-			logger.Info("email already exists", zap.String("email", request.Email))
-			problemDetails := errorpkg.NewProblemDetails().
-				WithType("auth", "email-already-exists").
-				WithTitle("Email Already Registered").
-				WithStatus(http.StatusConflict).
-				WithDetail("An account with this email already exists").
-				WithInstance(c.Request().URL.Path)
-			return c.JSON(http.StatusConflict, problemDetails)
-		}
-
-		logger.Error("failed to create account", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("auth", "internal-error").
-			WithTitle("Internal Server Error").
-			WithStatus(http.StatusInternalServerError).
-			WithDetail("An unexpected error occurred while creating the account").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusInternalServerError, problemDetails)
+		return err
 	}
 
-	setAuthCookies(c, response)
+	e.Cookies.SetSession(c, response)
 
 	return c.JSON(http.StatusCreated, response)
 }
 
 func (e AuthHandlerImpl) Login(c echo.Context) error {
-	logger := logging.With(zap.String("handler", "AuthHandler.Login"))
-
 	var request domain.LoginRequest
-	if err := c.Bind(&request); err != nil {
-		logger.Error("failed to bind request", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("auth", "invalid-request").
-			WithTitle("Invalid Request").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("Failed to parse request body").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusBadRequest, problemDetails)
-	}
-
-	if err := validatorpkg.NewValidator().Validate(request); err != nil {
-		logger.Error("validation failed", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("auth", "validation-error").
-			WithTitle("Validation Failed").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("One or more fields failed validation").
-			WithInstance(c.Request().URL.Path).
-			AddFieldErrors(
-				errorpkg.NewProblemDetailsFromStructValidation(err.(validator.ValidationErrors)),
-			)
-		return c.JSON(http.StatusBadRequest, problemDetails)
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
 	}
 
 	response, err := e.AuthService.Login(c.Request().Context(), request)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidCredentials) {
-			logger.Info("invalid credentials", zap.String("email", request.Email))
-			problemDetails := errorpkg.NewProblemDetails().
-				WithType("auth", "invalid-credentials").
-				WithTitle("Invalid Credentials").
-				WithStatus(http.StatusUnauthorized).
-				WithDetail("Invalid email or password").
-				WithInstance(c.Request().URL.Path)
-			return c.JSON(http.StatusUnauthorized, problemDetails)
-		}
+		return err
+	}
 
-		if errors.Is(err, domain.ErrUserDeactivated) {
-			logger.Info("user deactivated", zap.String("email", request.Email))
-			problemDetails := errorpkg.NewProblemDetails().
-				WithType("auth", "user-deactivated").
-				WithTitle("Account Deactivated").
-				WithStatus(http.StatusForbidden).
-				WithDetail("Your account has been deactivated").
-				WithInstance(c.Request().URL.Path)
-			return c.JSON(http.StatusForbidden, problemDetails)
-		}
+	e.Cookies.SetSession(c, response)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ChangeExpiredPassword redeems the challenge returned by Login for a
+// password past its maximum age, setting a new password and signing in.
+func (e AuthHandlerImpl) ChangeExpiredPassword(c echo.Context) error {
+	var request domain.ChangeExpiredPasswordRequest
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
+	}
 
-		logger.Error("failed to login", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("auth", "internal-error").
-			WithTitle("Internal Server Error").
-			WithStatus(http.StatusInternalServerError).
-			WithDetail("An unexpected error occurred during login").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusInternalServerError, problemDetails)
+	response, err := e.AuthService.ChangeExpiredPassword(c.Request().Context(), request)
+	if err != nil {
+		return err
 	}
 
-	setAuthCookies(c, response)
+	e.Cookies.SetSession(c, response)
 
 	return c.JSON(http.StatusOK, response)
 }
 
 func (e AuthHandlerImpl) Logout(c echo.Context) error {
-	logger := logging.With(zap.String("handler", "AuthHandler.Logout"))
+	logger := logging.WithContext(c.Request().Context(), zap.String("handler", "AuthHandler.Logout"))
 
-	sessionID := c.Get("session_id").(string)
+	sessionID, err := requireSession(c)
+	if err != nil {
+		return err
+	}
 	if err := e.AuthService.Logout(c.Request().Context(), sessionID); err != nil {
 		logger.Error("failed to deactivate session", zap.Error(err))
 	}
 
-	clearAuthCookies(c)
+	e.Cookies.ClearSession(c)
 	return c.NoContent(http.StatusOK)
 }
 
-func (e AuthHandlerImpl) UpdatePassword(c echo.Context) error {
-	logger := logging.With(zap.String("handler", "AuthHandler.UpdatePassword"))
+// ListSessions lists the sessions of the signed in user, marking the one
+// making the request.
+func (e AuthHandlerImpl) ListSessions(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+	sessionID, _ := c.Get("session_id").(string)
 
-	var request domain.UpdatePasswordRequest
-	if err := c.Bind(&request); err != nil {
-		logger.Error("failed to bind request", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("user", "invalid-request").
-			WithTitle("Invalid Request").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("Failed to parse request body").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusBadRequest, problemDetails)
+	sessions, err := e.AuthService.ListSessions(c.Request().Context(), userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.SessionsResponse{Sessions: sessions})
+}
+
+// RevokeSession ends one of the user's sessions. Like creating API keys, it
+// can't be done with an API key.
+func (e AuthHandlerImpl) RevokeSession(c echo.Context) error {
+	if _, err := requireSession(c); err != nil {
+		return err
+	}
+	userID := c.Get("user_id").(string)
+
+	if err := e.AuthService.RevokeSession(c.Request().Context(), userID, c.Param("id")); err != nil {
+		return err
 	}
 
-	if err := validatorpkg.NewValidator().Validate(request); err != nil {
-		logger.Error("validation failed", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("user", "validation-error").
-			WithTitle("Validation Failed").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("One or more fields failed validation").
-			WithInstance(c.Request().URL.Path).
-			AddFieldErrors(
-				errorpkg.NewProblemDetailsFromStructValidation(err.(validator.ValidationErrors)),
-			)
-		return c.JSON(http.StatusBadRequest, problemDetails)
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (e AuthHandlerImpl) UpdatePassword(c echo.Context) error {
+	var request domain.UpdatePasswordRequest
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
 	}
 
 	userID := c.Get("user_id").(string)
 
 	if err := e.AuthService.UpdatePassword(c.Request().Context(), userID, request); err != nil {
-		if errors.Is(err, domain.ErrInvalidCurrentPassword) {
-			logger.Info("invalid current password", zap.String("user_id", userID))
-			problemDetails := errorpkg.NewProblemDetails().
-				WithType("user", "invalid-current-password").
-				WithTitle("Invalid Current Password").
-				WithStatus(http.StatusUnauthorized).
-				WithDetail("The current password provided is incorrect").
-				WithInstance(c.Request().URL.Path)
-			return c.JSON(http.StatusUnauthorized, problemDetails)
-		}
-
-		logger.Error("failed to update password", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("user", "internal-error").
-			WithTitle("Internal Server Error").
-			WithStatus(http.StatusInternalServerError).
-			WithDetail("An unexpected error occurred while updating the password").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusInternalServerError, problemDetails)
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
 func (e AuthHandlerImpl) UpdateUser(c echo.Context) error {
-	logger := logging.With(zap.String("handler", "AuthHandler.UpdateUser"))
-
 	var request domain.UpdateUserRequest
-	if err := c.Bind(&request); err != nil {
-		logger.Error("failed to bind request", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("user", "invalid-request").
-			WithTitle("Invalid Request").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("Failed to parse request body").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusBadRequest, problemDetails)
-	}
-
-	if err := validatorpkg.NewValidator().Validate(request); err != nil {
-		logger.Error("validation failed", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("user", "validation-error").
-			WithTitle("Validation Failed").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("One or more fields failed validation").
-			WithInstance(c.Request().URL.Path).
-			AddFieldErrors(
-				errorpkg.NewProblemDetailsFromStructValidation(err.(validator.ValidationErrors)),
-			)
-		return c.JSON(http.StatusBadRequest, problemDetails)
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
 	}
 
 	userID := c.Get("user_id").(string)
 
 	response, err := e.AuthService.UpdateUser(c.Request().Context(), userID, request)
 	if err != nil {
-		if errors.Is(err, domain.ErrEmailAlreadyExists) {
-			logger.Info("email already exists", zap.String("user_id", userID))
-			problemDetails := errorpkg.NewProblemDetails().
-				WithType("user", "email-already-exists").
-				WithTitle("Email Already Registered").
-				WithStatus(http.StatusConflict).
-				WithDetail("An account with this email already exists").
-				WithInstance(c.Request().URL.Path)
-			return c.JSON(http.StatusConflict, problemDetails)
-		}
-
-		logger.Error("failed to update user", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("user", "internal-error").
-			WithTitle("Internal Server Error").
-			WithStatus(http.StatusInternalServerError).
-			WithDetail("An unexpected error occurred while updating the profile").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusInternalServerError, problemDetails)
+		return err
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -287,130 +160,68 @@ func (e AuthHandlerImpl) Me(c echo.Context) error {
 }
 
 func (e AuthHandlerImpl) DeleteUser(c echo.Context) error {
-	logger := logging.With(zap.String("handler", "AuthHandler.DeleteUser"))
-
+	if _, err := requireSession(c); err != nil {
+		return err
+	}
 	userID := c.Get("user_id").(string)
 
 	if err := e.AuthService.DeleteUser(c.Request().Context(), userID); err != nil {
-		logger.Error("failed to delete user", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("user", "internal-error").
-			WithTitle("Internal Server Error").
-			WithStatus(http.StatusInternalServerError).
-			WithDetail("An unexpected error occurred while deleting the account").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusInternalServerError, problemDetails)
+		return err
 	}
 
-	clearAuthCookies(c)
+	e.Cookies.ClearSession(c)
 	return c.NoContent(http.StatusOK)
 }
 
 func (e AuthHandlerImpl) ReactivateAccount(c echo.Context) error {
-	logger := logging.With(zap.String("handler", "AuthHandler.ReactivateAccount"))
-
 	var request domain.LoginRequest
-	if err := c.Bind(&request); err != nil {
-		logger.Error("failed to bind request", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("auth", "invalid-request").
-			WithTitle("Invalid Request").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("Failed to parse request body").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusBadRequest, problemDetails)
-	}
-
-	if err := validatorpkg.NewValidator().Validate(request); err != nil {
-		logger.Error("validation failed", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("auth", "validation-error").
-			WithTitle("Validation Failed").
-			WithStatus(http.StatusBadRequest).
-			WithDetail("One or more fields failed validation").
-			WithInstance(c.Request().URL.Path).
-			AddFieldErrors(
-				errorpkg.NewProblemDetailsFromStructValidation(err.(validator.ValidationErrors)),
-			)
-		return c.JSON(http.StatusBadRequest, problemDetails)
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
 	}
 
 	response, err := e.AuthService.ReactivateAccount(c.Request().Context(), request)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidCredentials) {
-			logger.Info("invalid credentials", zap.String("email", request.Email))
-			problemDetails := errorpkg.NewProblemDetails().
-				WithType("auth", "invalid-credentials").
-				WithTitle("Invalid Credentials").
-				WithStatus(http.StatusUnauthorized).
-				WithDetail("Invalid email or password").
-				WithInstance(c.Request().URL.Path)
-			return c.JSON(http.StatusUnauthorized, problemDetails)
-		}
+		return err
+	}
+
+	e.Cookies.SetSession(c, response)
+
+	return c.JSON(http.StatusOK, response)
+}
 
-		if errors.Is(err, domain.ErrUserNotDeactivated) {
-			logger.Info("user not deactivated", zap.String("email", request.Email))
-			problemDetails := errorpkg.NewProblemDetails().
-				WithType("auth", "user-not-deactivated").
-				WithTitle("Account Not Deactivated").
-				WithStatus(http.StatusBadRequest).
-				WithDetail("This account is not deactivated").
-				WithInstance(c.Request().URL.Path)
-			return c.JSON(http.StatusBadRequest, problemDetails)
+// Refresh rotates the session tokens. Browsers rely on the refresh_token
+// cookie; other clients send the token in the body.
+func (e AuthHandlerImpl) Refresh(c echo.Context) error {
+	var request domain.RefreshRequest
+	if c.Request().Header.Get(echo.HeaderContentType) != "" {
+		if err := bindAndValidate(c, &request); err != nil {
+			return err
+		}
+	} else {
+		refreshToken, ok := e.Cookies.Get(c, domain.CookieRefreshToken)
+		if !ok {
+			return domain.ErrInvalidRefreshToken
 		}
+		request.RefreshToken = refreshToken
+	}
 
-		logger.Error("failed to reactivate account", zap.Error(err))
-		problemDetails := errorpkg.NewProblemDetails().
-			WithType("auth", "internal-error").
-			WithTitle("Internal Server Error").
-			WithStatus(http.StatusInternalServerError).
-			WithDetail("An unexpected error occurred while reactivating the account").
-			WithInstance(c.Request().URL.Path)
-		return c.JSON(http.StatusInternalServerError, problemDetails)
+	response, err := e.AuthService.Refresh(c.Request().Context(), request.RefreshToken)
+	if err != nil {
+		return err
 	}
 
-	setAuthCookies(c, response)
+	e.Cookies.SetSession(c, response)
 
 	return c.JSON(http.StatusOK, response)
 }
 
-func clearAuthCookies(c echo.Context) {
-	c.SetCookie(&http.Cookie{
-		Name:     "access_token",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-	})
-	c.SetCookie(&http.Cookie{
-		Name:     "refresh_token",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-	})
-}
-
-func setAuthCookies(c echo.Context, response *domain.AuthResponse) {
-	isProduction := config.Env.Env == "production"
-
-	c.SetCookie(&http.Cookie{
-		Name:     "access_token",
-		Value:    response.AccessToken,
-		Path:     "/",
-		MaxAge:   config.Env.Token.AccessTokenExpiry * 60,
-		HttpOnly: true,
-		Secure:   isProduction,
-		SameSite: http.SameSiteStrictMode,
-	})
-
-	c.SetCookie(&http.Cookie{
-		Name:     "refresh_token",
-		Value:    response.RefreshToken,
-		Path:     "/",
-		MaxAge:   config.Env.Token.RefreshTokenExpiry * 60,
-		HttpOnly: true,
-		Secure:   isProduction,
-		SameSite: http.SameSiteStrictMode,
-	})
+// requireSession returns the session of the request. Requests made with an
+// API key have none and are refused, for operations a leaked key must not
+// be able to perform.
+func requireSession(c echo.Context) (string, error) {
+	sessionID, ok := c.Get("session_id").(string)
+	if !ok {
+		return "", domain.ErrSessionRequired
+	}
+	return sessionID, nil
 }
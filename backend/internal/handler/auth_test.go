@@ -8,28 +8,58 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/SergioLNeves/migos/internal/config"
 	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/cookie"
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
 	"github.com/SergioLNeves/migos/internal/pkg/logging"
 	mockpkg "github.com/SergioLNeves/migos/mock"
 )
 
 func TestMain(m *testing.M) {
 	logging.NewLogger(&domain.Config{Env: "development", LogLevel: "error"})
+	config.Env.OIDC.LoginURL = "/login"
 	os.Exit(m.Run())
 }
 
 func newHandler(t *testing.T) (*AuthHandlerImpl, *mockpkg.MockAuthService) {
 	t.Helper()
 	authService := mockpkg.NewMockAuthService(t)
-	h := &AuthHandlerImpl{AuthService: authService}
+	h := &AuthHandlerImpl{AuthService: authService, Cookies: newCookies(t)}
 	return h, authService
 }
 
+// newCookies returns a cookie manager with the default policy.
+func newCookies(t *testing.T) *cookie.Manager {
+	t.Helper()
+	cookies, err := cookie.New(&domain.Config{
+		HTTP:   domain.HTTPConfig{CSRFKey: "test-csrf-key-of-at-least-32-bytes"},
+		Token:  domain.TokenConfig{AccessTokenExpiry: 15, RefreshTokenExpiry: 60},
+		Social: domain.SocialConfig{StateTTL: 10 * time.Minute},
+	})
+	require.NoError(t, err)
+	return cookies
+}
+
+const problemJSON = "application/problem+json"
+
+// serve runs fn the way Echo does, rendering a returned error through the
+// central error handler.
+func serve(c echo.Context, fn echo.HandlerFunc) error {
+	err := fn(c)
+	if err != nil {
+		errorpkg.HTTPErrorHandler(err, c)
+	}
+	return err
+}
+
 func newFormContext(method, path, body string) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
 	req := httptest.NewRequest(method, path, strings.NewReader(body))
@@ -38,6 +68,14 @@ func newFormContext(method, path, body string) (echo.Context, *httptest.Response
 	return e.NewContext(req, rec), rec
 }
 
+func newJSONContext(method, path, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
 func TestCreateAccount(t *testing.T) {
 	t.Run("should return 201 and tokens on success", func(t *testing.T) {
 		t.Parallel()
@@ -49,7 +87,7 @@ func TestCreateAccount(t *testing.T) {
 			Name: "Test User", Email: "user@test.com", Password: "password123",
 		}).Return(&domain.AuthResponse{AccessToken: "at", RefreshToken: "rt"}, nil)
 
-		err := h.CreateAccount(c)
+		err := serve(c, h.CreateAccount)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusCreated, rec.Code)
@@ -60,6 +98,22 @@ func TestCreateAccount(t *testing.T) {
 		assert.Equal(t, "rt", resp.RefreshToken)
 	})
 
+	t.Run("should return 201 and tokens on JSON body", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/user/create-account", `{"name":"Test User","avatar":"a.png","email":"user@test.com","password":"password123"}`)
+
+		authService.On("CreateAccount", mock.Anything, domain.CreateAccountRequest{
+			Name: "Test User", Avatar: "a.png", Email: "user@test.com", Password: "password123",
+		}).Return(&domain.AuthResponse{AccessToken: "at", RefreshToken: "rt"}, nil)
+
+		err := serve(c, h.CreateAccount)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
 	t.Run("should return 409 when email already exists", func(t *testing.T) {
 		t.Parallel()
 
@@ -70,10 +124,11 @@ func TestCreateAccount(t *testing.T) {
 			Name: "Test User", Email: "user@test.com", Password: "password123",
 		}).Return(nil, domain.ErrEmailAlreadyExists)
 
-		err := h.CreateAccount(c)
+		err := serve(c, h.CreateAccount)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrEmailAlreadyExists)
 		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 500 on service error", func(t *testing.T) {
@@ -86,10 +141,11 @@ func TestCreateAccount(t *testing.T) {
 			Name: "Test User", Email: "user@test.com", Password: "password123",
 		}).Return(nil, errors.New("unexpected"))
 
-		err := h.CreateAccount(c)
+		err := serve(c, h.CreateAccount)
 
-		assert.NoError(t, err)
+		assert.Error(t, err)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 400 on validation error", func(t *testing.T) {
@@ -98,10 +154,11 @@ func TestCreateAccount(t *testing.T) {
 		h, _ := newHandler(t)
 		c, rec := newFormContext(http.MethodPost, "/v1/user/create-account", "email=invalid&password=short")
 
-		err := h.CreateAccount(c)
+		err := serve(c, h.CreateAccount)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, errorpkg.ErrValidation)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 }
 
@@ -116,7 +173,7 @@ func TestLogin(t *testing.T) {
 			Email: "user@test.com", Password: "password123",
 		}).Return(&domain.AuthResponse{AccessToken: "at", RefreshToken: "rt"}, nil)
 
-		err := h.Login(c)
+		err := serve(c, h.Login)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -127,6 +184,92 @@ func TestLogin(t *testing.T) {
 		assert.Equal(t, "rt", resp.RefreshToken)
 	})
 
+	t.Run("should return 200 and tokens on JSON body", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/login", `{"email":"user@test.com","password":"password123"}`)
+
+		authService.On("Login", mock.Anything, domain.LoginRequest{
+			Email: "user@test.com", Password: "password123",
+		}).Return(&domain.AuthResponse{AccessToken: "at", RefreshToken: "rt"}, nil)
+
+		err := serve(c, h.Login)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("should return 400 on unknown JSON field", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/login", `{"email":"user@test.com","password":"password123","remember":true}`)
+
+		err := serve(c, h.Login)
+
+		assert.ErrorIs(t, err, errorpkg.ErrInvalidRequest)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("should return 400 on malformed JSON", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/login", `{"email":`)
+
+		err := serve(c, h.Login)
+
+		assert.ErrorIs(t, err, errorpkg.ErrInvalidRequest)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should return 400 on trailing data after JSON object", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/login", `{"email":"user@test.com","password":"password123"} {}`)
+
+		err := serve(c, h.Login)
+
+		assert.ErrorIs(t, err, errorpkg.ErrInvalidRequest)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should return 415 on unsupported content type", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newHandler(t)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", strings.NewReader("<login/>"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationXML)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := serve(c, h.Login)
+
+		assert.ErrorIs(t, err, errorpkg.ErrUnsupportedMediaType)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Body.String(), "unsupported-media-type")
+	})
+
+	t.Run("should return 415 when content type is missing", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newHandler(t)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", strings.NewReader("email=user@test.com"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := serve(c, h.Login)
+
+		assert.ErrorIs(t, err, errorpkg.ErrUnsupportedMediaType)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
 	t.Run("should return 401 on invalid credentials", func(t *testing.T) {
 		t.Parallel()
 
@@ -137,10 +280,11 @@ func TestLogin(t *testing.T) {
 			Email: "user@test.com", Password: "wrong",
 		}).Return(nil, domain.ErrInvalidCredentials)
 
-		err := h.Login(c)
+		err := serve(c, h.Login)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 500 on service error", func(t *testing.T) {
@@ -153,10 +297,11 @@ func TestLogin(t *testing.T) {
 			Email: "user@test.com", Password: "password123",
 		}).Return(nil, errors.New("unexpected"))
 
-		err := h.Login(c)
+		err := serve(c, h.Login)
 
-		assert.NoError(t, err)
+		assert.Error(t, err)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 403 when user is deactivated", func(t *testing.T) {
@@ -169,10 +314,29 @@ func TestLogin(t *testing.T) {
 			Email: "user@test.com", Password: "password123",
 		}).Return(nil, domain.ErrUserDeactivated)
 
-		err := h.Login(c)
+		err := serve(c, h.Login)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrUserDeactivated)
 		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("should return 403 when password has expired", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newFormContext(http.MethodPost, "/v1/auth/login", "email=user@test.com&password=password123")
+
+		authService.On("Login", mock.Anything, domain.LoginRequest{
+			Email: "user@test.com", Password: "password123",
+		}).Return(nil, domain.ErrPasswordExpired)
+
+		err := serve(c, h.Login)
+
+		assert.ErrorIs(t, err, domain.ErrPasswordExpired)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Body.String(), "password-expired")
 	})
 
 	t.Run("should return 400 on validation error", func(t *testing.T) {
@@ -181,10 +345,11 @@ func TestLogin(t *testing.T) {
 		h, _ := newHandler(t)
 		c, rec := newFormContext(http.MethodPost, "/v1/auth/login", "email=invalid&password=")
 
-		err := h.Login(c)
+		err := serve(c, h.Login)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, errorpkg.ErrValidation)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 }
 
@@ -201,7 +366,7 @@ func TestLogout(t *testing.T) {
 
 		authService.On("Logout", mock.Anything, "some-session-id").Return(nil)
 
-		err := h.Logout(c)
+		err := serve(c, h.Logout)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -219,7 +384,7 @@ func TestLogout(t *testing.T) {
 
 		authService.On("Logout", mock.Anything, "some-session-id").Return(errors.New("db error"))
 
-		err := h.Logout(c)
+		err := serve(c, h.Logout)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -242,22 +407,81 @@ func TestUpdatePassword(t *testing.T) {
 			CurrentPassword: "oldpass123", NewPassword: "newpass123",
 		}).Return(nil)
 
-		err := h.UpdatePassword(c)
+		err := serve(c, h.UpdatePassword)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should return 204 on JSON body", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newJSONContext(http.MethodPatch, "/v1/user/password", `{"current_password":"oldpass123","new_password":"newpass123"}`)
+		c.Set("user_id", "some-user-id")
+
+		authService.On("UpdatePassword", mock.Anything, "some-user-id", domain.UpdatePasswordRequest{
+			CurrentPassword: "oldpass123", NewPassword: "newpass123",
+		}).Return(nil)
+
+		err := serve(c, h.UpdatePassword)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusNoContent, rec.Code)
 	})
 
+	t.Run("should return 400 on JSON validation error", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newHandler(t)
+		c, rec := newJSONContext(http.MethodPatch, "/v1/user/password", `{"current_password":"oldpass123"}`)
+
+		err := serve(c, h.UpdatePassword)
+
+		assert.ErrorIs(t, err, errorpkg.ErrValidation)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"field":"new_password"`)
+	})
+
+	t.Run("should return 400 with field errors when the new password breaks the policy", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newJSONContext(http.MethodPatch, "/v1/user/password", `{"current_password":"oldpass123","new_password":"short"}`)
+		c.Set("user_id", "some-user-id")
+
+		authService.On("UpdatePassword", mock.Anything, "some-user-id", domain.UpdatePasswordRequest{
+			CurrentPassword: "oldpass123", NewPassword: "short",
+		}).Return(&domain.PasswordPolicyError{Field: "new_password", Violations: []domain.PasswordViolation{
+			{Rule: domain.PasswordRuleMinLength, Limit: 8},
+			{Rule: domain.PasswordRuleBreached},
+		}})
+
+		err := serve(c, h.UpdatePassword)
+
+		assert.ErrorIs(t, err, domain.ErrWeakPassword)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var problem errorpkg.ProblemDetails
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "urn:auth-session-api/user/weak-password", problem.Type)
+		assert.Equal(t, []errorpkg.ProblemDetailsFieldError{
+			{Field: "new_password", Message: "new_password must be at least 8 characters long"},
+			{Field: "new_password", Message: "new_password has appeared in a data breach and can't be used"},
+		}, problem.FieldErrors)
+	})
+
 	t.Run("should return 400 on validation error", func(t *testing.T) {
 		t.Parallel()
 
 		h, _ := newHandler(t)
 		c, rec := newFormContext(http.MethodPatch, "/v1/user/password", "")
 
-		err := h.UpdatePassword(c)
+		err := serve(c, h.UpdatePassword)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, errorpkg.ErrValidation)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 401 when current password is wrong", func(t *testing.T) {
@@ -275,10 +499,11 @@ func TestUpdatePassword(t *testing.T) {
 			CurrentPassword: "wrongpass", NewPassword: "newpass123",
 		}).Return(domain.ErrInvalidCurrentPassword)
 
-		err := h.UpdatePassword(c)
+		err := serve(c, h.UpdatePassword)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrInvalidCurrentPassword)
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 500 on service error", func(t *testing.T) {
@@ -296,10 +521,11 @@ func TestUpdatePassword(t *testing.T) {
 			CurrentPassword: "oldpass123", NewPassword: "newpass123",
 		}).Return(errors.New("unexpected"))
 
-		err := h.UpdatePassword(c)
+		err := serve(c, h.UpdatePassword)
 
-		assert.NoError(t, err)
+		assert.Error(t, err)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 }
 
@@ -317,7 +543,7 @@ func TestMe(t *testing.T) {
 		c.Set("email", "user@test.com")
 		c.Set("avatar", "https://example.com/avatar.png")
 
-		err := h.Me(c)
+		err := serve(c, h.Me)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -341,10 +567,11 @@ func TestDeleteUser(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 		c.Set("user_id", "some-user-id")
+		c.Set("session_id", "some-session-id")
 
 		authService.On("DeleteUser", mock.Anything, "some-user-id").Return(nil)
 
-		err := h.DeleteUser(c)
+		err := serve(c, h.DeleteUser)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -359,13 +586,15 @@ func TestDeleteUser(t *testing.T) {
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 		c.Set("user_id", "some-user-id")
+		c.Set("session_id", "some-session-id")
 
 		authService.On("DeleteUser", mock.Anything, "some-user-id").Return(errors.New("unexpected"))
 
-		err := h.DeleteUser(c)
+		err := serve(c, h.DeleteUser)
 
-		assert.NoError(t, err)
+		assert.Error(t, err)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 }
 
@@ -387,7 +616,7 @@ func TestUpdateUser(t *testing.T) {
 			ID: "some-user-id", Name: "New Name", Email: "new@test.com", Avatar: "http://avatar.com/pic.png",
 		}, nil)
 
-		err := h.UpdateUser(c)
+		err := serve(c, h.UpdateUser)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -406,10 +635,11 @@ func TestUpdateUser(t *testing.T) {
 		h, _ := newHandler(t)
 		c, rec := newFormContext(http.MethodPatch, "/v1/user/profile", "email=not-an-email")
 
-		err := h.UpdateUser(c)
+		err := serve(c, h.UpdateUser)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, errorpkg.ErrValidation)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 409 when email already exists", func(t *testing.T) {
@@ -427,10 +657,11 @@ func TestUpdateUser(t *testing.T) {
 			Email: "taken@test.com",
 		}).Return(nil, domain.ErrEmailAlreadyExists)
 
-		err := h.UpdateUser(c)
+		err := serve(c, h.UpdateUser)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrEmailAlreadyExists)
 		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 500 on service error", func(t *testing.T) {
@@ -448,10 +679,11 @@ func TestUpdateUser(t *testing.T) {
 			Name: "New Name",
 		}).Return(nil, errors.New("unexpected"))
 
-		err := h.UpdateUser(c)
+		err := serve(c, h.UpdateUser)
 
-		assert.NoError(t, err)
+		assert.Error(t, err)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 }
 
@@ -466,7 +698,7 @@ func TestReactivateAccount(t *testing.T) {
 			Email: "user@test.com", Password: "password123",
 		}).Return(&domain.AuthResponse{AccessToken: "at", RefreshToken: "rt"}, nil)
 
-		err := h.ReactivateAccount(c)
+		err := serve(c, h.ReactivateAccount)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -483,10 +715,11 @@ func TestReactivateAccount(t *testing.T) {
 		h, _ := newHandler(t)
 		c, rec := newFormContext(http.MethodPatch, "/v1/user/reactivate", "email=invalid&password=")
 
-		err := h.ReactivateAccount(c)
+		err := serve(c, h.ReactivateAccount)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, errorpkg.ErrValidation)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 401 on invalid credentials", func(t *testing.T) {
@@ -499,10 +732,11 @@ func TestReactivateAccount(t *testing.T) {
 			Email: "user@test.com", Password: "wrong",
 		}).Return(nil, domain.ErrInvalidCredentials)
 
-		err := h.ReactivateAccount(c)
+		err := serve(c, h.ReactivateAccount)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 400 when user is not deactivated", func(t *testing.T) {
@@ -515,10 +749,11 @@ func TestReactivateAccount(t *testing.T) {
 			Email: "user@test.com", Password: "password123",
 		}).Return(nil, domain.ErrUserNotDeactivated)
 
-		err := h.ReactivateAccount(c)
+		err := serve(c, h.ReactivateAccount)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrUserNotDeactivated)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 500 on service error", func(t *testing.T) {
@@ -531,9 +766,183 @@ func TestReactivateAccount(t *testing.T) {
 			Email: "user@test.com", Password: "password123",
 		}).Return(nil, errors.New("unexpected"))
 
-		err := h.ReactivateAccount(c)
+		err := serve(c, h.ReactivateAccount)
 
-		assert.NoError(t, err)
+		assert.Error(t, err)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	t.Run("should rotate tokens from the request body", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/refresh", `{"refresh_token":"rt"}`)
+
+		authService.On("Refresh", mock.Anything, "rt").
+			Return(&domain.AuthResponse{AccessToken: "new-at", RefreshToken: "new-rt"}, nil)
+
+		err := serve(c, h.Refresh)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.AuthResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "new-at", resp.AccessToken)
+		assert.Equal(t, "new-rt", resp.RefreshToken)
+	})
+
+	t.Run("should rotate tokens and cookies from the refresh_token cookie", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/v1/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "rt"})
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		authService.On("Refresh", mock.Anything, "rt").
+			Return(&domain.AuthResponse{AccessToken: "new-at", RefreshToken: "new-rt", SessionID: "session-1"}, nil)
+
+		err := serve(c, h.Refresh)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		cookies := rec.Result().Cookies()
+		assert.Len(t, cookies, 3)
+		assert.Equal(t, "new-at", cookies[0].Value)
+		assert.Equal(t, "new-rt", cookies[1].Value)
+		assert.Equal(t, domain.CookieCSRFToken, cookies[2].Name)
+		assert.Equal(t, h.Cookies.CSRFToken("session-1"), cookies[2].Value)
+		assert.False(t, cookies[2].HttpOnly)
+		assert.Equal(t, cookies[2].Value, rec.Header().Get(domain.CSRFHeader))
+	})
+
+	t.Run("should return 401 without a body or cookie", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newHandler(t)
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodPost, "/v1/auth/refresh", nil), rec)
+
+		err := serve(c, h.Refresh)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("should return 400 when the body has no refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/refresh", `{}`)
+
+		err := serve(c, h.Refresh)
+
+		assert.ErrorIs(t, err, errorpkg.ErrValidation)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should return 401 when the service rejects the token", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/v1/auth/refresh", `{"refresh_token":"revoked"}`)
+
+		authService.On("Refresh", mock.Anything, "revoked").Return(nil, domain.ErrInvalidRefreshToken)
+
+		err := serve(c, h.Refresh)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, problemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+}
+
+func TestListSessions(t *testing.T) {
+	t.Run("should return the sessions and mark the current one", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newQueryContext("/v1/auth/sessions")
+		c.Set("user_id", "user-1")
+		c.Set("session_id", "session-1")
+
+		authService.On("ListSessions", mock.Anything, "user-1", "session-1").Return([]domain.SessionResponse{
+			{ID: "session-1", Current: true},
+			{ID: "session-2", ClientID: "client-1", ClientName: "migos-cli", Scope: "openid"},
+		}, nil)
+
+		err := serve(c, h.ListSessions)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.SessionsResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Len(t, resp.Sessions, 2)
+		assert.True(t, resp.Sessions[0].Current)
+		assert.Equal(t, "migos-cli", resp.Sessions[1].ClientName)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	newRevokeContext := func(sessionID string) (echo.Context, *httptest.ResponseRecorder) {
+		c, rec := newQueryContext("/v1/auth/sessions/" + sessionID)
+		c.Request().Method = http.MethodDelete
+		c.SetParamNames("id")
+		c.SetParamValues(sessionID)
+		c.Set("user_id", "user-1")
+		return c, rec
+	}
+
+	t.Run("should return 204 when the session is revoked", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newRevokeContext("session-2")
+		c.Set("session_id", "session-1")
+
+		authService.On("RevokeSession", mock.Anything, "user-1", "session-2").Return(nil)
+
+		err := serve(c, h.RevokeSession)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should return 404 for sessions the user doesn't have", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newRevokeContext("session-9")
+		c.Set("session_id", "session-1")
+
+		authService.On("RevokeSession", mock.Anything, "user-1", "session-9").Return(domain.ErrSessionNotFound)
+
+		err := serve(c, h.RevokeSession)
+
+		assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "session/not-found")
+	})
+
+	t.Run("should return 403 when authenticated with an API key", func(t *testing.T) {
+		t.Parallel()
+
+		h, authService := newHandler(t)
+		c, rec := newRevokeContext("session-2")
+
+		err := serve(c, h.RevokeSession)
+
+		assert.ErrorIs(t, err, domain.ErrSessionRequired)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		authService.AssertNotCalled(t, "RevokeSession")
 	})
 }
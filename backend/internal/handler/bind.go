@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+	validatorpkg "github.com/SergioLNeves/migos/internal/pkg/validator"
+)
+
+// bindAndValidate binds the request body into req according to its
+// Content-Type and validates it, returning errors that HTTPErrorHandler
+// renders as 400 or 415 problems.
+func bindAndValidate(c echo.Context, req any) error {
+	if err := bindBody(c, req); err != nil {
+		return err
+	}
+
+	return validate(req)
+}
+
+// validate runs the struct validation rules on an already bound request.
+func validate(req any) error {
+	if err := validatorpkg.NewValidator().Validate(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return fmt.Errorf("%w: %v", errorpkg.ErrInvalidRequest, err)
+		}
+		return &errorpkg.ValidationError{Errors: validationErrors}
+	}
+
+	return nil
+}
+
+// bindBody accepts application/json, decoded strictly, and
+// application/x-www-form-urlencoded, which the HTML pages still submit.
+func bindBody(c echo.Context, req any) error {
+	mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errorpkg.ErrUnsupportedMediaType, err)
+	}
+
+	switch mediaType {
+	case echo.MIMEApplicationJSON:
+		return decodeJSON(c.Request().Body, req)
+	case echo.MIMEApplicationForm:
+		if err := (&echo.DefaultBinder{}).BindBody(c, req); err != nil {
+			return fmt.Errorf("%w: %v", errorpkg.ErrInvalidRequest, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errorpkg.ErrUnsupportedMediaType, mediaType)
+	}
+}
+
+// decodeJSON decodes a single JSON object into req, rejecting unknown fields
+// and trailing data.
+func decodeJSON(body io.Reader, req any) error {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(req); err != nil {
+		return fmt.Errorf("%w: %v", errorpkg.ErrInvalidRequest, err)
+	}
+	if decoder.More() {
+		return fmt.Errorf("%w: unexpected data after JSON object", errorpkg.ErrInvalidRequest)
+	}
+
+	return nil
+}
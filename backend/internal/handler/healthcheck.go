@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/SergioLNeves/migos/internal/domain"
 	"github.com/labstack/echo/v4"
 	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
 )
 
 type HealthCheckHandlerImpl struct {
@@ -24,11 +27,24 @@ func NewHealthCheckHandler(i *do.Injector) (domain.HealthCheckHandler, error) {
 	}, nil
 }
 
-func (h HealthCheckHandlerImpl) Check(ctx echo.Context) error {
-	check, err := h.healthCheckService.Check()
-	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, err)
+func (h HealthCheckHandlerImpl) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.healthCheckService.Live(c.Request().Context()))
+}
+
+func (h HealthCheckHandlerImpl) Ready(c echo.Context) error {
+	check := h.healthCheckService.Ready(c.Request().Context())
+	if check.Status != domain.HealthStatusUp {
+		logger := logging.WithContext(c.Request().Context(), zap.String("handler", "HealthCheckHandler.Ready"))
+		for _, component := range check.Components {
+			if component.Status != domain.HealthStatusUp {
+				logger.Warn("component not ready",
+					zap.String("component", component.Name),
+					zap.String("error", component.Error),
+				)
+			}
+		}
+		return c.JSON(http.StatusServiceUnavailable, check)
 	}
 
-	return ctx.JSON(http.StatusOK, check)
+	return c.JSON(http.StatusOK, check)
 }
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+func newHealthCheckHandler(t *testing.T) (*HealthCheckHandlerImpl, *mockpkg.MockHealthCheckerService) {
+	t.Helper()
+	healthCheckService := mockpkg.NewMockHealthCheckerService(t)
+	return &HealthCheckHandlerImpl{healthCheckService: healthCheckService}, healthCheckService
+}
+
+func newHealthContext(path string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	return e.NewContext(httptest.NewRequest(http.MethodGet, path, nil), rec), rec
+}
+
+func TestHealthCheckLive(t *testing.T) {
+	t.Run("should return 200", func(t *testing.T) {
+		t.Parallel()
+
+		h, healthCheckService := newHealthCheckHandler(t)
+		c, rec := newHealthContext("/health/live")
+
+		healthCheckService.On("Live", mock.Anything).Return(domain.HealthCheck{Status: domain.HealthStatusUp})
+
+		err := h.Live(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"status":"up"}`, rec.Body.String())
+	})
+}
+
+func TestHealthCheckReady(t *testing.T) {
+	t.Run("should return 200 with components when ready", func(t *testing.T) {
+		t.Parallel()
+
+		h, healthCheckService := newHealthCheckHandler(t)
+		c, rec := newHealthContext("/health/ready")
+
+		healthCheckService.On("Ready", mock.Anything).Return(domain.HealthCheck{
+			Status:     domain.HealthStatusUp,
+			Components: []domain.ComponentHealth{{Name: "database", Status: domain.HealthStatusUp, LatencyMs: 0.4}},
+		})
+
+		err := h.Ready(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.HealthCheck
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, domain.HealthStatusUp, resp.Status)
+		assert.Len(t, resp.Components, 1)
+	})
+
+	t.Run("should return 503 with the failing component when down", func(t *testing.T) {
+		t.Parallel()
+
+		h, healthCheckService := newHealthCheckHandler(t)
+		c, rec := newHealthContext("/health/ready")
+
+		healthCheckService.On("Ready", mock.Anything).Return(domain.HealthCheck{
+			Status: domain.HealthStatusDown,
+			Components: []domain.ComponentHealth{
+				{Name: "database", Status: domain.HealthStatusDown, Error: "database is locked"},
+			},
+		})
+
+		err := h.Ready(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+		var resp domain.HealthCheck
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "database is locked", resp.Components[0].Error)
+	})
+
+	t.Run("should return 503 while draining", func(t *testing.T) {
+		t.Parallel()
+
+		h, healthCheckService := newHealthCheckHandler(t)
+		c, rec := newHealthContext("/health/ready")
+
+		healthCheckService.On("Ready", mock.Anything).Return(domain.HealthCheck{Status: domain.HealthStatusDraining})
+
+		err := h.Ready(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
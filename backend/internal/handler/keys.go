@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+type KeysHandlerImpl struct {
+	tokenProvider domain.TokenProvider
+}
+
+func NewKeysHandler(i *do.Injector) (domain.KeysHandler, error) {
+	tokenProvider := do.MustInvoke[domain.TokenProvider](i)
+
+	return &KeysHandlerImpl{
+		tokenProvider: tokenProvider,
+	}, nil
+}
+
+// JWKS publishes the signing key so other services can verify access tokens
+// without calling this API. Verifiers refetch on an unknown "kid", so a
+// short cache is enough to pick up a rotated key.
+func (h KeysHandlerImpl) JWKS(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, h.tokenProvider.JWKS())
+}
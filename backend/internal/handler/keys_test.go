@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+func TestJWKS(t *testing.T) {
+	t.Run("should return the signing keys with a short cache", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		h := &KeysHandlerImpl{tokenProvider: tokenProvider}
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil), rec)
+
+		tokenProvider.On("JWKS").Return(domain.JWKS{Keys: []domain.JWK{{
+			Kty: "RSA", Use: "sig", Alg: "RS256", Kid: "kid-1", N: "modulus", E: "AQAB",
+		}}})
+
+		err := h.JWKS(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{"keys":[{"kty":"RSA","use":"sig","alg":"RS256","kid":"kid-1","n":"modulus","e":"AQAB"}]}`, rec.Body.String())
+	})
+}
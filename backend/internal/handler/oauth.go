@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+type OAuthHandlerImpl struct {
+	OAuthService          domain.OAuthService
+	ServiceAccountService domain.ServiceAccountService
+}
+
+func NewOAuthHandler(i *do.Injector) (domain.OAuthHandler, error) {
+	oauthService := do.MustInvoke[domain.OAuthService](i)
+	serviceAccountService := do.MustInvoke[domain.ServiceAccountService](i)
+
+	return &OAuthHandlerImpl{
+		OAuthService:          oauthService,
+		ServiceAccountService: serviceAccountService,
+	}, nil
+}
+
+func (h OAuthHandlerImpl) Introspect(c echo.Context) error {
+	var request domain.IntrospectRequest
+	client, err := h.bindClientRequest(c, &request, &request.ClientCredentials)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.OAuthService.Introspect(c.Request().Context(), client, request)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h OAuthHandlerImpl) Revoke(c echo.Context) error {
+	var request domain.RevokeRequest
+	client, err := h.bindClientRequest(c, &request, &request.ClientCredentials)
+	if err != nil {
+		return err
+	}
+
+	if err := h.OAuthService.Revoke(c.Request().Context(), client, request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// Token issues service account tokens with the client_credentials grant.
+// Like the other client requests, the account is authenticated before the
+// body is validated.
+func (h OAuthHandlerImpl) Token(c echo.Context) error {
+	var request domain.ClientCredentialsRequest
+	if err := bindBody(c, &request); err != nil {
+		return err
+	}
+
+	clientID, clientSecret, err := h.clientCredentials(c, request.ClientCredentials)
+	if err != nil {
+		return err
+	}
+
+	account, err := h.ServiceAccountService.Authenticate(c.Request().Context(), clientID, clientSecret)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidClient) {
+			return h.rejectClient(c)
+		}
+		return err
+	}
+	c.Set("client_id", account.ID.String())
+
+	if err := validate(&request); err != nil {
+		return err
+	}
+
+	response, err := h.ServiceAccountService.Token(c.Request().Context(), account, request)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, response)
+}
+
+// bindClientRequest binds the body, authenticates the client and only then
+// validates, so unauthenticated callers learn nothing about the request.
+func (h OAuthHandlerImpl) bindClientRequest(c echo.Context, req any, creds *domain.ClientCredentials) (*domain.OAuthClient, error) {
+	if err := bindBody(c, req); err != nil {
+		return nil, err
+	}
+
+	clientID, clientSecret, err := h.clientCredentials(c, *creds)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := h.OAuthService.AuthenticateClient(c.Request().Context(), clientID, clientSecret)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidClient) {
+			return nil, h.rejectClient(c)
+		}
+		return nil, err
+	}
+	c.Set("client_id", client.ID.String())
+
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// clientCredentials reads them from HTTP Basic authentication
+// (client_secret_basic), falling back to client_id and client_secret in the
+// body.
+func (h OAuthHandlerImpl) clientCredentials(c echo.Context, creds domain.ClientCredentials) (string, string, error) {
+	username, password, ok := c.Request().BasicAuth()
+	if !ok {
+		return creds.ClientID, creds.ClientSecret, nil
+	}
+
+	// RFC 6749 section 2.3.1 form-encodes both values before encoding them
+	// as Basic credentials.
+	clientID, errID := url.QueryUnescape(username)
+	clientSecret, errSecret := url.QueryUnescape(password)
+	if errID != nil || errSecret != nil {
+		return "", "", h.rejectClient(c)
+	}
+	return clientID, clientSecret, nil
+}
+
+func (h OAuthHandlerImpl) rejectClient(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="migos"`)
+	return domain.ErrInvalidClient
+}
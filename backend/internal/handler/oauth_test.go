@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+var testClient = &domain.OAuthClient{ID: uuid.MustParse("6f1c1b8e-4c1a-4a55-9d1e-2f8a7b3c9d10"), Name: "gateway"}
+
+func newOAuthHandler(t *testing.T) (*OAuthHandlerImpl, *mockpkg.MockOAuthService) {
+	t.Helper()
+	oauthService := mockpkg.NewMockOAuthService(t)
+	return &OAuthHandlerImpl{OAuthService: oauthService}, oauthService
+}
+
+func TestIntrospect(t *testing.T) {
+	t.Run("should authenticate the client with basic auth and return the introspection", func(t *testing.T) {
+		t.Parallel()
+
+		h, oauthService := newOAuthHandler(t)
+		c, rec := newFormContext(http.MethodPost, "/oauth/introspect", "token=at&token_type_hint=access_token")
+		c.Request().SetBasicAuth(testClient.ID.String(), "s%2Fecret")
+
+		oauthService.On("AuthenticateClient", mock.Anything, testClient.ID.String(), "s/ecret").Return(testClient, nil)
+		oauthService.On("Introspect", mock.Anything, testClient, domain.IntrospectRequest{Token: "at", TokenTypeHint: "access_token"}).
+			Return(&domain.IntrospectionResponse{Active: true, TokenType: "access_token", Subject: "user-1", ExpiresAt: 1700000000}, nil)
+
+		err := serve(c, h.Introspect)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.Equal(t, testClient.ID.String(), c.Get("client_id"))
+		assert.JSONEq(t, `{"active":true,"token_type":"access_token","sub":"user-1","exp":1700000000}`, rec.Body.String())
+	})
+
+	t.Run("should accept client credentials in the body", func(t *testing.T) {
+		t.Parallel()
+
+		h, oauthService := newOAuthHandler(t)
+		c, rec := newJSONContext(http.MethodPost, "/oauth/introspect", `{"token":"at","client_id":"`+testClient.ID.String()+`","client_secret":"secret"}`)
+
+		oauthService.On("AuthenticateClient", mock.Anything, testClient.ID.String(), "secret").Return(testClient, nil)
+		oauthService.On("Introspect", mock.Anything, testClient, mock.AnythingOfType("domain.IntrospectRequest")).
+			Return(&domain.IntrospectionResponse{Active: false}, nil)
+
+		err := serve(c, h.Introspect)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"active":false}`, rec.Body.String())
+	})
+
+	t.Run("should return 401 with a basic challenge for an invalid client", func(t *testing.T) {
+		t.Parallel()
+
+		h, oauthService := newOAuthHandler(t)
+		c, rec := newFormContext(http.MethodPost, "/oauth/introspect", "token=at")
+		c.Request().SetBasicAuth(testClient.ID.String(), "wrong")
+
+		oauthService.On("AuthenticateClient", mock.Anything, testClient.ID.String(), "wrong").Return(nil, domain.ErrInvalidClient)
+
+		err := serve(c, h.Introspect)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Basic realm="migos"`, rec.Header().Get("WWW-Authenticate"))
+		assert.Contains(t, rec.Body.String(), "oauth/invalid-client")
+	})
+
+	t.Run("should reject unauthenticated clients before validating the body", func(t *testing.T) {
+		t.Parallel()
+
+		h, oauthService := newOAuthHandler(t)
+		c, rec := newFormContext(http.MethodPost, "/oauth/introspect", "")
+
+		oauthService.On("AuthenticateClient", mock.Anything, "", "").Return(nil, domain.ErrInvalidClient)
+
+		err := serve(c, h.Introspect)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("should return 400 when an authenticated client omits the token", func(t *testing.T) {
+		t.Parallel()
+
+		h, oauthService := newOAuthHandler(t)
+		c, rec := newFormContext(http.MethodPost, "/oauth/introspect", "")
+		c.Request().SetBasicAuth(testClient.ID.String(), "secret")
+
+		oauthService.On("AuthenticateClient", mock.Anything, testClient.ID.String(), "secret").Return(testClient, nil)
+
+		err := serve(c, h.Introspect)
+
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		oauthService.AssertNotCalled(t, "Introspect")
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	t.Run("should revoke the token and return 200", func(t *testing.T) {
+		t.Parallel()
+
+		h, oauthService := newOAuthHandler(t)
+		c, rec := newFormContext(http.MethodPost, "/oauth/revoke", "token=rt&token_type_hint=refresh_token")
+		c.Request().SetBasicAuth(testClient.ID.String(), "secret")
+
+		oauthService.On("AuthenticateClient", mock.Anything, testClient.ID.String(), "secret").Return(testClient, nil)
+		oauthService.On("Revoke", mock.Anything, testClient, domain.RevokeRequest{Token: "rt", TokenTypeHint: "refresh_token"}).Return(nil)
+
+		err := serve(c, h.Revoke)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("should return 401 for an invalid client", func(t *testing.T) {
+		t.Parallel()
+
+		h, oauthService := newOAuthHandler(t)
+		c, rec := newFormContext(http.MethodPost, "/oauth/revoke", "token=rt")
+
+		oauthService.On("AuthenticateClient", mock.Anything, "", "").Return(nil, domain.ErrInvalidClient)
+
+		err := serve(c, h.Revoke)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		oauthService.AssertNotCalled(t, "Revoke")
+	})
+}
+
+func TestOAuthToken(t *testing.T) {
+	newTokenHandler := func(t *testing.T) (*OAuthHandlerImpl, *mockpkg.MockServiceAccountService) {
+		serviceAccountService := mockpkg.NewMockServiceAccountService(t)
+		return &OAuthHandlerImpl{ServiceAccountService: serviceAccountService}, serviceAccountService
+	}
+	account := &domain.ServiceAccount{ID: uuid.New(), Scopes: "orders:read"}
+
+	t.Run("should issue a token to an authenticated service account", func(t *testing.T) {
+		t.Parallel()
+
+		h, serviceAccountService := newTokenHandler(t)
+		c, rec := newFormContext(http.MethodPost, "/oauth/token", "grant_type=client_credentials&scope=orders:read")
+		c.Request().SetBasicAuth(account.ID.String(), "secret")
+
+		request := domain.ClientCredentialsRequest{GrantType: domain.GrantTypeClientCredentials, Scope: "orders:read"}
+		serviceAccountService.On("Authenticate", mock.Anything, account.ID.String(), "secret").Return(account, nil)
+		serviceAccountService.On("Token", mock.Anything, account, request).Return(&domain.ClientCredentialsResponse{
+			AccessToken: "at", TokenType: "Bearer", ExpiresIn: 900, Scope: "orders:read",
+		}, nil)
+
+		err := serve(c, h.Token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{"access_token":"at","token_type":"Bearer","expires_in":900,"scope":"orders:read"}`, rec.Body.String())
+	})
+
+	t.Run("should return 401 with a basic challenge for a wrong secret", func(t *testing.T) {
+		t.Parallel()
+
+		h, serviceAccountService := newTokenHandler(t)
+		c, rec := newFormContext(http.MethodPost, "/oauth/token", "grant_type=client_credentials")
+		c.Request().SetBasicAuth(account.ID.String(), "wrong")
+
+		serviceAccountService.On("Authenticate", mock.Anything, account.ID.String(), "wrong").Return(nil, domain.ErrInvalidClient)
+
+		err := serve(c, h.Token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Basic")
+		serviceAccountService.AssertNotCalled(t, "Token")
+	})
+}
@@ -0,0 +1,389 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/middleware"
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+)
+
+type OIDCHandlerImpl struct {
+	OIDCService domain.OIDCService
+	// SessionAuth authenticates GET /authorize, which redirects to the
+	// login page instead of answering 401.
+	SessionAuth echo.MiddlewareFunc
+	Cookies     domain.CookieManager
+}
+
+func NewOIDCHandler(i *do.Injector) (domain.OIDCHandler, error) {
+	oidcService := do.MustInvoke[domain.OIDCService](i)
+	tokenProvider := do.MustInvoke[domain.TokenProvider](i)
+	sessionRepo := do.MustInvoke[domain.SessionRepository](i)
+	authRepo := do.MustInvoke[domain.AuthRepository](i)
+	cookies := do.MustInvoke[domain.CookieManager](i)
+
+	return &OIDCHandlerImpl{
+		OIDCService: oidcService,
+		SessionAuth: middleware.SessionAuth(tokenProvider, sessionRepo, authRepo, cookies),
+		Cookies:     cookies,
+	}, nil
+}
+
+func (h OIDCHandlerImpl) Discovery(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.OIDCService.Discovery())
+}
+
+// Authorize starts the authorization code flow. Users without a session are
+// sent to the login page, which returns them here once signed in.
+func (h OIDCHandlerImpl) Authorize(c echo.Context) error {
+	var request domain.AuthorizeRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &request); err != nil {
+		return fmt.Errorf("%w: %v", errorpkg.ErrInvalidRequest, err)
+	}
+
+	err := h.SessionAuth(func(c echo.Context) error {
+		authorization, err := h.OIDCService.Authorize(c.Request().Context(), c.Get("user_id").(string), request)
+		return h.respond(c, request, authorization, err)
+	})(c)
+	if errors.Is(err, domain.ErrUnauthorized) {
+		return c.Redirect(http.StatusFound, loginURL(c.Request().RequestURI))
+	}
+	return err
+}
+
+// Consent receives the answer to the consent page, which sends the CSRF
+// token of the session in its form.
+func (h OIDCHandlerImpl) Consent(c echo.Context) error {
+	if _, err := requireSession(c); err != nil {
+		return err
+	}
+
+	var request domain.ConsentRequest
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
+	}
+
+	authorization, err := h.OIDCService.Consent(c.Request().Context(), c.Get("user_id").(string), request)
+	return h.respond(c, request.AuthorizeRequest, authorization, err)
+}
+
+// Token redeems authorization codes and refresh tokens. Confidential
+// clients authenticate with HTTP Basic or with client_id and client_secret
+// in the body; public clients send client_id only.
+func (h OIDCHandlerImpl) Token(c echo.Context) error {
+	var request domain.TokenRequest
+	if err := bindBody(c, &request); err != nil {
+		return err
+	}
+
+	if err := h.basicAuth(c, &request.ClientCredentials); err != nil {
+		return err
+	}
+
+	if err := validate(&request); err != nil {
+		return err
+	}
+
+	response, err := h.OIDCService.Token(c.Request().Context(), request)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidClient) {
+			return h.rejectClient(c)
+		}
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, response)
+}
+
+// DeviceCode starts the device flow for clients that can't open a browser.
+// The device shows the user code and polls /token with the device code.
+func (h OIDCHandlerImpl) DeviceCode(c echo.Context) error {
+	var request domain.DeviceCodeRequest
+	if err := bindBody(c, &request); err != nil {
+		return err
+	}
+
+	if err := h.basicAuth(c, &request.ClientCredentials); err != nil {
+		return err
+	}
+
+	response, err := h.OIDCService.DeviceAuthorization(c.Request().Context(), request)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidClient) {
+			return h.rejectClient(c)
+		}
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, response)
+}
+
+// Device is the verification page where a signed in user enters the user
+// code shown by the device. Like Authorize, users without a session are
+// sent to the login page first.
+func (h OIDCHandlerImpl) Device(c echo.Context) error {
+	var request domain.DeviceRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &request); err != nil {
+		return fmt.Errorf("%w: %v", errorpkg.ErrInvalidRequest, err)
+	}
+
+	err := h.SessionAuth(func(c echo.Context) error {
+		if request.UserCode == "" {
+			return renderPage(c, http.StatusOK, devicePage, deviceView{})
+		}
+
+		prompt, err := h.OIDCService.DevicePrompt(c.Request().Context(), request.UserCode)
+		if errors.Is(err, domain.ErrInvalidUserCode) {
+			return renderPage(c, http.StatusBadRequest, devicePage, deviceView{UserCode: request.UserCode, Invalid: true})
+		}
+		if err != nil {
+			return err
+		}
+		return renderPage(c, http.StatusOK, devicePage, deviceView{UserCode: request.UserCode, Prompt: prompt, CSRFToken: h.csrfToken(c)})
+	})(c)
+	if errors.Is(err, domain.ErrUnauthorized) {
+		return c.Redirect(http.StatusFound, loginURL(c.Request().RequestURI))
+	}
+	return err
+}
+
+// ApproveDevice receives the answer to the device verification page, which
+// sends the CSRF token the same way as the consent page.
+func (h OIDCHandlerImpl) ApproveDevice(c echo.Context) error {
+	if _, err := requireSession(c); err != nil {
+		return err
+	}
+
+	var request domain.DeviceApprovalRequest
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
+	}
+
+	err := h.OIDCService.ApproveDevice(c.Request().Context(), c.Get("user_id").(string), request)
+	if errors.Is(err, domain.ErrInvalidUserCode) {
+		return renderPage(c, http.StatusBadRequest, devicePage, deviceView{UserCode: request.UserCode, Invalid: true})
+	}
+	if err != nil {
+		return err
+	}
+
+	return renderPage(c, http.StatusOK, deviceDonePage, request.Consent == domain.ConsentApprove)
+}
+
+func (h OIDCHandlerImpl) UserInfo(c echo.Context) error {
+	sessionID, err := requireSession(c)
+	if err != nil {
+		return err
+	}
+	userID := c.Get("user_id").(string)
+
+	response, err := h.OIDCService.UserInfo(c.Request().Context(), userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// respond turns the outcome of an authorization request into the consent
+// page or a redirect back to the client. Errors are only redirected once the
+// client and redirect URI are known to be valid; otherwise they are shown to
+// the user, so the server can't be used as an open redirector.
+func (h OIDCHandlerImpl) respond(c echo.Context, req domain.AuthorizeRequest, authorization *domain.Authorization, err error) error {
+	if errors.Is(err, domain.ErrInvalidClient) || errors.Is(err, domain.ErrInvalidRedirectURI) {
+		return err
+	}
+
+	params := url.Values{}
+	switch {
+	case err != nil:
+		entry, _ := errorpkg.Errors.Lookup(err)
+		code := entry.OAuthError
+		if code == "" {
+			if entry.Status >= http.StatusInternalServerError {
+				code = "server_error"
+			} else {
+				code = "invalid_request"
+			}
+		}
+		params.Set("error", code)
+		params.Set("error_description", entry.Detail)
+	case authorization.Consent != nil:
+		return renderPage(c, http.StatusOK, consentPage, consentView{Request: req, Prompt: authorization.Consent, CSRFToken: h.csrfToken(c)})
+	default:
+		params.Set("code", authorization.Code)
+	}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+
+	return c.Redirect(http.StatusFound, withQuery(req.RedirectURI, params))
+}
+
+func (h OIDCHandlerImpl) rejectClient(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="migos"`)
+	return domain.ErrInvalidClient
+}
+
+// basicAuth copies HTTP Basic client credentials, when present, over the
+// ones in the body. Both parts are form-encoded (RFC 6749 section 2.3.1).
+func (h OIDCHandlerImpl) basicAuth(c echo.Context, creds *domain.ClientCredentials) error {
+	username, password, ok := c.Request().BasicAuth()
+	if !ok {
+		return nil
+	}
+
+	var errID, errSecret error
+	creds.ClientID, errID = url.QueryUnescape(username)
+	creds.ClientSecret, errSecret = url.QueryUnescape(password)
+	if errID != nil || errSecret != nil {
+		return h.rejectClient(c)
+	}
+	return nil
+}
+
+// renderPage writes one of the server rendered pages, which must never be
+// cached or framed.
+func renderPage(c echo.Context, status int, page *template.Template, data any) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("X-Frame-Options", "DENY")
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	c.Response().WriteHeader(status)
+	return page.Execute(c.Response(), data)
+}
+
+// loginURL points to the login page with returnTo as the return_to
+// parameter.
+func loginURL(returnTo string) string {
+	return withQuery(config.Env.OIDC.LoginURL, url.Values{"return_to": {returnTo}})
+}
+
+// withQuery appends params to the query of rawURL, keeping the parameters
+// it already has.
+func withQuery(rawURL string, params url.Values) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// csrfToken returns the CSRF token of the session of the request, for the
+// forms of the pages.
+func (h OIDCHandlerImpl) csrfToken(c echo.Context) string {
+	sessionID, _ := c.Get("session_id").(string)
+	return h.Cookies.CSRFToken(sessionID)
+}
+
+type consentView struct {
+	Request   domain.AuthorizeRequest
+	Prompt    *domain.ConsentPrompt
+	CSRFToken string
+}
+
+var consentPage = template.Must(template.New("consent").Parse(`<!doctype html>
+<html>
+  <head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>Authorize {{.Prompt.ClientName}}</title>
+  </head>
+  <body>
+    <h1>{{.Prompt.ClientName}} wants to access your account</h1>
+    <p>It is asking for:</p>
+    <ul>
+      {{range .Prompt.Scopes}}<li>{{.}}</li>
+      {{end}}
+    </ul>
+    <form method="post" action="/authorize">
+      <input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+      <input type="hidden" name="response_type" value="{{.Request.ResponseType}}">
+      <input type="hidden" name="client_id" value="{{.Request.ClientID}}">
+      <input type="hidden" name="redirect_uri" value="{{.Request.RedirectURI}}">
+      <input type="hidden" name="scope" value="{{.Request.Scope}}">
+      <input type="hidden" name="state" value="{{.Request.State}}">
+      <input type="hidden" name="nonce" value="{{.Request.Nonce}}">
+      <input type="hidden" name="code_challenge" value="{{.Request.CodeChallenge}}">
+      <input type="hidden" name="code_challenge_method" value="{{.Request.CodeChallengeMethod}}">
+      <button type="submit" name="consent" value="approve">Allow</button>
+      <button type="submit" name="consent" value="deny">Deny</button>
+    </form>
+  </body>
+</html>
+`))
+
+type deviceView struct {
+	UserCode  string
+	Invalid   bool
+	Prompt    *domain.ConsentPrompt
+	CSRFToken string
+}
+
+var devicePage = template.Must(template.New("device").Parse(`<!doctype html>
+<html>
+  <head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>Connect a device</title>
+  </head>
+  <body>
+    {{if .Prompt}}
+    <h1>{{.Prompt.ClientName}} wants to access your account</h1>
+    <p>Only continue if the code <strong>{{.UserCode}}</strong> is shown on your device. It is asking for:</p>
+    <ul>
+      {{range .Prompt.Scopes}}<li>{{.}}</li>
+      {{end}}
+    </ul>
+    <form method="post" action="/device">
+      <input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+      <input type="hidden" name="user_code" value="{{.UserCode}}">
+      <button type="submit" name="consent" value="approve">Allow</button>
+      <button type="submit" name="consent" value="deny">Deny</button>
+    </form>
+    {{else}}
+    <h1>Connect a device</h1>
+    {{if .Invalid}}<p>The code is invalid or has expired. Check the code shown on your device.</p>{{end}}
+    <form method="get" action="/device">
+      <label for="user_code">Enter the code shown on your device</label>
+      <input id="user_code" name="user_code" value="{{.UserCode}}" autocomplete="off" autofocus>
+      <button type="submit">Continue</button>
+    </form>
+    {{end}}
+  </body>
+</html>
+`))
+
+var deviceDonePage = template.Must(template.New("device-done").Parse(`<!doctype html>
+<html>
+  <head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>Connect a device</title>
+  </head>
+  <body>
+    {{if .}}
+    <h1>Device connected</h1>
+    <p>You can return to your device. It appears among your sessions, where you can sign it out at any time.</p>
+    {{else}}
+    <h1>Request denied</h1>
+    <p>The device was not given access to your account.</p>
+    {{end}}
+  </body>
+</html>
+`))
@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+const authorizeQuery = "/authorize?response_type=code&client_id=6f1c1b8e-4c1a-4a55-9d1e-2f8a7b3c9d10" +
+	"&redirect_uri=https%3A%2F%2Fapp.example.com%2Fcallback&scope=openid+email&state=xyz" +
+	"&code_challenge=tOjSOuMkpKiuAygo1dzbdjpgvEZWqbRVdCoIgNaZQJ4&code_challenge_method=S256"
+
+// signedIn stands in for SessionAuth with a signed in user.
+func signedIn(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Set("user_id", "user-1")
+		c.Set("session_id", "session-1")
+		return next(c)
+	}
+}
+
+// signedOut stands in for SessionAuth without a session.
+func signedOut(echo.HandlerFunc) echo.HandlerFunc {
+	return func(echo.Context) error { return domain.ErrUnauthorized }
+}
+
+func newOIDCHandler(t *testing.T, sessionAuth echo.MiddlewareFunc) (*OIDCHandlerImpl, *mockpkg.MockOIDCService) {
+	t.Helper()
+	oidcService := mockpkg.NewMockOIDCService(t)
+	return &OIDCHandlerImpl{OIDCService: oidcService, SessionAuth: sessionAuth, Cookies: newCookies(t)}, oidcService
+}
+
+func newQueryContext(target string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Run("should send users without a session to the login page", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newOIDCHandler(t, signedOut)
+		c, rec := newQueryContext(authorizeQuery)
+
+		err := serve(c, h.Authorize)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "/login?return_to="+url.QueryEscape(authorizeQuery), rec.Header().Get("Location"))
+	})
+
+	t.Run("should redirect the code and state back to the client", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, signedIn)
+		c, rec := newQueryContext(authorizeQuery)
+
+		oidcService.On("Authorize", mock.Anything, "user-1", mock.MatchedBy(func(req domain.AuthorizeRequest) bool {
+			return req.ClientID == testClient.ID.String() && req.Scope == "openid email" && req.State == "xyz"
+		})).Return(&domain.Authorization{Code: "c0de"}, nil)
+
+		err := serve(c, h.Authorize)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "https://app.example.com/callback?code=c0de&state=xyz", rec.Header().Get("Location"))
+	})
+
+	t.Run("should render the consent page with the request in hidden fields", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, signedIn)
+		c, rec := newQueryContext(authorizeQuery)
+
+		oidcService.On("Authorize", mock.Anything, "user-1", mock.AnythingOfType("domain.AuthorizeRequest")).
+			Return(&domain.Authorization{Consent: &domain.ConsentPrompt{ClientName: "<webapp>", Scopes: []string{"openid", "email"}}}, nil)
+
+		err := serve(c, h.Authorize)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, echo.MIMETextHTMLCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Body.String(), "&lt;webapp&gt; wants to access your account")
+		assert.Contains(t, rec.Body.String(), `name="redirect_uri" value="https://app.example.com/callback"`)
+		assert.Contains(t, rec.Body.String(), `name="consent" value="approve"`)
+	})
+
+	t.Run("should redirect errors once the redirect URI is trusted", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, signedIn)
+		c, rec := newQueryContext(authorizeQuery)
+
+		oidcService.On("Authorize", mock.Anything, "user-1", mock.AnythingOfType("domain.AuthorizeRequest")).
+			Return(nil, domain.ErrInvalidScope)
+
+		err := serve(c, h.Authorize)
+
+		assert.NoError(t, err)
+		location, err := url.Parse(rec.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, "app.example.com", location.Host)
+		assert.Equal(t, "invalid_scope", location.Query().Get("error"))
+		assert.Equal(t, "xyz", location.Query().Get("state"))
+	})
+
+	t.Run("should not redirect to an unregistered redirect URI", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, signedIn)
+		c, rec := newQueryContext(authorizeQuery)
+
+		oidcService.On("Authorize", mock.Anything, "user-1", mock.AnythingOfType("domain.AuthorizeRequest")).
+			Return(nil, domain.ErrInvalidRedirectURI)
+
+		err := serve(c, h.Authorize)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidRedirectURI)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Empty(t, rec.Header().Get("Location"))
+		assert.Contains(t, rec.Body.String(), "oauth/invalid-redirect-uri")
+	})
+}
+
+func TestConsent(t *testing.T) {
+	t.Run("should redirect access_denied when the user denies", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, nil)
+		c, rec := newFormContext(http.MethodPost, "/authorize",
+			"response_type=code&client_id=client&redirect_uri=https%3A%2F%2Fapp.example.com%2Fcallback&state=xyz&consent=deny")
+		c.Set("user_id", "user-1")
+		c.Set("session_id", "session-1")
+
+		oidcService.On("Consent", mock.Anything, "user-1", mock.MatchedBy(func(req domain.ConsentRequest) bool {
+			return req.Consent == domain.ConsentDeny && req.ClientID == "client"
+		})).Return(nil, domain.ErrAccessDenied)
+
+		err := serve(c, h.Consent)
+
+		assert.NoError(t, err)
+		location, err := url.Parse(rec.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, "access_denied", location.Query().Get("error"))
+		assert.Equal(t, "xyz", location.Query().Get("state"))
+	})
+}
+
+func TestToken(t *testing.T) {
+	t.Run("should take the client credentials from basic auth", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, nil)
+		c, rec := newFormContext(http.MethodPost, "/token", "grant_type=authorization_code&code=c0de&code_verifier=v")
+		c.Request().SetBasicAuth(testClient.ID.String(), "s%2Fecret")
+
+		oidcService.On("Token", mock.Anything, domain.TokenRequest{
+			GrantType:         domain.GrantTypeAuthorizationCode,
+			Code:              "c0de",
+			CodeVerifier:      "v",
+			ClientCredentials: domain.ClientCredentials{ClientID: testClient.ID.String(), ClientSecret: "s/ecret"},
+		}).Return(&domain.TokenResponse{AccessToken: "at", TokenType: "Bearer", ExpiresIn: 900, RefreshToken: "rt", IDToken: "id"}, nil)
+
+		err := serve(c, h.Token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{"access_token":"at","token_type":"Bearer","expires_in":900,"refresh_token":"rt","id_token":"id"}`, rec.Body.String())
+	})
+
+	t.Run("should report OAuth errors with the error member", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, nil)
+		c, rec := newFormContext(http.MethodPost, "/token", "grant_type=authorization_code&code=used&client_id=client")
+
+		oidcService.On("Token", mock.Anything, mock.AnythingOfType("domain.TokenRequest")).Return(nil, domain.ErrInvalidGrant)
+
+		err := serve(c, h.Token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"error":"invalid_grant"`)
+	})
+
+	t.Run("should challenge clients that fail authentication", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, nil)
+		c, rec := newFormContext(http.MethodPost, "/token", "grant_type=refresh_token&refresh_token=rt&client_id=client")
+
+		oidcService.On("Token", mock.Anything, mock.AnythingOfType("domain.TokenRequest")).Return(nil, domain.ErrInvalidClient)
+
+		err := serve(c, h.Token)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Basic realm="migos"`, rec.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func TestUserInfo(t *testing.T) {
+	t.Run("should return the claims of the token's session", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, nil)
+		c, rec := newQueryContext("/userinfo")
+		c.Set("user_id", "user-1")
+		c.Set("session_id", "session-1")
+
+		oidcService.On("UserInfo", mock.Anything, "user-1", "session-1").
+			Return(&domain.UserInfo{Subject: "user-1", Email: "ada@example.com"}, nil)
+
+		err := serve(c, h.UserInfo)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"sub":"user-1","email":"ada@example.com"}`, rec.Body.String())
+	})
+}
+
+func TestDeviceCode(t *testing.T) {
+	t.Run("should start the device flow for the client", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, nil)
+		c, rec := newFormContext(http.MethodPost, "/oauth/device/code", "client_id=client&scope=openid")
+
+		oidcService.On("DeviceAuthorization", mock.Anything, domain.DeviceCodeRequest{
+			Scope:             "openid",
+			ClientCredentials: domain.ClientCredentials{ClientID: "client"},
+		}).Return(&domain.DeviceCodeResponse{
+			DeviceCode: "dc", UserCode: "WDJB-MJHT", VerificationURI: "http://example.com/device",
+			VerificationURIComplete: "http://example.com/device?user_code=WDJB-MJHT", ExpiresIn: 600, Interval: 5,
+		}, nil)
+
+		err := serve(c, h.DeviceCode)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{"device_code":"dc","user_code":"WDJB-MJHT","verification_uri":"http://example.com/device",
+			"verification_uri_complete":"http://example.com/device?user_code=WDJB-MJHT","expires_in":600,"interval":5}`, rec.Body.String())
+	})
+
+	t.Run("should challenge clients that fail authentication", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, nil)
+		c, rec := newFormContext(http.MethodPost, "/oauth/device/code", "scope=openid")
+		c.Request().SetBasicAuth(testClient.ID.String(), "wrong")
+
+		oidcService.On("DeviceAuthorization", mock.Anything, mock.MatchedBy(func(req domain.DeviceCodeRequest) bool {
+			return req.ClientID == testClient.ID.String() && req.ClientSecret == "wrong"
+		})).Return(nil, domain.ErrInvalidClient)
+
+		err := serve(c, h.DeviceCode)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Basic realm="migos"`, rec.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func TestDevice(t *testing.T) {
+	t.Run("should send users without a session to the login page", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newOIDCHandler(t, signedOut)
+		c, rec := newQueryContext("/device?user_code=WDJB-MJHT")
+
+		err := serve(c, h.Device)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "/login?return_to="+url.QueryEscape("/device?user_code=WDJB-MJHT"), rec.Header().Get("Location"))
+	})
+
+	t.Run("should ask for the user code when none is given", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newOIDCHandler(t, signedIn)
+		c, rec := newQueryContext("/device")
+
+		err := serve(c, h.Device)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+		assert.Contains(t, rec.Body.String(), `name="user_code"`)
+	})
+
+	t.Run("should show the client and scopes of the user code", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, signedIn)
+		c, rec := newQueryContext("/device?user_code=WDJB-MJHT")
+
+		oidcService.On("DevicePrompt", mock.Anything, "WDJB-MJHT").
+			Return(&domain.ConsentPrompt{ClientName: "migos-cli", Scopes: []string{"openid", "email"}}, nil)
+
+		err := serve(c, h.Device)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "migos-cli wants to access your account")
+		assert.Contains(t, rec.Body.String(), `<input type="hidden" name="user_code" value="WDJB-MJHT">`)
+		assert.Contains(t, rec.Body.String(), `value="approve"`)
+	})
+
+	t.Run("should ask again for an invalid user code", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, signedIn)
+		c, rec := newQueryContext("/device?user_code=XXXX")
+
+		oidcService.On("DevicePrompt", mock.Anything, "XXXX").Return(nil, domain.ErrInvalidUserCode)
+
+		err := serve(c, h.Device)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "The code is invalid or has expired")
+	})
+}
+
+func TestApproveDevice(t *testing.T) {
+	t.Run("should record the approval of the signed in user", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, nil)
+		c, rec := newFormContext(http.MethodPost, "/device", "user_code=WDJB-MJHT&consent=approve")
+		c.Set("user_id", "user-1")
+		c.Set("session_id", "session-1")
+
+		oidcService.On("ApproveDevice", mock.Anything, "user-1", domain.DeviceApprovalRequest{
+			UserCode: "WDJB-MJHT", Consent: domain.ConsentApprove,
+		}).Return(nil)
+
+		err := serve(c, h.ApproveDevice)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Device connected")
+	})
+
+	t.Run("should reject API keys", func(t *testing.T) {
+		t.Parallel()
+
+		h, oidcService := newOIDCHandler(t, nil)
+		c, rec := newFormContext(http.MethodPost, "/device", "user_code=WDJB-MJHT&consent=approve")
+		c.Set("user_id", "user-1")
+
+		err := serve(c, h.ApproveDevice)
+
+		assert.ErrorIs(t, err, domain.ErrSessionRequired)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		oidcService.AssertNotCalled(t, "ApproveDevice")
+	})
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+type ServiceAccountHandlerImpl struct {
+	ServiceAccountService domain.ServiceAccountService
+}
+
+func NewServiceAccountHandler(i *do.Injector) (domain.ServiceAccountHandler, error) {
+	serviceAccountService := do.MustInvoke[domain.ServiceAccountService](i)
+
+	return &ServiceAccountHandlerImpl{
+		ServiceAccountService: serviceAccountService,
+	}, nil
+}
+
+func (h ServiceAccountHandlerImpl) Create(c echo.Context) error {
+	var request domain.CreateServiceAccountRequest
+	if err := bindAndValidate(c, &request); err != nil {
+		return err
+	}
+
+	response, err := h.ServiceAccountService.Create(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (h ServiceAccountHandlerImpl) List(c echo.Context) error {
+	accounts, err := h.ServiceAccountService.List(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, domain.ServiceAccountsResponse{ServiceAccounts: accounts})
+}
+
+func (h ServiceAccountHandlerImpl) Delete(c echo.Context) error {
+	if err := h.ServiceAccountService.Delete(c.Request().Context(), c.Param("id")); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreateSecret adds a secret for rotation; the account keeps its other
+// secrets until they are deleted.
+func (h ServiceAccountHandlerImpl) CreateSecret(c echo.Context) error {
+	response, err := h.ServiceAccountService.CreateSecret(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (h ServiceAccountHandlerImpl) DeleteSecret(c echo.Context) error {
+	if err := h.ServiceAccountService.DeleteSecret(c.Request().Context(), c.Param("id"), c.Param("secret_id")); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+)
+
+type SocialHandlerImpl struct {
+	SocialService domain.SocialService
+	Cookies       domain.CookieManager
+}
+
+func NewSocialHandler(i *do.Injector) (domain.SocialHandler, error) {
+	socialService := do.MustInvoke[domain.SocialService](i)
+	cookies := do.MustInvoke[domain.CookieManager](i)
+	return &SocialHandlerImpl{SocialService: socialService, Cookies: cookies}, nil
+}
+
+func (h SocialHandlerImpl) Providers(c echo.Context) error {
+	return c.JSON(http.StatusOK, domain.SocialProvidersResponse{Providers: h.SocialService.Providers()})
+}
+
+// Login sends the browser to the provider. The state also goes in a cookie
+// scoped to the callback, so a callback can't be replayed in another browser.
+func (h SocialHandlerImpl) Login(c echo.Context) error {
+	var request domain.SocialLoginRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &request); err != nil {
+		return fmt.Errorf("%w: %v", errorpkg.ErrInvalidRequest, err)
+	}
+
+	provider := c.Param("provider")
+	login, err := h.SocialService.Begin(c.Request().Context(), provider, callbackURL(c, provider), request.ReturnTo)
+	if err != nil {
+		return err
+	}
+
+	h.Cookies.SetSocialState(c, login.State, callbackPath(provider))
+
+	return c.Redirect(http.StatusFound, login.AuthorizationURL)
+}
+
+// Callback completes the login and starts a session. The user is sent on by
+// a link on a page rather than a redirect: browsers treat a redirect at the
+// end of the provider's cross-site navigation as cross-site and would not
+// send the SameSite=Strict session cookies along. The link is the only place
+// return_to is written, as an href html/template encodes.
+func (h SocialHandlerImpl) Callback(c echo.Context) error {
+	var request domain.SocialCallbackRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &request); err != nil {
+		return fmt.Errorf("%w: %v", errorpkg.ErrInvalidRequest, err)
+	}
+
+	provider := c.Param("provider")
+	h.Cookies.ClearSocialState(c, callbackPath(provider))
+
+	state, ok := h.Cookies.Get(c, domain.CookieSocialState)
+	if !ok || request.State == "" || state != request.State {
+		return domain.ErrSocialLoginStateInvalid
+	}
+
+	result, err := h.SocialService.Complete(c.Request().Context(), provider, request)
+	if err != nil {
+		return err
+	}
+
+	h.Cookies.SetSession(c, result.Tokens)
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	c.Response().WriteHeader(http.StatusOK)
+	return continuePage.Execute(c.Response(), result.ReturnTo)
+}
+
+func callbackPath(provider string) string {
+	return "/v1/auth/social/" + provider + "/callback"
+}
+
+// callbackURL is the redirect URI registered with the provider, on
+// SOCIAL_REDIRECT_BASE_URL or else on the origin of the request.
+func callbackURL(c echo.Context, provider string) string {
+	base := config.Env.Social.RedirectBaseURL
+	if base == "" {
+		base = c.Scheme() + "://" + c.Request().Host
+	}
+	return strings.TrimSuffix(base, "/") + callbackPath(provider)
+}
+
+var continuePage = template.Must(template.New("continue").Parse(`<!doctype html>
+<html>
+  <head>
+    <meta charset="utf-8">
+    <title>Signed in</title>
+  </head>
+  <body>
+    <p>Signed in. <a href="{{.}}">Continue</a></p>
+  </body>
+</html>
+`))
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+func newSocialHandler(t *testing.T) (*SocialHandlerImpl, *mockpkg.MockSocialService) {
+	t.Helper()
+	socialService := mockpkg.NewMockSocialService(t)
+	return &SocialHandlerImpl{SocialService: socialService, Cookies: newCookies(t)}, socialService
+}
+
+func newProviderContext(target string) (echo.Context, *httptest.ResponseRecorder) {
+	c, rec := newQueryContext(target)
+	c.SetParamNames("provider")
+	c.SetParamValues("google")
+	return c, rec
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestSocialLogin(t *testing.T) {
+	t.Run("should redirect to the provider with the state in a callback cookie", func(t *testing.T) {
+		t.Parallel()
+
+		h, socialService := newSocialHandler(t)
+		c, rec := newProviderContext("/v1/auth/social/google?return_to=%2Fsettings")
+
+		socialService.On("Begin", mock.Anything, "google", "http://example.com/v1/auth/social/google/callback", "/settings").
+			Return(&domain.SocialLogin{AuthorizationURL: "https://accounts.example.com/authorize?state=st4te", State: "st4te"}, nil)
+
+		err := serve(c, h.Login)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "https://accounts.example.com/authorize?state=st4te", rec.Header().Get("Location"))
+		cookie := findCookie(rec.Result().Cookies(), domain.CookieSocialState)
+		require.NotNil(t, cookie)
+		assert.Equal(t, "st4te", cookie.Value)
+		assert.Equal(t, "/v1/auth/social/google/callback", cookie.Path)
+		assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+		assert.True(t, cookie.HttpOnly)
+	})
+
+	t.Run("should report unknown providers", func(t *testing.T) {
+		t.Parallel()
+
+		h, socialService := newSocialHandler(t)
+		c, rec := newProviderContext("/v1/auth/social/google")
+
+		socialService.On("Begin", mock.Anything, "google", mock.Anything, "").Return(nil, domain.ErrSocialProviderNotFound)
+
+		err := serve(c, h.Login)
+
+		assert.ErrorIs(t, err, domain.ErrSocialProviderNotFound)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestSocialCallback(t *testing.T) {
+	t.Run("should set the session cookies and continue to return_to", func(t *testing.T) {
+		t.Parallel()
+
+		h, socialService := newSocialHandler(t)
+		c, rec := newProviderContext("/v1/auth/social/google/callback?code=c0de&state=st4te")
+		c.Request().AddCookie(&http.Cookie{Name: domain.CookieSocialState, Value: "st4te"})
+
+		socialService.On("Complete", mock.Anything, "google", domain.SocialCallbackRequest{Code: "c0de", State: "st4te"}).
+			Return(&domain.SocialLoginResult{
+				Tokens:   &domain.AuthResponse{AccessToken: "at", RefreshToken: "rt"},
+				ReturnTo: "/settings?tab=security",
+			}, nil)
+
+		err := serve(c, h.Callback)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.Contains(t, rec.Body.String(), `<a href="/settings?tab=security">Continue</a>`)
+		assert.NotContains(t, rec.Body.String(), "http-equiv")
+		cookies := rec.Result().Cookies()
+		assert.Equal(t, "at", findCookie(cookies, "access_token").Value)
+		assert.Equal(t, "rt", findCookie(cookies, "refresh_token").Value)
+		assert.Equal(t, -1, findCookie(cookies, domain.CookieSocialState).MaxAge)
+	})
+
+	t.Run("should reject callbacks for logins started in another browser", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newSocialHandler(t)
+		c, rec := newProviderContext("/v1/auth/social/google/callback?code=c0de&state=st4te")
+		c.Request().AddCookie(&http.Cookie{Name: domain.CookieSocialState, Value: "other"})
+
+		err := serve(c, h.Callback)
+
+		assert.ErrorIs(t, err, domain.ErrSocialLoginStateInvalid)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Nil(t, findCookie(rec.Result().Cookies(), "access_token"))
+	})
+}
@@ -0,0 +1,195 @@
+package jobs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+)
+
+// Job is a periodic maintenance task. Run returns the number of rows affected.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) (int64, error)
+}
+
+type Scheduler struct {
+	jobs    map[string]Job
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+	running atomic.Bool
+}
+
+func NewScheduler(i *do.Injector) (domain.JobScheduler, error) {
+	sessionRepo := do.MustInvoke[domain.SessionRepository](i)
+	authRepo := do.MustInvoke[domain.AuthRepository](i)
+	authorizationRepo := do.MustInvoke[domain.AuthorizationRepository](i)
+	identityRepo := do.MustInvoke[domain.IdentityRepository](i)
+	apiKeyRepo := do.MustInvoke[domain.APIKeyRepository](i)
+
+	return newScheduler(
+		SessionCleanup(sessionRepo),
+		UserCleanup(authRepo),
+		AuthorizationCodeCleanup(authorizationRepo),
+		DeviceAuthorizationCleanup(authorizationRepo),
+		SocialLoginStateCleanup(identityRepo),
+		APIKeyCleanup(apiKeyRepo),
+		PasswordChallengeCleanup(authRepo),
+	), nil
+}
+
+func newScheduler(jobs ...Job) *Scheduler {
+	s := &Scheduler{
+		jobs: make(map[string]Job, len(jobs)),
+		stop: make(chan struct{}),
+	}
+	for _, job := range jobs {
+		s.jobs[job.Name] = job
+	}
+	return s
+}
+
+// SessionCleanup deletes sessions whose refresh window has passed.
+func SessionCleanup(sessionRepo domain.SessionRepository) Job {
+	return Job{
+		Name:     "session-cleanup",
+		Interval: 12 * time.Hour,
+		Run:      sessionRepo.DeleteExpiredSessions,
+	}
+}
+
+// UserCleanup purges accounts that have been deactivated for longer than the grace period.
+func UserCleanup(authRepo domain.AuthRepository) Job {
+	return Job{
+		Name:     "user-cleanup",
+		Interval: 24 * time.Hour,
+		Run:      authRepo.DeleteDeactivatedUsers,
+	}
+}
+
+// AuthorizationCodeCleanup deletes authorization codes that expired unredeemed.
+func AuthorizationCodeCleanup(authorizationRepo domain.AuthorizationRepository) Job {
+	return Job{
+		Name:     "authorization-code-cleanup",
+		Interval: time.Hour,
+		Run:      authorizationRepo.DeleteExpiredAuthorizationCodes,
+	}
+}
+
+// DeviceAuthorizationCleanup deletes device authorizations that expired
+// before the device redeemed them.
+func DeviceAuthorizationCleanup(authorizationRepo domain.AuthorizationRepository) Job {
+	return Job{
+		Name:     "device-authorization-cleanup",
+		Interval: time.Hour,
+		Run:      authorizationRepo.DeleteExpiredDeviceAuthorizations,
+	}
+}
+
+// SocialLoginStateCleanup deletes social logins that were never completed.
+func SocialLoginStateCleanup(identityRepo domain.IdentityRepository) Job {
+	return Job{
+		Name:     "social-login-state-cleanup",
+		Interval: time.Hour,
+		Run:      identityRepo.DeleteExpiredLoginStates,
+	}
+}
+
+// APIKeyCleanup deletes API keys past their expiry.
+func APIKeyCleanup(apiKeyRepo domain.APIKeyRepository) Job {
+	return Job{
+		Name:     "api-key-cleanup",
+		Interval: 24 * time.Hour,
+		Run:      apiKeyRepo.DeleteExpiredAPIKeys,
+	}
+}
+
+// PasswordChallengeCleanup deletes password change challenges that expired
+// unredeemed.
+func PasswordChallengeCleanup(authRepo domain.AuthRepository) Job {
+	return Job{
+		Name:     "password-challenge-cleanup",
+		Interval: time.Hour,
+		Run:      authRepo.DeleteExpiredPasswordChallenges,
+	}
+}
+
+// Start launches one ticker goroutine per job. It returns immediately.
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+	s.running.Store(true)
+}
+
+func (s *Scheduler) loop(job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			_, _ = s.RunNow(context.Background(), job.Name) //nolint:errcheck // failures are logged by RunNow
+		}
+	}
+}
+
+// RunNow executes the named job synchronously and logs its outcome.
+func (s *Scheduler) RunNow(ctx context.Context, name string) (int64, error) {
+	job, ok := s.jobs[name]
+	if !ok {
+		return 0, domain.ErrJobNotFound
+	}
+
+	logger := logging.With(zap.String("job", job.Name))
+
+	deleted, err := job.Run(ctx)
+	if err != nil {
+		logger.Error("job failed", zap.Error(err))
+		return 0, err
+	}
+	metrics.CleanupRowsDeletedTotal.WithLabelValues(job.Name).Add(float64(deleted))
+	if deleted > 0 {
+		logger.Info("job completed", zap.Int64("deleted", deleted))
+	}
+
+	return deleted, nil
+}
+
+// Jobs returns the registered job names in a stable order.
+func (s *Scheduler) Jobs() []string {
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Running reports whether Start was called and Shutdown was not.
+func (s *Scheduler) Running() bool {
+	return s.running.Load()
+}
+
+// Shutdown stops every ticker and waits for in-flight runs to return.
+func (s *Scheduler) Shutdown() error {
+	s.running.Store(false)
+	s.once.Do(func() { close(s.stop) })
+	s.wg.Wait()
+	return nil
+}
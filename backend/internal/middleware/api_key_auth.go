@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+)
+
+// apiKeyTouchInterval bounds how often the last use of a key is written,
+// so a busy script doesn't turn every request into a write.
+const apiKeyTouchInterval = time.Minute
+
+// APIKeyAuth authenticates "Authorization: Bearer mig_..." requests with an
+// API key and hands every other request to sessionAuth, so protected routes
+// accept either. Requests with a key carry "api_key_id" instead of
+// "session_id" in the context; keys with only the read scope are limited to
+// safe methods.
+func APIKeyAuth(
+	apiKeyRepo domain.APIKeyRepository,
+	authRepo domain.AuthRepository,
+	sessionAuth echo.MiddlewareFunc,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withSession := sessionAuth(next)
+
+		return func(c echo.Context) error {
+			token, ok := bearerToken(c)
+			if !ok || !strings.HasPrefix(token, domain.APIKeyPrefix) {
+				return withSession(c)
+			}
+
+			ctx := c.Request().Context()
+			logger := logging.WithContext(ctx, zap.String("middleware", "APIKeyAuth"))
+
+			sum := sha256.Sum256([]byte(token))
+			key, err := apiKeyRepo.FindAPIKeyByHash(ctx, hex.EncodeToString(sum[:]))
+			if err != nil {
+				if errors.Is(err, domain.ErrAPIKeyNotFound) {
+					logger.Warn("unknown api key")
+					return domain.ErrUnauthorized
+				}
+				return fmt.Errorf("failed to find api key: %w", err)
+			}
+
+			now := time.Now()
+			if !key.ExpiresAt.After(now) {
+				logger.Info("api key expired", zap.String("api_key_id", key.ID.String()))
+				return domain.ErrUnauthorized
+			}
+
+			user, err := authRepo.FindUserByID(ctx, key.UserID)
+			if err != nil {
+				if errors.Is(err, domain.ErrUserNotFound) {
+					return domain.ErrUnauthorized
+				}
+				return fmt.Errorf("failed to find user for api key: %w", err)
+			}
+			if user.DeletedAt != nil {
+				return domain.ErrUnauthorized
+			}
+
+			scopes := strings.Fields(key.Scopes)
+			if !apiKeyAllows(scopes, c.Request().Method) {
+				return domain.ErrInsufficientScope
+			}
+
+			if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+				if err := apiKeyRepo.TouchAPIKey(ctx, key.ID, now); err != nil {
+					logger.Error("failed to record api key use", zap.Error(err))
+				}
+			}
+
+			c.Set("user_id", user.ID.String())
+			c.Set("email", user.Email)
+			c.Set("name", user.Name)
+			c.Set("avatar", user.Avatar)
+			c.Set("api_key_id", key.ID.String())
+
+			requestLogger := logging.FromContext(ctx).With(
+				zap.String("user_id", user.ID.String()),
+				zap.String("api_key_id", key.ID.String()),
+			)
+			c.SetRequest(c.Request().WithContext(logging.NewContext(ctx, requestLogger)))
+
+			return next(c)
+		}
+	}
+}
+
+// apiKeyAllows reports whether scopes permit a request with method: read
+// covers the safe methods and write covers every method.
+func apiKeyAllows(scopes []string, method string) bool {
+	if slices.Contains(scopes, domain.APIKeyScopeWrite) {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return slices.Contains(scopes, domain.APIKeyScopeRead)
+	default:
+		return false
+	}
+}
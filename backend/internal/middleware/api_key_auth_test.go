@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+const testAPIKey = "mig_TESTKEY234567ABCDEFGHIJKLMNOP"
+
+func newAPIKeyContext(method, authorization string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/v1/auth/me", nil)
+	if authorization != "" {
+		req.Header.Set(echo.HeaderAuthorization, authorization)
+	}
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+// passthrough stands in for sessionAuth in requests that carry an API key.
+func passthrough(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
+
+func testAPIKeyHash() string {
+	sum := sha256.Sum256([]byte(testAPIKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func newTestAPIKey(userID uuid.UUID, scopes string) *domain.APIKey {
+	return &domain.APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      "ci",
+		Prefix:    testAPIKey[:12],
+		KeyHash:   testAPIKeyHash(),
+		Scopes:    scopes,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	t.Run("should hand requests without an api key to sessionAuth", func(t *testing.T) {
+		t.Parallel()
+
+		apiKeyRepo := mockpkg.NewMockAPIKeyRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		sessionAuthCalled := false
+		sessionAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				sessionAuthCalled = true
+				return next(c)
+			}
+		}
+
+		c, _ := newAPIKeyContext(http.MethodGet, "Bearer eyJhbGciOiJSUzI1NiJ9.e30.sig")
+		err := APIKeyAuth(apiKeyRepo, authRepo, sessionAuth)(dummyNext)(c)
+
+		assert.NoError(t, err)
+		assert.True(t, sessionAuthCalled)
+	})
+
+	t.Run("should authenticate the user of a valid api key", func(t *testing.T) {
+		t.Parallel()
+
+		apiKeyRepo := mockpkg.NewMockAPIKeyRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		user := &domain.User{ID: uuid.New(), Email: "john@example.com", Name: "John"}
+		key := newTestAPIKey(user.ID, "read")
+
+		apiKeyRepo.On("FindAPIKeyByHash", mock.Anything, testAPIKeyHash()).Return(key, nil)
+		apiKeyRepo.On("TouchAPIKey", mock.Anything, key.ID, mock.AnythingOfType("time.Time")).Return(nil)
+		authRepo.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		c, _ := newAPIKeyContext(http.MethodGet, "Bearer "+testAPIKey)
+		err := APIKeyAuth(apiKeyRepo, authRepo, passthrough)(dummyNext)(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID.String(), c.Get("user_id"))
+		assert.Equal(t, key.ID.String(), c.Get("api_key_id"))
+		assert.Nil(t, c.Get("session_id"))
+	})
+
+	t.Run("should not record the use of a key used within the last minute", func(t *testing.T) {
+		t.Parallel()
+
+		apiKeyRepo := mockpkg.NewMockAPIKeyRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		user := &domain.User{ID: uuid.New()}
+		key := newTestAPIKey(user.ID, "read")
+		usedAt := time.Now().Add(-10 * time.Second)
+		key.LastUsedAt = &usedAt
+
+		apiKeyRepo.On("FindAPIKeyByHash", mock.Anything, testAPIKeyHash()).Return(key, nil)
+		authRepo.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		c, _ := newAPIKeyContext(http.MethodGet, "Bearer "+testAPIKey)
+		err := APIKeyAuth(apiKeyRepo, authRepo, passthrough)(dummyNext)(c)
+
+		assert.NoError(t, err)
+		apiKeyRepo.AssertNotCalled(t, "TouchAPIKey", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return 403 when a read key changes state", func(t *testing.T) {
+		t.Parallel()
+
+		apiKeyRepo := mockpkg.NewMockAPIKeyRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		user := &domain.User{ID: uuid.New()}
+		key := newTestAPIKey(user.ID, "read")
+
+		apiKeyRepo.On("FindAPIKeyByHash", mock.Anything, testAPIKeyHash()).Return(key, nil)
+		authRepo.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		c, rec := newAPIKeyContext(http.MethodPatch, "Bearer "+testAPIKey)
+		err := serve(c, APIKeyAuth(apiKeyRepo, authRepo, passthrough)(dummyNext))
+
+		assert.ErrorIs(t, err, domain.ErrInsufficientScope)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should return 401 for an unknown api key", func(t *testing.T) {
+		t.Parallel()
+
+		apiKeyRepo := mockpkg.NewMockAPIKeyRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+
+		apiKeyRepo.On("FindAPIKeyByHash", mock.Anything, testAPIKeyHash()).Return(nil, domain.ErrAPIKeyNotFound)
+
+		c, rec := newAPIKeyContext(http.MethodGet, "Bearer "+testAPIKey)
+		err := serve(c, APIKeyAuth(apiKeyRepo, authRepo, passthrough)(dummyNext))
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("should return 401 for an expired api key", func(t *testing.T) {
+		t.Parallel()
+
+		apiKeyRepo := mockpkg.NewMockAPIKeyRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		key := newTestAPIKey(uuid.New(), "write")
+		key.ExpiresAt = time.Now().Add(-time.Minute)
+
+		apiKeyRepo.On("FindAPIKeyByHash", mock.Anything, testAPIKeyHash()).Return(key, nil)
+
+		c, rec := newAPIKeyContext(http.MethodGet, "Bearer "+testAPIKey)
+		err := serve(c, APIKeyAuth(apiKeyRepo, authRepo, passthrough)(dummyNext))
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("should return 401 when the user is deactivated", func(t *testing.T) {
+		t.Parallel()
+
+		apiKeyRepo := mockpkg.NewMockAPIKeyRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		deletedAt := time.Now()
+		user := &domain.User{ID: uuid.New(), DeletedAt: &deletedAt}
+		key := newTestAPIKey(user.ID, "write")
+
+		apiKeyRepo.On("FindAPIKeyByHash", mock.Anything, testAPIKeyHash()).Return(key, nil)
+		authRepo.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		c, rec := newAPIKeyContext(http.MethodGet, "Bearer "+testAPIKey)
+		err := serve(c, APIKeyAuth(apiKeyRepo, authRepo, passthrough)(dummyNext))
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+// csrfFormField carries the CSRF token in the forms of the HTML pages.
+const csrfFormField = "csrf_token"
+
+// verifyCSRF checks the token of a state changing request authenticated by
+// the cookies of sessionID. Browsers send cookies with cross-site requests
+// but don't let other sites read them, so only pages of the API's origin,
+// or of an allowed one handed the token, can send it back.
+func verifyCSRF(c echo.Context, cookies domain.CookieManager, sessionID string) error {
+	if safeMethod(c.Request().Method) {
+		return nil
+	}
+
+	token := c.Request().Header.Get(domain.CSRFHeader)
+	if token == "" && strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationForm) {
+		token = c.FormValue(csrfFormField)
+	}
+	if !hmac.Equal([]byte(token), []byte(cookies.CSRFToken(sessionID))) {
+		return domain.ErrInvalidCSRFToken
+	}
+	return nil
+}
+
+// safeMethod reports whether method is read only (RFC 9110 section 9.2.1).
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
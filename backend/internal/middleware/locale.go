@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/SergioLNeves/migos/internal/pkg/i18n"
+)
+
+const (
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+)
+
+// Locale negotiates the response language from Accept-Language and stores it
+// in the request context for validation messages and ProblemDetails.
+func Locale() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			locale := i18n.Match(req.Header.Get(headerAcceptLanguage))
+
+			header := c.Response().Header()
+			header.Add(echo.HeaderVary, headerAcceptLanguage)
+			header.Set(headerContentLanguage, i18n.Tag(locale))
+
+			c.SetRequest(req.WithContext(i18n.NewContext(req.Context(), locale)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SergioLNeves/migos/internal/pkg/i18n"
+)
+
+func TestLocale(t *testing.T) {
+	t.Run("should store the negotiated locale and set Content-Language", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8")
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+
+		var locale string
+		err := Locale()(func(c echo.Context) error {
+			locale = i18n.FromContext(c.Request().Context())
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, i18n.PtBR, locale)
+		assert.Equal(t, "pt-BR", rec.Header().Get("Content-Language"))
+		assert.Equal(t, "Accept-Language", rec.Header().Get(echo.HeaderVary))
+	})
+
+	t.Run("should fall back to english without Accept-Language", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+		var locale string
+		_ = Locale()(func(c echo.Context) error {
+			locale = i18n.FromContext(c.Request().Context())
+			return nil
+		})(c)
+
+		assert.Equal(t, i18n.EN, locale)
+		assert.Equal(t, "en", rec.Header().Get("Content-Language"))
+	})
+}
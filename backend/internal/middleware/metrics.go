@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+)
+
+// Metrics records request count and latency labelled by the matched route
+// template, so path parameters don't explode label cardinality.
+func Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			labels := []string{c.Request().Method, routeTemplate(c), strconv.Itoa(responseStatus(c, err))}
+			metrics.HTTPRequestsTotal.WithLabelValues(labels...).Inc()
+			metrics.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
+
+// responseStatus resolves the status the error handler will write when the
+// handler returned an error instead of writing a response itself, looking
+// the error up like errorpkg.HTTPErrorHandler does.
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	entry, _ := errorpkg.Errors.Lookup(err)
+	return entry.Status
+}
+
+func routeTemplate(c echo.Context) string {
+	if route := c.Path(); route != "" {
+		return route
+	}
+	return "unmatched"
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Run("should count requests by route template and status", func(t *testing.T) {
+		t.Parallel()
+
+		e := echo.New()
+		e.Use(Metrics())
+		e.GET("/metrics-test/:id", func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		for _, id := range []string{"a", "b"} {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics-test/"+id, nil))
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+		}
+
+		counter := metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/metrics-test/:id", "204")
+		assert.Equal(t, float64(2), testutil.ToFloat64(counter))
+	})
+
+	t.Run("should record 500 when handler returns a plain error", func(t *testing.T) {
+		t.Parallel()
+
+		e := echo.New()
+		e.Use(Metrics())
+		e.GET("/metrics-error", func(_ echo.Context) error {
+			return errors.New("boom")
+		})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics-error", nil))
+
+		counter := metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/metrics-error", "500")
+		assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+	})
+
+	t.Run("should record the status of registered domain errors", func(t *testing.T) {
+		t.Parallel()
+
+		e := echo.New()
+		e.HTTPErrorHandler = errorpkg.HTTPErrorHandler
+		e.Use(Metrics())
+		e.GET("/metrics-unauthorized", func(_ echo.Context) error {
+			return fmt.Errorf("find session: %w", domain.ErrUnauthorized)
+		})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics-unauthorized", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		counter := metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/metrics-unauthorized", "401")
+		assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+		assert.Zero(t, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/metrics-unauthorized", "500")))
+	})
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+	"github.com/SergioLNeves/migos/internal/pkg/openapi"
+)
+
+// RequestValidation rejects request bodies that don't match the OpenAPI
+// document before they reach the handler. JSON and form bodies are checked;
+// other media types are left to the handler, which answers 415. The body is
+// restored so the handler can bind it again.
+func RequestValidation(validator *openapi.Validator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			mediaType, _, err := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+			if err != nil || (mediaType != echo.MIMEApplicationJSON && mediaType != echo.MIMEApplicationForm) {
+				return next(c)
+			}
+
+			raw, err := io.ReadAll(req.Body)
+			if err != nil {
+				return fmt.Errorf("%w: %v", errorpkg.ErrInvalidRequest, err)
+			}
+			req.Body = io.NopCloser(bytes.NewReader(raw))
+
+			body, err := decodeBody(mediaType, raw)
+			if err != nil {
+				return fmt.Errorf("%w: %v", errorpkg.ErrInvalidRequest, err)
+			}
+
+			err = validator.Validate(req.Method, c.Path(), body)
+			var requestErr *openapi.RequestError
+			if errors.As(err, &requestErr) {
+				fields := make([]errorpkg.ProblemDetailsFieldError, len(requestErr.Fields))
+				for i, field := range requestErr.Fields {
+					fields[i] = errorpkg.NewProblemDetailsFieldError(field.Field, field.Message)
+				}
+				return &errorpkg.ValidationError{Fields: fields}
+			}
+			if err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// decodeBody turns a JSON or form body into JSON values. Form fields keep
+// their first value, as a string.
+func decodeBody(mediaType string, raw []byte) (any, error) {
+	if mediaType == echo.MIMEApplicationJSON {
+		return jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	}
+
+	values, err := url.ParseQuery(string(raw))
+	if err != nil {
+		return nil, err
+	}
+	body := make(map[string]any, len(values))
+	for name := range values {
+		body[name] = values.Get(name)
+	}
+	return body, nil
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+	"github.com/SergioLNeves/migos/internal/pkg/openapi"
+)
+
+func newRequestValidation(t *testing.T) echo.MiddlewareFunc {
+	t.Helper()
+	doc := openapi.Generate(openapi.Info{Title: "test", Version: "1"}, []openapi.Route{{
+		Method: http.MethodPost, Path: "/v1/auth/login", OperationID: "login",
+		Request: domain.LoginRequest{}, Response: domain.AuthResponse{}, Status: http.StatusOK,
+	}})
+	validator, err := openapi.NewValidator(doc)
+	require.NoError(t, err)
+	return RequestValidation(validator)
+}
+
+func newBodyContext(contentType, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.SetPath("/v1/auth/login")
+	return c, rec
+}
+
+func TestRequestValidation(t *testing.T) {
+	t.Run("should pass a valid JSON body through with the body intact", func(t *testing.T) {
+		t.Parallel()
+
+		body := `{"email":"user@test.com","password":"password123"}`
+		c, _ := newBodyContext(echo.MIMEApplicationJSON, body)
+
+		var received string
+		err := newRequestValidation(t)(func(c echo.Context) error {
+			raw, err := io.ReadAll(c.Request().Body)
+			received = string(raw)
+			return err
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, body, received)
+	})
+
+	t.Run("should return 400 with field errors on a JSON body that violates the schema", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newBodyContext(echo.MIMEApplicationJSON, `{"email":"invalid","remember":true}`)
+
+		err := serve(c, newRequestValidation(t)(dummyNext))
+
+		assert.ErrorIs(t, err, errorpkg.ErrValidation)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"field":"email"`)
+		assert.Contains(t, rec.Body.String(), `"field":"password"`)
+		assert.Contains(t, rec.Body.String(), `"field":"remember"`)
+	})
+
+	t.Run("should validate form bodies", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newBodyContext(echo.MIMEApplicationForm, "email=user@test.com")
+
+		err := serve(c, newRequestValidation(t)(dummyNext))
+
+		assert.ErrorIs(t, err, errorpkg.ErrValidation)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"field":"password"`)
+	})
+
+	t.Run("should leave other media types to the handler", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newBodyContext(echo.MIMEApplicationXML, "<login/>")
+
+		err := newRequestValidation(t)(dummyNext)(c)
+
+		assert.NoError(t, err)
+	})
+}
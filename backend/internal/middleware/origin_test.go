@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+)
+
+func TestParseOrigins(t *testing.T) {
+	t.Run("should canonicalize a comma separated list", func(t *testing.T) {
+		t.Parallel()
+
+		origins, err := ParseOrigins(" https://App.example.com, http://localhost:3000/ ,")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://app.example.com", "http://localhost:3000"}, origins)
+	})
+
+	t.Run("should refuse wildcards and URLs that are not origins", func(t *testing.T) {
+		t.Parallel()
+
+		for _, list := range []string{"*", "https://*.example.com", "example.com", "https://example.com/app", "ftp://example.com", "https://user@example.com"} {
+			_, err := ParseOrigins(list)
+			assert.Error(t, err, list)
+		}
+	})
+}
+
+func TestOriginCheck(t *testing.T) {
+	allowed := []string{"https://app.example.com"}
+
+	check := func(method string, headers map[string]string) error {
+		req := httptest.NewRequest(method, "/v1/user/profile", nil)
+		req.Host = "auth.example.com"
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		return OriginCheck(allowed)(dummyNext)(c)
+	}
+
+	t.Run("should let same origin, allowed and non browser requests through", func(t *testing.T) {
+		t.Parallel()
+
+		for _, headers := range []map[string]string{
+			{echo.HeaderOrigin: "https://auth.example.com"},
+			{echo.HeaderOrigin: "http://auth.example.com"},
+			{echo.HeaderOrigin: "https://APP.example.com"},
+			{"Referer": "https://auth.example.com/settings"},
+			{},
+		} {
+			assert.NoError(t, check(http.MethodPatch, headers), headers)
+		}
+	})
+
+	t.Run("should refuse state changing requests from other origins", func(t *testing.T) {
+		t.Parallel()
+
+		for _, headers := range []map[string]string{
+			{echo.HeaderOrigin: "https://evil.example.com"},
+			{echo.HeaderOrigin: "null"},
+			{"Referer": "https://evil.example.com/attack"},
+			{echo.HeaderOrigin: "https://evil.example.com", "Referer": "https://auth.example.com/"},
+		} {
+			assert.ErrorIs(t, check(http.MethodDelete, headers), errorpkg.ErrOriginNotAllowed, headers)
+		}
+	})
+
+	t.Run("should skip safe methods and requests with an Authorization header", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NoError(t, check(http.MethodGet, map[string]string{echo.HeaderOrigin: "https://evil.example.com"}))
+		assert.NoError(t, check(http.MethodPost, map[string]string{
+			echo.HeaderOrigin:        "https://evil.example.com",
+			echo.HeaderAuthorization: "Bearer token",
+		}))
+	})
+}
+
+func TestCORS(t *testing.T) {
+	handler := CORS([]string{"https://app.example.com"})(dummyNext)
+
+	preflight := func(origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/user/profile", nil)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPatch)
+		rec := httptest.NewRecorder()
+		_ = handler(echo.New().NewContext(req, rec))
+		return rec
+	}
+
+	t.Run("should answer allowed origins with credentials", func(t *testing.T) {
+		t.Parallel()
+
+		rec := preflight("https://app.example.com")
+
+		assert.Equal(t, "https://app.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+	})
+
+	t.Run("should not answer other origins", func(t *testing.T) {
+		t.Parallel()
+
+		rec := preflight("https://evil.example.com")
+
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	})
+}
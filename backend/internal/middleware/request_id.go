@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/requestid"
+)
+
+// RequestID reuses the caller's X-Request-ID when it is well formed, or
+// generates one, echoes it back and stores it in the request context along
+// with a logger that tags every line with it.
+func RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			id := req.Header.Get(requestid.Header)
+			if !requestid.Valid(id) {
+				id = uuid.NewString()
+			}
+
+			c.Response().Header().Set(requestid.Header, id)
+			c.Set("request_id", id)
+
+			ctx := requestid.NewContext(req.Context(), id)
+			ctx = logging.NewContext(ctx, logging.FromContext(ctx).With(zap.String("request_id", id)))
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/requestid"
+)
+
+func serveRequestID(header string, next echo.HandlerFunc) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		req.Header.Set(requestid.Header, header)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	_ = RequestID()(next)(c)
+	return rec
+}
+
+func TestRequestID(t *testing.T) {
+	t.Run("should generate an ID when header is missing", func(t *testing.T) {
+		t.Parallel()
+
+		var ctxID string
+		rec := serveRequestID("", func(c echo.Context) error {
+			ctxID = requestid.FromContext(c.Request().Context())
+			return nil
+		})
+
+		id := rec.Header().Get(requestid.Header)
+		_, err := uuid.Parse(id)
+		assert.NoError(t, err)
+		assert.Equal(t, id, ctxID)
+	})
+
+	t.Run("should reuse a well formed client ID", func(t *testing.T) {
+		t.Parallel()
+
+		var ctxID string
+		rec := serveRequestID("client-abc-123", func(c echo.Context) error {
+			ctxID = requestid.FromContext(c.Request().Context())
+			return nil
+		})
+
+		assert.Equal(t, "client-abc-123", rec.Header().Get(requestid.Header))
+		assert.Equal(t, "client-abc-123", ctxID)
+	})
+
+	t.Run("should replace an ID that is too long or has control characters", func(t *testing.T) {
+		t.Parallel()
+
+		for _, header := range []string{strings.Repeat("a", requestid.MaxLength+1), "bad id", "bad\tid"} {
+			rec := serveRequestID(header, func(_ echo.Context) error { return nil })
+
+			_, err := uuid.Parse(rec.Header().Get(requestid.Header))
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("should store a logger tagged with the request ID", func(t *testing.T) {
+		t.Parallel()
+
+		core, logs := observer.New(zap.InfoLevel)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(requestid.Header, "req-1")
+		req = req.WithContext(logging.NewContext(req.Context(), zap.New(core)))
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		err := RequestID()(func(c echo.Context) error {
+			logging.FromContext(c.Request().Context()).Info("handled")
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, logs.FilterField(zap.String("request_id", "req-1")).Len())
+	})
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+// headerPermissionsPolicy is missing from the header names of Echo.
+const headerPermissionsPolicy = "Permissions-Policy"
+
+// headerOff leaves a header of HEADERS_* out.
+const headerOff = "off"
+
+// Defaults of HEADERS_*. The API answers JSON, which needs no sources, and
+// the pages only post forms to the API. The /docs reference runs Scalar
+// from jsDelivr against the document of the API.
+const (
+	defaultHSTS               = "max-age=31536000"
+	defaultCSP                = "default-src 'none'; base-uri 'none'"
+	defaultFrameAncestors     = "'none'"
+	defaultContentTypeOptions = "nosniff"
+	defaultReferrerPolicy     = "no-referrer"
+	defaultPermissionsPolicy  = "accelerometer=(), camera=(), geolocation=(), gyroscope=(), magnetometer=(), microphone=(), payment=(), usb=()"
+	defaultDocsCSP            = "default-src 'none'; script-src https://cdn.jsdelivr.net; style-src 'unsafe-inline' https://cdn.jsdelivr.net; " +
+		"font-src https://cdn.jsdelivr.net https://fonts.scalar.com data:; img-src 'self' data: https:; connect-src 'self'; base-uri 'none'"
+)
+
+// referrerPolicies are the tokens of Referrer-Policy
+// (https://w3c.github.io/webappsec-referrer-policy/#referrer-policies).
+var referrerPolicies = []string{
+	"no-referrer", "no-referrer-when-downgrade", "origin", "origin-when-cross-origin",
+	"same-origin", "strict-origin", "strict-origin-when-cross-origin", "unsafe-url",
+}
+
+// SecurityHeaders are response headers by name. An empty value removes the
+// header, so a route can drop one set for every response.
+type SecurityHeaders map[string]string
+
+// HeaderPolicy holds the security headers of the API responses and the
+// overrides of the routes serving HTML.
+type HeaderPolicy struct {
+	API SecurityHeaders
+	// Page is for the consent, device and social login pages. They ask
+	// users to approve access, so they can't be framed (clickjacking).
+	Page SecurityHeaders
+	// Docs is for the /docs reference.
+	Docs SecurityHeaders
+}
+
+// ParseHeaderPolicy fills the defaults in cfg and checks it. HSTS is only
+// sent in production, which is served over HTTPS.
+func ParseHeaderPolicy(cfg domain.HeadersConfig, production bool) (*HeaderPolicy, error) {
+	frameAncestors := headerValue(cfg.FrameAncestors, defaultFrameAncestors)
+	if strings.ContainsAny(frameAncestors, ";,") {
+		return nil, fmt.Errorf("invalid HEADERS_FRAME_ANCESTORS %q: must be a space separated list of sources", cfg.FrameAncestors)
+	}
+
+	referrerPolicy := headerValue(cfg.ReferrerPolicy, defaultReferrerPolicy)
+	if referrerPolicy != "" {
+		// A list names fallbacks for browsers that don't know the last one.
+		for _, token := range strings.Split(referrerPolicy, ",") {
+			if !slices.Contains(referrerPolicies, strings.ToLower(strings.TrimSpace(token))) {
+				return nil, fmt.Errorf("invalid HEADERS_REFERRER_POLICY %q: unknown policy %q", cfg.ReferrerPolicy, token)
+			}
+		}
+	}
+
+	var hsts string
+	if production {
+		hsts = headerValue(cfg.HSTS, defaultHSTS)
+		if hsts != "" && !strings.HasPrefix(strings.ToLower(hsts), "max-age=") {
+			return nil, fmt.Errorf("invalid HEADERS_HSTS %q: must start with max-age=", cfg.HSTS)
+		}
+	}
+
+	base := SecurityHeaders{
+		echo.HeaderStrictTransportSecurity: hsts,
+		echo.HeaderXContentTypeOptions:     headerValue(cfg.ContentTypeOptions, defaultContentTypeOptions),
+		echo.HeaderReferrerPolicy:          referrerPolicy,
+		headerPermissionsPolicy:            headerValue(cfg.PermissionsPolicy, defaultPermissionsPolicy),
+	}
+	policy := &HeaderPolicy{
+		API:  base.withCSP(headerValue(cfg.CSP, defaultCSP), frameAncestors),
+		Page: base.withCSP(headerValue(cfg.PageCSP, defaultCSP), defaultFrameAncestors),
+		Docs: base.withCSP(headerValue(cfg.DocsCSP, defaultDocsCSP), defaultFrameAncestors),
+	}
+
+	for _, headers := range []SecurityHeaders{policy.API, policy.Page, policy.Docs} {
+		for name, value := range headers {
+			if strings.ContainsAny(value, "\r\n") {
+				return nil, fmt.Errorf("invalid %s header: must be a single line", name)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// headerValue returns the configured value of a header, def when unset or
+// nothing when turned off.
+func headerValue(value, def string) string {
+	switch strings.TrimSpace(value) {
+	case "":
+		return def
+	case headerOff:
+		return ""
+	default:
+		return strings.TrimSpace(value)
+	}
+}
+
+// withCSP returns a copy of h with the policy csp, which gets the
+// frame-ancestors directive unless it has its own, and the X-Frame-Options
+// header older browsers understand instead.
+func (h SecurityHeaders) withCSP(csp, frameAncestors string) SecurityHeaders {
+	headers := maps.Clone(h)
+	if frameAncestors != "" && !strings.Contains(csp, "frame-ancestors") {
+		if csp = strings.TrimRight(csp, "; "); csp != "" {
+			csp += "; "
+		}
+		csp += "frame-ancestors " + frameAncestors
+	}
+	headers[echo.HeaderContentSecurityPolicy] = csp
+
+	switch frameAncestors {
+	case "'none'":
+		headers[echo.HeaderXFrameOptions] = "DENY"
+	case "'self'":
+		headers[echo.HeaderXFrameOptions] = "SAMEORIGIN"
+	default:
+		headers[echo.HeaderXFrameOptions] = ""
+	}
+	return headers
+}
+
+// SecureHeaders sets headers on the response before the handler runs, so
+// errors and redirects carry them too. Used on a route, it replaces the
+// headers set for every response.
+func SecureHeaders(headers SecurityHeaders) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			for name, value := range headers {
+				if value == "" {
+					header.Del(name)
+				} else {
+					header.Set(name, value)
+				}
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+func TestParseHeaderPolicy(t *testing.T) {
+	t.Run("should default to a locked down policy without HSTS outside production", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := ParseHeaderPolicy(domain.HeadersConfig{}, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, SecurityHeaders{
+			echo.HeaderStrictTransportSecurity: "",
+			echo.HeaderContentSecurityPolicy:   "default-src 'none'; base-uri 'none'; frame-ancestors 'none'",
+			echo.HeaderXFrameOptions:           "DENY",
+			echo.HeaderXContentTypeOptions:     "nosniff",
+			echo.HeaderReferrerPolicy:          "no-referrer",
+			headerPermissionsPolicy:            defaultPermissionsPolicy,
+		}, policy.API)
+		assert.Equal(t, policy.API, policy.Page)
+		assert.Contains(t, policy.Docs[echo.HeaderContentSecurityPolicy], "script-src https://cdn.jsdelivr.net")
+	})
+
+	t.Run("should send HSTS in production", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := ParseHeaderPolicy(domain.HeadersConfig{}, true)
+		require.NoError(t, err)
+		assert.Equal(t, "max-age=31536000", policy.API[echo.HeaderStrictTransportSecurity])
+
+		policy, err = ParseHeaderPolicy(domain.HeadersConfig{HSTS: "max-age=63072000; includeSubDomains; preload"}, true)
+		require.NoError(t, err)
+		assert.Equal(t, "max-age=63072000; includeSubDomains; preload", policy.Page[echo.HeaderStrictTransportSecurity])
+	})
+
+	t.Run("should apply the configuration and leave out headers turned off", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := ParseHeaderPolicy(domain.HeadersConfig{
+			HSTS:               "off",
+			CSP:                "default-src 'self';",
+			FrameAncestors:     "'self' https://portal.example.com",
+			ContentTypeOptions: "off",
+			ReferrerPolicy:     "no-referrer, strict-origin-when-cross-origin",
+			PermissionsPolicy:  "off",
+			PageCSP:            "default-src 'none'; style-src 'self'",
+		}, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, SecurityHeaders{
+			echo.HeaderStrictTransportSecurity: "",
+			echo.HeaderContentSecurityPolicy:   "default-src 'self'; frame-ancestors 'self' https://portal.example.com",
+			echo.HeaderXFrameOptions:           "",
+			echo.HeaderXContentTypeOptions:     "",
+			echo.HeaderReferrerPolicy:          "no-referrer, strict-origin-when-cross-origin",
+			headerPermissionsPolicy:            "",
+		}, policy.API)
+		assert.Equal(t, "default-src 'none'; style-src 'self'; frame-ancestors 'none'", policy.Page[echo.HeaderContentSecurityPolicy])
+		assert.Equal(t, "DENY", policy.Page[echo.HeaderXFrameOptions])
+	})
+
+	t.Run("should keep frame-ancestors when CSP is turned off", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := ParseHeaderPolicy(domain.HeadersConfig{CSP: "off", FrameAncestors: "'self'"}, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "frame-ancestors 'self'", policy.API[echo.HeaderContentSecurityPolicy])
+		assert.Equal(t, "SAMEORIGIN", policy.API[echo.HeaderXFrameOptions])
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		t.Parallel()
+
+		for name, cfg := range map[string]domain.HeadersConfig{
+			"HSTS without max-age":     {HSTS: "includeSubDomains"},
+			"unknown referrer policy":  {ReferrerPolicy: "never"},
+			"frame ancestors with CSP": {FrameAncestors: "'none'; script-src *"},
+			"multi line header":        {PermissionsPolicy: "camera=()\r\nSet-Cookie: x=y"},
+		} {
+			_, err := ParseHeaderPolicy(cfg, true)
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestSecureHeaders(t *testing.T) {
+	t.Run("should let a route replace and remove the headers of every response", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := ParseHeaderPolicy(domain.HeadersConfig{FrameAncestors: "'self'"}, false)
+		require.NoError(t, err)
+		page := maps.Clone(policy.Page)
+		page[echo.HeaderReferrerPolicy] = ""
+
+		e := echo.New()
+		e.Use(SecureHeaders(policy.API))
+		e.GET("/page", dummyNext, SecureHeaders(page))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/page", nil))
+
+		assert.Equal(t, "DENY", rec.Header().Get(echo.HeaderXFrameOptions))
+		assert.Empty(t, rec.Header().Values(echo.HeaderReferrerPolicy))
+		assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "SAMEORIGIN", rec.Header().Get(echo.HeaderXFrameOptions))
+		assert.Equal(t, "no-referrer", rec.Header().Get(echo.HeaderReferrerPolicy))
+	})
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+)
+
+// ServiceAccountAuth authenticates "Authorization: Bearer" tokens issued by
+// the client_credentials grant and hands every other request to userAuth.
+// Requests from a service account carry "client_id" and "scopes" instead of
+// "user_id" in the context, so it only guards routes whose handlers don't
+// act on a user; RequireScope then decides what the account may call.
+func ServiceAccountAuth(
+	tokenProvider domain.TokenProvider,
+	serviceAccountRepo domain.ServiceAccountRepository,
+	userAuth echo.MiddlewareFunc,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withUser := userAuth(next)
+
+		return func(c echo.Context) error {
+			token, ok := bearerToken(c)
+			if !ok || strings.HasPrefix(token, domain.APIKeyPrefix) {
+				return withUser(c)
+			}
+
+			ctx := c.Request().Context()
+			claims, err := tokenProvider.ParseAccessToken(ctx, token)
+			if err != nil || claims.ClientID == "" {
+				return withUser(c)
+			}
+
+			logger := logging.WithContext(ctx, zap.String("middleware", "ServiceAccountAuth"))
+
+			if !claims.ExpiresAt.After(time.Now()) {
+				logger.Info("service account token expired")
+				return domain.ErrUnauthorized
+			}
+
+			id, err := uuid.Parse(claims.ClientID)
+			if err != nil {
+				return domain.ErrUnauthorized
+			}
+			if _, err := serviceAccountRepo.FindServiceAccountByID(ctx, id); err != nil {
+				if errors.Is(err, domain.ErrServiceAccountNotFound) {
+					logger.Warn("token of a deleted service account", zap.String("client_id", claims.ClientID))
+					return domain.ErrUnauthorized
+				}
+				return fmt.Errorf("failed to find service account: %w", err)
+			}
+
+			c.Set("client_id", claims.ClientID)
+			c.Set("scopes", claims.Scopes)
+
+			requestLogger := logging.FromContext(ctx).With(zap.String("client_id", claims.ClientID))
+			c.SetRequest(c.Request().WithContext(logging.NewContext(ctx, requestLogger)))
+
+			return next(c)
+		}
+	}
+}
+
+// RequireScope rejects callers that lack any of scopes with
+// ErrInsufficientScope. Service accounts have the scopes of their token;
+// users have their roles, as reported by token introspection.
+func RequireScope(authRepo domain.AuthRepository, scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			granted, ok := c.Get("scopes").([]string)
+			if !ok {
+				userID, err := uuid.Parse(fmt.Sprint(c.Get("user_id")))
+				if err != nil {
+					return domain.ErrUnauthorized
+				}
+				if granted, err = authRepo.FindRolesByUserID(c.Request().Context(), userID); err != nil {
+					return fmt.Errorf("failed to find roles: %w", err)
+				}
+			}
+
+			for _, scope := range scopes {
+				if !slices.Contains(granted, scope) {
+					return domain.ErrInsufficientScope
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
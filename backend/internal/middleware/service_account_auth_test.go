@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+const testServiceToken = "eyJhbGciOiJSUzI1NiJ9.e30.sig"
+
+func TestServiceAccountAuth(t *testing.T) {
+	t.Run("should hand user tokens to userAuth", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		serviceAccountRepo := mockpkg.NewMockServiceAccountRepository(t)
+		userAuthCalled := false
+		userAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				userAuthCalled = true
+				return next(c)
+			}
+		}
+
+		tokenProvider.On("ParseAccessToken", mock.Anything, testServiceToken).
+			Return(&domain.AccessTokenClaims{UserID: uuid.NewString(), ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+		c, _ := newAPIKeyContext(http.MethodGet, "Bearer "+testServiceToken)
+		err := ServiceAccountAuth(tokenProvider, serviceAccountRepo, userAuth)(dummyNext)(c)
+
+		assert.NoError(t, err)
+		assert.True(t, userAuthCalled)
+		assert.Nil(t, c.Get("client_id"))
+	})
+
+	t.Run("should authenticate the service account of a valid token", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		serviceAccountRepo := mockpkg.NewMockServiceAccountRepository(t)
+		accountID := uuid.New()
+
+		tokenProvider.On("ParseAccessToken", mock.Anything, testServiceToken).Return(&domain.AccessTokenClaims{
+			ClientID: accountID.String(), Scopes: []string{"admin"}, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		serviceAccountRepo.On("FindServiceAccountByID", mock.Anything, accountID).
+			Return(&domain.ServiceAccount{ID: accountID}, nil)
+
+		c, _ := newAPIKeyContext(http.MethodGet, "Bearer "+testServiceToken)
+		err := ServiceAccountAuth(tokenProvider, serviceAccountRepo, passthrough)(dummyNext)(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, accountID.String(), c.Get("client_id"))
+		assert.Equal(t, []string{"admin"}, c.Get("scopes"))
+		assert.Nil(t, c.Get("user_id"))
+	})
+
+	t.Run("should return 401 for a token of a deleted service account", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		serviceAccountRepo := mockpkg.NewMockServiceAccountRepository(t)
+		accountID := uuid.New()
+
+		tokenProvider.On("ParseAccessToken", mock.Anything, testServiceToken).Return(&domain.AccessTokenClaims{
+			ClientID: accountID.String(), Scopes: []string{"admin"}, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		serviceAccountRepo.On("FindServiceAccountByID", mock.Anything, accountID).
+			Return(nil, domain.ErrServiceAccountNotFound)
+
+		c, rec := newAPIKeyContext(http.MethodGet, "Bearer "+testServiceToken)
+		err := serve(c, ServiceAccountAuth(tokenProvider, serviceAccountRepo, passthrough)(dummyNext))
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("should return 401 for an expired token", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		serviceAccountRepo := mockpkg.NewMockServiceAccountRepository(t)
+
+		tokenProvider.On("ParseAccessToken", mock.Anything, testServiceToken).Return(&domain.AccessTokenClaims{
+			ClientID: uuid.NewString(), ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		c, _ := newAPIKeyContext(http.MethodGet, "Bearer "+testServiceToken)
+		err := ServiceAccountAuth(tokenProvider, serviceAccountRepo, passthrough)(dummyNext)(c)
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	})
+}
+
+func TestRequireScope(t *testing.T) {
+	t.Run("should accept a service account granted every scope", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newAPIKeyContext(http.MethodGet, "")
+		c.Set("scopes", []string{"read", "admin"})
+
+		err := RequireScope(mockpkg.NewMockAuthRepository(t), "admin")(dummyNext)(c)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return 403 when a service account lacks a scope", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newAPIKeyContext(http.MethodGet, "")
+		c.Set("scopes", []string{"read"})
+
+		err := serve(c, RequireScope(mockpkg.NewMockAuthRepository(t), "admin")(dummyNext))
+
+		assert.ErrorIs(t, err, domain.ErrInsufficientScope)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should check the roles of a user", func(t *testing.T) {
+		t.Parallel()
+
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		userID := uuid.New()
+		authRepo.On("FindRolesByUserID", mock.Anything, userID).Return([]string{domain.RoleAdmin}, nil)
+
+		c, _ := newAPIKeyContext(http.MethodGet, "")
+		c.Set("user_id", userID.String())
+
+		err := RequireScope(authRepo, domain.RoleAdmin)(dummyNext)(c)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the roles cannot be read", func(t *testing.T) {
+		t.Parallel()
+
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		userID := uuid.New()
+		authRepo.On("FindRolesByUserID", mock.Anything, userID).Return(nil, errors.New("db down"))
+
+		c, _ := newAPIKeyContext(http.MethodGet, "")
+		c.Set("user_id", userID.String())
+
+		err := RequireScope(authRepo, domain.RoleAdmin)(dummyNext)(c)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, domain.ErrInsufficientScope)
+	})
+}
@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"context"
 	"errors"
-	"net/http"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,167 +13,266 @@ import (
 
 	"github.com/SergioLNeves/migos/internal/config"
 	"github.com/SergioLNeves/migos/internal/domain"
-	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
 	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
 )
 
+// SessionAuth authenticates the request from the session cookies, rotating
+// them on every request, or from an "Authorization: Bearer" access token.
+// Bearer tokens are checked for expiry and are not rotated; those clients
+// renew them through the refresh endpoint, as do cookie clients when the
+// refresh cookie is scoped to it. State changing requests made with cookies
+// must also send the CSRF token of the session. Sessions of OpenID Connect
+// clients are refused: their tokens only serve ClientSessionAuth routes.
 func SessionAuth(
 	tokenProvider domain.TokenProvider,
 	sessionRepo domain.SessionRepository,
 	authRepo domain.AuthRepository,
+	cookies domain.CookieManager,
 ) echo.MiddlewareFunc {
+	return sessionAuthenticator{
+		tokenProvider: tokenProvider,
+		sessionRepo:   sessionRepo,
+		authRepo:      authRepo,
+		cookieManager: cookies,
+	}.middleware()
+}
+
+// ClientSessionAuth is SessionAuth also letting in the access tokens of
+// OpenID Connect clients, for /userinfo. Handlers read the client and the
+// scopes it was granted from the session.
+func ClientSessionAuth(
+	tokenProvider domain.TokenProvider,
+	sessionRepo domain.SessionRepository,
+	authRepo domain.AuthRepository,
+	cookies domain.CookieManager,
+) echo.MiddlewareFunc {
+	return sessionAuthenticator{
+		tokenProvider: tokenProvider,
+		sessionRepo:   sessionRepo,
+		authRepo:      authRepo,
+		cookieManager: cookies,
+		clients:       true,
+	}.middleware()
+}
+
+func (auth sessionAuthenticator) middleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			logger := logging.With(zap.String("middleware", "SessionAuth"))
+			ctx := c.Request().Context()
+			logger := logging.WithContext(ctx, zap.String("middleware", "SessionAuth"))
 
-			cookie, err := c.Cookie("access_token")
-			if err != nil || cookie.Value == "" {
-				logger.Warn("missing access token cookie")
-				return unauthorizedResponse(c)
+			var (
+				user    *domain.User
+				session *domain.Session
+				err     error
+			)
+			if accessToken, ok := bearerToken(c); ok {
+				user, session, err = auth.bearer(c, logger, accessToken)
+			} else {
+				user, session, err = auth.cookies(c, logger)
 			}
-
-			accessClaims, err := tokenProvider.ParseAccessToken(cookie.Value)
 			if err != nil {
-				logger.Warn("invalid access token", zap.Error(err))
-				return unauthorizedResponse(c)
+				return err
 			}
 
-			sessionID, err := uuid.Parse(accessClaims.SessionID)
-			if err != nil {
-				logger.Warn("invalid session ID in token", zap.Error(err))
-				return unauthorizedResponse(c)
-			}
+			c.Set("user_id", user.ID.String())
+			c.Set("email", user.Email)
+			c.Set("name", user.Name)
+			c.Set("avatar", user.Avatar)
+			c.Set("session_id", session.ID.String())
 
-			session, err := sessionRepo.FindSessionByID(c.Request().Context(), sessionID)
-			if err != nil {
-				if errors.Is(err, domain.ErrSessionNotFound) {
-					logger.Info("session not found", zap.String("session_id", sessionID.String()))
-					clearAuthCookies(c)
-					return unauthorizedResponse(c)
-				}
-				logger.Error("failed to find session", zap.Error(err))
-				return internalErrorResponse(c)
-			}
+			requestLogger := logging.FromContext(ctx).With(
+				zap.String("user_id", user.ID.String()),
+				zap.String("session_id", session.ID.String()),
+			)
+			c.SetRequest(c.Request().WithContext(logging.NewContext(ctx, requestLogger)))
 
-			// Try refresh flow: parse refresh token to check if it's still valid
-			refreshCookie, err := c.Cookie("refresh_token")
-			if err != nil || refreshCookie.Value == "" {
-				logger.Warn("missing refresh token cookie")
-				clearAuthCookies(c)
-				return unauthorizedResponse(c)
-			}
+			return next(c)
+		}
+	}
+}
 
-			_, refreshErr := tokenProvider.ParseRefreshToken(refreshCookie.Value)
-			if refreshErr != nil {
-				logger.Info("refresh token expired, clearing session", zap.Error(refreshErr))
-				if _, deleteErr := sessionRepo.DeleteSession(c.Request().Context(), sessionID); deleteErr != nil {
-					logger.Error("failed to delete expired session", zap.Error(deleteErr))
-				}
-				clearAuthCookies(c)
-				return unauthorizedResponse(c)
-			}
+type sessionAuthenticator struct {
+	tokenProvider domain.TokenProvider
+	sessionRepo   domain.SessionRepository
+	authRepo      domain.AuthRepository
+	cookieManager domain.CookieManager
+	// clients lets in the sessions of OpenID Connect clients.
+	clients bool
+}
 
-			// Refresh token is valid — find user from session and regenerate tokens
-			user, err := authRepo.FindUserByID(c.Request().Context(), session.UserID)
-			if err != nil {
-				if errors.Is(err, domain.ErrUserNotFound) {
-					logger.Warn("user not found for token refresh", zap.String("user_id", session.UserID.String()))
-					return unauthorizedResponse(c)
-				}
-				logger.Error("failed to find user for token refresh", zap.Error(err))
-				return internalErrorResponse(c)
-			}
+func bearerToken(c echo.Context) (string, bool) {
+	scheme, token, ok := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
 
-			newAccessToken, err := tokenProvider.GenerateAccessToken(session.ID.String())
-			if err != nil {
-				logger.Error("failed to generate new access token", zap.Error(err))
-				return internalErrorResponse(c)
-			}
+func (a sessionAuthenticator) bearer(c echo.Context, logger *zap.Logger, accessToken string) (*domain.User, *domain.Session, error) {
+	ctx := c.Request().Context()
 
-			newRefreshToken, err := tokenProvider.GenerateRefreshToken(user.ID.String(), session.ID.String())
-			if err != nil {
-				logger.Error("failed to generate new refresh token", zap.Error(err))
-				return internalErrorResponse(c)
-			}
+	accessClaims, err := a.tokenProvider.ParseAccessToken(ctx, accessToken)
+	if err != nil {
+		logger.Warn("invalid bearer token", zap.Error(err))
+		return nil, nil, domain.ErrUnauthorized
+	}
 
-			setAuthCookies(c, &domain.AuthResponse{
-				AccessToken:  newAccessToken,
-				RefreshToken: newRefreshToken,
-			})
+	if !accessClaims.ExpiresAt.After(time.Now()) {
+		logger.Info("bearer token expired")
+		return nil, nil, domain.ErrUnauthorized
+	}
 
-			newExpiry := time.Now().Add(time.Duration(config.Env.Token.RefreshTokenExpiry) * time.Minute)
-			if updateErr := sessionRepo.UpdateSessionExpiry(c.Request().Context(), sessionID, newExpiry); updateErr != nil {
-				logger.Error("failed to update session expiry", zap.Error(updateErr))
-			}
+	session, err := a.session(ctx, logger, accessClaims)
+	if err != nil {
+		return nil, nil, err
+	}
 
-			c.Set("user_id", user.ID.String())
-			c.Set("email", user.Email)
-			c.Set("name", user.Name)
-			c.Set("avatar", user.Avatar)
-			c.Set("session_id", session.ID.String())
+	user, err := a.user(ctx, logger, session.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
 
-			return next(c)
+	return user, session, nil
+}
+
+func (a sessionAuthenticator) cookies(c echo.Context, logger *zap.Logger) (*domain.User, *domain.Session, error) {
+	ctx := c.Request().Context()
+
+	accessToken, ok := a.cookieManager.Get(c, domain.CookieAccessToken)
+	if !ok {
+		logger.Warn("missing access token cookie")
+		return nil, nil, domain.ErrUnauthorized
+	}
+
+	accessClaims, err := a.tokenProvider.ParseAccessToken(ctx, accessToken)
+	if err != nil {
+		logger.Warn("invalid access token", zap.Error(err))
+		return nil, nil, domain.ErrUnauthorized
+	}
+
+	session, err := a.session(ctx, logger, accessClaims)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) {
+			a.cookieManager.ClearSession(c)
 		}
+		return nil, nil, err
 	}
-}
 
-func clearAuthCookies(c echo.Context) {
-	c.SetCookie(&http.Cookie{
-		Name:     "access_token",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-	})
-	c.SetCookie(&http.Cookie{
-		Name:     "refresh_token",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-	})
-}
+	// A refresh cookie scoped to the refresh endpoint never comes here, so
+	// the access cookie stands on its own like a bearer token.
+	if a.cookieManager.RefreshScoped() {
+		if !accessClaims.ExpiresAt.After(time.Now()) {
+			logger.Info("access token cookie expired")
+			return nil, nil, domain.ErrUnauthorized
+		}
+		if err := verifyCSRF(c, a.cookieManager, session.ID.String()); err != nil {
+			logger.Warn("missing or invalid csrf token")
+			return nil, nil, err
+		}
+		user, err := a.user(ctx, logger, session.UserID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return user, session, nil
+	}
 
-func setAuthCookies(c echo.Context, response *domain.AuthResponse) {
-	isProduction := config.Env.Env == "production"
-
-	c.SetCookie(&http.Cookie{
-		Name:     "access_token",
-		Value:    response.AccessToken,
-		Path:     "/",
-		MaxAge:   config.Env.Token.AccessTokenExpiry * 60,
-		HttpOnly: true,
-		Secure:   isProduction,
-		SameSite: http.SameSiteStrictMode,
-	})
+	// Try refresh flow: parse refresh token to check if it's still valid
+	refreshToken, ok := a.cookieManager.Get(c, domain.CookieRefreshToken)
+	if !ok {
+		logger.Warn("missing refresh token cookie")
+		a.cookieManager.ClearSession(c)
+		return nil, nil, domain.ErrUnauthorized
+	}
 
-	c.SetCookie(&http.Cookie{
-		Name:     "refresh_token",
-		Value:    response.RefreshToken,
-		Path:     "/",
-		MaxAge:   config.Env.Token.RefreshTokenExpiry * 60,
-		HttpOnly: true,
-		Secure:   isProduction,
-		SameSite: http.SameSiteStrictMode,
+	_, refreshErr := a.tokenProvider.ParseRefreshToken(ctx, refreshToken)
+	if refreshErr != nil {
+		logger.Info("refresh token expired, clearing session", zap.Error(refreshErr))
+		if _, deleteErr := a.sessionRepo.DeleteSession(ctx, session.ID); deleteErr != nil {
+			logger.Error("failed to delete expired session", zap.Error(deleteErr))
+		} else {
+			metrics.SessionsRevokedTotal.WithLabelValues("refresh_expired").Inc()
+		}
+		a.cookieManager.ClearSession(c)
+		return nil, nil, domain.ErrUnauthorized
+	}
+
+	if err := verifyCSRF(c, a.cookieManager, session.ID.String()); err != nil {
+		logger.Warn("missing or invalid csrf token")
+		return nil, nil, err
+	}
+
+	// Refresh token is valid — find user from session and regenerate tokens
+	user, err := a.user(ctx, logger, session.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newAccessToken, err := a.tokenProvider.GenerateAccessToken(ctx, user.ID.String(), session.ID.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate new access token: %w", err)
+	}
+
+	newRefreshToken, err := a.tokenProvider.GenerateRefreshToken(ctx, user.ID.String(), session.ID.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate new refresh token: %w", err)
+	}
+
+	a.cookieManager.SetSession(c, &domain.AuthResponse{
+		AccessToken:  newAccessToken,
+		RefreshToken: newRefreshToken,
+		SessionID:    session.ID.String(),
 	})
+	metrics.TokensRefreshedTotal.Inc()
+
+	newExpiry := time.Now().Add(time.Duration(config.Env.Token.RefreshTokenExpiry) * time.Minute)
+	if updateErr := a.sessionRepo.UpdateSessionExpiry(ctx, session.ID, newExpiry); updateErr != nil {
+		logger.Error("failed to update session expiry", zap.Error(updateErr))
+	}
+
+	return user, session, nil
 }
 
-func unauthorizedResponse(c echo.Context) error {
-	problemDetails := errorpkg.NewProblemDetails().
-		WithType("auth", "unauthorized").
-		WithTitle("Unauthorized").
-		WithStatus(http.StatusUnauthorized).
-		WithDetail("Authentication required").
-		WithInstance(c.Request().URL.Path)
-	return c.JSON(http.StatusUnauthorized, problemDetails)
+func (a sessionAuthenticator) session(ctx context.Context, logger *zap.Logger, claims *domain.AccessTokenClaims) (*domain.Session, error) {
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		logger.Warn("invalid session ID in token", zap.Error(err))
+		return nil, domain.ErrUnauthorized
+	}
+
+	session, err := a.sessionRepo.FindSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			logger.Info("session not found", zap.String("session_id", sessionID.String()))
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	// The tokens of a client session are meant for the client's own use
+	// of /userinfo, not for managing the account.
+	if session.ClientID != nil && !a.clients {
+		logger.Warn("access token of an OpenID Connect client", zap.String("client_id", session.ClientID.String()))
+		return nil, domain.ErrUnauthorized
+	}
+	if claims.OIDCClientID != "" && (session.ClientID == nil || session.ClientID.String() != claims.OIDCClientID) {
+		logger.Warn("access token issued to another client", zap.String("session_id", sessionID.String()))
+		return nil, domain.ErrUnauthorized
+	}
+
+	return session, nil
 }
 
-func internalErrorResponse(c echo.Context) error {
-	problemDetails := errorpkg.NewProblemDetails().
-		WithType("auth", "internal-error").
-		WithTitle("Internal Server Error").
-		WithStatus(http.StatusInternalServerError).
-		WithDetail("An unexpected error occurred").
-		WithInstance(c.Request().URL.Path)
-	return c.JSON(http.StatusInternalServerError, problemDetails)
+func (a sessionAuthenticator) user(ctx context.Context, logger *zap.Logger, id uuid.UUID) (*domain.User, error) {
+	user, err := a.authRepo.FindUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			logger.Warn("user not found for session", zap.String("user_id", id.String()))
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to find user for session: %w", err)
+	}
+
+	return user, nil
 }
@@ -4,19 +4,41 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/cookie"
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
 	"github.com/SergioLNeves/migos/internal/pkg/logging"
 	mockpkg "github.com/SergioLNeves/migos/mock"
 )
 
+// testCookies is the cookie manager of the tests, with the default policy.
+var testCookies = newCookies(domain.CookieConfig{})
+
+func newCookies(cfg domain.CookieConfig) *cookie.Manager {
+	cookies, err := cookie.New(&domain.Config{
+		HTTP:   domain.HTTPConfig{CSRFKey: "test-csrf-key-of-at-least-32-bytes"},
+		Token:  domain.TokenConfig{AccessTokenExpiry: 15, RefreshTokenExpiry: 60},
+		Cookie: cfg,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return cookies
+}
+
 func TestMain(m *testing.M) {
 	logging.NewLogger(&domain.Config{Env: "development", LogLevel: "error"})
 	os.Exit(m.Run())
@@ -35,6 +57,16 @@ func newMiddlewareContext(accessToken, refreshToken string) (echo.Context, *http
 	return e.NewContext(req, rec), rec
 }
 
+// serve runs fn the way Echo does, rendering a returned error through the
+// central error handler.
+func serve(c echo.Context, fn echo.HandlerFunc) error {
+	err := fn(c)
+	if err != nil {
+		errorpkg.HTTPErrorHandler(err, c)
+	}
+	return err
+}
+
 func dummyNext(_ echo.Context) error {
 	return nil
 }
@@ -48,12 +80,13 @@ func TestSessionAuth(t *testing.T) {
 		authRepo := mockpkg.NewMockAuthRepository(t)
 
 		c, rec := newMiddlewareContext("", "")
-		handler := SessionAuth(tokenProvider, sessionRepo, authRepo)(dummyNext)
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(dummyNext)
 
-		err := handler(c)
+		err := serve(c, handler)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 401 when access token is invalid", func(t *testing.T) {
@@ -63,15 +96,16 @@ func TestSessionAuth(t *testing.T) {
 		sessionRepo := mockpkg.NewMockSessionRepository(t)
 		authRepo := mockpkg.NewMockAuthRepository(t)
 
-		tokenProvider.On("ParseAccessToken", "bad-token").Return(nil, errors.New("invalid"))
+		tokenProvider.On("ParseAccessToken", mock.Anything, "bad-token").Return(nil, errors.New("invalid"))
 
 		c, rec := newMiddlewareContext("bad-token", "")
-		handler := SessionAuth(tokenProvider, sessionRepo, authRepo)(dummyNext)
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(dummyNext)
 
-		err := handler(c)
+		err := serve(c, handler)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 401 when session not found", func(t *testing.T) {
@@ -83,16 +117,17 @@ func TestSessionAuth(t *testing.T) {
 
 		sessionID := uuid.New()
 		claims := &domain.AccessTokenClaims{SessionID: sessionID.String()}
-		tokenProvider.On("ParseAccessToken", "valid-token").Return(claims, nil)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "valid-token").Return(claims, nil)
 		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(nil, domain.ErrSessionNotFound)
 
 		c, rec := newMiddlewareContext("valid-token", "")
-		handler := SessionAuth(tokenProvider, sessionRepo, authRepo)(dummyNext)
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(dummyNext)
 
-		err := handler(c)
+		err := serve(c, handler)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should return 401 when refresh token is missing", func(t *testing.T) {
@@ -105,16 +140,17 @@ func TestSessionAuth(t *testing.T) {
 		sessionID := uuid.New()
 		session := &domain.Session{ID: sessionID, UserID: uuid.New()}
 		claims := &domain.AccessTokenClaims{SessionID: sessionID.String()}
-		tokenProvider.On("ParseAccessToken", "valid-token").Return(claims, nil)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "valid-token").Return(claims, nil)
 		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(session, nil)
 
 		c, rec := newMiddlewareContext("valid-token", "")
-		handler := SessionAuth(tokenProvider, sessionRepo, authRepo)(dummyNext)
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(dummyNext)
 
-		err := handler(c)
+		err := serve(c, handler)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should delete session and return 401 when refresh token is expired", func(t *testing.T) {
@@ -127,18 +163,19 @@ func TestSessionAuth(t *testing.T) {
 		sessionID := uuid.New()
 		session := &domain.Session{ID: sessionID, UserID: uuid.New()}
 		claims := &domain.AccessTokenClaims{SessionID: sessionID.String()}
-		tokenProvider.On("ParseAccessToken", "valid-token").Return(claims, nil)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "valid-token").Return(claims, nil)
 		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(session, nil)
-		tokenProvider.On("ParseRefreshToken", "expired-refresh").Return(nil, errors.New("expired"))
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "expired-refresh").Return(nil, errors.New("expired"))
 		sessionRepo.On("DeleteSession", mock.Anything, sessionID).Return(session, nil)
 
 		c, rec := newMiddlewareContext("valid-token", "expired-refresh")
-		handler := SessionAuth(tokenProvider, sessionRepo, authRepo)(dummyNext)
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(dummyNext)
 
-		err := handler(c)
+		err := serve(c, handler)
 
-		assert.NoError(t, err)
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("should pass through and set context on valid tokens", func(t *testing.T) {
@@ -155,12 +192,12 @@ func TestSessionAuth(t *testing.T) {
 		accessClaims := &domain.AccessTokenClaims{SessionID: sessionID.String()}
 		refreshClaims := &domain.RefreshTokenClaims{UserID: userID.String(), SessionID: sessionID.String()}
 
-		tokenProvider.On("ParseAccessToken", "valid-token").Return(accessClaims, nil)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "valid-token").Return(accessClaims, nil)
 		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(session, nil)
-		tokenProvider.On("ParseRefreshToken", "valid-refresh").Return(refreshClaims, nil)
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "valid-refresh").Return(refreshClaims, nil)
 		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
-		tokenProvider.On("GenerateAccessToken", sessionID.String()).Return("new-access", nil)
-		tokenProvider.On("GenerateRefreshToken", userID.String(), sessionID.String()).Return("new-refresh", nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, userID.String(), sessionID.String()).Return("new-access", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, userID.String(), sessionID.String()).Return("new-refresh", nil)
 		sessionRepo.On("UpdateSessionExpiry", mock.Anything, sessionID, mock.AnythingOfType("time.Time")).Return(nil)
 
 		var ctxUserID, ctxEmail, ctxSessionID string
@@ -168,18 +205,254 @@ func TestSessionAuth(t *testing.T) {
 			ctxUserID = c.Get("user_id").(string)
 			ctxEmail = c.Get("email").(string)
 			ctxSessionID = c.Get("session_id").(string)
+			logging.FromContext(c.Request().Context()).Info("handled")
 			return nil
 		}
 
+		core, logs := observer.New(zap.InfoLevel)
 		c, rec := newMiddlewareContext("valid-token", "valid-refresh")
-		handler := SessionAuth(tokenProvider, sessionRepo, authRepo)(next)
+		c.Request().Header.Set(domain.CSRFHeader, testCookies.CSRFToken(sessionID.String()))
+		c.SetRequest(c.Request().WithContext(logging.NewContext(c.Request().Context(), zap.New(core))))
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(next)
 
-		err := handler(c)
+		err := serve(c, handler)
 
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Equal(t, userID.String(), ctxUserID)
 		assert.Equal(t, "user@test.com", ctxEmail)
 		assert.Equal(t, sessionID.String(), ctxSessionID)
+		handled := logs.FilterMessage("handled").All()
+		if assert.Len(t, handled, 1) {
+			fields := handled[0].ContextMap()
+			assert.Equal(t, userID.String(), fields["user_id"])
+			assert.Equal(t, sessionID.String(), fields["session_id"])
+		}
+	})
+
+	t.Run("should return 403 on cookie requests without the CSRF token", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		sessionRepo := mockpkg.NewMockSessionRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+
+		sessionID := uuid.New()
+		otherSessionID := uuid.New()
+		accessClaims := &domain.AccessTokenClaims{SessionID: sessionID.String()}
+		refreshClaims := &domain.RefreshTokenClaims{SessionID: sessionID.String()}
+		tokenProvider.On("ParseAccessToken", mock.Anything, "valid-token").Return(accessClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(&domain.Session{ID: sessionID}, nil)
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "valid-refresh").Return(refreshClaims, nil)
+
+		for name, token := range map[string]string{
+			"missing":          "",
+			"of other session": testCookies.CSRFToken(otherSessionID.String()),
+		} {
+			c, rec := newMiddlewareContext("valid-token", "valid-refresh")
+			if token != "" {
+				c.Request().Header.Set(domain.CSRFHeader, token)
+			}
+			handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(dummyNext)
+
+			err := serve(c, handler)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidCSRFToken, name)
+			assert.Equal(t, http.StatusForbidden, rec.Code, name)
+			assert.Empty(t, rec.Result().Cookies(), name)
+		}
+	})
+
+	t.Run("should accept the CSRF token of a form and skip safe methods", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		sessionRepo := mockpkg.NewMockSessionRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+
+		userID := uuid.New()
+		sessionID := uuid.New()
+		accessClaims := &domain.AccessTokenClaims{SessionID: sessionID.String()}
+		refreshClaims := &domain.RefreshTokenClaims{UserID: userID.String(), SessionID: sessionID.String()}
+		tokenProvider.On("ParseAccessToken", mock.Anything, "valid-token").Return(accessClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(&domain.Session{ID: sessionID, UserID: userID}, nil)
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "valid-refresh").Return(refreshClaims, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, userID.String(), sessionID.String()).Return("new-access", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, userID.String(), sessionID.String()).Return("new-refresh", nil)
+		sessionRepo.On("UpdateSessionExpiry", mock.Anything, sessionID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		form := url.Values{"consent": {"approve"}, "csrf_token": {testCookies.CSRFToken(sessionID.String())}}
+		e := echo.New()
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodPost, "/authorize", strings.NewReader(form.Encode())),
+			httptest.NewRequest(http.MethodGet, "/authorize", nil),
+		} {
+			if req.Method == http.MethodPost {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			}
+			req.AddCookie(&http.Cookie{Name: "access_token", Value: "valid-token"})
+			req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "valid-refresh"})
+			rec := httptest.NewRecorder()
+			handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(dummyNext)
+
+			err := serve(e.NewContext(req, rec), handler)
+
+			assert.NoError(t, err, req.Method)
+			cookies := rec.Result().Cookies()
+			if assert.Len(t, cookies, 3, req.Method) {
+				assert.Equal(t, domain.CookieCSRFToken, cookies[2].Name)
+				assert.Equal(t, testCookies.CSRFToken(sessionID.String()), cookies[2].Value)
+			}
+		}
+	})
+
+	t.Run("should not rotate when the refresh cookie is scoped to the refresh path", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		sessionRepo := mockpkg.NewMockSessionRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		cookies := newCookies(domain.CookieConfig{RefreshPath: "/v1/auth/refresh"})
+
+		userID := uuid.New()
+		sessionID := uuid.New()
+		claims := &domain.AccessTokenClaims{SessionID: sessionID.String(), ExpiresAt: time.Now().Add(time.Minute)}
+		tokenProvider.On("ParseAccessToken", mock.Anything, "valid-token").Return(claims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(&domain.Session{ID: sessionID, UserID: userID}, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil)
+
+		c, rec := newMiddlewareContext("valid-token", "")
+		c.Request().Header.Set(domain.CSRFHeader, cookies.CSRFToken(sessionID.String()))
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, cookies)(dummyNext)
+
+		err := serve(c, handler)
+
+		assert.NoError(t, err)
+		assert.Empty(t, rec.Result().Cookies())
+	})
+
+	t.Run("should return 401 when the access cookie expired and the refresh cookie is scoped", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		sessionRepo := mockpkg.NewMockSessionRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+		cookies := newCookies(domain.CookieConfig{RefreshPath: "/v1/auth/refresh"})
+
+		sessionID := uuid.New()
+		claims := &domain.AccessTokenClaims{SessionID: sessionID.String(), ExpiresAt: time.Now().Add(-time.Minute)}
+		tokenProvider.On("ParseAccessToken", mock.Anything, "expired-token").Return(claims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(&domain.Session{ID: sessionID}, nil)
+
+		c, rec := newMiddlewareContext("expired-token", "")
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, cookies)(dummyNext)
+
+		err := serve(c, handler)
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("should authenticate a bearer token without rotating cookies", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		sessionRepo := mockpkg.NewMockSessionRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+
+		userID := uuid.New()
+		sessionID := uuid.New()
+		claims := &domain.AccessTokenClaims{SessionID: sessionID.String(), ExpiresAt: time.Now().Add(time.Minute)}
+		tokenProvider.On("ParseAccessToken", mock.Anything, "bearer-token").Return(claims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(&domain.Session{ID: sessionID, UserID: userID}, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil)
+
+		var ctxUserID string
+		next := func(c echo.Context) error {
+			ctxUserID = c.Get("user_id").(string)
+			return nil
+		}
+
+		c, rec := newMiddlewareContext("", "")
+		c.Request().Header.Set(echo.HeaderAuthorization, "Bearer bearer-token")
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(next)
+
+		err := serve(c, handler)
+
+		assert.NoError(t, err)
+		assert.Equal(t, userID.String(), ctxUserID)
+		assert.Empty(t, rec.Result().Cookies())
+	})
+
+	t.Run("should return 401 when the bearer token is expired", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		sessionRepo := mockpkg.NewMockSessionRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+
+		claims := &domain.AccessTokenClaims{SessionID: uuid.NewString(), ExpiresAt: time.Now().Add(-time.Minute)}
+		tokenProvider.On("ParseAccessToken", mock.Anything, "expired-token").Return(claims, nil)
+
+		c, rec := newMiddlewareContext("", "")
+		c.Request().Header.Set(echo.HeaderAuthorization, "Bearer expired-token")
+		handler := SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(dummyNext)
+
+		err := serve(c, handler)
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("should let the tokens of OpenID Connect clients in only with ClientSessionAuth", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		sessionRepo := mockpkg.NewMockSessionRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+
+		userID := uuid.New()
+		sessionID := uuid.New()
+		clientID := uuid.New()
+		claims := &domain.AccessTokenClaims{SessionID: sessionID.String(), OIDCClientID: clientID.String(), ExpiresAt: time.Now().Add(time.Minute)}
+		tokenProvider.On("ParseAccessToken", mock.Anything, "client-token").Return(claims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).
+			Return(&domain.Session{ID: sessionID, UserID: userID, ClientID: &clientID, Scope: "openid"}, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil).Once()
+
+		request := func(auth echo.MiddlewareFunc) (int, error) {
+			c, rec := newMiddlewareContext("", "")
+			c.Request().Header.Set(echo.HeaderAuthorization, "Bearer client-token")
+			err := serve(c, auth(dummyNext))
+			return rec.Code, err
+		}
+
+		code, err := request(SessionAuth(tokenProvider, sessionRepo, authRepo, testCookies))
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		_, err = request(ClientSessionAuth(tokenProvider, sessionRepo, authRepo, testCookies))
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return 401 when a client token names another client than its session", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		sessionRepo := mockpkg.NewMockSessionRepository(t)
+		authRepo := mockpkg.NewMockAuthRepository(t)
+
+		sessionID := uuid.New()
+		claims := &domain.AccessTokenClaims{SessionID: sessionID.String(), OIDCClientID: uuid.NewString(), ExpiresAt: time.Now().Add(time.Minute)}
+		tokenProvider.On("ParseAccessToken", mock.Anything, "client-token").Return(claims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(&domain.Session{ID: sessionID, UserID: uuid.New()}, nil)
+
+		c, rec := newMiddlewareContext("", "")
+		c.Request().Header.Set(echo.HeaderAuthorization, "Bearer client-token")
+		err := serve(c, ClientSessionAuth(tokenProvider, sessionRepo, authRepo, testCookies)(dummyNext))
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	})
 }
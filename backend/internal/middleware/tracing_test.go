@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+func findSpan(recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Run("should name the span after the route template and expose the trace ID", func(t *testing.T) {
+		t.Parallel()
+
+		var traceID string
+		e := echo.New()
+		e.Use(Tracing())
+		e.GET("/tracing-test/:id", func(c echo.Context) error {
+			traceID = tracing.TraceID(c.Request().Context())
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tracing-test/abc", nil))
+
+		span := findSpan(recorder, "GET /tracing-test/:id")
+		require.NotNil(t, span)
+		assert.Equal(t, span.SpanContext().TraceID().String(), traceID)
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	})
+
+	t.Run("should continue the trace from the traceparent header", func(t *testing.T) {
+		t.Parallel()
+
+		e := echo.New()
+		e.Use(Tracing())
+		e.GET("/tracing-parent", func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/tracing-parent", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		e.ServeHTTP(httptest.NewRecorder(), req)
+
+		span := findSpan(recorder, "GET /tracing-parent")
+		require.NotNil(t, span)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	})
+
+	t.Run("should mark the span as error when handler returns a plain error", func(t *testing.T) {
+		t.Parallel()
+
+		e := echo.New()
+		e.Use(Tracing())
+		e.GET("/tracing-error", func(_ echo.Context) error {
+			return errors.New("boom")
+		})
+
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tracing-error", nil))
+
+		span := findSpan(recorder, "GET /tracing-error")
+		require.NotNil(t, span)
+		assert.Equal(t, codes.Error, span.Status().Code)
+	})
+
+	t.Run("should not mark the span as error for registered client errors", func(t *testing.T) {
+		t.Parallel()
+
+		e := echo.New()
+		e.Use(Tracing())
+		e.GET("/tracing-unauthorized", func(_ echo.Context) error {
+			return domain.ErrUnauthorized
+		})
+
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tracing-unauthorized", nil))
+
+		span := findSpan(recorder, "GET /tracing-unauthorized")
+		require.NotNil(t, span)
+		assert.Equal(t, codes.Unset, span.Status().Code)
+		assert.Contains(t, span.Attributes(), semconv.HTTPResponseStatusCode(http.StatusUnauthorized))
+	})
+}
@@ -0,0 +1,224 @@
+// Package cookie sets and reads the cookies of the API in one place, so
+// their names, scope and attributes follow COOKIE_* everywhere.
+package cookie
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/security"
+)
+
+const (
+	hostPrefix   = "__Host-"
+	securePrefix = "__Secure-"
+)
+
+// minCSRFKeySize is the shortest HTTP_CSRF_KEY accepted, as for any
+// HMAC-SHA256 key.
+const minCSRFKeySize = 32
+
+// Manager sets the session, CSRF and social login state cookies with the
+// domain, prefixes, SameSite mode and partitioning of the configuration,
+// sealing the HttpOnly ones when seal keys are configured.
+type Manager struct {
+	cfg      domain.CookieConfig
+	secure   bool
+	sameSite http.SameSite
+	sealer   *security.CookieSealer
+	csrfKey  []byte
+
+	accessMaxAge  int
+	refreshMaxAge int
+	stateMaxAge   int
+}
+
+func NewManager(_ *do.Injector) (domain.CookieManager, error) {
+	return New(&config.Env)
+}
+
+// New validates the cookie policy of cfg. Sessions are bound to the cookies
+// they were set with, so changing the prefixes or seal keys signs users out.
+func New(cfg *domain.Config) (*Manager, error) {
+	c := cfg.Cookie
+	m := &Manager{
+		cfg:           c,
+		secure:        cfg.Env == "production" || c.SecurePrefix || c.Partitioned,
+		accessMaxAge:  cfg.Token.AccessTokenExpiry * 60,
+		refreshMaxAge: cfg.Token.RefreshTokenExpiry * 60,
+		stateMaxAge:   int(cfg.Social.StateTTL.Seconds()),
+	}
+
+	switch strings.ToLower(c.SameSite) {
+	case "", "strict":
+		m.sameSite = http.SameSiteStrictMode
+	case "lax":
+		m.sameSite = http.SameSiteLaxMode
+	case "none":
+		// Browsers drop SameSite=None cookies that aren't Secure.
+		m.sameSite = http.SameSiteNoneMode
+		m.secure = true
+	default:
+		return nil, fmt.Errorf("invalid COOKIE_SAMESITE %q: must be strict, lax or none", c.SameSite)
+	}
+	if c.RefreshPath == "" {
+		m.cfg.RefreshPath = "/"
+	}
+	if !strings.HasPrefix(m.cfg.RefreshPath, "/") {
+		return nil, fmt.Errorf("invalid COOKIE_REFRESH_PATH %q: must start with /", c.RefreshPath)
+	}
+
+	sealer, err := security.NewCookieSealer(c)
+	if err != nil {
+		return nil, err
+	}
+	m.sealer = sealer
+
+	if m.csrfKey, err = csrfKey(cfg); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// csrfKey returns the HMAC key of the CSRF tokens. Production requires
+// HTTP_CSRF_KEY: a generated key differs between replicas and changes on
+// every restart, breaking the CSRF token of every session. Elsewhere one is
+// generated, with a warning.
+func csrfKey(cfg *domain.Config) ([]byte, error) {
+	key := cfg.HTTP.CSRFKey
+	switch {
+	case len(key) >= minCSRFKeySize:
+		return []byte(key), nil
+	case key != "":
+		return nil, fmt.Errorf("HTTP_CSRF_KEY must be at least %d bytes", minCSRFKeySize)
+	case cfg.Env == "production":
+		return nil, fmt.Errorf("HTTP_CSRF_KEY is required in production")
+	}
+
+	logging.Warn("HTTP_CSRF_KEY is not set, using a random key: CSRF tokens won't survive a restart or work across replicas")
+	generated := make([]byte, minCSRFKeySize)
+	_, _ = rand.Read(generated) // never fails, see crypto/rand.Read
+	return generated, nil
+}
+
+func (m *Manager) SetSession(c echo.Context, tokens *domain.AuthResponse) {
+	m.set(c, domain.CookieAccessToken, tokens.AccessToken, "/", m.accessMaxAge, m.sameSite)
+	m.set(c, domain.CookieRefreshToken, tokens.RefreshToken, m.cfg.RefreshPath, m.refreshMaxAge, m.sameSite)
+
+	csrfToken := m.CSRFToken(tokens.SessionID)
+	m.set(c, domain.CookieCSRFToken, csrfToken, "/", m.refreshMaxAge, m.sameSite)
+	c.Response().Header().Set(domain.CSRFHeader, csrfToken)
+}
+
+func (m *Manager) ClearSession(c echo.Context) {
+	m.set(c, domain.CookieAccessToken, "", "/", -1, m.sameSite)
+	m.set(c, domain.CookieRefreshToken, "", m.cfg.RefreshPath, -1, m.sameSite)
+	m.set(c, domain.CookieCSRFToken, "", "/", -1, m.sameSite)
+}
+
+func (m *Manager) SetSocialState(c echo.Context, state, path string) {
+	m.set(c, domain.CookieSocialState, state, path, m.stateMaxAge, http.SameSiteLaxMode)
+}
+
+func (m *Manager) ClearSocialState(c echo.Context, path string) {
+	m.set(c, domain.CookieSocialState, "", path, -1, http.SameSiteLaxMode)
+}
+
+func (m *Manager) Get(c echo.Context, name string) (string, bool) {
+	cookie, err := c.Cookie(m.name(name, m.onRoot(name)))
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	if m.sealer == nil || !sealed(name) {
+		return cookie.Value, true
+	}
+
+	value, err := m.sealer.Open(cookie.Value, name)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// CSRFToken is an HMAC of the session ID. It needs no storage and lasts as
+// long as the session, while a token planted by a sibling domain can't
+// match a session it doesn't know the key of.
+func (m *Manager) CSRFToken(sessionID string) string {
+	mac := hmac.New(sha256.New, m.csrfKey)
+	mac.Write([]byte(sessionID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (m *Manager) RefreshScoped() bool {
+	return m.cfg.RefreshPath != "/"
+}
+
+func (m *Manager) set(c echo.Context, name, value, path string, maxAge int, sameSite http.SameSite) {
+	if value != "" && m.sealer != nil && sealed(name) {
+		sealedValue, err := m.sealer.Seal(value, name)
+		if err != nil {
+			// Sealing only fails without randomness; better no cookie than
+			// a readable one.
+			logging.FromContext(c.Request().Context()).Error("failed to seal cookie", zap.String("cookie", name), zap.Error(err))
+			return
+		}
+		value = sealedValue
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:        m.name(name, path == "/"),
+		Value:       value,
+		Path:        path,
+		Domain:      m.cfg.Domain,
+		MaxAge:      maxAge,
+		Secure:      m.secure,
+		HttpOnly:    name != domain.CookieCSRFToken,
+		SameSite:    sameSite,
+		Partitioned: m.cfg.Partitioned,
+	})
+}
+
+// name returns the full name of the cookie named base, set on "/" when
+// root. Browsers only accept __Host- cookies on "/" without a Domain, so
+// the others get __Secure-.
+func (m *Manager) name(base string, root bool) string {
+	name := m.cfg.NamePrefix + base
+	switch {
+	case !m.cfg.SecurePrefix:
+		return name
+	case m.cfg.Domain == "" && root:
+		return hostPrefix + name
+	default:
+		return securePrefix + name
+	}
+}
+
+// onRoot reports whether the cookie named base is set on "/".
+func (m *Manager) onRoot(base string) bool {
+	switch base {
+	case domain.CookieRefreshToken:
+		return m.cfg.RefreshPath == "/"
+	case domain.CookieSocialState:
+		return false
+	default:
+		return true
+	}
+}
+
+// sealed reports whether the cookie named base is encrypted when seal keys
+// are configured. The CSRF cookie is not: scripts must read it.
+func sealed(base string) bool {
+	return base != domain.CookieCSRFToken
+}
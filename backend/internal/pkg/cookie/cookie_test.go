@@ -0,0 +1,219 @@
+package cookie
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/security"
+)
+
+func newManager(t *testing.T, env string, cfg domain.CookieConfig) *Manager {
+	t.Helper()
+	m, err := New(&domain.Config{
+		Env:    env,
+		HTTP:   domain.HTTPConfig{CSRFKey: "test-csrf-key-of-at-least-32-bytes"},
+		Token:  domain.TokenConfig{AccessTokenExpiry: 15, RefreshTokenExpiry: 60},
+		Social: domain.SocialConfig{StateTTL: 10 * time.Minute},
+		Cookie: cfg,
+	})
+	require.NoError(t, err)
+	return m
+}
+
+func newContext() (echo.Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	return echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/v1/auth/login", nil), rec), rec
+}
+
+func cookiesByName(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range rec.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return cookies
+}
+
+var tokens = &domain.AuthResponse{AccessToken: "at", RefreshToken: "rt", SessionID: "session-1"}
+
+func TestManagerSetSession(t *testing.T) {
+	t.Run("should keep the defaults of the API", func(t *testing.T) {
+		t.Parallel()
+
+		m := newManager(t, "development", domain.CookieConfig{})
+		c, rec := newContext()
+
+		m.SetSession(c, tokens)
+
+		cookies := cookiesByName(rec)
+		require.Len(t, cookies, 3)
+		for _, name := range []string{domain.CookieAccessToken, domain.CookieRefreshToken} {
+			assert.Equal(t, "/", cookies[name].Path, name)
+			assert.True(t, cookies[name].HttpOnly, name)
+			assert.False(t, cookies[name].Secure, name)
+			assert.Equal(t, http.SameSiteStrictMode, cookies[name].SameSite, name)
+		}
+		assert.Equal(t, "at", cookies[domain.CookieAccessToken].Value)
+		assert.Equal(t, 15*60, cookies[domain.CookieAccessToken].MaxAge)
+		assert.Equal(t, "rt", cookies[domain.CookieRefreshToken].Value)
+		assert.Equal(t, 60*60, cookies[domain.CookieRefreshToken].MaxAge)
+		assert.False(t, cookies[domain.CookieCSRFToken].HttpOnly)
+		assert.Equal(t, m.CSRFToken("session-1"), cookies[domain.CookieCSRFToken].Value)
+		assert.Equal(t, m.CSRFToken("session-1"), rec.Header().Get(domain.CSRFHeader))
+		assert.False(t, m.RefreshScoped())
+	})
+
+	t.Run("should apply the domain, prefixes, SameSite and partitioning", func(t *testing.T) {
+		t.Parallel()
+
+		m := newManager(t, "development", domain.CookieConfig{
+			NamePrefix:   "staging_",
+			SecurePrefix: true,
+			SameSite:     "none",
+			Partitioned:  true,
+			RefreshPath:  "/v1/auth/refresh",
+		})
+		c, rec := newContext()
+
+		m.SetSession(c, tokens)
+
+		cookies := cookiesByName(rec)
+		access := cookies["__Host-staging_access_token"]
+		refresh := cookies["__Secure-staging_refresh_token"]
+		require.NotNil(t, access)
+		require.NotNil(t, refresh)
+		require.NotNil(t, cookies["__Host-staging_csrf_token"])
+		assert.Equal(t, "/v1/auth/refresh", refresh.Path)
+		for _, cookie := range cookies {
+			assert.True(t, cookie.Secure, cookie.Name)
+			assert.True(t, cookie.Partitioned, cookie.Name)
+			assert.Equal(t, http.SameSiteNoneMode, cookie.SameSite, cookie.Name)
+			assert.Empty(t, cookie.Domain, cookie.Name)
+		}
+		assert.True(t, m.RefreshScoped())
+
+		m = newManager(t, "production", domain.CookieConfig{Domain: "example.com", SecurePrefix: true, SameSite: "lax"})
+		c, rec = newContext()
+
+		m.SetSession(c, tokens)
+
+		for _, cookie := range rec.Result().Cookies() {
+			assert.Contains(t, []string{"__Secure-access_token", "__Secure-refresh_token", "__Secure-csrf_token"}, cookie.Name)
+			assert.Equal(t, "example.com", cookie.Domain)
+			assert.True(t, cookie.Secure, cookie.Name)
+			assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite, cookie.Name)
+		}
+	})
+
+	t.Run("should clear the cookies on their own paths", func(t *testing.T) {
+		t.Parallel()
+
+		m := newManager(t, "development", domain.CookieConfig{RefreshPath: "/v1/auth/refresh"})
+		c, rec := newContext()
+
+		m.ClearSession(c)
+
+		cookies := cookiesByName(rec)
+		require.Len(t, cookies, 3)
+		for _, cookie := range cookies {
+			assert.Equal(t, -1, cookie.MaxAge, cookie.Name)
+			assert.Empty(t, cookie.Value, cookie.Name)
+		}
+		assert.Equal(t, "/v1/auth/refresh", cookies[domain.CookieRefreshToken].Path)
+	})
+}
+
+func TestManagerSocialState(t *testing.T) {
+	t.Parallel()
+
+	m := newManager(t, "development", domain.CookieConfig{SecurePrefix: true})
+	c, rec := newContext()
+
+	m.SetSocialState(c, "st4te", "/v1/auth/social/google/callback")
+
+	cookie := rec.Result().Cookies()[0]
+	assert.Equal(t, "__Secure-social_state", cookie.Name)
+	assert.Equal(t, "/v1/auth/social/google/callback", cookie.Path)
+	assert.Equal(t, 600, cookie.MaxAge)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/social/google/callback", nil)
+	req.AddCookie(cookie)
+	state, ok := m.Get(echo.New().NewContext(req, httptest.NewRecorder()), domain.CookieSocialState)
+	assert.True(t, ok)
+	assert.Equal(t, "st4te", state)
+}
+
+func TestManagerSealing(t *testing.T) {
+	key, err := security.GenerateKey("k1", security.EncryptionKeySize)
+	require.NoError(t, err)
+	m := newManager(t, "development", domain.CookieConfig{SealKeys: key})
+
+	get := func(name, value string) (string, bool) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+		return m.Get(echo.New().NewContext(req, httptest.NewRecorder()), name)
+	}
+
+	t.Run("should seal the HttpOnly cookies and open them back", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newContext()
+		m.SetSession(c, tokens)
+
+		cookies := cookiesByName(rec)
+		access := cookies[domain.CookieAccessToken].Value
+		assert.NotEqual(t, "at", access)
+		assert.Contains(t, access, "k1.")
+		assert.Equal(t, m.CSRFToken("session-1"), cookies[domain.CookieCSRFToken].Value)
+
+		value, ok := get(domain.CookieAccessToken, access)
+		assert.True(t, ok)
+		assert.Equal(t, "at", value)
+	})
+
+	t.Run("should treat tampered, moved and unsealed values as missing", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newContext()
+		m.SetSession(c, tokens)
+		access := cookiesByName(rec)[domain.CookieAccessToken].Value
+
+		for name, value := range map[string]string{
+			"tampered": access[:len(access)-2] + "AA",
+			"unsealed": "at",
+		} {
+			_, ok := get(domain.CookieAccessToken, value)
+			assert.False(t, ok, name)
+		}
+		_, ok := get(domain.CookieRefreshToken, access)
+		assert.False(t, ok, "moved")
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	for name, cfg := range map[string]domain.CookieConfig{
+		"unknown SameSite":      {SameSite: "always"},
+		"relative refresh path": {RefreshPath: "v1/auth/refresh"},
+		"invalid seal key":      {SealKeys: "k1:c2hvcnQ="},
+	} {
+		_, err := New(&domain.Config{Cookie: cfg})
+		assert.Error(t, err, name)
+	}
+
+	for name, cfg := range map[string]*domain.Config{
+		"short CSRF key":                 {HTTP: domain.HTTPConfig{CSRFKey: "short"}},
+		"missing CSRF key in production": {Env: "production"},
+	} {
+		_, err := New(cfg)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,88 @@
+package error
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/i18n"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/requestid"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+// HTTPErrorHandler renders any error returned by a handler or middleware as
+// an application/problem+json response resolved through Errors, so handlers
+// only need to return the domain error.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	ctx := c.Request().Context()
+	locale := i18n.FromContext(ctx)
+	entry, _ := Errors.Lookup(err)
+
+	problem := entry.Problem().
+		WithInstance(c.Request().URL.Path).
+		WithTraceID(tracing.TraceID(ctx)).
+		WithRequestID(requestid.FromContext(ctx))
+
+	if text, ok := i18n.Problem(locale, entry.Scope+"/"+entry.Code); ok {
+		problem = problem.WithTitle(text.Title)
+		if text.Detail != "" {
+			problem = problem.WithDetail(text.Detail)
+		}
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		problem = problem.AddFieldErrors(NewProblemDetailsFromStructValidation(validationErr.Errors, locale)).
+			AddFieldErrors(validationErr.Fields)
+	}
+
+	var policyErr *domain.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		problem = problem.AddFieldErrors(NewProblemDetailsFromPasswordPolicy(policyErr, locale))
+	}
+
+	var challengeErr *domain.PasswordChangeRequiredError
+	if errors.As(err, &challengeErr) {
+		problem = problem.WithChallengeToken(challengeErr.ChallengeToken)
+	}
+
+	var busyErr *domain.ServerBusyError
+	if errors.As(err, &busyErr) {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(busyErr.RetryAfter())))
+	}
+
+	logger := logging.WithContext(ctx,
+		zap.String("problem", problem.Type),
+		zap.Int("status", problem.Status),
+	)
+	if problem.Status >= http.StatusInternalServerError {
+		logger.Error("request failed", zap.Error(err))
+	} else {
+		logger.Info("request rejected", zap.Error(err))
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(problem.Status)
+	} else {
+		err = problem.ServeJSON(c.Response(), c.Request())
+	}
+	if err != nil {
+		logger.Error("failed to write problem response", zap.Error(err))
+	}
+}
+
+// retryAfterSeconds rounds d up to whole seconds, at least one, as
+// Retry-After takes no fractions.
+func retryAfterSeconds(d time.Duration) int {
+	return max(1, int((d+time.Second-1)/time.Second))
+}
@@ -6,12 +6,16 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/goccy/go-json"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/i18n"
+	validatorpkg "github.com/SergioLNeves/migos/internal/pkg/validator"
 )
 
 // ProblemDetailsFieldError represents a field validation error
 type ProblemDetailsFieldError struct {
-	Field   string `json:"field" example:"username"`
-	Message string `json:"message" example:"validation failed for 'required' tag"`
+	Field   string `json:"field" example:"email"`
+	Message string `json:"message" example:"email is a required field"`
 }
 
 // NewProblemDetailsFieldError creates a new ProblemDetailsFieldError with the specified field and message.
@@ -22,11 +26,24 @@ func NewProblemDetailsFieldError(field, message string) ProblemDetailsFieldError
 	}
 }
 
-// NewProblemDetailsFromStructValidation converts validator.ValidationErrors to ProblemDetailsFieldError slice.
-func NewProblemDetailsFromStructValidation(ve validator.ValidationErrors) []ProblemDetailsFieldError {
+// NewProblemDetailsFromStructValidation converts validator.ValidationErrors to ProblemDetailsFieldError slice,
+// using the request field names and messages translated to locale.
+func NewProblemDetailsFromStructValidation(ve validator.ValidationErrors, locale string) []ProblemDetailsFieldError {
+	trans := validatorpkg.Translator(locale)
+
 	var fieldErrors []ProblemDetailsFieldError
 	for _, fieldError := range ve {
-		fieldErrors = append(fieldErrors, NewProblemDetailsFieldError(fieldError.StructField(), fmt.Sprintf("validation failed for '%s' tag", fieldError.Tag())))
+		fieldErrors = append(fieldErrors, NewProblemDetailsFieldError(fieldError.Field(), fieldError.Translate(trans)))
+	}
+	return fieldErrors
+}
+
+// NewProblemDetailsFromPasswordPolicy converts the violations of a password policy
+// error to field errors on its field, with messages translated to locale.
+func NewProblemDetailsFromPasswordPolicy(pe *domain.PasswordPolicyError, locale string) []ProblemDetailsFieldError {
+	var fieldErrors []ProblemDetailsFieldError
+	for _, v := range pe.Violations {
+		fieldErrors = append(fieldErrors, NewProblemDetailsFieldError(pe.Field, i18n.PasswordRule(locale, pe.Field, v.Rule, v.Limit)))
 	}
 	return fieldErrors
 }
@@ -41,6 +58,12 @@ type ProblemDetails struct {
 	FieldErrors []ProblemDetailsFieldError `json:"errors,omitempty"`
 	Limit       int                        `json:"limit,omitempty" example:"10"`
 	Code        int                        `json:"code,omitempty" example:"1001"`
+	TraceID     string                     `json:"trace_id,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	RequestID   string                     `json:"request_id,omitempty" example:"5f1c1d2e-8a4b-4c1e-9a57-1f0b8f6b2c3d"`
+	OAuthError  string                     `json:"error,omitempty" example:"invalid_grant"`
+	// ChallengeToken is set on password-change-required problems and is
+	// redeemed with a new password at /v1/auth/password-change.
+	ChallengeToken string `json:"challenge_token,omitempty" example:"q3Zk0m9xR2c8YvJ5tB7nWg"`
 }
 
 // NewProblemDetails creates a new ProblemDetails with default type "about:blank".
@@ -80,6 +103,30 @@ func (p ProblemDetails) WithInstance(instance string) ProblemDetails {
 	return p
 }
 
+// WithTraceID sets the trace_id extension member so clients can quote it when reporting errors.
+func (p ProblemDetails) WithTraceID(traceID string) ProblemDetails {
+	p.TraceID = traceID
+	return p
+}
+
+// WithRequestID sets the request_id extension member, matching the X-Request-ID response header.
+func (p ProblemDetails) WithRequestID(requestID string) ProblemDetails {
+	p.RequestID = requestID
+	return p
+}
+
+// WithOAuthError sets the error extension member to an RFC 6749 error code.
+func (p ProblemDetails) WithOAuthError(code string) ProblemDetails {
+	p.OAuthError = code
+	return p
+}
+
+// WithChallengeToken sets the challenge_token extension member.
+func (p ProblemDetails) WithChallengeToken(token string) ProblemDetails {
+	p.ChallengeToken = token
+	return p
+}
+
 // AddFieldErrors appends multiple field errors to the ProblemDetails.
 func (p ProblemDetails) AddFieldErrors(errs []ProblemDetailsFieldError) ProblemDetails {
 	p.FieldErrors = append(p.FieldErrors, errs...)
@@ -0,0 +1,160 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+var (
+	ErrInvalidRequest = fmt.Errorf("Error Invalid Request")
+	ErrValidation     = fmt.Errorf("Error Validation Failed")
+
+	ErrUnsupportedMediaType = fmt.Errorf("Error Unsupported Media Type")
+	ErrOriginNotAllowed     = fmt.Errorf("Error Origin Not Allowed")
+)
+
+// ValidationError carries the validator output of a request that failed
+// validation, so messages can be translated when the response is rendered.
+// Fields holds violations that were already rendered elsewhere, such as by
+// the OpenAPI request validator. It matches ErrValidation under errors.Is.
+type ValidationError struct {
+	Errors validator.ValidationErrors
+	Fields []ProblemDetailsFieldError
+}
+
+func (e *ValidationError) Error() string { return ErrValidation.Error() }
+
+func (e *ValidationError) Unwrap() error { return ErrValidation }
+
+// Entry maps a sentinel error to the problem it is rendered as.
+type Entry struct {
+	Err    error
+	Scope  string
+	Code   string
+	Title  string
+	Status int
+	Detail string
+	// OAuthError is the RFC 6749 error code of OAuth errors, sent as the
+	// "error" member so OAuth client libraries can read it.
+	OAuthError string
+}
+
+// Problem builds the ProblemDetails for the entry, without request-scoped members.
+func (e Entry) Problem() ProblemDetails {
+	return NewProblemDetails().
+		WithType(e.Scope, e.Code).
+		WithTitle(e.Title).
+		WithStatus(e.Status).
+		WithDetail(e.Detail).
+		WithOAuthError(e.OAuthError)
+}
+
+// Registry resolves errors to entries in registration order, falling back to
+// a generic internal error for anything unregistered.
+type Registry struct {
+	entries  []Entry
+	fallback Entry
+}
+
+func NewRegistry(fallback Entry, entries ...Entry) *Registry {
+	return &Registry{entries: entries, fallback: fallback}
+}
+
+// Lookup returns the first entry whose Err matches err under errors.Is, and
+// whether one was found.
+func (r *Registry) Lookup(err error) (Entry, bool) {
+	for _, entry := range r.entries {
+		if errors.Is(err, entry.Err) {
+			return entry, true
+		}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpEntry(httpErr.Code, httpErr.Message), true
+	}
+
+	return r.fallback, false
+}
+
+// Entries returns every registered entry followed by the fallback, in the
+// order used for documentation.
+func (r *Registry) Entries() []Entry {
+	return append(append([]Entry{}, r.entries...), r.fallback)
+}
+
+// Fallback returns the entry used for unregistered errors.
+func (r *Registry) Fallback() Entry {
+	return r.fallback
+}
+
+// httpEntry describes errors raised by Echo itself, such as unknown routes.
+func httpEntry(status int, message any) Entry {
+	title := http.StatusText(status)
+	detail, ok := message.(string)
+	if !ok || detail == title {
+		detail = ""
+	}
+	return Entry{
+		Scope:  "http",
+		Code:   strings.ReplaceAll(strings.ToLower(title), " ", "-"),
+		Title:  title,
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Errors is the registry of every error the API reports to clients.
+var Errors = NewRegistry(
+	Entry{Scope: "server", Code: "internal-error", Title: "Internal Server Error", Status: http.StatusInternalServerError, Detail: "An unexpected error occurred"},
+	Entry{Err: domain.ErrServerBusy, Scope: "server", Code: "busy", Title: "Server Busy", Status: http.StatusServiceUnavailable, Detail: "The server is handling too many requests, try again later"},
+	Entry{Err: ErrInvalidRequest, Scope: "request", Code: "invalid-request", Title: "Invalid Request", Status: http.StatusBadRequest, Detail: "Failed to parse request body"},
+	Entry{Err: ErrValidation, Scope: "request", Code: "validation-error", Title: "Validation Failed", Status: http.StatusBadRequest, Detail: "One or more fields failed validation"},
+	Entry{Err: ErrUnsupportedMediaType, Scope: "request", Code: "unsupported-media-type", Title: "Unsupported Media Type", Status: http.StatusUnsupportedMediaType, Detail: "Send the request body as application/json or application/x-www-form-urlencoded"},
+	Entry{Err: ErrOriginNotAllowed, Scope: "request", Code: "origin-not-allowed", Title: "Origin Not Allowed", Status: http.StatusForbidden, Detail: "Requests from this origin are not allowed to change state"},
+	Entry{Err: domain.ErrUnauthorized, Scope: "auth", Code: "unauthorized", Title: "Unauthorized", Status: http.StatusUnauthorized, Detail: "Authentication required"},
+	Entry{Err: domain.ErrInsufficientScope, Scope: "auth", Code: "insufficient-scope", Title: "Insufficient Scope", Status: http.StatusForbidden, Detail: "The credentials used do not grant the scope this request needs", OAuthError: "insufficient_scope"},
+	Entry{Err: domain.ErrInvalidCSRFToken, Scope: "auth", Code: "invalid-csrf-token", Title: "Invalid CSRF Token", Status: http.StatusForbidden, Detail: "Send the csrf_token cookie value in the X-CSRF-Token header"},
+	Entry{Err: domain.ErrSessionRequired, Scope: "auth", Code: "session-required", Title: "Session Required", Status: http.StatusForbidden, Detail: "This operation requires signing in and can't be performed with an API key"},
+	Entry{Err: domain.ErrSessionNotFound, Scope: "session", Code: "not-found", Title: "Session Not Found", Status: http.StatusNotFound, Detail: "No session with this ID belongs to you"},
+	Entry{Err: domain.ErrInvalidRefreshToken, Scope: "auth", Code: "invalid-refresh-token", Title: "Invalid Refresh Token", Status: http.StatusUnauthorized, Detail: "The refresh token is invalid, expired or revoked"},
+	Entry{Err: domain.ErrInvalidCredentials, Scope: "auth", Code: "invalid-credentials", Title: "Invalid Credentials", Status: http.StatusUnauthorized, Detail: "Invalid email or password"},
+	Entry{Err: domain.ErrUserDeactivated, Scope: "auth", Code: "user-deactivated", Title: "Account Deactivated", Status: http.StatusForbidden, Detail: "Your account has been deactivated"},
+	Entry{Err: domain.ErrPasswordExpired, Scope: "auth", Code: "password-expired", Title: "Password Expired", Status: http.StatusForbidden, Detail: "Your password has expired and must be reset"},
+	Entry{Err: domain.ErrPasswordChangeRequired, Scope: "auth", Code: "password-change-required", Title: "Password Change Required", Status: http.StatusForbidden, Detail: "Your password is older than the maximum age and must be changed"},
+	Entry{Err: domain.ErrInvalidPasswordChallenge, Scope: "auth", Code: "invalid-password-challenge", Title: "Invalid Password Challenge", Status: http.StatusUnauthorized, Detail: "The password change challenge is invalid or expired"},
+	Entry{Err: domain.ErrUserNotDeactivated, Scope: "auth", Code: "user-not-deactivated", Title: "Account Not Deactivated", Status: http.StatusBadRequest, Detail: "This account is not deactivated"},
+	Entry{Err: domain.ErrEmailAlreadyExists, Scope: "user", Code: "email-already-exists", Title: "Email Already Registered", Status: http.StatusConflict, Detail: "An account with this email already exists"},
+	Entry{Err: domain.ErrWeakPassword, Scope: "user", Code: "weak-password", Title: "Weak Password", Status: http.StatusBadRequest, Detail: "The password does not meet the password policy"},
+	Entry{Err: domain.ErrInvalidCurrentPassword, Scope: "user", Code: "invalid-current-password", Title: "Invalid Current Password", Status: http.StatusUnauthorized, Detail: "The current password provided is incorrect"},
+	Entry{Err: domain.ErrUserNotFound, Scope: "user", Code: "not-found", Title: "User Not Found", Status: http.StatusNotFound, Detail: "The user does not exist"},
+	Entry{Err: domain.ErrInvalidRole, Scope: "user", Code: "invalid-role", Title: "Invalid Role", Status: http.StatusBadRequest, Detail: "The role is not recognised"},
+	Entry{Err: domain.ErrInvalidClient, Scope: "oauth", Code: "invalid-client", Title: "Invalid Client", Status: http.StatusUnauthorized, Detail: "Client authentication failed", OAuthError: "invalid_client"},
+	Entry{Err: domain.ErrUnauthorizedClient, Scope: "oauth", Code: "unauthorized-client", Title: "Unauthorized Client", Status: http.StatusForbidden, Detail: "The token was not issued to the client", OAuthError: "unauthorized_client"},
+	Entry{Err: domain.ErrInvalidRedirectURI, Scope: "oauth", Code: "invalid-redirect-uri", Title: "Invalid Redirect URI", Status: http.StatusBadRequest, Detail: "The redirect URI is not registered for the client", OAuthError: "invalid_request"},
+	Entry{Err: domain.ErrInvalidAuthorizeRequest, Scope: "oauth", Code: "invalid-request", Title: "Invalid Authorization Request", Status: http.StatusBadRequest, Detail: "The authorization request is missing or has invalid parameters", OAuthError: "invalid_request"},
+	Entry{Err: domain.ErrUnsupportedResponseType, Scope: "oauth", Code: "unsupported-response-type", Title: "Unsupported Response Type", Status: http.StatusBadRequest, Detail: "Only the code response type is supported", OAuthError: "unsupported_response_type"},
+	Entry{Err: domain.ErrInvalidScope, Scope: "oauth", Code: "invalid-scope", Title: "Invalid Scope", Status: http.StatusBadRequest, Detail: "The requested scope is unknown, lacks openid or is not granted to the client", OAuthError: "invalid_scope"},
+	Entry{Err: domain.ErrAccessDenied, Scope: "oauth", Code: "access-denied", Title: "Access Denied", Status: http.StatusForbidden, Detail: "The user denied the authorization request", OAuthError: "access_denied"},
+	Entry{Err: domain.ErrInvalidGrant, Scope: "oauth", Code: "invalid-grant", Title: "Invalid Grant", Status: http.StatusBadRequest, Detail: "The authorization code, refresh token or device code is invalid, expired or was issued to another client", OAuthError: "invalid_grant"},
+	Entry{Err: domain.ErrUnsupportedGrantType, Scope: "oauth", Code: "unsupported-grant-type", Title: "Unsupported Grant Type", Status: http.StatusBadRequest, Detail: "The grant type is not supported", OAuthError: "unsupported_grant_type"},
+	Entry{Err: domain.ErrAuthorizationPending, Scope: "oauth", Code: "authorization-pending", Title: "Authorization Pending", Status: http.StatusBadRequest, Detail: "The user has not answered the device request yet; keep polling", OAuthError: "authorization_pending"},
+	Entry{Err: domain.ErrSlowDown, Scope: "oauth", Code: "slow-down", Title: "Slow Down", Status: http.StatusBadRequest, Detail: "The device is polling too fast; the interval was increased by 5 seconds", OAuthError: "slow_down"},
+	Entry{Err: domain.ErrExpiredToken, Scope: "oauth", Code: "expired-token", Title: "Expired Device Code", Status: http.StatusBadRequest, Detail: "The device code has expired; start the device flow again", OAuthError: "expired_token"},
+	Entry{Err: domain.ErrInvalidUserCode, Scope: "oauth", Code: "invalid-user-code", Title: "Invalid User Code", Status: http.StatusBadRequest, Detail: "The user code is invalid, expired or was already used"},
+	Entry{Err: domain.ErrSocialProviderNotFound, Scope: "social", Code: "provider-not-found", Title: "Provider Not Found", Status: http.StatusNotFound, Detail: "No identity provider is configured with this name"},
+	Entry{Err: domain.ErrSocialLoginStateInvalid, Scope: "social", Code: "invalid-state", Title: "Invalid Login State", Status: http.StatusBadRequest, Detail: "The sign in was not started by this browser or has expired; start it again"},
+	Entry{Err: domain.ErrSocialLoginFailed, Scope: "social", Code: "login-failed", Title: "Sign In Failed", Status: http.StatusUnauthorized, Detail: "The identity provider did not confirm who you are"},
+	Entry{Err: domain.ErrAPIKeyNotFound, Scope: "api-key", Code: "not-found", Title: "API Key Not Found", Status: http.StatusNotFound, Detail: "No API key with this ID belongs to you"},
+	Entry{Err: domain.ErrAPIKeyNameConflict, Scope: "api-key", Code: "name-conflict", Title: "API Key Name In Use", Status: http.StatusConflict, Detail: "You already have an API key with this name"},
+	Entry{Err: domain.ErrServiceAccountNotFound, Scope: "service-account", Code: "not-found", Title: "Service Account Not Found", Status: http.StatusNotFound, Detail: "No service account has this ID"},
+	Entry{Err: domain.ErrServiceAccountSecretNotFound, Scope: "service-account", Code: "secret-not-found", Title: "Service Account Secret Not Found", Status: http.StatusNotFound, Detail: "No secret with this ID belongs to the service account"},
+	Entry{Err: domain.ErrSocialEmailNotVerified, Scope: "social", Code: "email-not-verified", Title: "Email Not Verified", Status: http.StatusForbidden, Detail: "The identity provider did not share a verified email for your account"},
+)
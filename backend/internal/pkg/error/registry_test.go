@@ -0,0 +1,190 @@
+package error
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/i18n"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/requestid"
+	validatorpkg "github.com/SergioLNeves/migos/internal/pkg/validator"
+)
+
+func TestMain(m *testing.M) {
+	logging.NewLogger(&domain.Config{Env: "development", LogLevel: "error"})
+	os.Exit(m.Run())
+}
+
+func TestRegistryLookup(t *testing.T) {
+	t.Run("should match wrapped domain errors", func(t *testing.T) {
+		t.Parallel()
+
+		entry, ok := Errors.Lookup(fmt.Errorf("failed to login: %w", domain.ErrInvalidCredentials))
+
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnauthorized, entry.Status)
+		assert.Equal(t, "urn:auth-session-api/auth/invalid-credentials", entry.Problem().Type)
+	})
+
+	t.Run("should map echo HTTP errors by status", func(t *testing.T) {
+		t.Parallel()
+
+		entry, ok := Errors.Lookup(echo.ErrNotFound)
+
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, entry.Status)
+		assert.Equal(t, "urn:auth-session-api/http/not-found", entry.Problem().Type)
+	})
+
+	t.Run("should fall back to internal error for unregistered errors", func(t *testing.T) {
+		t.Parallel()
+
+		entry, ok := Errors.Lookup(fmt.Errorf("disk full"))
+
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, entry.Status)
+		assert.Equal(t, "An unexpected error occurred", entry.Detail)
+	})
+
+	t.Run("should list every entry with the fallback last", func(t *testing.T) {
+		t.Parallel()
+
+		entries := Errors.Entries()
+
+		assert.Equal(t, "internal-error", entries[len(entries)-1].Code)
+		for _, entry := range entries[:len(entries)-1] {
+			assert.NotNil(t, entry.Err, entry.Code)
+		}
+	})
+}
+
+func validationErrors(t *testing.T, req any) validator.ValidationErrors {
+	t.Helper()
+	var ve validator.ValidationErrors
+	assert.True(t, errors.As(validatorpkg.NewValidator().Validate(req), &ve))
+	return ve
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	newContext := func(method string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(method, "/v1/auth/login", nil)
+		req = req.WithContext(requestid.NewContext(req.Context(), "req-1"))
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("should render registered errors as problem+json", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newContext(http.MethodPost)
+
+		HTTPErrorHandler(domain.ErrUserDeactivated, c)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
+
+		var problem ProblemDetails
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "urn:auth-session-api/auth/user-deactivated", problem.Type)
+		assert.Equal(t, "/v1/auth/login", problem.Instance)
+		assert.Equal(t, "req-1", problem.RequestID)
+	})
+
+	t.Run("should include field errors for validation failures", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newContext(http.MethodPost)
+
+		HTTPErrorHandler(&ValidationError{Errors: validationErrors(t, domain.LoginRequest{})}, c)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var problem ProblemDetails
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "urn:auth-session-api/request/validation-error", problem.Type)
+		assert.Equal(t, []ProblemDetailsFieldError{
+			{Field: "email", Message: "email is a required field"},
+			{Field: "password", Message: "password is a required field"},
+		}, problem.FieldErrors)
+	})
+
+	t.Run("should translate title, detail and field errors to the request locale", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newContext(http.MethodPost)
+		c.SetRequest(c.Request().WithContext(i18n.NewContext(c.Request().Context(), i18n.PtBR)))
+
+		HTTPErrorHandler(&ValidationError{Errors: validationErrors(t, domain.LoginRequest{Password: "x"})}, c)
+
+		var problem ProblemDetails
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "Falha na Validação", problem.Title)
+		assert.Equal(t, "Um ou mais campos são inválidos", problem.Detail)
+		assert.Equal(t, []ProblemDetailsFieldError{
+			{Field: "email", Message: "email é um campo obrigatório"},
+		}, problem.FieldErrors)
+	})
+
+	t.Run("should translate registered domain errors to spanish", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newContext(http.MethodPost)
+		c.SetRequest(c.Request().WithContext(i18n.NewContext(c.Request().Context(), i18n.ES)))
+
+		HTTPErrorHandler(domain.ErrInvalidCredentials, c)
+
+		var problem ProblemDetails
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "Credenciales Inválidas", problem.Title)
+		assert.Equal(t, "urn:auth-session-api/auth/invalid-credentials", problem.Type)
+	})
+
+	t.Run("should send Retry-After in whole seconds for retryable errors", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newContext(http.MethodPost)
+
+		HTTPErrorHandler(fmt.Errorf("failed to hash password: %w", &domain.ServerBusyError{After: 1500 * time.Millisecond}), c)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+
+		var problem ProblemDetails
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "urn:auth-session-api/server/busy", problem.Type)
+	})
+
+	t.Run("should not write a body for HEAD requests", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newContext(http.MethodHead)
+
+		HTTPErrorHandler(echo.ErrNotFound, c)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("should leave committed responses untouched", func(t *testing.T) {
+		t.Parallel()
+
+		c, rec := newContext(http.MethodPost)
+		assert.NoError(t, c.NoContent(http.StatusNoContent))
+
+		HTTPErrorHandler(fmt.Errorf("late failure"), c)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+}
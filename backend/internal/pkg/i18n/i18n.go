@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+)
+
+// Locales understood by the API. Names follow go-playground/locales so they
+// can be passed straight to the validator's translators.
+const (
+	EN   = "en"
+	PtBR = "pt_BR"
+	ES   = "es"
+)
+
+// Default is used when Accept-Language is missing or matches nothing we support.
+const Default = EN
+
+var (
+	locales = []string{EN, PtBR, ES}
+	matcher = language.NewMatcher([]language.Tag{
+		language.English,
+		language.BrazilianPortuguese,
+		language.Spanish,
+	})
+)
+
+// Match picks the best supported locale for an Accept-Language header value,
+// honouring q-values; any Portuguese variant maps to pt_BR and any Spanish one to es.
+func Match(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return Default
+	}
+
+	_, idx, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Default
+	}
+	return locales[idx]
+}
+
+// Tag returns the BCP 47 form of a locale, for the Content-Language header.
+func Tag(locale string) string {
+	switch locale {
+	case PtBR:
+		return "pt-BR"
+	case ES:
+		return "es"
+	default:
+		return "en"
+	}
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the negotiated locale.
+func NewContext(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext returns the locale stored in ctx, or Default.
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(contextKey{}).(string); ok {
+		return locale
+	}
+	return Default
+}
@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"should default to english when header is empty", "", EN},
+		{"should match brazilian portuguese", "pt-BR", PtBR},
+		{"should map other portuguese variants to pt_BR", "pt-PT,pt;q=0.9", PtBR},
+		{"should match spanish regional variants", "es-MX", ES},
+		{"should honour q-values", "en;q=0.5, es;q=0.9", ES},
+		{"should default to english for unsupported languages", "fr-FR, de;q=0.8", EN},
+		{"should default to english for malformed headers", "!!!", EN},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, Match(tc.header))
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("should return the stored locale or the default", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, Default, FromContext(context.Background()))
+		assert.Equal(t, ES, FromContext(NewContext(context.Background(), ES)))
+	})
+}
@@ -0,0 +1,55 @@
+package i18n
+
+import "fmt"
+
+// passwordRules holds the field error messages of the password policy rules
+// by locale and then by rule, formatted with the field name and the rule's
+// limit.
+var passwordRules = map[string]map[string]string{
+	EN: {
+		"min_length":    "%[1]s must be at least %[2]d characters long",
+		"max_length":    "%[1]s must be at most %[2]d characters long",
+		"lowercase":     "%[1]s must contain a lowercase letter",
+		"uppercase":     "%[1]s must contain an uppercase letter",
+		"digit":         "%[1]s must contain a digit",
+		"symbol":        "%[1]s must contain a symbol",
+		"personal_info": "%[1]s must not contain your email or name",
+		"breached":      "%[1]s has appeared in a data breach and can't be used",
+		"reused":        "%[1]s must differ from your last %[2]d passwords",
+	},
+	PtBR: {
+		"min_length":    "%[1]s deve ter pelo menos %[2]d caracteres",
+		"max_length":    "%[1]s deve ter no máximo %[2]d caracteres",
+		"lowercase":     "%[1]s deve conter uma letra minúscula",
+		"uppercase":     "%[1]s deve conter uma letra maiúscula",
+		"digit":         "%[1]s deve conter um dígito",
+		"symbol":        "%[1]s deve conter um símbolo",
+		"personal_info": "%[1]s não pode conter seu email ou nome",
+		"breached":      "%[1]s apareceu em um vazamento de dados e não pode ser usada",
+		"reused":        "%[1]s deve ser diferente das suas últimas %[2]d senhas",
+	},
+	ES: {
+		"min_length":    "%[1]s debe tener al menos %[2]d caracteres",
+		"max_length":    "%[1]s debe tener como máximo %[2]d caracteres",
+		"lowercase":     "%[1]s debe contener una letra minúscula",
+		"uppercase":     "%[1]s debe contener una letra mayúscula",
+		"digit":         "%[1]s debe contener un dígito",
+		"symbol":        "%[1]s debe contener un símbolo",
+		"personal_info": "%[1]s no puede contener tu correo electrónico o nombre",
+		"breached":      "%[1]s apareció en una filtración de datos y no puede usarse",
+		"reused":        "%[1]s debe ser distinta de tus últimas %[2]d contraseñas",
+	},
+}
+
+// PasswordRule returns the message of a broken password policy rule on
+// field, in locale or else in English.
+func PasswordRule(locale, field, rule string, limit int) string {
+	format, ok := passwordRules[locale][rule]
+	if !ok {
+		format, ok = passwordRules[EN][rule]
+	}
+	if !ok {
+		return fmt.Sprintf("%s breaks the password policy rule %s", field, rule)
+	}
+	return fmt.Sprintf(format, field, limit)
+}
@@ -0,0 +1,118 @@
+package i18n
+
+// Text is the translated title and detail of a problem type.
+type Text struct {
+	Title  string
+	Detail string
+}
+
+// problems holds translations keyed by locale and then by "scope/code" of the
+// entry in errorpkg.Errors. English is the registry itself.
+var problems = map[string]map[string]Text{
+	PtBR: {
+		"server/internal-error":            {"Erro Interno do Servidor", "Ocorreu um erro inesperado"},
+		"server/busy":                      {"Servidor Ocupado", "O servidor está atendendo requisições demais, tente novamente mais tarde"},
+		"request/invalid-request":          {"Requisição Inválida", "Não foi possível interpretar o corpo da requisição"},
+		"request/validation-error":         {"Falha na Validação", "Um ou mais campos são inválidos"},
+		"request/unsupported-media-type":   {"Tipo de Mídia Não Suportado", "Envie o corpo da requisição como application/json ou application/x-www-form-urlencoded"},
+		"request/origin-not-allowed":       {"Origem Não Permitida", "Requisições desta origem não podem alterar o estado"},
+		"auth/unauthorized":                {"Não Autorizado", "Autenticação necessária"},
+		"auth/insufficient-scope":          {"Escopo Insuficiente", "As credenciais usadas não concedem o escopo que esta requisição exige"},
+		"auth/invalid-csrf-token":          {"Token CSRF Inválido", "Envie o valor do cookie csrf_token no cabeçalho X-CSRF-Token"},
+		"auth/session-required":            {"Sessão Necessária", "Esta operação exige login e não pode ser feita com uma chave de API"},
+		"api-key/not-found":                {"Chave de API Não Encontrada", "Nenhuma chave de API com este ID pertence a você"},
+		"api-key/name-conflict":            {"Nome de Chave de API em Uso", "Você já tem uma chave de API com este nome"},
+		"service-account/not-found":        {"Conta de Serviço Não Encontrada", "Nenhuma conta de serviço tem este ID"},
+		"service-account/secret-not-found": {"Segredo da Conta de Serviço Não Encontrado", "Nenhum segredo com este ID pertence à conta de serviço"},
+		"auth/invalid-refresh-token":       {"Refresh Token Inválido", "O refresh token é inválido, expirou ou foi revogado"},
+		"auth/invalid-credentials":         {"Credenciais Inválidas", "Email ou senha inválidos"},
+		"auth/user-deactivated":            {"Conta Desativada", "Sua conta foi desativada"},
+		"auth/password-expired":            {"Senha Expirada", "Sua senha expirou e precisa ser redefinida"},
+		"auth/password-change-required":    {"Troca de Senha Obrigatória", "Sua senha é mais antiga que a idade máxima e precisa ser trocada"},
+		"auth/invalid-password-challenge":  {"Desafio de Troca de Senha Inválido", "O desafio de troca de senha é inválido ou expirou"},
+		"auth/user-not-deactivated":        {"Conta Não Desativada", "Esta conta não está desativada"},
+		"user/email-already-exists":        {"Email Já Cadastrado", "Já existe uma conta com este email"},
+		"user/weak-password":               {"Senha Fraca", "A senha não atende à política de senhas"},
+		"user/invalid-current-password":    {"Senha Atual Inválida", "A senha atual informada está incorreta"},
+		"user/not-found":                   {"Usuário Não Encontrado", "O usuário não existe"},
+		"user/invalid-role":                {"Papel Inválido", "O papel informado não é reconhecido"},
+		"oauth/invalid-client":             {"Cliente Inválido", "Falha na autenticação do cliente"},
+		"oauth/unauthorized-client":        {"Cliente Não Autorizado", "O token não foi emitido para o cliente"},
+		"oauth/invalid-redirect-uri":       {"URI de Redirecionamento Inválida", "A URI de redirecionamento não está registrada para o cliente"},
+		"oauth/invalid-request":            {"Requisição de Autorização Inválida", "A requisição de autorização tem parâmetros ausentes ou inválidos"},
+		"oauth/unsupported-response-type":  {"Tipo de Resposta Não Suportado", "Apenas o tipo de resposta code é suportado"},
+		"oauth/invalid-scope":              {"Escopo Inválido", "O escopo solicitado é desconhecido, não inclui openid ou não foi concedido ao cliente"},
+		"oauth/access-denied":              {"Acesso Negado", "O usuário recusou a autorização"},
+		"oauth/invalid-grant":              {"Concessão Inválida", "O código de autorização, refresh token ou código de dispositivo é inválido, expirou ou foi emitido para outro cliente"},
+		"oauth/unsupported-grant-type":     {"Tipo de Concessão Não Suportado", "O tipo de concessão não é suportado"},
+		"oauth/authorization-pending":      {"Autorização Pendente", "O usuário ainda não respondeu à solicitação do dispositivo; continue consultando"},
+		"oauth/slow-down":                  {"Reduza a Frequência", "O dispositivo está consultando rápido demais; o intervalo foi aumentado em 5 segundos"},
+		"oauth/expired-token":              {"Código de Dispositivo Expirado", "O código do dispositivo expirou; inicie o fluxo de dispositivo novamente"},
+		"oauth/invalid-user-code":          {"Código de Usuário Inválido", "O código de usuário é inválido, expirou ou já foi usado"},
+		"session/not-found":                {"Sessão Não Encontrada", "Nenhuma sessão com este ID pertence a você"},
+		"social/provider-not-found":        {"Provedor Não Encontrado", "Nenhum provedor de identidade está configurado com este nome"},
+		"social/invalid-state":             {"Estado de Login Inválido", "O login não foi iniciado por este navegador ou expirou; inicie-o novamente"},
+		"social/login-failed":              {"Falha no Login", "O provedor de identidade não confirmou quem você é"},
+		"social/email-not-verified":        {"Email Não Verificado", "O provedor de identidade não compartilhou um email verificado da sua conta"},
+		"http/not-found":                   {"Não Encontrado", ""},
+		"http/method-not-allowed":          {"Método Não Permitido", ""},
+		"http/too-many-requests":           {"Muitas Requisições", ""},
+		"http/request-entity-too-large":    {"Corpo da Requisição Muito Grande", ""},
+	},
+	ES: {
+		"server/internal-error":            {"Error Interno del Servidor", "Ocurrió un error inesperado"},
+		"server/busy":                      {"Servidor Ocupado", "El servidor está atendiendo demasiadas solicitudes, inténtalo de nuevo más tarde"},
+		"request/invalid-request":          {"Solicitud Inválida", "No se pudo interpretar el cuerpo de la solicitud"},
+		"request/validation-error":         {"Validación Fallida", "Uno o más campos no son válidos"},
+		"request/unsupported-media-type":   {"Tipo de Medio No Soportado", "Envía el cuerpo de la solicitud como application/json o application/x-www-form-urlencoded"},
+		"request/origin-not-allowed":       {"Origen No Permitido", "Las solicitudes desde este origen no pueden modificar el estado"},
+		"auth/unauthorized":                {"No Autorizado", "Se requiere autenticación"},
+		"auth/insufficient-scope":          {"Alcance Insuficiente", "Las credenciales usadas no conceden el alcance que esta solicitud requiere"},
+		"auth/invalid-csrf-token":          {"Token CSRF Inválido", "Envía el valor de la cookie csrf_token en el encabezado X-CSRF-Token"},
+		"auth/session-required":            {"Sesión Requerida", "Esta operación requiere iniciar sesión y no puede realizarse con una clave de API"},
+		"api-key/not-found":                {"Clave de API No Encontrada", "Ninguna clave de API con este ID te pertenece"},
+		"api-key/name-conflict":            {"Nombre de Clave de API en Uso", "Ya tienes una clave de API con este nombre"},
+		"service-account/not-found":        {"Cuenta de Servicio No Encontrada", "Ninguna cuenta de servicio tiene este ID"},
+		"service-account/secret-not-found": {"Secreto de Cuenta de Servicio No Encontrado", "Ningún secreto con este ID pertenece a la cuenta de servicio"},
+		"auth/invalid-refresh-token":       {"Token de Actualización Inválido", "El token de actualización es inválido, expiró o fue revocado"},
+		"auth/invalid-credentials":         {"Credenciales Inválidas", "Correo electrónico o contraseña inválidos"},
+		"auth/user-deactivated":            {"Cuenta Desactivada", "Tu cuenta ha sido desactivada"},
+		"auth/password-expired":            {"Contraseña Expirada", "Tu contraseña ha expirado y debe restablecerse"},
+		"auth/password-change-required":    {"Cambio de Contraseña Obligatorio", "Tu contraseña supera la antigüedad máxima y debe cambiarse"},
+		"auth/invalid-password-challenge":  {"Desafío de Cambio de Contraseña Inválido", "El desafío de cambio de contraseña es inválido o ha expirado"},
+		"auth/user-not-deactivated":        {"Cuenta No Desactivada", "Esta cuenta no está desactivada"},
+		"user/email-already-exists":        {"Correo Ya Registrado", "Ya existe una cuenta con este correo electrónico"},
+		"user/weak-password":               {"Contraseña Débil", "La contraseña no cumple la política de contraseñas"},
+		"user/invalid-current-password":    {"Contraseña Actual Inválida", "La contraseña actual proporcionada es incorrecta"},
+		"user/not-found":                   {"Usuario No Encontrado", "El usuario no existe"},
+		"user/invalid-role":                {"Rol Inválido", "El rol no es reconocido"},
+		"oauth/invalid-client":             {"Cliente Inválido", "Falló la autenticación del cliente"},
+		"oauth/unauthorized-client":        {"Cliente No Autorizado", "El token no fue emitido para el cliente"},
+		"oauth/invalid-redirect-uri":       {"URI de Redirección Inválida", "La URI de redirección no está registrada para el cliente"},
+		"oauth/invalid-request":            {"Solicitud de Autorización Inválida", "La solicitud de autorización tiene parámetros ausentes o inválidos"},
+		"oauth/unsupported-response-type":  {"Tipo de Respuesta No Soportado", "Solo se admite el tipo de respuesta code"},
+		"oauth/invalid-scope":              {"Alcance Inválido", "El alcance solicitado es desconocido, no incluye openid o no fue concedido al cliente"},
+		"oauth/access-denied":              {"Acceso Denegado", "El usuario rechazó la autorización"},
+		"oauth/invalid-grant":              {"Concesión Inválida", "El código de autorización, refresh token o código de dispositivo es inválido, expiró o fue emitido para otro cliente"},
+		"oauth/unsupported-grant-type":     {"Tipo de Concesión No Soportado", "El tipo de concesión no es soportado"},
+		"oauth/authorization-pending":      {"Autorización Pendiente", "El usuario aún no respondió la solicitud del dispositivo; sigue consultando"},
+		"oauth/slow-down":                  {"Reduce la Frecuencia", "El dispositivo está consultando demasiado rápido; el intervalo se aumentó en 5 segundos"},
+		"oauth/expired-token":              {"Código de Dispositivo Expirado", "El código del dispositivo expiró; inicia el flujo de dispositivo de nuevo"},
+		"oauth/invalid-user-code":          {"Código de Usuario Inválido", "El código de usuario es inválido, expiró o ya fue usado"},
+		"session/not-found":                {"Sesión No Encontrada", "Ninguna sesión con este ID te pertenece"},
+		"social/provider-not-found":        {"Proveedor No Encontrado", "Ningún proveedor de identidad está configurado con este nombre"},
+		"social/invalid-state":             {"Estado de Inicio de Sesión Inválido", "El inicio de sesión no fue iniciado por este navegador o expiró; inícialo de nuevo"},
+		"social/login-failed":              {"Inicio de Sesión Fallido", "El proveedor de identidad no confirmó quién eres"},
+		"social/email-not-verified":        {"Correo No Verificado", "El proveedor de identidad no compartió un correo verificado de tu cuenta"},
+		"http/not-found":                   {"No Encontrado", ""},
+		"http/method-not-allowed":          {"Método No Permitido", ""},
+		"http/too-many-requests":           {"Demasiadas Solicitudes", ""},
+		"http/request-entity-too-large":    {"Cuerpo de la Solicitud Demasiado Grande", ""},
+	},
+}
+
+// Problem returns the translation of a problem type, if the locale has one.
+func Problem(locale, key string) (Text, bool) {
+	text, ok := problems[locale][key]
+	return text, ok
+}
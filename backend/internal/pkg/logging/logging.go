@@ -1,8 +1,10 @@
 package logging
 
 import (
+	"context"
 	"log"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -92,6 +94,34 @@ func With(fields ...zap.Field) *zap.Logger {
 	return GetLogger().With(fields...)
 }
 
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx carrying the given request-scoped logger
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the request-scoped logger stored in ctx, falling back
+// to the global logger outside of a request
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return GetLogger()
+}
+
+// WithContext creates a child of the logger in ctx with additional fields
+// plus the trace_id and span_id of the span carried by ctx, when there is one
+func WithContext(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", spanContext.TraceID().String()),
+			zap.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	return FromContext(ctx).With(fields...)
+}
+
 // getLogLevel returns the appropriate log level
 func getLogLevel(loglevel string) zapcore.Level {
 	switch loglevel {
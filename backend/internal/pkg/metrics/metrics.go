@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "migos"
+
+// Registry holds every collector exposed on the metrics listener. A private
+// registry keeps test binaries and the CLI from sharing global state.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests processed, by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency, by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	LoginsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "logins_total",
+		Help:      "Login attempts, by result and failure reason.",
+	}, []string{"result", "reason"})
+
+	AccountsCreatedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "accounts_created_total",
+		Help:      "Accounts created, by source.",
+	}, []string{"source"})
+
+	SessionsRevokedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "sessions_revoked_total",
+		Help:      "Sessions deleted before expiry, by reason.",
+	}, []string{"reason"})
+
+	TokensRefreshedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "tokens_refreshed_total",
+		Help:      "Token pairs re-issued by the SessionAuth middleware.",
+	})
+
+	SocialLoginsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "social_logins_total",
+		Help:      "Logins through upstream identity providers, by provider and result.",
+	}, []string{"provider", "result"})
+
+	OAuthTokensIssuedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "oauth",
+		Name:      "tokens_issued_total",
+		Help:      "Tokens issued by the OAuth token endpoint, by grant type.",
+	}, []string{"grant_type"})
+
+	CleanupRowsDeletedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "jobs",
+		Name:      "cleanup_rows_deleted_total",
+		Help:      "Rows removed by cleanup jobs, by job name.",
+	}, []string{"job"})
+
+	PasswordHashDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "security",
+		Name:      "password_hash_duration_seconds",
+		Help:      "Time spent hashing or verifying passwords, by operation and algorithm.",
+		Buckets:   []float64{.01, .025, .05, .1, .2, .3, .5, .75, 1, 2},
+	}, []string{"operation", "algorithm"})
+
+	PasswordRehashesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "security",
+		Name:      "password_rehashes_total",
+		Help:      "Password hashes with an outdated algorithm or parameters replaced at login.",
+	})
+
+	PasswordHashQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "security",
+		Name:      "password_hash_queue_depth",
+		Help:      "Password hash operations waiting for a free worker.",
+	})
+
+	PasswordHashInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "security",
+		Name:      "password_hash_in_flight",
+		Help:      "Password hash operations running on a worker.",
+	})
+
+	PasswordHashRejectedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "security",
+		Name:      "password_hash_rejected_total",
+		Help:      "Password hash operations rejected after waiting the whole queue timeout for a worker.",
+	})
+
+	PasswordPolicyViolationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "security",
+		Name:      "password_policy_violations_total",
+		Help:      "Password policy rules broken by new passwords, by rule.",
+	}, []string{"rule"})
+
+	StorageOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "storage",
+		Name:      "operation_duration_seconds",
+		Help:      "Database statement latency, by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation", "table"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		LoginsTotal,
+		AccountsCreatedTotal,
+		SessionsRevokedTotal,
+		TokensRefreshedTotal,
+		SocialLoginsTotal,
+		OAuthTokensIssuedTotal,
+		CleanupRowsDeletedTotal,
+		PasswordHashDuration,
+		PasswordRehashesTotal,
+		PasswordHashQueueDepth,
+		PasswordHashInFlight,
+		PasswordHashRejectedTotal,
+		PasswordPolicyViolationsTotal,
+		StorageOperationDuration,
+	)
+}
+
+// Handler serves Registry in the Prometheus text exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
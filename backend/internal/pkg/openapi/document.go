@@ -0,0 +1,353 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+)
+
+// Version is the OpenAPI version of the generated document.
+const Version = "3.1.0"
+
+const (
+	mimeJSON    = "application/json"
+	mimeForm    = "application/x-www-form-urlencoded"
+	mimeProblem = "application/problem+json"
+
+	problemSchema = "ProblemDetails"
+	cookieAuth    = "cookieAuth"
+	bearerAuth    = "bearerAuth"
+	apiKeyAuth    = "apiKeyAuth"
+	clientBasic   = "clientBasic"
+	serviceAuth   = "clientCredentials"
+
+	// tokenURL is where service accounts get the tokens of serviceAuth.
+	tokenURL = "/oauth/token"
+)
+
+// Document is the subset of an OpenAPI 3.1 document the API describes itself with.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps lower-case HTTP methods to operations.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string      `json:"type"`
+	In           string      `json:"in,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	Scheme       string      `json:"scheme,omitempty"`
+	BearerFormat string      `json:"bearerFormat,omitempty"`
+	Description  string      `json:"description,omitempty"`
+	Flows        *OAuthFlows `json:"flows,omitempty"`
+}
+
+type OAuthFlows struct {
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+}
+
+type OAuthFlow struct {
+	TokenURL string            `json:"tokenUrl"`
+	Scopes   map[string]string `json:"scopes"`
+}
+
+// Route describes one operation of the API: where it is mounted, what it
+// reads and writes, and which registered errors it can return.
+type Route struct {
+	Method      string
+	Path        string // Echo syntax, e.g. /v1/user/:id
+	OperationID string
+	Summary     string
+	Tag         string
+	Auth        bool
+	// Scopes are required of the caller. Service accounts with the scopes
+	// may call these operations too; users need roles of the same names.
+	Scopes []string
+	// ClientAuth marks operations called by registered OAuth clients rather
+	// than users.
+	ClientAuth bool
+	Query      any // query parameters DTO, read from its query tags
+	Request    any // body DTO, nil when the operation has no body
+	// OptionalRequest marks the body as optional, for operations that can
+	// also read their input from cookies.
+	OptionalRequest bool
+	Response        any // success body, nil for responses without content
+	Status          int
+	Errors          []error
+	// Alternatives documents other non-problem responses by status, such as
+	// a readiness probe reporting 503 with the same body.
+	Alternatives map[int]any
+}
+
+var pathParam = regexp.MustCompile(`:([^/]+)`)
+
+// Path converts an Echo route path to an OpenAPI path template.
+func Path(echoPath string) string {
+	return pathParam.ReplaceAllString(echoPath, "{$1}")
+}
+
+// Generate builds the document for routes. Every body, response and the
+// ProblemDetails error type become component schemas; request and auth
+// failures common to all operations are added from errorpkg.Errors.
+func Generate(info Info, routes []Route) *Document {
+	schemas := newSchemaSet()
+	schemas.add(reflect.TypeOf(errorpkg.ProblemDetails{}), false)
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas: schemas.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				cookieAuth: {
+					Type:        "apiKey",
+					In:          "cookie",
+					Name:        "access_token",
+					Description: "Session cookies set by login, named after the COOKIE_* policy; the refresh_token cookie must be sent as well unless scoped to /v1/auth/refresh, and requests other than GET must copy the csrf_token cookie into the X-CSRF-Token header",
+				},
+				bearerAuth: {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "Access token returned by login; renew it through /v1/auth/refresh",
+				},
+				apiKeyAuth: {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "mig_",
+					Description:  "API key created in /v1/auth/api-keys; keys with only the read scope are limited to GET",
+				},
+				clientBasic: {
+					Type:        "http",
+					Scheme:      "basic",
+					Description: "OAuth client_id and client_secret; they may instead be sent in the request body",
+				},
+				serviceAuth: {
+					Type:        "oauth2",
+					Description: "Access token of a service account, from the client_credentials grant",
+					Flows: &OAuthFlows{ClientCredentials: &OAuthFlow{
+						TokenURL: tokenURL,
+						Scopes:   map[string]string{},
+					}},
+				},
+			},
+		},
+	}
+
+	scopes := doc.Components.SecuritySchemes[serviceAuth].Flows.ClientCredentials.Scopes
+	for _, route := range routes {
+		for _, scope := range route.Scopes {
+			scopes[scope] = fmt.Sprintf("Service accounts granted %s, or users with the %s role", scope, scope)
+		}
+
+		path := Path(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		(*item)[strings.ToLower(route.Method)] = operation(route, schemas)
+	}
+
+	return doc
+}
+
+// safeMethod reports whether method is read only, and so exempt from the
+// CSRF and origin checks.
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+func operation(route Route, schemas *schemaSet) *Operation {
+	op := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Responses:   map[string]*Response{},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"},
+		})
+	}
+
+	if route.Query != nil {
+		op.Parameters = append(op.Parameters, queryParameters(reflect.TypeOf(route.Query))...)
+	}
+
+	errs := route.Errors
+	if route.Request != nil {
+		body := schemas.add(reflect.TypeOf(route.Request), true)
+		op.RequestBody = &RequestBody{
+			Required: !route.OptionalRequest,
+			Content: map[string]MediaType{
+				mimeJSON: {Schema: body},
+				mimeForm: {Schema: body},
+			},
+		}
+		errs = append([]error{errorpkg.ErrInvalidRequest, errorpkg.ErrValidation, errorpkg.ErrUnsupportedMediaType}, errs...)
+	}
+	if route.Auth || len(route.Scopes) > 0 {
+		scopes := route.Scopes
+		if scopes == nil {
+			scopes = []string{}
+		}
+		op.Security = []map[string][]string{{cookieAuth: scopes}, {bearerAuth: scopes}, {apiKeyAuth: scopes}}
+		if len(route.Scopes) > 0 {
+			op.Security = append(op.Security, map[string][]string{serviceAuth: scopes})
+		}
+		errs = append([]error{domain.ErrUnauthorized, domain.ErrInsufficientScope}, errs...)
+		if !safeMethod(route.Method) {
+			errs = append(errs, domain.ErrInvalidCSRFToken)
+		}
+	}
+	if !safeMethod(route.Method) {
+		errs = append(errs, errorpkg.ErrOriginNotAllowed)
+	}
+	if route.ClientAuth {
+		op.Security = []map[string][]string{{clientBasic: {}}}
+		errs = append([]error{domain.ErrInvalidClient}, errs...)
+	}
+
+	success := &Response{Description: http.StatusText(route.Status)}
+	if route.Response != nil {
+		success.Content = map[string]MediaType{
+			mimeJSON: {Schema: schemas.add(reflect.TypeOf(route.Response), false)},
+		}
+	}
+	op.Responses[strconv.Itoa(route.Status)] = success
+
+	for status, body := range route.Alternatives {
+		op.Responses[strconv.Itoa(status)] = &Response{
+			Description: http.StatusText(status),
+			Content: map[string]MediaType{
+				mimeJSON: {Schema: schemas.add(reflect.TypeOf(body), false)},
+			},
+		}
+	}
+
+	for status, problems := range errorResponses(errs) {
+		op.Responses[strconv.Itoa(status)] = &Response{
+			Description: strings.Join(problems, ", "),
+			Content: map[string]MediaType{
+				mimeProblem: {Schema: ref(problemSchema)},
+			},
+		}
+	}
+
+	return op
+}
+
+// queryParameters describes the fields of t that have a query tag, including
+// those of embedded structs. They are optional unless validated as required.
+func queryParameters(t reflect.Type) []Parameter {
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			params = append(params, queryParameters(field.Type)...)
+			continue
+		}
+		name := field.Tag.Get("query")
+		if name == "" || name == "-" {
+			continue
+		}
+		params = append(params, Parameter{
+			Name:     name,
+			In:       "query",
+			Required: slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required"),
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	return params
+}
+
+// errorResponses groups the problem types of errs, plus the internal error
+// every operation can return, by status. Each one is looked up in
+// errorpkg.Errors so the document follows the registry.
+func errorResponses(errs []error) map[int][]string {
+	fallback := errorpkg.Errors.Fallback()
+	byStatus := map[int][]string{
+		fallback.Status: {"`" + fallback.Scope + "/" + fallback.Code + "`"},
+	}
+	seen := map[string]bool{}
+	for _, err := range errs {
+		entry, ok := errorpkg.Errors.Lookup(err)
+		if !ok {
+			panic(fmt.Sprintf("openapi: error %q is not registered in errorpkg.Errors", err))
+		}
+		problem := entry.Scope + "/" + entry.Code
+		if seen[problem] {
+			continue
+		}
+		seen[problem] = true
+		byStatus[entry.Status] = append(byStatus[entry.Status], "`"+problem+"`")
+	}
+	for _, problems := range byStatus {
+		sort.Strings(problems)
+	}
+	return byStatus
+}
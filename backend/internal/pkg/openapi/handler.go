@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+// Encode renders doc as indented JSON, the form served and committed to the
+// repository.
+func Encode(doc *Document) ([]byte, error) {
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	return append(body, '\n'), nil
+}
+
+// Handler serves doc as JSON. The document is encoded once, up front.
+func Handler(doc *Document) (http.Handler, error) {
+	body, err := Encode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}), nil
+}
+
+var docsPage = template.Must(template.New("docs").Parse(`<!doctype html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{.Title}}</title>
+</head>
+<body>
+  <script id="api-reference" data-url="{{.SpecURL}}"></script>
+  <script src="https://cdn.jsdelivr.net/npm/@scalar/api-reference"></script>
+</body>
+</html>
+`))
+
+// DocsHandler serves an interactive reference (Scalar, loaded from a CDN)
+// for the document published at specURL.
+func DocsHandler(title, specURL string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = docsPage.Execute(w, struct{ Title, SpecURL string }{title, specURL})
+	})
+}
@@ -0,0 +1,236 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+type signupRequest struct {
+	Name     string `json:"name" validate:"required,name"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Nickname string `json:"nickname" validate:"omitempty,min=3"`
+}
+
+type keyRequest struct {
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=read write"`
+	Days   int      `json:"days" validate:"required,min=1,max=365"`
+}
+
+type signupResponse struct {
+	ID    string   `json:"id"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+var testRoutes = []Route{
+	{
+		Method: http.MethodPost, Path: "/v1/signup", OperationID: "signup", Tag: "User",
+		Request: signupRequest{}, Response: signupResponse{}, Status: http.StatusCreated,
+		Errors: []error{domain.ErrEmailAlreadyExists},
+	},
+	{
+		Method: http.MethodGet, Path: "/v1/users/:id", OperationID: "getUser", Auth: true,
+		Response: signupResponse{}, Status: http.StatusOK,
+		Errors: []error{domain.ErrUserNotFound},
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("should build request schemas from json and validate tags", func(t *testing.T) {
+		t.Parallel()
+
+		doc := Generate(Info{Title: "test", Version: "1"}, testRoutes)
+		schema := doc.Components.Schemas["signupRequest"]
+
+		require.NotNil(t, schema)
+		assert.Equal(t, []string{"name", "email", "password"}, schema.Required)
+		assert.Equal(t, "email", schema.Properties["email"].Format)
+		assert.Equal(t, 8, *schema.Properties["password"].MinLength)
+		assert.NotEmpty(t, schema.Properties["name"].Pattern)
+		assert.Len(t, schema.Properties["nickname"].AnyOf, 2)
+		assert.False(t, *schema.AdditionalProperties)
+	})
+
+	t.Run("should translate numeric, slice and oneof rules", func(t *testing.T) {
+		t.Parallel()
+
+		doc := Generate(Info{Title: "test", Version: "1"}, []Route{{
+			Method: http.MethodPost, Path: "/v1/keys", OperationID: "createKey",
+			Request: keyRequest{}, Status: http.StatusCreated,
+		}})
+		schema := doc.Components.Schemas["keyRequest"]
+
+		require.NotNil(t, schema)
+		assert.Equal(t, []string{"scopes", "days"}, schema.Required)
+		assert.Equal(t, 1, *schema.Properties["scopes"].MinItems)
+		assert.Equal(t, []any{"read", "write"}, schema.Properties["scopes"].Items.Enum)
+		assert.Equal(t, 1, *schema.Properties["days"].Minimum)
+		assert.Equal(t, 365, *schema.Properties["days"].Maximum)
+	})
+
+	t.Run("should require response fields that are not omitempty", func(t *testing.T) {
+		t.Parallel()
+
+		doc := Generate(Info{Title: "test", Version: "1"}, testRoutes)
+		schema := doc.Components.Schemas["signupResponse"]
+
+		assert.Equal(t, []string{"id"}, schema.Required)
+		assert.Equal(t, "array", schema.Properties["roles"].Type)
+		assert.Nil(t, schema.AdditionalProperties)
+	})
+
+	t.Run("should document registered errors as problem responses", func(t *testing.T) {
+		t.Parallel()
+
+		doc := Generate(Info{Title: "test", Version: "1"}, testRoutes)
+		signup := (*doc.Paths["/v1/signup"])["post"]
+
+		assert.Contains(t, signup.Responses, "201")
+		assert.Contains(t, signup.Responses["400"].Description, "request/validation-error")
+		assert.Contains(t, signup.Responses["409"].Description, "user/email-already-exists")
+		assert.Contains(t, signup.Responses["415"].Description, "request/unsupported-media-type")
+		assert.Contains(t, signup.Responses["500"].Description, "server/internal-error")
+		assert.Equal(t, "#/components/schemas/ProblemDetails", signup.Responses["409"].Content[mimeProblem].Schema.Ref)
+	})
+
+	t.Run("should convert path parameters and mark authenticated operations", func(t *testing.T) {
+		t.Parallel()
+
+		doc := Generate(Info{Title: "test", Version: "1"}, testRoutes)
+		item, ok := doc.Paths["/v1/users/{id}"]
+		require.True(t, ok)
+		getUser := (*item)["get"]
+
+		assert.Equal(t, []Parameter{{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, getUser.Parameters)
+		assert.Equal(t, []map[string][]string{{cookieAuth: {}}, {bearerAuth: {}}, {apiKeyAuth: {}}}, getUser.Security)
+		assert.Contains(t, getUser.Responses["401"].Description, "auth/unauthorized")
+		assert.Contains(t, getUser.Responses["403"].Description, "auth/insufficient-scope")
+		assert.Contains(t, getUser.Responses["404"].Description, "user/not-found")
+	})
+
+	t.Run("should flatten embedded structs and mark client authenticated operations", func(t *testing.T) {
+		t.Parallel()
+
+		doc := Generate(Info{Title: "test", Version: "1"}, []Route{{
+			Method: http.MethodPost, Path: "/oauth/revoke", OperationID: "revoke", ClientAuth: true,
+			Request: domain.RevokeRequest{}, Status: http.StatusOK,
+		}})
+		schema := doc.Components.Schemas["RevokeRequest"]
+		revoke := (*doc.Paths["/oauth/revoke"])["post"]
+
+		require.NotNil(t, schema)
+		assert.Contains(t, schema.Properties, "client_id")
+		assert.Contains(t, schema.Properties, "client_secret")
+		assert.NotContains(t, schema.Properties, "ClientCredentials")
+		assert.Equal(t, []string{"token"}, schema.Required)
+		assert.Equal(t, []map[string][]string{{clientBasic: {}}}, revoke.Security)
+		assert.Contains(t, revoke.Responses["401"].Description, "oauth/invalid-client")
+	})
+
+	t.Run("should require the route scopes from users and service accounts", func(t *testing.T) {
+		t.Parallel()
+
+		doc := Generate(Info{Title: "test", Version: "1"}, []Route{{
+			Method: http.MethodDelete, Path: "/v1/admin/users/:id", OperationID: "deleteAnyUser", Auth: true,
+			Scopes: []string{"admin"}, Status: http.StatusNoContent,
+		}})
+		deleteAnyUser := (*doc.Paths["/v1/admin/users/{id}"])["delete"]
+		scheme := doc.Components.SecuritySchemes[serviceAuth]
+
+		scopes := []string{"admin"}
+		assert.Equal(t, []map[string][]string{
+			{cookieAuth: scopes}, {bearerAuth: scopes}, {apiKeyAuth: scopes}, {serviceAuth: scopes},
+		}, deleteAnyUser.Security)
+		require.NotNil(t, scheme.Flows)
+		assert.Equal(t, tokenURL, scheme.Flows.ClientCredentials.TokenURL)
+		assert.Contains(t, scheme.Flows.ClientCredentials.Scopes, "admin")
+	})
+
+	t.Run("should describe query parameters from query tags", func(t *testing.T) {
+		t.Parallel()
+
+		type searchQuery struct {
+			Term  string `query:"q" validate:"required"`
+			Page  string `query:"page"`
+			Extra string
+		}
+		doc := Generate(Info{Title: "test", Version: "1"}, []Route{{
+			Method: http.MethodGet, Path: "/search", OperationID: "search",
+			Query: searchQuery{}, Status: http.StatusOK,
+		}})
+		search := (*doc.Paths["/search"])["get"]
+
+		assert.Equal(t, []Parameter{
+			{Name: "q", In: "query", Required: true, Schema: &Schema{Type: "string"}},
+			{Name: "page", In: "query", Schema: &Schema{Type: "string"}},
+		}, search.Parameters)
+		assert.Nil(t, search.RequestBody)
+	})
+
+	t.Run("should panic on errors missing from the registry", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			Generate(Info{}, []Route{{Method: http.MethodGet, Path: "/", Status: http.StatusOK, Errors: []error{errors.New("unregistered")}}})
+		})
+	})
+}
+
+func TestValidator(t *testing.T) {
+	validator, err := NewValidator(Generate(Info{Title: "test", Version: "1"}, testRoutes))
+	require.NoError(t, err)
+
+	t.Run("should accept a valid body", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.Validate(http.MethodPost, "/v1/signup", map[string]any{
+			"name": "Ana Maria", "email": "ana@test.com", "password": "password123", "nickname": "",
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should report missing, unknown and invalid fields", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.Validate(http.MethodPost, "/v1/signup", map[string]any{
+			"email": "not-an-email", "password": "short", "admin": true,
+		})
+
+		var requestErr *RequestError
+		require.ErrorAs(t, err, &requestErr)
+		fields := map[string]string{}
+		for _, field := range requestErr.Fields {
+			fields[field.Field] = field.Message
+		}
+		assert.Equal(t, "is not allowed", fields["admin"])
+		assert.Equal(t, "is required", fields["name"])
+		assert.Contains(t, fields, "email")
+		assert.Contains(t, fields, "password")
+	})
+
+	t.Run("should apply rules of omitempty fields only when set", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.Validate(http.MethodPost, "/v1/signup", map[string]any{
+			"name": "Ana", "email": "ana@test.com", "password": "password123", "nickname": "ab",
+		})
+
+		var requestErr *RequestError
+		require.ErrorAs(t, err, &requestErr)
+		assert.Equal(t, []FieldError{{Field: "nickname", Message: "minLength: got 2, want 3"}}, requestErr.Fields)
+	})
+
+	t.Run("should skip operations without a request body", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NoError(t, validator.Validate(http.MethodGet, "/v1/users/:id", map[string]any{"any": 1}))
+		assert.NoError(t, validator.Validate(http.MethodGet, "/unknown", nil))
+	})
+}
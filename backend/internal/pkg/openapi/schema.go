@@ -0,0 +1,207 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	validatorpkg "github.com/SergioLNeves/migos/internal/pkg/validator"
+)
+
+// Schema is a JSON Schema (draft 2020-12) as used by OpenAPI 3.1.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Examples             []any              `json:"examples,omitempty"`
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// schemaSet collects named component schemas for Go struct types.
+type schemaSet struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaSet() *schemaSet {
+	return &schemaSet{schemas: map[string]*Schema{}}
+}
+
+// add registers t as a component schema and returns a reference to it.
+// Request schemas take required fields and constraints from validate tags
+// and reject unknown properties, matching the strict JSON binding; response
+// schemas require every field that is not omitempty.
+func (s *schemaSet) add(t reflect.Type, request bool) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if _, ok := s.schemas[t.Name()]; ok {
+		return ref(t.Name())
+	}
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	s.schemas[t.Name()] = schema
+	if request {
+		closed := false
+		schema.AdditionalProperties = &closed
+	}
+
+	s.properties(schema, t, request)
+
+	return ref(t.Name())
+}
+
+// properties adds the fields of t to schema. Embedded structs without a
+// JSON name are flattened, as encoding/json does.
+func (s *schemaSet) properties(schema *Schema, t reflect.Type, request bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			s.properties(schema, field.Type, request)
+			continue
+		}
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.field(field.Type, request)
+		if example := field.Tag.Get("example"); example != "" {
+			property.Examples = []any{exampleValue(field.Type, example)}
+		}
+
+		required := !strings.Contains(opts, "omitempty")
+		if request {
+			required = applyValidateTag(property, field.Tag.Get("validate"))
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+func (s *schemaSet) field(t reflect.Type, request bool) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: s.field(t.Elem(), request)}
+	case reflect.Struct:
+		return s.add(t, request)
+	default:
+		return &Schema{Type: "object"}
+	}
+}
+
+// applyValidateTag translates the validator rules the API uses into schema
+// keywords and reports whether the field is required. Under omitempty an
+// empty string skips the rules, so they only apply to non-empty values;
+// after dive the rules apply to the items of a slice.
+func applyValidateTag(property *Schema, tag string) bool {
+	rules, typed := property, property
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if rules == property {
+				required = true
+			}
+		case "omitempty":
+			if typed.Type == "string" {
+				empty := 0
+				branch := &Schema{}
+				typed.AnyOf = []*Schema{{MaxLength: &empty}, branch}
+				rules = branch
+			}
+		case "dive":
+			if typed.Items == nil {
+				return required
+			}
+			rules, typed = typed.Items, typed.Items
+		case "email":
+			rules.Format = "email"
+		case "name":
+			rules.Pattern = validatorpkg.NamePattern
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				rules.Enum = append(rules.Enum, value)
+			}
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			minimum := name == "min"
+			switch typed.Type {
+			case "string":
+				rules.MinLength, rules.MaxLength = bound(minimum, n, rules.MinLength, rules.MaxLength)
+			case "integer", "number":
+				rules.Minimum, rules.Maximum = bound(minimum, n, rules.Minimum, rules.Maximum)
+			case "array":
+				rules.MinItems, rules.MaxItems = bound(minimum, n, rules.MinItems, rules.MaxItems)
+			}
+		}
+	}
+	return required
+}
+
+// bound sets n as the lower or upper of a pair of limits.
+func bound(minimum bool, n int, lower, upper *int) (*int, *int) {
+	if minimum {
+		return &n, upper
+	}
+	return lower, &n
+}
+
+func exampleValue(t reflect.Type, example string) any {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(example, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(example, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(example); err == nil {
+			return b
+		}
+	}
+	return example
+}
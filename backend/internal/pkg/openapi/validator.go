@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const documentURL = "openapi.json"
+
+var (
+	templateParam = regexp.MustCompile(`\{([^}]+)\}`)
+	printer       = message.NewPrinter(language.English)
+)
+
+// FieldError is a request body property that does not match its schema.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// RequestError lists every violation found in a request body.
+type RequestError struct {
+	Fields []FieldError
+}
+
+func (e *RequestError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Field + ": " + field.Message
+	}
+	return "request does not match the API specification: " + strings.Join(messages, "; ")
+}
+
+// Validator checks request bodies against the schemas of a Document.
+type Validator struct {
+	schemas map[string]*jsonschema.Schema
+}
+
+// NewValidator compiles the request body schema of every operation in doc.
+func NewValidator(doc *Document) (*Validator, error) {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	resource, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	compiler.AssertFormat()
+	if err := compiler.AddResource(documentURL, resource); err != nil {
+		return nil, fmt.Errorf("failed to load document: %w", err)
+	}
+
+	v := &Validator{schemas: map[string]*jsonschema.Schema{}}
+	for path, item := range doc.Paths {
+		for method, op := range *item {
+			if op.RequestBody == nil {
+				continue
+			}
+			body, ok := op.RequestBody.Content[mimeJSON]
+			if !ok || body.Schema == nil {
+				continue
+			}
+			schema, err := compiler.Compile(documentURL + body.Schema.Ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile %s %s: %w", method, path, err)
+			}
+			v.schemas[key(method, templateParam.ReplaceAllString(path, ":$1"))] = schema
+		}
+	}
+
+	return v, nil
+}
+
+// Validate checks body, already decoded into JSON values, against the request
+// schema of the operation at method and Echo route path. Operations without
+// a request body are not checked.
+func (v *Validator) Validate(method, path string, body any) error {
+	schema, ok := v.schemas[key(method, path)]
+	if !ok {
+		return nil
+	}
+
+	err := schema.Validate(body)
+	if err == nil {
+		return nil
+	}
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return err
+	}
+
+	requestErr := &RequestError{}
+	collect(validationErr, &requestErr.Fields)
+	sort.SliceStable(requestErr.Fields, func(i, j int) bool {
+		return requestErr.Fields[i].Field < requestErr.Fields[j].Field
+	})
+	return requestErr
+}
+
+// collect flattens the leaf errors of err, naming missing and unknown
+// properties after themselves rather than their parent object.
+func collect(err *jsonschema.ValidationError, fields *[]FieldError) {
+	if len(err.Causes) > 0 {
+		for _, cause := range err.Causes {
+			collect(cause, fields)
+		}
+		return
+	}
+
+	location := strings.Join(err.InstanceLocation, ".")
+	switch k := err.ErrorKind.(type) {
+	case *kind.Required:
+		for _, name := range k.Missing {
+			*fields = append(*fields, FieldError{Field: join(location, name), Message: "is required"})
+		}
+	case *kind.MaxLength:
+		// The empty-string branch of an omitempty field; the other branch
+		// carries the meaningful error.
+		if k.Want == 0 {
+			return
+		}
+		*fields = append(*fields, FieldError{Field: location, Message: k.LocalizedString(printer)})
+	case *kind.AdditionalProperties:
+		for _, name := range k.Properties {
+			*fields = append(*fields, FieldError{Field: join(location, name), Message: "is not allowed"})
+		}
+	default:
+		*fields = append(*fields, FieldError{Field: location, Message: k.LocalizedString(printer)})
+	}
+}
+
+func join(location, name string) string {
+	if location == "" {
+		return name
+	}
+	return location + "." + name
+}
+
+func key(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
@@ -2,37 +2,101 @@ package validator
 
 import (
 	"errors"
+	"reflect"
 	"regexp"
 	"strings"
 
 	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/pt_BR"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	en_translations "github.com/go-playground/validator/v10/translations/en"
+	es_translations "github.com/go-playground/validator/v10/translations/es"
+	pt_BR_translations "github.com/go-playground/validator/v10/translations/pt_BR"
+
+	"github.com/SergioLNeves/migos/internal/pkg/i18n"
 )
 
-var nameRegex = regexp.MustCompile(`^[\p{L}\s'-]{2,}$`)
+// NamePattern is the expression behind the custom "name" tag.
+const NamePattern = `^[\p{L}\s'-]{2,}$`
+
+var nameRegex = regexp.MustCompile(NamePattern)
 
 var (
 	validate *validator.Validate
 	uni      *ut.UniversalTranslator
 )
 
+// nameMessages is the translation of the custom "name" tag per locale.
+var nameMessages = map[string]string{
+	i18n.EN:   "{0} must contain only letters, spaces, apostrophes or hyphens",
+	i18n.PtBR: "{0} deve conter apenas letras, espaços, apóstrofos ou hífens",
+	i18n.ES:   "{0} solo puede contener letras, espacios, apóstrofos o guiones",
+}
+
 func init() {
 	validate = validator.New()
+	validate.RegisterTagNameFunc(fieldName)
 
 	if err := validate.RegisterValidation("name", validateName); err != nil {
 		panic("failed to register name validation: " + err.Error())
 	}
 
 	enLocale := en.New()
-	uni = ut.New(enLocale, enLocale)
+	uni = ut.New(enLocale, enLocale, pt_BR.New(), es.New())
+
+	registrations := map[string]func(*validator.Validate, ut.Translator) error{
+		i18n.EN:   en_translations.RegisterDefaultTranslations,
+		i18n.PtBR: pt_BR_translations.RegisterDefaultTranslations,
+		i18n.ES:   es_translations.RegisterDefaultTranslations,
+	}
+	for locale, register := range registrations {
+		trans, _ := uni.GetTranslator(locale)
+		if err := register(validate, trans); err != nil {
+			panic("failed to register validator translations: " + err.Error())
+		}
+		if err := registerNameTranslation(trans, nameMessages[locale]); err != nil {
+			panic("failed to register name translation: " + err.Error())
+		}
+	}
+}
+
+// fieldName reports fields by their json tag, falling back to the form tag,
+// so validation errors use the names clients actually send.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
 
-	transEN, _ := uni.GetTranslator("en")
+func registerNameTranslation(trans ut.Translator, message string) error {
+	return validate.RegisterTranslation("name", trans,
+		func(ut ut.Translator) error {
+			return ut.Add("name", message, true)
+		},
+		func(ut ut.Translator, fe validator.FieldError) string {
+			translated, _ := ut.T("name", fe.Field())
+			return translated
+		},
+	)
+}
 
-	if err := en_translations.RegisterDefaultTranslations(validate, transEN); err != nil {
-		panic("failed to register validator translations: " + err.Error())
+// Translator returns the validator translator for a locale from the i18n
+// package, or the English one when the locale is unknown.
+func Translator(locale string) ut.Translator {
+	trans, found := uni.GetTranslator(locale)
+	if !found {
+		trans, _ = uni.GetTranslator(i18n.EN)
 	}
+	return trans
 }
 
 func validateName(fl validator.FieldLevel) bool {
@@ -58,7 +122,7 @@ func FormatValidationErrors(err error, lang string) map[string]string {
 
 	var validationErrors validator.ValidationErrors
 	if errors.As(err, &validationErrors) {
-		trans, _ := uni.GetTranslator(lang)
+		trans := Translator(lang)
 		for _, fe := range validationErrors {
 			fields[strings.ToLower(fe.Field())] = fe.Translate(trans)
 		}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+	"github.com/SergioLNeves/migos/internal/storage"
+)
+
+var TableAPIKey = "api_key"
+
+type APIKeyRepositoryImpl struct {
+	db storage.Storage
+}
+
+func NewAPIKeyRepository(i *do.Injector) (domain.APIKeyRepository, error) {
+	db := do.MustInvoke[storage.Storage](i)
+	return &APIKeyRepositoryImpl{db: db}, nil
+}
+
+func (r *APIKeyRepositoryImpl) CreateAPIKey(ctx context.Context, key *domain.APIKey) (err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyRepository.CreateAPIKey")
+	defer tracing.End(span, &err)
+
+	return r.db.Insert(ctx, TableAPIKey, key)
+}
+
+func (r *APIKeyRepositoryImpl) FindAPIKeyByHash(ctx context.Context, keyHash string) (_ *domain.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyRepository.FindAPIKeyByHash")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var key domain.APIKey
+	if err := db.WithContext(ctx).Table(TableAPIKey).Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+
+	return &key, nil
+}
+
+func (r *APIKeyRepositoryImpl) ListAPIKeysByUserID(ctx context.Context, userID uuid.UUID) (_ []domain.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyRepository.ListAPIKeysByUserID")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var keys []domain.APIKey
+	if err := db.WithContext(ctx).Table(TableAPIKey).Where("user_id = ?", userID).Order("created_at").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepositoryImpl) DeleteAPIKey(ctx context.Context, userID, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyRepository.DeleteAPIKey")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	result := db.WithContext(ctx).Table(TableAPIKey).Where("id = ? AND user_id = ?", id, userID).Delete(&domain.APIKey{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete api key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepositoryImpl) TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyRepository.TouchAPIKey")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	if err := db.WithContext(ctx).Table(TableAPIKey).Where("id = ?", id).Update("last_used_at", usedAt).Error; err != nil {
+		return fmt.Errorf("failed to update api key last use: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepositoryImpl) DeleteExpiredAPIKeys(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyRepository.DeleteExpiredAPIKeys")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return 0, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := db.WithContext(ctx).Table(TableAPIKey).Where("expires_at <= ?", time.Now()).Delete(&domain.APIKey{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired api keys: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -9,13 +9,18 @@ import (
 	"github.com/google/uuid"
 	"github.com/samber/do"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
 	"github.com/SergioLNeves/migos/internal/storage"
 )
 
 var (
-	TableUser = "user"
+	TableUser              = "user"
+	TableUserRole          = "user_role"
+	TablePasswordHistory   = "password_history"
+	TablePasswordChallenge = "password_change_challenge"
 )
 
 type AuthRepositoryImpl struct {
@@ -27,14 +32,20 @@ func NewAuthRepository(i *do.Injector) (domain.AuthRepository, error) {
 	return &AuthRepositoryImpl{db: db}, nil
 }
 
-func (r *AuthRepositoryImpl) CreateUser(ctx context.Context, user *domain.User) error {
+func (r *AuthRepositoryImpl) CreateUser(ctx context.Context, user *domain.User) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.CreateUser")
+	defer tracing.End(span, &err)
+
 	if err := r.db.Insert(ctx, TableUser, user); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *AuthRepositoryImpl) FindUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+func (r *AuthRepositoryImpl) FindUserByEmail(ctx context.Context, email string) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.FindUserByEmail")
+	defer tracing.End(span, &err)
+
 	var user domain.User
 	if err := r.db.FindByEmail(ctx, TableUser, email, &user); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -45,7 +56,10 @@ func (r *AuthRepositoryImpl) FindUserByEmail(ctx context.Context, email string)
 	return &user, nil
 }
 
-func (r *AuthRepositoryImpl) FindUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+func (r *AuthRepositoryImpl) FindUserByID(ctx context.Context, id uuid.UUID) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.FindUserByID")
+	defer tracing.End(span, &err)
+
 	db := r.db.GetDB().(*gorm.DB)
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
 	defer cancel()
@@ -59,11 +73,33 @@ func (r *AuthRepositoryImpl) FindUserByID(ctx context.Context, id uuid.UUID) (*d
 	return &user, nil
 }
 
-func (r *AuthRepositoryImpl) UpdateUser(ctx context.Context, user *domain.User) error {
+func (r *AuthRepositoryImpl) UpdateUser(ctx context.Context, user *domain.User) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.UpdateUser")
+	defer tracing.End(span, &err)
+
 	return r.db.Update(ctx, TableUser, user)
 }
 
-func (r *AuthRepositoryImpl) DeleteUser(ctx context.Context, id uuid.UUID) error {
+func (r *AuthRepositoryImpl) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.UpdatePasswordHash")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+	result := db.WithContext(ctx).Table(TableUser).
+		Where("id = ? AND password = ?", id, oldHash).
+		Update("password", newHash)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update password hash: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *AuthRepositoryImpl) DeleteUser(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.DeleteUser")
+	defer tracing.End(span, &err)
+
 	db := r.db.GetDB().(*gorm.DB)
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
 	defer cancel()
@@ -72,16 +108,170 @@ func (r *AuthRepositoryImpl) DeleteUser(ctx context.Context, id uuid.UUID) error
 	return result.Error
 }
 
-func (r *AuthRepositoryImpl) DeleteDeactivatedUsers(ctx context.Context) (int64, error) {
+func (r *AuthRepositoryImpl) DeleteDeactivatedUsers(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.DeleteDeactivatedUsers")
+	defer tracing.End(span, &err)
+
 	db := r.db.GetDB().(*gorm.DB)
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	sevenDaysAgo := time.Now().Add(-7 * 24 * time.Hour)
-	result := db.WithContext(ctx).Table(TableUser).
-		Where("deleted_at IS NOT NULL AND deleted_at <= ?", sevenDaysAgo).
-		Delete(&domain.User{})
+	var deleted int64
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		purged := tx.Table(TableUser).Select("id").Where("deleted_at IS NOT NULL AND deleted_at <= ?", sevenDaysAgo)
+		if err := tx.Table(TablePasswordHistory).Where("user_id IN (?)", purged).Delete(&domain.PasswordHistory{}).Error; err != nil {
+			return fmt.Errorf("failed to delete password history: %w", err)
+		}
+
+		result := tx.Table(TableUser).
+			Where("deleted_at IS NOT NULL AND deleted_at <= ?", sevenDaysAgo).
+			Delete(&domain.User{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete deactivated users: %w", result.Error)
+		}
+		deleted = result.RowsAffected
+		return nil
+	})
+	return deleted, err
+}
+
+func (r *AuthRepositoryImpl) GrantRole(ctx context.Context, userID uuid.UUID, role string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.GrantRole")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+	userRole := &domain.UserRole{UserID: userID, Role: role}
+	result := db.WithContext(ctx).Table(TableUserRole).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(userRole)
+	if result.Error != nil {
+		return fmt.Errorf("failed to grant role: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *AuthRepositoryImpl) RevokeRole(ctx context.Context, userID uuid.UUID, role string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.RevokeRole")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+	result := db.WithContext(ctx).Table(TableUserRole).
+		Where("user_id = ? AND role = ?", userID, role).
+		Delete(&domain.UserRole{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke role: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *AuthRepositoryImpl) FindRolesByUserID(ctx context.Context, userID uuid.UUID) (_ []string, err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.FindRolesByUserID")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+	var roles []string
+	if err := db.WithContext(ctx).Table(TableUserRole).Where("user_id = ?", userID).Order("role").Pluck("role", &roles).Error; err != nil {
+		return nil, fmt.Errorf("failed to find roles: %w", err)
+	}
+	return roles, nil
+}
+
+func (r *AuthRepositoryImpl) AddPasswordHistory(ctx context.Context, entry *domain.PasswordHistory, keep int) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.AddPasswordHistory")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(TablePasswordHistory).Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to add password history: %w", err)
+		}
+
+		newest := tx.Table(TablePasswordHistory).Select("id").
+			Where("user_id = ?", entry.UserID).Order("created_at DESC").Limit(keep)
+		if err := tx.Table(TablePasswordHistory).
+			Where("user_id = ? AND id NOT IN (?)", entry.UserID, newest).
+			Delete(&domain.PasswordHistory{}).Error; err != nil {
+			return fmt.Errorf("failed to prune password history: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *AuthRepositoryImpl) ListPasswordHistory(ctx context.Context, userID uuid.UUID, limit int) (_ []domain.PasswordHistory, err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.ListPasswordHistory")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+	var history []domain.PasswordHistory
+	if err := db.WithContext(ctx).Table(TablePasswordHistory).
+		Where("user_id = ?", userID).Order("created_at DESC").Limit(limit).
+		Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("failed to list password history: %w", err)
+	}
+	return history, nil
+}
+
+func (r *AuthRepositoryImpl) CreatePasswordChallenge(ctx context.Context, challenge *domain.PasswordChallenge) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.CreatePasswordChallenge")
+	defer tracing.End(span, &err)
+
+	return r.db.Insert(ctx, TablePasswordChallenge, challenge)
+}
+
+func (r *AuthRepositoryImpl) FindPasswordChallenge(ctx context.Context, id string) (_ *domain.PasswordChallenge, err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.FindPasswordChallenge")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+	var challenge domain.PasswordChallenge
+	if err := db.WithContext(ctx).Table(TablePasswordChallenge).Where("id = ?", id).First(&challenge).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrInvalidPasswordChallenge
+		}
+		return nil, fmt.Errorf("failed to find password challenge: %w", err)
+	}
+	return &challenge, nil
+}
+
+func (r *AuthRepositoryImpl) DeletePasswordChallenge(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.DeletePasswordChallenge")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+	result := db.WithContext(ctx).Table(TablePasswordChallenge).Where("id = ?", id).Delete(&domain.PasswordChallenge{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete password challenge: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidPasswordChallenge
+	}
+	return nil
+}
+
+func (r *AuthRepositoryImpl) DeleteExpiredPasswordChallenges(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "AuthRepository.DeleteExpiredPasswordChallenges")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	result := db.WithContext(ctx).Table(TablePasswordChallenge).Where("expires_at <= ?", time.Now()).Delete(&domain.PasswordChallenge{})
 	if result.Error != nil {
-		return 0, fmt.Errorf("failed to delete deactivated users: %w", result.Error)
+		return 0, fmt.Errorf("failed to delete expired password challenges: %w", result.Error)
 	}
 	return result.RowsAffected, nil
 }
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+	"github.com/SergioLNeves/migos/internal/storage"
+)
+
+var (
+	TableAuthorizationCode = "oauth_authorization_code"
+	TableOAuthGrant        = "oauth_grant"
+	TableDeviceAuth        = "oauth_device_authorization"
+)
+
+type AuthorizationRepositoryImpl struct {
+	db storage.Storage
+}
+
+func NewAuthorizationRepository(i *do.Injector) (domain.AuthorizationRepository, error) {
+	db := do.MustInvoke[storage.Storage](i)
+	return &AuthorizationRepositoryImpl{db: db}, nil
+}
+
+func (r *AuthorizationRepositoryImpl) CreateAuthorizationCode(ctx context.Context, code *domain.AuthorizationCode) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.CreateAuthorizationCode")
+	defer tracing.End(span, &err)
+
+	return r.db.Insert(ctx, TableAuthorizationCode, code)
+}
+
+func (r *AuthorizationRepositoryImpl) ConsumeAuthorizationCode(ctx context.Context, id string) (_ *domain.AuthorizationCode, err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.ConsumeAuthorizationCode")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var code domain.AuthorizationCode
+	if err := db.WithContext(ctx).Table(TableAuthorizationCode).Where("id = ?", id).First(&code).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAuthorizationCodeNotFound
+		}
+		return nil, fmt.Errorf("failed to find authorization code: %w", err)
+	}
+
+	// Only the request whose delete removed the row may use the code.
+	result := db.WithContext(ctx).Table(TableAuthorizationCode).Where("id = ?", id).Delete(&domain.AuthorizationCode{})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to delete authorization code: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrAuthorizationCodeNotFound
+	}
+
+	return &code, nil
+}
+
+func (r *AuthorizationRepositoryImpl) DeleteExpiredAuthorizationCodes(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.DeleteExpiredAuthorizationCodes")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return 0, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := db.WithContext(ctx).Table(TableAuthorizationCode).Where("expires_at <= ?", time.Now()).Delete(&domain.AuthorizationCode{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired authorization codes: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *AuthorizationRepositoryImpl) FindGrant(ctx context.Context, userID, clientID uuid.UUID) (_ *domain.OAuthGrant, err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.FindGrant")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var grant domain.OAuthGrant
+	if err := db.WithContext(ctx).Table(TableOAuthGrant).Where("user_id = ? AND client_id = ?", userID, clientID).First(&grant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrGrantNotFound
+		}
+		return nil, fmt.Errorf("failed to find grant: %w", err)
+	}
+
+	return &grant, nil
+}
+
+func (r *AuthorizationRepositoryImpl) SaveGrant(ctx context.Context, grant *domain.OAuthGrant) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.SaveGrant")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	err = db.WithContext(ctx).Table(TableOAuthGrant).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "client_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"scope", "updated_at"}),
+	}).Create(grant).Error
+	if err != nil {
+		return fmt.Errorf("failed to save grant: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AuthorizationRepositoryImpl) CreateDeviceAuthorization(ctx context.Context, device *domain.DeviceAuthorization) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.CreateDeviceAuthorization")
+	defer tracing.End(span, &err)
+
+	return r.db.Insert(ctx, TableDeviceAuth, device)
+}
+
+func (r *AuthorizationRepositoryImpl) FindDeviceAuthorization(ctx context.Context, id string) (_ *domain.DeviceAuthorization, err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.FindDeviceAuthorization")
+	defer tracing.End(span, &err)
+
+	return r.findDeviceAuthorization(ctx, "id = ?", id)
+}
+
+func (r *AuthorizationRepositoryImpl) FindDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (_ *domain.DeviceAuthorization, err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.FindDeviceAuthorizationByUserCode")
+	defer tracing.End(span, &err)
+
+	return r.findDeviceAuthorization(ctx, "user_code = ?", userCode)
+}
+
+func (r *AuthorizationRepositoryImpl) findDeviceAuthorization(ctx context.Context, query string, value string) (*domain.DeviceAuthorization, error) {
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var device domain.DeviceAuthorization
+	if err := db.WithContext(ctx).Table(TableDeviceAuth).Where(query, value).First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrDeviceAuthorizationNotFound
+		}
+		return nil, fmt.Errorf("failed to find device authorization: %w", err)
+	}
+
+	return &device, nil
+}
+
+func (r *AuthorizationRepositoryImpl) ResolveDeviceAuthorization(ctx context.Context, id string, userID uuid.UUID, status string, at time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.ResolveDeviceAuthorization")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	result := db.WithContext(ctx).Table(TableDeviceAuth).
+		Where("id = ? AND status = ?", id, domain.DeviceStatusPending).
+		Updates(map[string]any{"status": status, "user_id": userID, "approved_at": at})
+	if result.Error != nil {
+		return fmt.Errorf("failed to resolve device authorization: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDeviceAuthorizationNotFound
+	}
+
+	return nil
+}
+
+func (r *AuthorizationRepositoryImpl) RecordDevicePoll(ctx context.Context, id string, polledAt time.Time, interval int) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.RecordDevicePoll")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	err = db.WithContext(ctx).Table(TableDeviceAuth).Where("id = ?", id).
+		Updates(map[string]any{"last_polled_at": polledAt, "interval": interval}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record device poll: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AuthorizationRepositoryImpl) DeleteDeviceAuthorization(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.DeleteDeviceAuthorization")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	result := db.WithContext(ctx).Table(TableDeviceAuth).Where("id = ?", id).Delete(&domain.DeviceAuthorization{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete device authorization: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDeviceAuthorizationNotFound
+	}
+
+	return nil
+}
+
+func (r *AuthorizationRepositoryImpl) DeleteExpiredDeviceAuthorizations(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "AuthorizationRepository.DeleteExpiredDeviceAuthorizations")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return 0, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := db.WithContext(ctx).Table(TableDeviceAuth).Where("expires_at <= ?", time.Now()).Delete(&domain.DeviceAuthorization{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired device authorizations: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
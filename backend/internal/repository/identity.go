@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/do"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+	"github.com/SergioLNeves/migos/internal/storage"
+)
+
+var (
+	TableIdentity         = "identity"
+	TableSocialLoginState = "social_login_state"
+)
+
+type IdentityRepositoryImpl struct {
+	db storage.Storage
+}
+
+func NewIdentityRepository(i *do.Injector) (domain.IdentityRepository, error) {
+	db := do.MustInvoke[storage.Storage](i)
+	return &IdentityRepositoryImpl{db: db}, nil
+}
+
+func (r *IdentityRepositoryImpl) FindIdentity(ctx context.Context, provider, subject string) (_ *domain.Identity, err error) {
+	ctx, span := tracing.Start(ctx, "IdentityRepository.FindIdentity")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var identity domain.Identity
+	if err := db.WithContext(ctx).Table(TableIdentity).Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("failed to find identity: %w", err)
+	}
+
+	return &identity, nil
+}
+
+func (r *IdentityRepositoryImpl) SaveIdentity(ctx context.Context, identity *domain.Identity) (err error) {
+	ctx, span := tracing.Start(ctx, "IdentityRepository.SaveIdentity")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	err = db.WithContext(ctx).Table(TableIdentity).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "provider"}, {Name: "subject"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "email", "updated_at"}),
+	}).Create(identity).Error
+	if err != nil {
+		return fmt.Errorf("failed to save identity: %w", err)
+	}
+
+	return nil
+}
+
+func (r *IdentityRepositoryImpl) CreateLoginState(ctx context.Context, state *domain.SocialLoginState) (err error) {
+	ctx, span := tracing.Start(ctx, "IdentityRepository.CreateLoginState")
+	defer tracing.End(span, &err)
+
+	return r.db.Insert(ctx, TableSocialLoginState, state)
+}
+
+func (r *IdentityRepositoryImpl) ConsumeLoginState(ctx context.Context, id string) (_ *domain.SocialLoginState, err error) {
+	ctx, span := tracing.Start(ctx, "IdentityRepository.ConsumeLoginState")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var state domain.SocialLoginState
+	if err := db.WithContext(ctx).Table(TableSocialLoginState).Where("id = ?", id).First(&state).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSocialLoginStateInvalid
+		}
+		return nil, fmt.Errorf("failed to find login state: %w", err)
+	}
+
+	result := db.WithContext(ctx).Table(TableSocialLoginState).Where("id = ?", id).Delete(&domain.SocialLoginState{})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to delete login state: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domain.ErrSocialLoginStateInvalid
+	}
+
+	return &state, nil
+}
+
+func (r *IdentityRepositoryImpl) DeleteExpiredLoginStates(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "IdentityRepository.DeleteExpiredLoginStates")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return 0, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := db.WithContext(ctx).Table(TableSocialLoginState).Where("expires_at <= ?", time.Now()).Delete(&domain.SocialLoginState{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired login states: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
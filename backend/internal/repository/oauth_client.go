@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+	"github.com/SergioLNeves/migos/internal/storage"
+)
+
+var TableOAuthClient = "oauth_client"
+
+type OAuthClientRepositoryImpl struct {
+	db storage.Storage
+}
+
+func NewOAuthClientRepository(i *do.Injector) (domain.OAuthClientRepository, error) {
+	db := do.MustInvoke[storage.Storage](i)
+	return &OAuthClientRepositoryImpl{db: db}, nil
+}
+
+func (r *OAuthClientRepositoryImpl) CreateClient(ctx context.Context, client *domain.OAuthClient) (err error) {
+	ctx, span := tracing.Start(ctx, "OAuthClientRepository.CreateClient")
+	defer tracing.End(span, &err)
+
+	return r.db.Insert(ctx, TableOAuthClient, client)
+}
+
+func (r *OAuthClientRepositoryImpl) FindClientByID(ctx context.Context, id uuid.UUID) (_ *domain.OAuthClient, err error) {
+	ctx, span := tracing.Start(ctx, "OAuthClientRepository.FindClientByID")
+	defer tracing.End(span, &err)
+
+	var client domain.OAuthClient
+	if err := r.db.FindByID(ctx, TableOAuthClient, id, &client); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrClientNotFound
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *OAuthClientRepositoryImpl) ListClients(ctx context.Context) (_ []domain.OAuthClient, err error) {
+	ctx, span := tracing.Start(ctx, "OAuthClientRepository.ListClients")
+	defer tracing.End(span, &err)
+
+	db := r.db.GetDB().(*gorm.DB)
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+	var clients []domain.OAuthClient
+	if err := db.WithContext(ctx).Table(TableOAuthClient).Order("created_at").Find(&clients).Error; err != nil {
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+	return clients, nil
+}
+
+func (r *OAuthClientRepositoryImpl) DeleteClient(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "OAuthClientRepository.DeleteClient")
+	defer tracing.End(span, &err)
+
+	var client domain.OAuthClient
+	if err := r.db.FindOneAndDelete(ctx, TableOAuthClient, id, &client); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrClientNotFound
+		}
+		return err
+	}
+	return nil
+}
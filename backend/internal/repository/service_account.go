@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+	"github.com/SergioLNeves/migos/internal/storage"
+)
+
+var (
+	TableServiceAccount       = "service_account"
+	TableServiceAccountSecret = "service_account_secret"
+)
+
+type ServiceAccountRepositoryImpl struct {
+	db storage.Storage
+}
+
+func NewServiceAccountRepository(i *do.Injector) (domain.ServiceAccountRepository, error) {
+	db := do.MustInvoke[storage.Storage](i)
+	return &ServiceAccountRepositoryImpl{db: db}, nil
+}
+
+func (r *ServiceAccountRepositoryImpl) CreateServiceAccount(ctx context.Context, account *domain.ServiceAccount) (err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountRepository.CreateServiceAccount")
+	defer tracing.End(span, &err)
+
+	return r.db.Insert(ctx, TableServiceAccount, account)
+}
+
+func (r *ServiceAccountRepositoryImpl) FindServiceAccountByID(ctx context.Context, id uuid.UUID) (_ *domain.ServiceAccount, err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountRepository.FindServiceAccountByID")
+	defer tracing.End(span, &err)
+
+	var account domain.ServiceAccount
+	if err := r.db.FindByID(ctx, TableServiceAccount, id, &account); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrServiceAccountNotFound
+		}
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *ServiceAccountRepositoryImpl) ListServiceAccounts(ctx context.Context) (_ []domain.ServiceAccount, err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountRepository.ListServiceAccounts")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var accounts []domain.ServiceAccount
+	if err := db.WithContext(ctx).Table(TableServiceAccount).Order("created_at").Find(&accounts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (r *ServiceAccountRepositoryImpl) DeleteServiceAccount(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountRepository.DeleteServiceAccount")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Table(TableServiceAccount).Where("id = ?", id).Delete(&domain.ServiceAccount{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete service account: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrServiceAccountNotFound
+		}
+
+		if err := tx.Table(TableServiceAccountSecret).Where("service_account_id = ?", id).Delete(&domain.ServiceAccountSecret{}).Error; err != nil {
+			return fmt.Errorf("failed to delete service account secrets: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *ServiceAccountRepositoryImpl) CreateSecret(ctx context.Context, secret *domain.ServiceAccountSecret) (err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountRepository.CreateSecret")
+	defer tracing.End(span, &err)
+
+	return r.db.Insert(ctx, TableServiceAccountSecret, secret)
+}
+
+func (r *ServiceAccountRepositoryImpl) ListSecrets(ctx context.Context, serviceAccountID uuid.UUID) (_ []domain.ServiceAccountSecret, err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountRepository.ListSecrets")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var secrets []domain.ServiceAccountSecret
+	if err := db.WithContext(ctx).Table(TableServiceAccountSecret).Where("service_account_id = ?", serviceAccountID).Order("created_at").Find(&secrets).Error; err != nil {
+		return nil, fmt.Errorf("failed to list service account secrets: %w", err)
+	}
+
+	return secrets, nil
+}
+
+func (r *ServiceAccountRepositoryImpl) DeleteSecret(ctx context.Context, serviceAccountID, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountRepository.DeleteSecret")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	result := db.WithContext(ctx).Table(TableServiceAccountSecret).Where("id = ? AND service_account_id = ?", id, serviceAccountID).Delete(&domain.ServiceAccountSecret{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete service account secret: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrServiceAccountSecretNotFound
+	}
+
+	return nil
+}
+
+func (r *ServiceAccountRepositoryImpl) TouchSecret(ctx context.Context, id uuid.UUID, usedAt time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountRepository.TouchSecret")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	if err := db.WithContext(ctx).Table(TableServiceAccountSecret).Where("id = ?", id).Update("last_used_at", usedAt).Error; err != nil {
+		return fmt.Errorf("failed to update service account secret last use: %w", err)
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
 	"github.com/SergioLNeves/migos/internal/storage"
 )
 
@@ -25,14 +26,20 @@ func NewSessionRepository(i *do.Injector) (domain.SessionRepository, error) {
 	return &SessionRepositoryImpl{db: db}, nil
 }
 
-func (r *SessionRepositoryImpl) CreateSession(ctx context.Context, session *domain.Session) error {
+func (r *SessionRepositoryImpl) CreateSession(ctx context.Context, session *domain.Session) (err error) {
+	ctx, span := tracing.Start(ctx, "SessionRepository.CreateSession")
+	defer tracing.End(span, &err)
+
 	if err := r.db.Insert(ctx, TableSession, session); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *SessionRepositoryImpl) FindSessionByID(ctx context.Context, sessionID uuid.UUID) (*domain.Session, error) {
+func (r *SessionRepositoryImpl) FindSessionByID(ctx context.Context, sessionID uuid.UUID) (_ *domain.Session, err error) {
+	ctx, span := tracing.Start(ctx, "SessionRepository.FindSessionByID")
+	defer tracing.End(span, &err)
+
 	var session domain.Session
 	if err := r.db.FindByID(ctx, TableSession, sessionID, &session); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -48,7 +55,34 @@ func (r *SessionRepositoryImpl) FindSessionByID(ctx context.Context, sessionID u
 	return &session, nil
 }
 
-func (r *SessionRepositoryImpl) DeleteSession(ctx context.Context, sessionID uuid.UUID) (*domain.Session, error) {
+func (r *SessionRepositoryImpl) FindSessionsByUserID(ctx context.Context, userID uuid.UUID) (_ []domain.Session, err error) {
+	ctx, span := tracing.Start(ctx, "SessionRepository.FindSessionsByUserID")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return nil, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	var sessions []domain.Session
+	result := db.WithContext(ctx).Table(TableSession).
+		Where("user_id = ? AND expires_at > ?", userID, time.Now()).
+		Order("created_at DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", result.Error)
+	}
+
+	return sessions, nil
+}
+
+func (r *SessionRepositoryImpl) DeleteSession(ctx context.Context, sessionID uuid.UUID) (_ *domain.Session, err error) {
+	ctx, span := tracing.Start(ctx, "SessionRepository.DeleteSession")
+	defer tracing.End(span, &err)
+
 	var session domain.Session
 	if err := r.db.FindOneAndDelete(ctx, TableSession, sessionID, &session); err != nil {
 		return nil, err
@@ -56,7 +90,10 @@ func (r *SessionRepositoryImpl) DeleteSession(ctx context.Context, sessionID uui
 	return &session, nil
 }
 
-func (r *SessionRepositoryImpl) UpdateSessionExpiry(ctx context.Context, sessionID uuid.UUID, expiresAt time.Time) error {
+func (r *SessionRepositoryImpl) UpdateSessionExpiry(ctx context.Context, sessionID uuid.UUID, expiresAt time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "SessionRepository.UpdateSessionExpiry")
+	defer tracing.End(span, &err)
+
 	db, ok := r.db.GetDB().(*gorm.DB)
 	if !ok {
 		return fmt.Errorf("failed to get database instance")
@@ -73,7 +110,10 @@ func (r *SessionRepositoryImpl) UpdateSessionExpiry(ctx context.Context, session
 	return nil
 }
 
-func (r *SessionRepositoryImpl) DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) error {
+func (r *SessionRepositoryImpl) RotateRefreshToken(ctx context.Context, sessionID uuid.UUID, current, next string, expiresAt time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "SessionRepository.RotateRefreshToken")
+	defer tracing.End(span, &err)
+
 	db, ok := r.db.GetDB().(*gorm.DB)
 	if !ok {
 		return fmt.Errorf("failed to get database instance")
@@ -82,11 +122,41 @@ func (r *SessionRepositoryImpl) DeleteSessionsByUserID(ctx context.Context, user
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
 	defer cancel()
 
+	// Only the request whose update matched the current hash may use the
+	// refresh token, like ConsumeAuthorizationCode's delete.
+	result := db.WithContext(ctx).Table(TableSession).
+		Where("id = ? AND refresh_token_hash = ?", sessionID, current).
+		Updates(map[string]any{"refresh_token_hash": next, "expires_at": expiresAt})
+	if result.Error != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *SessionRepositoryImpl) DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "SessionRepository.DeleteSessionsByUserID")
+	defer tracing.End(span, &err)
+
+	db, ok := r.db.GetDB().(*gorm.DB)
+	if !ok {
+		return 0, fmt.Errorf("failed to get database instance")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
 	result := db.WithContext(ctx).Table(TableSession).Where("user_id = ?", userID).Delete(&domain.Session{})
-	return result.Error
+	return result.RowsAffected, result.Error
 }
 
-func (r *SessionRepositoryImpl) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+func (r *SessionRepositoryImpl) DeleteExpiredSessions(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "SessionRepository.DeleteExpiredSessions")
+	defer tracing.End(span, &err)
+
 	db, ok := r.db.GetDB().(*gorm.DB)
 	if !ok {
 		return 0, fmt.Errorf("failed to get database instance")
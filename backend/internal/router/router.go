@@ -0,0 +1,322 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/openapi"
+)
+
+// Route is an endpoint of the API together with its OpenAPI description, so
+// the routes Echo serves and the published document come from one table.
+type Route struct {
+	openapi.Route
+	Handler echo.HandlerFunc
+	// Page marks routes that may answer with an HTML page.
+	Page bool
+	// Clients marks Auth routes that OpenID Connect clients may call with
+	// the access tokens issued to them.
+	Clients bool
+}
+
+var Info = openapi.Info{
+	Title:       "Migos Auth Session API",
+	Version:     "1.0.0",
+	Description: "Session based authentication with RS256 JWTs. Errors are returned as application/problem+json (RFC 7807).",
+}
+
+// Handlers are the handlers the route table dispatches to.
+type Handlers struct {
+	Health domain.HealthCheckHandler
+	Auth   domain.AuthHandler
+	Keys   domain.KeysHandler
+	OAuth  domain.OAuthHandler
+	OIDC   domain.OIDCHandler
+	Social domain.SocialHandler
+	APIKey domain.APIKeyHandler
+
+	ServiceAccount domain.ServiceAccountHandler
+}
+
+// Routes returns the route table of the API.
+func Routes(h Handlers) []Route {
+	health, auth := h.Health, h.Auth
+	return []Route{
+		{Handler: health.Ready, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/health", OperationID: "health", Tag: "Health",
+			Summary:  "Alias of /health/ready",
+			Response: domain.HealthCheck{}, Status: http.StatusOK,
+			Alternatives: map[int]any{http.StatusServiceUnavailable: domain.HealthCheck{}},
+		}},
+		{Handler: health.Live, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/health/live", OperationID: "healthLive", Tag: "Health",
+			Summary:  "Liveness: the process is responding",
+			Response: domain.HealthCheck{}, Status: http.StatusOK,
+		}},
+		{Handler: health.Ready, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/health/ready", OperationID: "healthReady", Tag: "Health",
+			Summary:  "Readiness with status and latency per component",
+			Response: domain.HealthCheck{}, Status: http.StatusOK,
+			Alternatives: map[int]any{http.StatusServiceUnavailable: domain.HealthCheck{}},
+		}},
+		{Handler: h.Keys.JWKS, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/.well-known/jwks.json", OperationID: "jwks", Tag: "Keys",
+			Summary:  "Public keys that verify access tokens (RFC 7517)",
+			Response: domain.JWKS{}, Status: http.StatusOK,
+		}},
+		{Handler: h.OAuth.Introspect, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/oauth/introspect", OperationID: "introspect", Tag: "OAuth", ClientAuth: true,
+			Summary: "Report whether a token is active (RFC 7662)",
+			Request: domain.IntrospectRequest{}, Response: domain.IntrospectionResponse{}, Status: http.StatusOK,
+		}},
+		{Handler: h.OAuth.Revoke, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/oauth/revoke", OperationID: "revoke", Tag: "OAuth", ClientAuth: true,
+			Summary: "Revoke a token issued to the client by ending its session (RFC 7009)",
+			Request: domain.RevokeRequest{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrUnauthorizedClient},
+		}},
+		{Handler: h.OAuth.Token, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/oauth/token", OperationID: "oauthToken", Tag: "OAuth", ClientAuth: true,
+			Summary: "Issue a service account access token with the client_credentials grant",
+			Request: domain.ClientCredentialsRequest{}, Response: domain.ClientCredentialsResponse{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrUnsupportedGrantType, domain.ErrInvalidScope},
+		}},
+		{Handler: h.OIDC.Discovery, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/.well-known/openid-configuration", OperationID: "openidConfiguration", Tag: "OIDC",
+			Summary:  "OpenID Provider metadata",
+			Response: domain.DiscoveryDocument{}, Status: http.StatusOK,
+		}},
+		{Handler: h.OIDC.Authorize, Page: true, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/authorize", OperationID: "authorize", Tag: "OIDC",
+			Summary: "Start the authorization code flow with PKCE; redirects to the login page, shows the consent page or redirects back to the client",
+			Query:   domain.AuthorizeRequest{}, Status: http.StatusFound,
+			Errors: []error{domain.ErrInvalidClient, domain.ErrInvalidRedirectURI},
+		}},
+		{Handler: h.OIDC.Consent, Page: true, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/authorize", OperationID: "consent", Tag: "OIDC", Auth: true,
+			Summary: "Answer the consent page and redirect back to the client",
+			Request: domain.ConsentRequest{}, Status: http.StatusFound,
+			Errors: []error{domain.ErrSessionRequired, domain.ErrInvalidClient, domain.ErrInvalidRedirectURI},
+		}},
+		{Handler: h.OIDC.Token, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/token", OperationID: "token", Tag: "OIDC", ClientAuth: true,
+			Summary: "Redeem an authorization code, refresh token or device code; public clients send only client_id",
+			Request: domain.TokenRequest{}, Response: domain.TokenResponse{}, Status: http.StatusOK,
+			Errors: []error{
+				domain.ErrInvalidGrant, domain.ErrUnsupportedGrantType,
+				domain.ErrAuthorizationPending, domain.ErrSlowDown, domain.ErrExpiredToken, domain.ErrAccessDenied,
+			},
+		}},
+		{Handler: h.OIDC.DeviceCode, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/oauth/device/code", OperationID: "deviceCode", Tag: "OIDC", ClientAuth: true,
+			Summary: "Start the device flow: returns the device code to poll /token with and the user code to show (RFC 8628)",
+			Request: domain.DeviceCodeRequest{}, Response: domain.DeviceCodeResponse{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrInvalidScope},
+		}},
+		{Handler: h.OIDC.Device, Page: true, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/device", OperationID: "device", Tag: "OIDC",
+			Summary: "Verification page where the signed in user enters the user code; redirects to the login page without a session",
+			Query:   domain.DeviceRequest{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrInvalidUserCode},
+		}},
+		{Handler: h.OIDC.ApproveDevice, Page: true, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/device", OperationID: "approveDevice", Tag: "OIDC", Auth: true,
+			Summary: "Approve or deny the device showing the user code",
+			Request: domain.DeviceApprovalRequest{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrSessionRequired, domain.ErrInvalidUserCode},
+		}},
+		{Handler: h.OIDC.UserInfo, Clients: true, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/userinfo", OperationID: "userInfo", Tag: "OIDC", Auth: true,
+			Summary:  "Claims of the signed in user allowed by the token's scopes",
+			Response: domain.UserInfo{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrSessionRequired},
+		}},
+		{Handler: auth.CreateAccount, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/v1/user/create-account", OperationID: "createAccount", Tag: "User",
+			Summary: "Create an account and start a session",
+			Request: domain.CreateAccountRequest{}, Response: domain.AuthResponse{}, Status: http.StatusCreated,
+			Errors: []error{domain.ErrEmailAlreadyExists, domain.ErrWeakPassword, domain.ErrServerBusy},
+		}},
+		{Handler: auth.UpdatePassword, Route: openapi.Route{
+			Method: http.MethodPatch, Path: "/v1/user/password", OperationID: "updatePassword", Tag: "User", Auth: true,
+			Summary: "Change the password of the signed in user",
+			Request: domain.UpdatePasswordRequest{}, Status: http.StatusNoContent,
+			Errors: []error{domain.ErrInvalidCurrentPassword, domain.ErrWeakPassword, domain.ErrServerBusy},
+		}},
+		{Handler: auth.UpdateUser, Route: openapi.Route{
+			Method: http.MethodPatch, Path: "/v1/user/profile", OperationID: "updateProfile", Tag: "User", Auth: true,
+			Summary: "Update name, email or avatar of the signed in user",
+			Request: domain.UpdateUserRequest{}, Response: domain.UserResponse{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrEmailAlreadyExists},
+		}},
+		{Handler: auth.DeleteUser, Route: openapi.Route{
+			Method: http.MethodDelete, Path: "/v1/user", OperationID: "deleteUser", Tag: "User", Auth: true,
+			Summary: "Deactivate the signed in user and end the session",
+			Status:  http.StatusOK,
+			Errors:  []error{domain.ErrSessionRequired},
+		}},
+		{Handler: auth.ReactivateAccount, Route: openapi.Route{
+			Method: http.MethodPatch, Path: "/v1/user/reactivate", OperationID: "reactivateAccount", Tag: "User",
+			Summary: "Reactivate a deactivated account and start a session",
+			Request: domain.LoginRequest{}, Response: domain.AuthResponse{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrInvalidCredentials, domain.ErrUserNotDeactivated, domain.ErrPasswordExpired, domain.ErrPasswordChangeRequired, domain.ErrServerBusy},
+		}},
+		{Handler: auth.Login, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/v1/auth/login", OperationID: "login", Tag: "Auth",
+			Summary: "Sign in with email and password",
+			Request: domain.LoginRequest{}, Response: domain.AuthResponse{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrInvalidCredentials, domain.ErrUserDeactivated, domain.ErrPasswordExpired, domain.ErrPasswordChangeRequired, domain.ErrServerBusy},
+		}},
+		{Handler: auth.ChangeExpiredPassword, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/v1/auth/password-change", OperationID: "changeExpiredPassword", Tag: "Auth",
+			Summary: "Set a new password with the challenge returned by login and start a session",
+			Request: domain.ChangeExpiredPasswordRequest{}, Response: domain.AuthResponse{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrInvalidPasswordChallenge, domain.ErrWeakPassword, domain.ErrPasswordExpired, domain.ErrServerBusy},
+		}},
+		{Handler: auth.Refresh, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/v1/auth/refresh", OperationID: "refresh", Tag: "Auth",
+			Summary: "Rotate the session tokens, from the body or the refresh_token cookie",
+			Request: domain.RefreshRequest{}, OptionalRequest: true, Response: domain.AuthResponse{}, Status: http.StatusOK,
+			Errors: []error{domain.ErrInvalidRefreshToken, domain.ErrUserDeactivated},
+		}},
+		{Handler: auth.Logout, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/v1/auth/logout", OperationID: "logout", Tag: "Auth", Auth: true,
+			Summary: "End the current session",
+			Status:  http.StatusOK,
+			Errors:  []error{domain.ErrSessionRequired},
+		}},
+		{Handler: auth.Me, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/v1/auth/me", OperationID: "me", Tag: "Auth", Auth: true,
+			Summary:  "Return the signed in user",
+			Response: domain.UserResponse{}, Status: http.StatusOK,
+		}},
+		{Handler: auth.ListSessions, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/v1/auth/sessions", OperationID: "listSessions", Tag: "Auth", Auth: true,
+			Summary:  "List the sessions of the signed in user, including devices approved through the device flow",
+			Response: domain.SessionsResponse{}, Status: http.StatusOK,
+		}},
+		{Handler: auth.RevokeSession, Route: openapi.Route{
+			Method: http.MethodDelete, Path: "/v1/auth/sessions/:id", OperationID: "revokeSession", Tag: "Auth", Auth: true,
+			Summary: "End a session of the signed in user, signing out its browser or device",
+			Status:  http.StatusNoContent,
+			Errors:  []error{domain.ErrSessionRequired, domain.ErrSessionNotFound},
+		}},
+		{Handler: h.Social.Providers, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/v1/auth/providers", OperationID: "socialProviders", Tag: "Auth",
+			Summary:  "List the identity providers users can sign in with",
+			Response: domain.SocialProvidersResponse{}, Status: http.StatusOK,
+		}},
+		{Handler: h.Social.Login, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/v1/auth/social/:provider", OperationID: "socialLogin", Tag: "Auth",
+			Summary: "Redirect to the identity provider to sign in; return_to is where to go once signed in",
+			Query:   domain.SocialLoginRequest{}, Status: http.StatusFound,
+			Errors: []error{domain.ErrSocialProviderNotFound, domain.ErrSocialLoginFailed},
+		}},
+		{Handler: h.Social.Callback, Page: true, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/v1/auth/social/:provider/callback", OperationID: "socialCallback", Tag: "Auth",
+			Summary: "Complete the sign in, set the session cookies and show a page that continues to return_to",
+			Query:   domain.SocialCallbackRequest{}, Status: http.StatusOK,
+			Errors: []error{
+				domain.ErrSocialProviderNotFound, domain.ErrSocialLoginStateInvalid, domain.ErrSocialLoginFailed,
+				domain.ErrSocialEmailNotVerified, domain.ErrUserDeactivated,
+			},
+		}},
+		{Handler: h.APIKey.Create, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/v1/auth/api-keys", OperationID: "createAPIKey", Tag: "APIKeys", Auth: true,
+			Summary: "Create an API key; the key is only shown in this response",
+			Request: domain.CreateAPIKeyRequest{}, Response: domain.CreatedAPIKeyResponse{}, Status: http.StatusCreated,
+			Errors: []error{domain.ErrSessionRequired, domain.ErrAPIKeyNameConflict},
+		}},
+		{Handler: h.APIKey.List, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/v1/auth/api-keys", OperationID: "listAPIKeys", Tag: "APIKeys", Auth: true,
+			Summary:  "List the API keys of the signed in user with their last use",
+			Response: domain.APIKeysResponse{}, Status: http.StatusOK,
+		}},
+		{Handler: h.APIKey.Revoke, Route: openapi.Route{
+			Method: http.MethodDelete, Path: "/v1/auth/api-keys/:id", OperationID: "revokeAPIKey", Tag: "APIKeys", Auth: true,
+			Summary: "Revoke an API key",
+			Status:  http.StatusNoContent,
+			Errors:  []error{domain.ErrAPIKeyNotFound},
+		}},
+		{Handler: h.ServiceAccount.Create, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/v1/admin/service-accounts", OperationID: "createServiceAccount", Tag: "ServiceAccounts",
+			Auth: true, Scopes: []string{domain.RoleAdmin},
+			Summary: "Create a service account; its first client_secret is only shown in this response",
+			Request: domain.CreateServiceAccountRequest{}, Response: domain.CreatedServiceAccountResponse{}, Status: http.StatusCreated,
+			Errors: []error{domain.ErrInvalidScope},
+		}},
+		{Handler: h.ServiceAccount.List, Route: openapi.Route{
+			Method: http.MethodGet, Path: "/v1/admin/service-accounts", OperationID: "listServiceAccounts", Tag: "ServiceAccounts",
+			Auth: true, Scopes: []string{domain.RoleAdmin},
+			Summary:  "List the service accounts with their secrets' last use",
+			Response: domain.ServiceAccountsResponse{}, Status: http.StatusOK,
+		}},
+		{Handler: h.ServiceAccount.Delete, Route: openapi.Route{
+			Method: http.MethodDelete, Path: "/v1/admin/service-accounts/:id", OperationID: "deleteServiceAccount", Tag: "ServiceAccounts",
+			Auth: true, Scopes: []string{domain.RoleAdmin},
+			Summary: "Delete a service account; its tokens stop being accepted",
+			Status:  http.StatusNoContent,
+			Errors:  []error{domain.ErrServiceAccountNotFound},
+		}},
+		{Handler: h.ServiceAccount.CreateSecret, Route: openapi.Route{
+			Method: http.MethodPost, Path: "/v1/admin/service-accounts/:id/secrets", OperationID: "createServiceAccountSecret", Tag: "ServiceAccounts",
+			Auth: true, Scopes: []string{domain.RoleAdmin},
+			Summary:  "Add a client_secret to rotate to; it is only shown in this response",
+			Response: domain.CreatedServiceAccountSecretResponse{}, Status: http.StatusCreated,
+			Errors: []error{domain.ErrServiceAccountNotFound},
+		}},
+		{Handler: h.ServiceAccount.DeleteSecret, Route: openapi.Route{
+			Method: http.MethodDelete, Path: "/v1/admin/service-accounts/:id/secrets/:secret_id", OperationID: "deleteServiceAccountSecret", Tag: "ServiceAccounts",
+			Auth: true, Scopes: []string{domain.RoleAdmin},
+			Summary: "Delete a client_secret once callers use a newer one",
+			Status:  http.StatusNoContent,
+			Errors:  []error{domain.ErrServiceAccountSecretNotFound},
+		}},
+	}
+}
+
+// Guards are the middlewares Register puts in front of protected routes.
+type Guards struct {
+	// Auth authenticates users on routes with Auth.
+	Auth echo.MiddlewareFunc
+	// ServiceAuth authenticates users and service accounts on routes with
+	// Scopes, before RequireScope checks the caller holds them.
+	ServiceAuth  echo.MiddlewareFunc
+	RequireScope func(scopes ...string) echo.MiddlewareFunc
+	// ClientAuth authenticates users on routes with Auth and Clients, also
+	// accepting the access tokens of OpenID Connect clients.
+	ClientAuth echo.MiddlewareFunc
+	// Page sets the security headers of HTML pages on routes with Page.
+	Page echo.MiddlewareFunc
+}
+
+// Register mounts routes on e, guarding the ones that require authentication
+// or scopes.
+func Register(e *echo.Echo, routes []Route, guards Guards) {
+	for _, route := range routes {
+		var middlewares []echo.MiddlewareFunc
+		if route.Page && guards.Page != nil {
+			middlewares = append(middlewares, guards.Page)
+		}
+		switch {
+		case len(route.Scopes) > 0:
+			middlewares = append(middlewares, guards.ServiceAuth, guards.RequireScope(route.Scopes...))
+		case route.Auth && route.Clients:
+			middlewares = append(middlewares, guards.ClientAuth)
+		case route.Auth:
+			middlewares = append(middlewares, guards.Auth)
+		}
+		e.Add(route.Method, route.Path, route.Handler, middlewares...)
+	}
+}
+
+// Document generates the OpenAPI document describing routes.
+func Document(routes []Route) *openapi.Document {
+	specs := make([]openapi.Route, len(routes))
+	for i, route := range routes {
+		specs[i] = route.Route
+	}
+	return openapi.Generate(Info, specs)
+}
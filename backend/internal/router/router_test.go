@@ -0,0 +1,163 @@
+package router
+
+import (
+	"errors"
+	"flag"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/pkg/openapi"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+var update = flag.Bool("update", false, "rewrite docs/openapi.json from the route table")
+
+var specPath = filepath.Join("..", "..", "docs", "openapi.json")
+
+func newHandlers(t *testing.T) Handlers {
+	return Handlers{
+		Health: mockpkg.NewMockHealthCheckHandler(t),
+		Auth:   mockpkg.NewMockAuthHandler(t),
+		Keys:   mockpkg.NewMockKeysHandler(t),
+		OAuth:  mockpkg.NewMockOAuthHandler(t),
+		OIDC:   mockpkg.NewMockOIDCHandler(t),
+		Social: mockpkg.NewMockSocialHandler(t),
+		APIKey: mockpkg.NewMockAPIKeyHandler(t),
+
+		ServiceAccount: mockpkg.NewMockServiceAccountHandler(t),
+	}
+}
+
+func TestDocument(t *testing.T) {
+	t.Run("should match the committed docs/openapi.json", func(t *testing.T) {
+		routes := Routes(newHandlers(t))
+		got, err := openapi.Encode(Document(routes))
+		require.NoError(t, err)
+
+		if *update {
+			require.NoError(t, os.WriteFile(specPath, got, 0o644))
+		}
+
+		want, err := os.ReadFile(specPath)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got),
+			"the route table or a DTO changed; run `make docs` (or go test ./internal/router -update) and commit docs/openapi.json")
+	})
+
+	t.Run("should describe every registered route exactly once", func(t *testing.T) {
+		t.Parallel()
+
+		routes := Routes(newHandlers(t))
+		doc := Document(routes)
+
+		operations := map[string]bool{}
+		for _, route := range routes {
+			assert.NotContains(t, operations, route.OperationID, "duplicate operationId")
+			operations[route.OperationID] = true
+		}
+
+		e := echo.New()
+		Register(e, routes, Guards{RequireScope: requireNothing})
+		for _, registered := range e.Routes() {
+			item, ok := doc.Paths[openapi.Path(registered.Path)]
+			if assert.True(t, ok, "%s is not documented", registered.Path) {
+				assert.Contains(t, *item, strings.ToLower(registered.Method))
+			}
+		}
+		assert.Len(t, e.Routes(), len(routes))
+	})
+}
+
+func TestDocumentValidator(t *testing.T) {
+	t.Run("should compile the request schemas of every operation", func(t *testing.T) {
+		t.Parallel()
+
+		routes := Routes(newHandlers(t))
+		validator, err := openapi.NewValidator(Document(routes))
+		require.NoError(t, err)
+
+		err = validator.Validate("POST", "/v1/user/create-account", map[string]any{
+			"name": "Ana Maria", "email": "ana@test.com", "password": "password123",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("should guard only authenticated routes with sessionAuth", func(t *testing.T) {
+		t.Parallel()
+
+		var guarded []string
+		sessionAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				guarded = append(guarded, c.Request().Method+" "+c.Path())
+				return errors.New("unauthorized")
+			}
+		}
+
+		routes := Routes(newHandlers(t))
+		e := echo.New()
+		Register(e, routes, Guards{Auth: sessionAuth, ClientAuth: sessionAuth, ServiceAuth: sessionAuth, RequireScope: requireNothing})
+
+		var want []string
+		for _, route := range routes {
+			if route.Auth {
+				want = append(want, route.Method+" "+route.Path)
+				req := httptest.NewRequest(route.Method, route.Path, nil)
+				e.ServeHTTP(httptest.NewRecorder(), req)
+			}
+		}
+
+		sort.Strings(want)
+		sort.Strings(guarded)
+		assert.Equal(t, want, guarded)
+		assert.NotEmpty(t, want)
+	})
+	t.Run("should check the scopes of scoped routes after serviceAuth", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+		serviceAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				calls = append(calls, "auth "+c.Request().Method+" "+c.Path())
+				return next(c)
+			}
+		}
+		requireScope := func(scopes ...string) echo.MiddlewareFunc {
+			return func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					calls = append(calls, "scope "+strings.Join(scopes, " "))
+					return errors.New("insufficient scope")
+				}
+			}
+		}
+
+		routes := Routes(newHandlers(t))
+		e := echo.New()
+		Register(e, routes, Guards{Auth: requireNothing(), ServiceAuth: serviceAuth, RequireScope: requireScope})
+
+		var want []string
+		for _, route := range routes {
+			if len(route.Scopes) > 0 {
+				want = append(want, "auth "+route.Method+" "+route.Path, "scope "+strings.Join(route.Scopes, " "))
+				req := httptest.NewRequest(route.Method, route.Path, nil)
+				e.ServeHTTP(httptest.NewRecorder(), req)
+			}
+		}
+
+		assert.Equal(t, want, calls)
+		assert.NotEmpty(t, want)
+	})
+}
+
+func requireNothing(...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+}
@@ -0,0 +1,58 @@
+package security
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // the HIBP corpus is keyed by SHA-1
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// breachedCorpus looks passwords up in a local copy of the Have I Been Pwned
+// Pwned Passwords range files. As with the range API, the first five hex
+// digits of the SHA-1 pick the file and the other 35 are searched in it, so
+// only one small file is read per lookup and the password never leaves the
+// host.
+type breachedCorpus struct {
+	dir string
+}
+
+// count returns how many times password appears in the corpus. A prefix
+// without a range file counts as not breached, so a partial corpus still
+// works.
+func (b *breachedCorpus) count(password string) (int, error) {
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // the HIBP corpus is keyed by SHA-1
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	f, err := os.Open(filepath.Join(b.dir, prefix+".txt"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to open breached password range %s: %w", prefix, err)
+	}
+	defer f.Close() //nolint:errcheck // read only
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineSuffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(lineSuffix, suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return 0, fmt.Errorf("invalid count in breached password range %s: %w", prefix, err)
+		}
+		return n, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read breached password range %s: %w", prefix, err)
+	}
+	return 0, nil
+}
@@ -0,0 +1,62 @@
+package security
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+// CookieSealer encrypts cookie values with AES-256-GCM under the primary
+// key, bound to the cookie name so a value can't be moved to another
+// cookie. Values sealed under a retired key still open. A sealed value
+// reads "<key ID>.<nonce and ciphertext>", in unpadded base64url, which is
+// safe in cookies.
+type CookieSealer struct {
+	keys *keyring
+}
+
+// NewCookieSealer returns nil when no seal keys are configured.
+func NewCookieSealer(cfg domain.CookieConfig) (*CookieSealer, error) {
+	keys, err := loadKeyring(cfg.SealKeys, cfg.SealKeysFile, cfg.SealKeyID, EncryptionKeySize, EncryptionKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cookie seal keys: %w", err)
+	}
+	if keys == nil {
+		return nil, nil
+	}
+	return &CookieSealer{keys: keys}, nil
+}
+
+func (s *CookieSealer) Seal(value, name string) (string, error) {
+	key, err := s.keys.key(s.keys.primary)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(key, []byte(value), []byte(name))
+	if err != nil {
+		return "", err
+	}
+	return s.keys.primary + "." + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func (s *CookieSealer) Open(value, name string) (string, error) {
+	id, encoded, ok := strings.Cut(value, ".")
+	if !ok {
+		return "", fmt.Errorf("failed to open cookie %s: not sealed", name)
+	}
+	key, err := s.keys.key(id)
+	if err != nil {
+		return "", fmt.Errorf("failed to open cookie %s: %w", name, err)
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to open cookie %s: %w", name, err)
+	}
+	plaintext, err := open(key, sealed, []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("failed to open cookie %s: %w", name, err)
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,214 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+const (
+	// EncryptionKeySize is the size of the AES-256 key encryption keys.
+	EncryptionKeySize = 32
+	dataKeySize       = 32
+)
+
+// envelopePrefix starts every sealed value, which reads
+// "enc:v2:<key ID>:<wrapped data key>:<ciphertext>" with both binary parts
+// as unpadded base64 and prefixed by their GCM nonce. legacyEnvelopePrefix
+// values were bound to the column name only; they still open and are
+// sealed again by ReencryptColumns.
+const (
+	envelopePrefix       = "enc:v2:"
+	legacyEnvelopePrefix = "enc:v1:"
+)
+
+// FieldCipher implements envelope encryption of column values: a fresh data
+// key seals each value with AES-GCM and is itself sealed by the primary
+// key encryption key. Both are bound to the table, column and primary key
+// of the row, so a value copied into another column or row does not open.
+type FieldCipher struct {
+	keys *keyring
+	pii  bool
+}
+
+func NewFieldCipher(_ *do.Injector) (domain.FieldCipher, error) {
+	return newFieldCipher(config.Env.Encryption)
+}
+
+func newFieldCipher(cfg domain.EncryptionConfig) (*FieldCipher, error) {
+	keys, err := loadKeyring(cfg.Keys, cfg.KeysFile, cfg.KeyID, EncryptionKeySize, EncryptionKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption keys: %w", err)
+	}
+	if cfg.PII && keys == nil {
+		return nil, fmt.Errorf("ENCRYPTION_PII needs encryption keys")
+	}
+	return &FieldCipher{keys: keys, pii: cfg.PII}, nil
+}
+
+func (c *FieldCipher) Seal(plaintext string, field domain.SealedField, encrypt bool) (string, error) {
+	if !encrypt || c.keys == nil {
+		// A plaintext that looks sealed would fail to open when read back.
+		if sealedValue(plaintext) {
+			return "", fmt.Errorf("failed to store %s: plaintext starts with %q", field, plaintext[:len(envelopePrefix)])
+		}
+		return plaintext, nil
+	}
+	if len(field.Row) == 0 {
+		return "", fmt.Errorf("failed to seal %s: missing the primary key of the row", field)
+	}
+
+	aad := additionalData(field)
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	kek, err := c.keys.key(c.keys.primary)
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := seal(kek, dataKey, aad)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(dataKey, []byte(plaintext), aad)
+	if err != nil {
+		return "", err
+	}
+
+	return envelopePrefix + c.keys.primary + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (c *FieldCipher) Open(value string, field domain.SealedField) (string, error) {
+	var aad []byte
+	switch {
+	case strings.HasPrefix(value, envelopePrefix):
+		if len(field.Row) == 0 {
+			return "", fmt.Errorf("failed to open %s: missing the primary key of the row", field)
+		}
+		aad = additionalData(field)
+	case strings.HasPrefix(value, legacyEnvelopePrefix):
+		aad = []byte(field.Column)
+	default:
+		return value, nil
+	}
+	if c.keys == nil {
+		return "", fmt.Errorf("failed to open %s: it is encrypted but no encryption keys are configured", field)
+	}
+
+	parts := strings.Split(value[len(envelopePrefix):], ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("failed to open %s: malformed envelope", field)
+	}
+	kek, err := c.keys.key(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", field, err)
+	}
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: malformed data key: %w", field, err)
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: malformed ciphertext: %w", field, err)
+	}
+
+	dataKey, err := open(kek, wrapped, aad)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s data key: %w", field, err)
+	}
+	plaintext, err := open(dataKey, ciphertext, aad)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", field, err)
+	}
+	return string(plaintext), nil
+}
+
+func (c *FieldCipher) Current(value, keyID string) bool {
+	if !sealedValue(value) {
+		return keyID == ""
+	}
+	return keyID != "" && strings.HasPrefix(value, envelopePrefix) && c.KeyID(value) == keyID
+}
+
+// KeyID returns the ID of the key that sealed value, or "" when it is
+// plaintext.
+func (c *FieldCipher) KeyID(value string) string {
+	if !sealedValue(value) {
+		return ""
+	}
+	id, _, _ := strings.Cut(value[len(envelopePrefix):], ":")
+	return id
+}
+
+func (c *FieldCipher) PrimaryKeyID() string {
+	if c.keys == nil {
+		return ""
+	}
+	return c.keys.primary
+}
+
+func (c *FieldCipher) EncryptPII() bool {
+	return c.pii
+}
+
+// sealedValue reports whether value is an envelope of any version.
+func sealedValue(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix) || strings.HasPrefix(value, legacyEnvelopePrefix)
+}
+
+// additionalData encodes field for authentication, each part prefixed by
+// its length so no two fields encode alike.
+func additionalData(field domain.SealedField) []byte {
+	var aad []byte
+	for _, part := range append([]string{field.Table, field.Column}, field.Row...) {
+		aad = binary.AppendUvarint(aad, uint64(len(part)))
+		aad = append(aad, part...)
+	}
+	return aad
+}
+
+// seal encrypts plaintext with AES-GCM under key, authenticating aad, and
+// returns the nonce followed by the ciphertext.
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
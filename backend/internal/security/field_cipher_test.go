@@ -0,0 +1,193 @@
+package security
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+func testKey(t *testing.T, id string) string {
+	t.Helper()
+	key, err := GenerateKey(id, EncryptionKeySize)
+	require.NoError(t, err)
+	return key
+}
+
+var (
+	userName     = domain.SealedField{Table: "user", Column: "name", Row: []string{"user-1"}}
+	codeVerifier = domain.SealedField{Table: "social_login_state", Column: "code_verifier", Row: []string{"state-1"}}
+)
+
+func TestFieldCipher(t *testing.T) {
+	t.Run("should seal values that open only in the same column and row", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := newFieldCipher(domain.EncryptionConfig{Keys: testKey(t, "k1")})
+		require.NoError(t, err)
+
+		sealed, err := c.Seal("Maria da Silva", userName, true)
+		require.NoError(t, err)
+		again, err := c.Seal("Maria da Silva", userName, true)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(sealed, "enc:v2:k1:"), sealed)
+		assert.NotContains(t, sealed, "Maria")
+		assert.NotEqual(t, sealed, again)
+		assert.Equal(t, "k1", c.KeyID(sealed))
+		assert.True(t, c.Current(sealed, "k1"))
+
+		opened, err := c.Open(sealed, userName)
+		require.NoError(t, err)
+		assert.Equal(t, "Maria da Silva", opened)
+
+		for name, field := range map[string]domain.SealedField{
+			"other column": {Table: "user", Column: "avatar", Row: userName.Row},
+			"other table":  {Table: "identity", Column: "name", Row: userName.Row},
+			"other row":    {Table: "user", Column: "name", Row: []string{"user-2"}},
+			"no row":       {Table: "user", Column: "name"},
+			"split key":    {Table: "user", Column: "name", Row: []string{"user", "-1"}},
+		} {
+			_, err = c.Open(sealed, field)
+			assert.Error(t, err, name)
+		}
+		_, err = c.Open(sealed[:len(sealed)-2]+"AA", userName)
+		assert.Error(t, err)
+
+		_, err = c.Seal("Maria da Silva", domain.SealedField{Table: "user", Column: "name"}, true)
+		assert.Error(t, err)
+	})
+
+	t.Run("should open v1 values bound to the column only", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := newFieldCipher(domain.EncryptionConfig{Keys: testKey(t, "k1")})
+		require.NoError(t, err)
+		kek, err := c.keys.key("k1")
+		require.NoError(t, err)
+		dataKey := make([]byte, dataKeySize)
+		wrapped, err := seal(kek, dataKey, []byte("name"))
+		require.NoError(t, err)
+		ciphertext, err := seal(dataKey, []byte("Maria"), []byte("name"))
+		require.NoError(t, err)
+		legacy := "enc:v1:k1:" + base64.RawStdEncoding.EncodeToString(wrapped) + ":" + base64.RawStdEncoding.EncodeToString(ciphertext)
+
+		opened, err := c.Open(legacy, userName)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Maria", opened)
+		assert.Equal(t, "k1", c.KeyID(legacy))
+		assert.False(t, c.Current(legacy, "k1"), "v1 values are sealed again")
+		assert.False(t, c.Current(legacy, ""))
+	})
+
+	t.Run("should pass plaintext through", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := newFieldCipher(domain.EncryptionConfig{Keys: testKey(t, "k1")})
+		require.NoError(t, err)
+
+		stored, err := c.Seal("Maria", userName, false)
+		require.NoError(t, err)
+		assert.Equal(t, "Maria", stored)
+		assert.Equal(t, "", c.KeyID(stored))
+		assert.True(t, c.Current(stored, ""))
+		assert.False(t, c.Current(stored, "k1"))
+
+		opened, err := c.Open("written before encryption", domain.SealedField{Table: "user", Column: "name"})
+		require.NoError(t, err)
+		assert.Equal(t, "written before encryption", opened)
+
+		for _, plaintext := range []string{"enc:v1:looks-sealed", "enc:v2:looks-sealed"} {
+			_, err = c.Seal(plaintext, userName, false)
+			assert.Error(t, err, plaintext)
+		}
+	})
+
+	t.Run("should open values sealed under a retired key", func(t *testing.T) {
+		t.Parallel()
+
+		oldKey, newKey := testKey(t, "2025"), testKey(t, "2026")
+		old, err := newFieldCipher(domain.EncryptionConfig{Keys: oldKey})
+		require.NoError(t, err)
+		sealed, err := old.Seal("code-verifier", codeVerifier, true)
+		require.NoError(t, err)
+
+		c, err := newFieldCipher(domain.EncryptionConfig{Keys: oldKey + "," + newKey, KeyID: "2026"})
+		require.NoError(t, err)
+		assert.Equal(t, "2026", c.PrimaryKeyID())
+
+		opened, err := c.Open(sealed, codeVerifier)
+		require.NoError(t, err)
+		assert.Equal(t, "code-verifier", opened)
+
+		resealed, err := c.Seal(opened, codeVerifier, true)
+		require.NoError(t, err)
+		assert.Equal(t, "2026", c.KeyID(resealed))
+		assert.False(t, c.Current(sealed, "2026"))
+		assert.True(t, c.Current(resealed, "2026"))
+	})
+
+	t.Run("should store plaintext without keys and refuse sealed values", func(t *testing.T) {
+		t.Parallel()
+
+		sealer, err := newFieldCipher(domain.EncryptionConfig{Keys: testKey(t, "k1")})
+		require.NoError(t, err)
+		sealed, err := sealer.Seal("secret", codeVerifier, true)
+		require.NoError(t, err)
+
+		c, err := newFieldCipher(domain.EncryptionConfig{})
+		require.NoError(t, err)
+
+		stored, err := c.Seal("secret", codeVerifier, true)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", stored)
+		assert.Equal(t, "", c.PrimaryKeyID())
+
+		_, err = c.Open(sealed, codeVerifier)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewFieldCipher(t *testing.T) {
+	t.Run("should load keys from the environment and a file", func(t *testing.T) {
+		t.Parallel()
+
+		file := filepath.Join(t.TempDir(), "keys")
+		require.NoError(t, os.WriteFile(file, []byte("# retired\n"+testKey(t, "k2")+"\n\n"), 0o600))
+
+		c, err := newFieldCipher(domain.EncryptionConfig{Keys: testKey(t, "k1"), KeysFile: file})
+		require.NoError(t, err)
+		assert.Equal(t, "k1", c.PrimaryKeyID())
+
+		c, err = newFieldCipher(domain.EncryptionConfig{KeysFile: file, KeyID: "k2"})
+		require.NoError(t, err)
+		assert.Equal(t, "k2", c.PrimaryKeyID())
+	})
+
+	t.Run("should reject invalid keys and settings", func(t *testing.T) {
+		t.Parallel()
+
+		key := testKey(t, "k1")
+		for name, cfg := range map[string]domain.EncryptionConfig{
+			"no separator":     {Keys: "c2hvcnQ="},
+			"invalid ID":       {Keys: "bad id:" + strings.TrimPrefix(key, "k1:")},
+			"not base64":       {Keys: "k1:not base64!"},
+			"wrong size":       {Keys: "k1:c2hvcnQ="},
+			"duplicate ID":     {Keys: key + "," + key},
+			"unknown primary":  {Keys: key, KeyID: "k2"},
+			"primary, no keys": {KeyID: "k1"},
+			"PII without keys": {PII: true},
+			"missing file":     {KeysFile: filepath.Join(t.TempDir(), "missing")},
+		} {
+			_, err := newFieldCipher(cfg)
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+)
+
+// hashPool bounds how many password hashes run at once. Hashing is CPU
+// bound, so running more of them than there are CPUs only makes every one
+// slower and starves other requests; callers over the limit queue for a
+// free slot and are shed with a ServerBusyError once queueTimeout passes.
+type hashPool struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+}
+
+func newHashPool(workers int, queueTimeout time.Duration) (*hashPool, error) {
+	if workers < 0 {
+		return nil, fmt.Errorf("invalid password hash workers %d: must not be negative", workers)
+	}
+	if queueTimeout <= 0 {
+		return nil, fmt.Errorf("invalid password hash queue timeout %s: must be positive", queueTimeout)
+	}
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &hashPool{slots: make(chan struct{}, workers), queueTimeout: queueTimeout}, nil
+}
+
+// run calls fn on a free slot, waiting for one up to the queue timeout or
+// until ctx is done.
+func (p *hashPool) run(ctx context.Context, fn func()) error {
+	if err := p.acquire(ctx); err != nil {
+		return err
+	}
+	defer func() { <-p.slots }()
+
+	metrics.PasswordHashInFlight.Inc()
+	defer metrics.PasswordHashInFlight.Dec()
+
+	fn()
+	return nil
+}
+
+func (p *hashPool) acquire(ctx context.Context) error {
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	metrics.PasswordHashQueueDepth.Inc()
+	defer metrics.PasswordHashQueueDepth.Dec()
+
+	timer := time.NewTimer(p.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		metrics.PasswordHashRejectedTotal.Inc()
+		return &domain.ServerBusyError{After: p.queueTimeout}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
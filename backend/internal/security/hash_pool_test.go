@@ -0,0 +1,87 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+// occupy holds every slot of p until the returned func is called.
+func occupy(t *testing.T, p *hashPool) func() {
+	t.Helper()
+	release := make(chan struct{})
+	for range cap(p.slots) {
+		started := make(chan struct{})
+		go func() {
+			_ = p.run(context.Background(), func() {
+				close(started)
+				<-release
+			})
+		}()
+		<-started
+	}
+	return func() { close(release) }
+}
+
+func TestHashPool(t *testing.T) {
+	t.Run("should size the pool to the CPUs by default", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := newHashPool(0, time.Second)
+		require.NoError(t, err)
+
+		assert.Equal(t, runtime.GOMAXPROCS(0), cap(p.slots))
+	})
+
+	t.Run("should reject with a retryable busy error once the queue timeout passes", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := newHashPool(1, 20*time.Millisecond)
+		require.NoError(t, err)
+		release := occupy(t, p)
+		defer release()
+
+		ran := false
+		err = p.run(context.Background(), func() { ran = true })
+
+		assert.ErrorIs(t, err, domain.ErrServerBusy)
+		var busyErr *domain.ServerBusyError
+		require.True(t, errors.As(err, &busyErr))
+		assert.Equal(t, 20*time.Millisecond, busyErr.RetryAfter())
+		assert.False(t, ran)
+	})
+
+	t.Run("should run queued calls when a worker frees up", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := newHashPool(1, time.Second)
+		require.NoError(t, err)
+		release := occupy(t, p)
+		time.AfterFunc(10*time.Millisecond, release)
+
+		ran := false
+		assert.NoError(t, p.run(context.Background(), func() { ran = true }))
+		assert.True(t, ran)
+	})
+
+	t.Run("should stop waiting when the request is canceled", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := newHashPool(1, time.Minute)
+		require.NoError(t, err)
+		release := occupy(t, p)
+		defer release()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, p.run(ctx, func() {}), context.Canceled)
+	})
+}
@@ -1,21 +1,31 @@
 package security
 
 import (
+	"context"
 	"crypto/rsa"
+	"encoding/base64"
 	"fmt"
+	"math/big"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/samber/do"
 
 	"github.com/SergioLNeves/migos/internal/config"
 	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
 )
 
 type JWTProvider struct {
 	privateKey         *rsa.PrivateKey
 	publicKey          *rsa.PublicKey
+	keyID              string
+	issuer             string
+	audience           string
 	accessTokenExpiry  time.Duration
 	refreshTokenExpiry time.Duration
 }
@@ -44,22 +54,31 @@ func NewJWTProvider(_ *do.Injector) (domain.TokenProvider, error) {
 	return &JWTProvider{
 		privateKey:         privateKey,
 		publicKey:          publicKey,
+		keyID:              KeyID(publicKey),
+		issuer:             config.Env.Token.Issuer,
+		audience:           config.Env.Token.Audience,
 		accessTokenExpiry:  time.Duration(config.Env.Token.AccessTokenExpiry) * time.Minute,
 		refreshTokenExpiry: time.Duration(config.Env.Token.RefreshTokenExpiry) * time.Minute,
 	}, nil
 }
 
-func (j *JWTProvider) GenerateAccessToken(sessionID string) (string, error) {
+// GenerateAccessToken signs a token other services can verify offline
+// against the JWKS, checking the issuer and audience.
+func (j *JWTProvider) GenerateAccessToken(ctx context.Context, userID, sessionID string) (_ string, err error) {
+	_, span := tracing.Start(ctx, "JWTProvider.GenerateAccessToken")
+	defer tracing.End(span, &err)
+
 	now := time.Now()
 	claims := jwt.MapClaims{
-		"sub": sessionID,
-		"iat": now.Unix(),
-		"exp": now.Add(j.accessTokenExpiry).Unix(),
+		"sub":        userID,
+		"session_id": sessionID,
+		"iss":        j.issuer,
+		"aud":        j.audience,
+		"iat":        now.Unix(),
+		"exp":        now.Add(j.accessTokenExpiry).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-
-	signed, err := token.SignedString(j.privateKey)
+	signed, err := j.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign access token: %w", err)
 	}
@@ -67,18 +86,53 @@ func (j *JWTProvider) GenerateAccessToken(sessionID string) (string, error) {
 	return signed, nil
 }
 
-func (j *JWTProvider) GenerateRefreshToken(userID string, sessionID string) (string, error) {
+// GenerateClientAccessToken signs the access token of a session started by
+// an OpenID Connect client. Its audience is the client, not j.audience, so
+// other services and the first-party routes reject it; client_id and scope
+// tell this service which client it belongs to and what it was granted.
+func (j *JWTProvider) GenerateClientAccessToken(ctx context.Context, userID, sessionID, clientID, scope string) (_ string, err error) {
+	_, span := tracing.Start(ctx, "JWTProvider.GenerateClientAccessToken")
+	defer tracing.End(span, &err)
+
 	now := time.Now()
 	claims := jwt.MapClaims{
 		"sub":        userID,
 		"session_id": sessionID,
+		"client_id":  clientID,
+		"scope":      scope,
+		"iss":        j.issuer,
+		"aud":        clientID,
 		"iat":        now.Unix(),
-		"exp":        now.Add(j.refreshTokenExpiry).Unix(),
+		"exp":        now.Add(j.accessTokenExpiry).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	signed, err := j.sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign client access token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (j *JWTProvider) GenerateRefreshToken(ctx context.Context, userID string, sessionID string) (_ string, err error) {
+	_, span := tracing.Start(ctx, "JWTProvider.GenerateRefreshToken")
+	defer tracing.End(span, &err)
 
-	signed, err := token.SignedString(j.privateKey)
+	// No audience: refresh tokens are only accepted by this service, so
+	// verifiers checking "aud" reject them as access tokens. The jti keeps
+	// two tokens of a session issued in the same second apart, so a rotated
+	// token never equals the one it replaced.
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":        userID,
+		"session_id": sessionID,
+		"jti":        uuid.NewString(),
+		"iss":        j.issuer,
+		"iat":        now.Unix(),
+		"exp":        now.Add(j.refreshTokenExpiry).Unix(),
+	}
+
+	signed, err := j.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign refresh token: %w", err)
 	}
@@ -86,7 +140,73 @@ func (j *JWTProvider) GenerateRefreshToken(userID string, sessionID string) (str
 	return signed, nil
 }
 
-func (j *JWTProvider) ParseAccessToken(tokenString string) (*domain.AccessTokenClaims, error) {
+// GenerateIDToken signs an OpenID Connect ID token. Its audience is the
+// client, never j.audience, and it has no session_id claim, so it is not
+// accepted as an access or refresh token.
+func (j *JWTProvider) GenerateIDToken(ctx context.Context, idClaims domain.IDTokenClaims) (_ string, err error) {
+	_, span := tracing.Start(ctx, "JWTProvider.GenerateIDToken")
+	defer tracing.End(span, &err)
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":       idClaims.Subject,
+		"aud":       idClaims.Audience,
+		"iss":       j.issuer,
+		"iat":       now.Unix(),
+		"exp":       now.Add(j.accessTokenExpiry).Unix(),
+		"auth_time": idClaims.AuthTime.Unix(),
+	}
+	optional := map[string]string{
+		"sid":     idClaims.SessionID,
+		"nonce":   idClaims.Nonce,
+		"name":    idClaims.Name,
+		"email":   idClaims.Email,
+		"picture": idClaims.Picture,
+	}
+	for name, value := range optional {
+		if value != "" {
+			claims[name] = value
+		}
+	}
+
+	signed, err := j.sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign id token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// GenerateServiceAccountToken signs an access token for a service account.
+// It carries client_id and scope instead of session_id, so it is verified
+// like a user access token but never accepted where a session is needed.
+func (j *JWTProvider) GenerateServiceAccountToken(ctx context.Context, serviceAccountID string, scopes []string) (_ string, err error) {
+	_, span := tracing.Start(ctx, "JWTProvider.GenerateServiceAccountToken")
+	defer tracing.End(span, &err)
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":       serviceAccountID,
+		"client_id": serviceAccountID,
+		"scope":     strings.Join(scopes, " "),
+		"iss":       j.issuer,
+		"aud":       j.audience,
+		"iat":       now.Unix(),
+		"exp":       now.Add(j.accessTokenExpiry).Unix(),
+	}
+
+	signed, err := j.sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign service account token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (j *JWTProvider) ParseAccessToken(ctx context.Context, tokenString string) (_ *domain.AccessTokenClaims, err error) {
+	_, span := tracing.Start(ctx, "JWTProvider.ParseAccessToken")
+	defer tracing.End(span, &err)
+
 	token, err := j.parseToken(tokenString, jwt.WithoutClaimsValidation())
 	if err != nil {
 		return nil, err
@@ -97,12 +217,69 @@ func (j *JWTProvider) ParseAccessToken(tokenString string) (*domain.AccessTokenC
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return nil, fmt.Errorf("invalid token expiration")
+	}
+
+	subject, _ := claims["sub"].(string)
+	sessionID, hasSession := claims["session_id"].(string)
+	if clientID, ok := claims["client_id"].(string); ok && hasSession {
+		audience, err := claims.GetAudience()
+		if err != nil || !slices.Contains(audience, clientID) || clientID == "" {
+			return nil, fmt.Errorf("not an access token")
+		}
+		scope, _ := claims["scope"].(string)
+		return &domain.AccessTokenClaims{
+			UserID:       subject,
+			SessionID:    sessionID,
+			OIDCClientID: clientID,
+			Scopes:       strings.Fields(scope),
+			IssuedAt:     issuedAt(claims),
+			ExpiresAt:    expiresAt.Time,
+		}, nil
+	}
+	if clientID, ok := claims["client_id"].(string); ok {
+		audience, err := claims.GetAudience()
+		if err != nil || !slices.Contains(audience, j.audience) || clientID == "" {
+			return nil, fmt.Errorf("not an access token")
+		}
+		scope, _ := claims["scope"].(string)
+		return &domain.AccessTokenClaims{
+			ClientID:  clientID,
+			Scopes:    strings.Fields(scope),
+			IssuedAt:  issuedAt(claims),
+			ExpiresAt: expiresAt.Time,
+		}, nil
+	}
+	if !hasSession {
+		// Issued before access tokens carried the user: "sub" held the
+		// session. Accepted until they expire. Those never had an
+		// audience, unlike ID tokens.
+		if _, hasAudience := claims["aud"]; hasAudience {
+			return nil, fmt.Errorf("not an access token")
+		}
+		return &domain.AccessTokenClaims{SessionID: subject, ExpiresAt: expiresAt.Time}, nil
+	}
+
+	// Refresh tokens carry the same sub and session_id but no audience.
+	audience, err := claims.GetAudience()
+	if err != nil || !slices.Contains(audience, j.audience) {
+		return nil, fmt.Errorf("not an access token")
+	}
+
 	return &domain.AccessTokenClaims{
-		SessionID: claims["sub"].(string),
+		UserID:    subject,
+		SessionID: sessionID,
+		IssuedAt:  issuedAt(claims),
+		ExpiresAt: expiresAt.Time,
 	}, nil
 }
 
-func (j *JWTProvider) ParseRefreshToken(tokenString string) (*domain.RefreshTokenClaims, error) {
+func (j *JWTProvider) ParseRefreshToken(ctx context.Context, tokenString string) (_ *domain.RefreshTokenClaims, err error) {
+	_, span := tracing.Start(ctx, "JWTProvider.ParseRefreshToken")
+	defer tracing.End(span, &err)
+
 	token, err := j.parseToken(tokenString)
 	if err != nil {
 		return nil, err
@@ -113,12 +290,51 @@ func (j *JWTProvider) ParseRefreshToken(tokenString string) (*domain.RefreshToke
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
+	if _, ok := claims["aud"]; ok {
+		return nil, fmt.Errorf("not a refresh token")
+	}
+
+	userID, _ := claims["sub"].(string)
+	sessionID, _ := claims["session_id"].(string)
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil || userID == "" || sessionID == "" {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+
 	return &domain.RefreshTokenClaims{
-		UserID:    claims["sub"].(string),
-		SessionID: claims["session_id"].(string),
+		UserID:    userID,
+		SessionID: sessionID,
+		IssuedAt:  issuedAt(claims),
+		ExpiresAt: expiresAt.Time,
 	}, nil
 }
 
+func issuedAt(claims jwt.MapClaims) time.Time {
+	iat, err := claims.GetIssuedAt()
+	if err != nil || iat == nil {
+		return time.Time{}
+	}
+	return iat.Time
+}
+
+// JWKS returns the public signing key for /.well-known/jwks.json.
+func (j *JWTProvider) JWKS() domain.JWKS {
+	return domain.JWKS{Keys: []domain.JWK{{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: jwt.SigningMethodRS256.Alg(),
+		Kid: j.keyID,
+		N:   base64.RawURLEncoding.EncodeToString(j.publicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(j.publicKey.E)).Bytes()),
+	}}}
+}
+
+func (j *JWTProvider) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = j.keyID
+	return token.SignedString(j.privateKey)
+}
+
 func (j *JWTProvider) parseToken(tokenString string, opts ...jwt.ParserOption) (*jwt.Token, error) {
 	keyFunc := func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
@@ -0,0 +1,120 @@
+package security
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// keyIDPattern keeps key IDs safe to embed in hashes and envelopes, which
+// use "$" and ":" as separators.
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// keyring holds named keys so data sealed under a retired key still opens
+// while new data uses the primary one.
+type keyring struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// loadKeyring reads "id:base64" keys given inline, comma separated, and in
+// file, one per line with "#" comments. primary defaults to the first key.
+// It returns nil when no keys are given. Every key must be between minSize
+// and maxSize bytes long.
+func loadKeyring(inline, file, primary string, minSize, maxSize int) (*keyring, error) {
+	entries := strings.Split(inline, ",")
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open key file: %w", err)
+		}
+		defer f.Close() //nolint:errcheck // read only
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			entries = append(entries, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read key file %s: %w", file, err)
+		}
+	}
+
+	ring := &keyring{keys: map[string][]byte{}}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(entry, ":")
+		// Not quoting the entry keeps a misplaced key out of the logs.
+		if !ok {
+			return nil, fmt.Errorf("invalid key entry: must be ID:BASE64")
+		}
+		if !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid key ID %q: must be up to 32 letters, digits, - or _", id)
+		}
+		if _, dup := ring.keys[id]; dup {
+			return nil, fmt.Errorf("duplicate key ID %q", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", id, err)
+		}
+		if len(key) < minSize || len(key) > maxSize {
+			return nil, fmt.Errorf("invalid key %q: %d bytes, must be %s", id, len(key), sizeRange(minSize, maxSize))
+		}
+
+		ring.keys[id] = key
+		if ring.primary == "" {
+			ring.primary = id
+		}
+	}
+
+	if len(ring.keys) == 0 {
+		if primary != "" {
+			return nil, fmt.Errorf("key ID %q is set but no keys are configured", primary)
+		}
+		return nil, nil
+	}
+	if primary != "" {
+		if _, ok := ring.keys[primary]; !ok {
+			return nil, fmt.Errorf("unknown primary key ID %q", primary)
+		}
+		ring.primary = primary
+	}
+	return ring, nil
+}
+
+func sizeRange(minSize, maxSize int) string {
+	if minSize == maxSize {
+		return fmt.Sprintf("%d", minSize)
+	}
+	return fmt.Sprintf("between %d and %d", minSize, maxSize)
+}
+
+// key returns the key named id.
+func (r *keyring) key(id string) ([]byte, error) {
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", id)
+	}
+	return key, nil
+}
+
+// GenerateKey returns a new random key as an "id:base64" entry for the key
+// settings and files.
+func GenerateKey(id string, size int) (string, error) {
+	if !keyIDPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid key ID %q: must be up to 32 letters, digits, - or _", id)
+	}
+	key := make([]byte, size)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return id + ":" + base64.StdEncoding.EncodeToString(key), nil
+}
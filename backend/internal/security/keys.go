@@ -0,0 +1,119 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+)
+
+const DefaultKeySize = 2048
+
+// GenerateRSAKeyPair writes a new PKCS#1 private key and its PKIX public key
+// as PEM files. It refuses to overwrite existing files.
+func GenerateRSAKeyPair(privatePath, publicPath string, bits int) error {
+	for _, path := range []string{privatePath, publicPath} {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("key file %s already exists", path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to stat key file: %w", err)
+		}
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	privPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+	if err := os.WriteFile(privatePath, privPEM, 0o600); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	pubPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: pubDER,
+	})
+	if err := os.WriteFile(publicPath, pubPEM, 0o644); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	return nil
+}
+
+// RotateRSAKeyPair moves the current key pair aside with a timestamp suffix
+// and puts a fresh one in its place. It returns the backup paths. The new
+// pair is generated next to the current one first, and every rename is
+// undone on failure, so the current pair is never left half replaced.
+// Tokens signed with the old key stop verifying once the server restarts.
+func RotateRSAKeyPair(privatePath, publicPath string, bits int) (_, _ string, err error) {
+	stamp := "." + time.Now().UTC().Format("20060102T150405Z")
+	privBackup, pubBackup := privatePath+stamp+".bak", publicPath+stamp+".bak"
+	privNew, pubNew := privatePath+stamp+".new", publicPath+stamp+".new"
+
+	for _, path := range []string{privatePath, publicPath} {
+		if _, err := os.Stat(path); err != nil {
+			return "", "", fmt.Errorf("failed to stat key file: %w", err)
+		}
+	}
+
+	if err := GenerateRSAKeyPair(privNew, pubNew, bits); err != nil {
+		_ = os.Remove(privNew)
+		_ = os.Remove(pubNew)
+		return "", "", err
+	}
+
+	// Each completed rename is undone, last first, if a later one fails.
+	var undo []func()
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+		_ = os.Remove(privNew)
+		_ = os.Remove(pubNew)
+	}()
+	moves := []struct{ from, to, what string }{
+		{privatePath, privBackup, "back up private key"},
+		{publicPath, pubBackup, "back up public key"},
+		{privNew, privatePath, "install private key"},
+		{pubNew, publicPath, "install public key"},
+	}
+	for _, move := range moves {
+		if err := os.Rename(move.from, move.to); err != nil {
+			return "", "", fmt.Errorf("failed to %s: %w", move.what, err)
+		}
+		undo = append(undo, func() { _ = os.Rename(move.to, move.from) })
+	}
+
+	return privBackup, pubBackup, nil
+}
+
+// KeyID returns the RFC 7638 thumbprint of key, used as the "kid" of the
+// tokens it verifies so a rotated key gets a new ID.
+func KeyID(key *rsa.PublicKey) string {
+	// Members in lexicographic order, no whitespace, as the RFC requires.
+	thumbprint := fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`,
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+	)
+	sum := sha256.Sum256([]byte(thumbprint))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
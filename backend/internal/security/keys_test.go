@@ -0,0 +1,58 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateRSAKeyPair(t *testing.T) {
+	newPair := func(t *testing.T) (string, string) {
+		dir := t.TempDir()
+		privatePath, publicPath := filepath.Join(dir, "private.pem"), filepath.Join(dir, "public.pem")
+		require.NoError(t, GenerateRSAKeyPair(privatePath, publicPath, DefaultKeySize))
+		return privatePath, publicPath
+	}
+	read := func(t *testing.T, path string) string {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	t.Run("should back up the current pair and put a new one in place", func(t *testing.T) {
+		t.Parallel()
+
+		privatePath, publicPath := newPair(t)
+		oldPrivate, oldPublic := read(t, privatePath), read(t, publicPath)
+
+		privBackup, pubBackup, err := RotateRSAKeyPair(privatePath, publicPath, DefaultKeySize)
+
+		require.NoError(t, err)
+		assert.Equal(t, oldPrivate, read(t, privBackup))
+		assert.Equal(t, oldPublic, read(t, pubBackup))
+		assert.NotEqual(t, oldPrivate, read(t, privatePath))
+		assert.NotEqual(t, oldPublic, read(t, publicPath))
+		entries, err := os.ReadDir(filepath.Dir(privatePath))
+		require.NoError(t, err)
+		assert.Len(t, entries, 4)
+	})
+
+	t.Run("should leave the current pair untouched when it fails", func(t *testing.T) {
+		t.Parallel()
+
+		privatePath, publicPath := newPair(t)
+		oldPrivate, oldPublic := read(t, privatePath), read(t, publicPath)
+
+		_, _, err := RotateRSAKeyPair(privatePath, publicPath, 16)
+
+		require.Error(t, err)
+		assert.Equal(t, oldPrivate, read(t, privatePath))
+		assert.Equal(t, oldPublic, read(t, publicPath))
+		entries, err := os.ReadDir(filepath.Dir(privatePath))
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+}
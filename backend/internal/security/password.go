@@ -0,0 +1,285 @@
+package security
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samber/do"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+
+	// pepperPrefix marks a hash of the HMAC of the password under a pepper
+	// key, followed by the key ID and the hash itself:
+	// $pepper$<key ID>$argon2id$v=19$...
+	pepperPrefix  = "$pepper$"
+	minPepperSize = 32
+	maxPepperSize = 64
+)
+
+// ErrPasswordMismatch is returned by Check when the password does not match
+// the hash.
+var ErrPasswordMismatch = errors.New("password does not match")
+
+// Argon2Params are the cost parameters of argon2id. Memory is in KiB.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// PasswordHasher hashes new passwords with one algorithm and verifies hashes
+// of every supported one, telling them apart by the prefix of the encoded
+// hash: "$argon2id$" (PHC string format) or "$2a$"/"$2b$"/"$2y$" (bcrypt).
+// Hash and Check run on a bounded pool of workers. With pepper keys, the
+// password is replaced by its HMAC under the primary key before hashing, so
+// a stolen database alone can't be brute forced.
+type PasswordHasher struct {
+	algorithm  string
+	argon2     Argon2Params
+	bcryptCost int
+	pool       *hashPool
+	pepper     *keyring
+}
+
+func NewPasswordHasher(_ *do.Injector) (domain.PasswordHasher, error) {
+	return newPasswordHasher(config.Env.Password)
+}
+
+func newPasswordHasher(cfg domain.PasswordConfig) (*PasswordHasher, error) {
+	params := Argon2Params{Memory: cfg.Argon2Memory, Iterations: cfg.Argon2Iterations, Parallelism: cfg.Argon2Parallelism}
+
+	switch cfg.HashAlgorithm {
+	case domain.PasswordAlgorithmArgon2id:
+		if params.Iterations < 1 || params.Parallelism < 1 || params.Memory < 8*uint32(params.Parallelism) {
+			return nil, fmt.Errorf("invalid argon2id parameters: memory must be at least 8 KiB per thread and iterations and parallelism at least 1")
+		}
+	case domain.PasswordAlgorithmBcrypt:
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("invalid bcrypt cost %d: must be between %d and %d", cfg.BcryptCost, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", cfg.HashAlgorithm)
+	}
+
+	pool, err := newHashPool(cfg.HashWorkers, cfg.HashQueueTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	pepper, err := loadKeyring(cfg.PepperKeys, cfg.PepperKeysFile, cfg.PepperKeyID, minPepperSize, maxPepperSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pepper keys: %w", err)
+	}
+
+	return &PasswordHasher{algorithm: cfg.HashAlgorithm, argon2: params, bcryptCost: cfg.BcryptCost, pool: pool, pepper: pepper}, nil
+}
+
+func (h *PasswordHasher) Hash(ctx context.Context, password string) (hashed string, err error) {
+	ctx, span := tracing.Start(ctx, "PasswordHasher.Hash")
+	defer tracing.End(span, &err)
+
+	if poolErr := h.pool.run(ctx, func() { hashed, err = h.hash(password) }); poolErr != nil {
+		return "", poolErr
+	}
+	return hashed, err
+}
+
+func (h *PasswordHasher) hash(password string) (string, error) {
+	defer prometheus.NewTimer(metrics.PasswordHashDuration.WithLabelValues("hash", h.algorithm)).ObserveDuration()
+
+	prefix := ""
+	if h.pepper != nil {
+		peppered, err := h.applyPepper(h.pepper.primary, password)
+		if err != nil {
+			return "", err
+		}
+		prefix, password = pepperPrefix+h.pepper.primary, peppered
+	}
+
+	hashed, err := h.hashWithAlgorithm(password)
+	if err != nil {
+		return "", err
+	}
+	return prefix + hashed, nil
+}
+
+func (h *PasswordHasher) hashWithAlgorithm(password string) (string, error) {
+	if h.algorithm == domain.PasswordAlgorithmBcrypt {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash password: %w", err)
+		}
+		return string(hashed), nil
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, h.argon2.Iterations, h.argon2.Memory, h.argon2.Parallelism, argon2KeyLength)
+	return encodeArgon2(h.argon2, salt, key), nil
+}
+
+// Check returns nil when password matches hash, whichever supported
+// algorithm made it.
+func (h *PasswordHasher) Check(ctx context.Context, password, hash string) (err error) {
+	ctx, span := tracing.Start(ctx, "PasswordHasher.Check")
+	defer tracing.End(span, &err)
+
+	if poolErr := h.pool.run(ctx, func() { err = h.check(password, hash) }); poolErr != nil {
+		return poolErr
+	}
+	return err
+}
+
+func (h *PasswordHasher) check(password, hash string) error {
+	algorithm := hashAlgorithm(hash)
+	defer prometheus.NewTimer(metrics.PasswordHashDuration.WithLabelValues("check", algorithm)).ObserveDuration()
+
+	keyID, hash := splitPepper(hash)
+	if keyID != "" {
+		peppered, err := h.applyPepper(keyID, password)
+		if err != nil {
+			return err
+		}
+		password = peppered
+	}
+
+	switch algorithm {
+	case domain.PasswordAlgorithmArgon2id:
+		params, salt, key, err := decodeArgon2(hash)
+		if err != nil {
+			return err
+		}
+		other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(key, other) != 1 {
+			return ErrPasswordMismatch
+		}
+		return nil
+	case domain.PasswordAlgorithmBcrypt:
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return ErrPasswordMismatch
+			}
+			return fmt.Errorf("invalid bcrypt hash: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported password hash format")
+	}
+}
+
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	if hashAlgorithm(hash) != h.algorithm {
+		return true
+	}
+
+	primary := ""
+	if h.pepper != nil {
+		primary = h.pepper.primary
+	}
+	keyID, hash := splitPepper(hash)
+	if keyID != primary {
+		return true
+	}
+
+	if h.algorithm == domain.PasswordAlgorithmBcrypt {
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost != h.bcryptCost
+	}
+
+	params, salt, key, err := decodeArgon2(hash)
+	return err != nil || params != h.argon2 || len(salt) != argon2SaltLength || len(key) != argon2KeyLength
+}
+
+// applyPepper returns the HMAC-SHA256 of password under the pepper key
+// keyID, base64 encoded so it also fits bcrypt's 72 byte limit.
+func (h *PasswordHasher) applyPepper(keyID, password string) (string, error) {
+	if h.pepper == nil {
+		return "", fmt.Errorf("password hash uses pepper key %q but no pepper keys are configured", keyID)
+	}
+	key, err := h.pepper.key(keyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply pepper: %w", err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(password))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// splitPepper returns the pepper key ID of hash, or "" when it is not
+// peppered, and the hash without the pepper prefix.
+func splitPepper(hash string) (string, string) {
+	if !strings.HasPrefix(hash, pepperPrefix) {
+		return "", hash
+	}
+	keyID, rest, ok := strings.Cut(strings.TrimPrefix(hash, pepperPrefix), "$")
+	if !ok {
+		return "", hash
+	}
+	return keyID, "$" + rest
+}
+
+func hashAlgorithm(hash string) string {
+	_, hash = splitPepper(hash)
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return domain.PasswordAlgorithmArgon2id
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return domain.PasswordAlgorithmBcrypt
+	default:
+		return "unknown"
+	}
+}
+
+// encodeArgon2 writes the PHC string format used by the reference
+// implementation: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>.
+func encodeArgon2(params Argon2Params, salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decodeArgon2(hash string) (params Argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2id version")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+	}
+	if params.Iterations < 1 || params.Parallelism < 1 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id parameters")
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id key")
+	}
+	return params, salt, key, nil
+}
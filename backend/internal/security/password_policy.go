@@ -0,0 +1,130 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+// minPersonalInfoLength keeps short name words such as "de" from rejecting
+// most passwords.
+const minPersonalInfoLength = 3
+
+// PasswordPolicy checks new passwords against the configured length and
+// character class rules, the owner's email and name, and a local corpus of
+// breached passwords.
+type PasswordPolicy struct {
+	cfg      domain.PasswordPolicyConfig
+	breached *breachedCorpus
+}
+
+func NewPasswordPolicy(_ *do.Injector) (domain.PasswordPolicy, error) {
+	return newPasswordPolicy(config.Env.Policy)
+}
+
+func newPasswordPolicy(cfg domain.PasswordPolicyConfig) (*PasswordPolicy, error) {
+	if cfg.MinLength < 1 || cfg.MaxLength < cfg.MinLength {
+		return nil, fmt.Errorf("invalid password length limits %d-%d: minimum must be at least 1 and not above the maximum", cfg.MinLength, cfg.MaxLength)
+	}
+
+	policy := &PasswordPolicy{cfg: cfg}
+	if cfg.BreachedCorpus != "" {
+		if cfg.BreachedMinCount < 1 {
+			return nil, fmt.Errorf("invalid breached password minimum count %d: must be at least 1", cfg.BreachedMinCount)
+		}
+		info, err := os.Stat(cfg.BreachedCorpus)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open breached password corpus: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("breached password corpus %s is not a directory", cfg.BreachedCorpus)
+		}
+		policy.breached = &breachedCorpus{dir: cfg.BreachedCorpus}
+	}
+	return policy, nil
+}
+
+func (p *PasswordPolicy) Check(ctx context.Context, password string, owner domain.PasswordOwner) (violations []domain.PasswordViolation, err error) {
+	_, span := tracing.Start(ctx, "PasswordPolicy.Check")
+	defer tracing.End(span, &err)
+
+	length := utf8.RuneCountInString(password)
+	if length < p.cfg.MinLength {
+		violations = append(violations, domain.PasswordViolation{Rule: domain.PasswordRuleMinLength, Limit: p.cfg.MinLength})
+	}
+	if length > p.cfg.MaxLength {
+		violations = append(violations, domain.PasswordViolation{Rule: domain.PasswordRuleMaxLength, Limit: p.cfg.MaxLength})
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			symbol = true
+		}
+	}
+	for _, class := range []struct {
+		required, present bool
+		rule              string
+	}{
+		{p.cfg.RequireLowercase, lower, domain.PasswordRuleLowercase},
+		{p.cfg.RequireUppercase, upper, domain.PasswordRuleUppercase},
+		{p.cfg.RequireDigit, digit, domain.PasswordRuleDigit},
+		{p.cfg.RequireSymbol, symbol, domain.PasswordRuleSymbol},
+	} {
+		if class.required && !class.present {
+			violations = append(violations, domain.PasswordViolation{Rule: class.rule})
+		}
+	}
+
+	if p.cfg.ForbidPersonalInfo && containsPersonalInfo(password, owner) {
+		violations = append(violations, domain.PasswordViolation{Rule: domain.PasswordRulePersonalInfo})
+	}
+
+	// Passwords over the maximum are rejected anyway; skip hashing them.
+	if p.breached != nil && length <= p.cfg.MaxLength {
+		count, err := p.breached.count(password)
+		if err != nil {
+			return nil, err
+		}
+		if count >= p.cfg.BreachedMinCount {
+			violations = append(violations, domain.PasswordViolation{Rule: domain.PasswordRuleBreached})
+		}
+	}
+
+	for _, v := range violations {
+		metrics.PasswordPolicyViolationsTotal.WithLabelValues(v.Rule).Inc()
+	}
+	return violations, nil
+}
+
+// containsPersonalInfo reports whether password contains, ignoring case, the
+// owner's email, its local part or a word of their name.
+func containsPersonalInfo(password string, owner domain.PasswordOwner) bool {
+	password = strings.ToLower(password)
+	email := strings.ToLower(owner.Email)
+	local, _, _ := strings.Cut(email, "@")
+
+	for _, part := range append([]string{email, local}, strings.Fields(strings.ToLower(owner.Name))...) {
+		if utf8.RuneCountInString(part) >= minPersonalInfoLength && strings.Contains(password, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package security
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // the HIBP corpus is keyed by SHA-1
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+var testPolicy = domain.PasswordPolicyConfig{MinLength: 8, MaxLength: 16, ForbidPersonalInfo: true}
+
+// writeRange writes the HIBP range file holding password with count.
+func writeRange(t *testing.T, dir, password, count string) {
+	t.Helper()
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // the HIBP corpus is keyed by SHA-1
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	lines := "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" + strings.ToLower(hash[5:]) + ":" + count + "\r\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(lines), 0o600))
+}
+
+func rules(violations []domain.PasswordViolation) []string {
+	var names []string
+	for _, v := range violations {
+		names = append(names, v.Rule)
+	}
+	return names
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	owner := domain.PasswordOwner{Email: "maria.silva@example.com", Name: "Maria da Silva"}
+
+	t.Run("should accept passwords that follow every rule", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testPolicy
+		cfg.RequireLowercase, cfg.RequireUppercase, cfg.RequireDigit, cfg.RequireSymbol = true, true, true, true
+		p, err := newPasswordPolicy(cfg)
+		require.NoError(t, err)
+
+		violations, err := p.Check(ctx, "Tr0ub4dor&3", owner)
+
+		assert.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should report length limits in characters", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := newPasswordPolicy(testPolicy)
+		require.NoError(t, err)
+
+		short, err := p.Check(ctx, "çãõáéí", owner)
+		require.NoError(t, err)
+		long, err := p.Check(ctx, strings.Repeat("x", 17), owner)
+		require.NoError(t, err)
+		fits, err := p.Check(ctx, strings.Repeat("ç", 16), owner)
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.PasswordViolation{{Rule: domain.PasswordRuleMinLength, Limit: 8}}, short)
+		assert.Equal(t, []domain.PasswordViolation{{Rule: domain.PasswordRuleMaxLength, Limit: 16}}, long)
+		assert.Empty(t, fits)
+	})
+
+	t.Run("should report every missing character class", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testPolicy
+		cfg.RequireLowercase, cfg.RequireUppercase, cfg.RequireDigit, cfg.RequireSymbol = true, true, true, true
+		p, err := newPasswordPolicy(cfg)
+		require.NoError(t, err)
+
+		violations, err := p.Check(ctx, "lowercase", owner)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{domain.PasswordRuleUppercase, domain.PasswordRuleDigit, domain.PasswordRuleSymbol}, rules(violations))
+	})
+
+	t.Run("should reject the owner's email and name words but not short ones", func(t *testing.T) {
+		t.Parallel()
+
+		p, err := newPasswordPolicy(testPolicy)
+		require.NoError(t, err)
+
+		for _, password := range []string{"xMARIA.SILVAx", "silva-2024!", "my-maria-pw"} {
+			violations, err := p.Check(ctx, password, owner)
+			require.NoError(t, err)
+			assert.Equal(t, []string{domain.PasswordRulePersonalInfo}, rules(violations), password)
+		}
+
+		violations, err := p.Check(ctx, "da-da-da-da", owner)
+		assert.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should reject passwords in the breached corpus from the minimum count", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeRange(t, dir, "correct-horse", "3")
+		cfg := testPolicy
+		cfg.BreachedCorpus, cfg.BreachedMinCount = dir, 3
+		p, err := newPasswordPolicy(cfg)
+		require.NoError(t, err)
+		lenient := cfg
+		lenient.BreachedMinCount = 4
+		l, err := newPasswordPolicy(lenient)
+		require.NoError(t, err)
+
+		breached, err := p.Check(ctx, "correct-horse", owner)
+		require.NoError(t, err)
+		rare, err := l.Check(ctx, "correct-horse", owner)
+		require.NoError(t, err)
+		missing, err := p.Check(ctx, "battery-staple", owner)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{domain.PasswordRuleBreached}, rules(breached))
+		assert.Empty(t, rare)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("should fail on a corrupt range file", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeRange(t, dir, "correct-horse", "many")
+		cfg := testPolicy
+		cfg.BreachedCorpus, cfg.BreachedMinCount = dir, 1
+		p, err := newPasswordPolicy(cfg)
+		require.NoError(t, err)
+
+		_, err = p.Check(ctx, "correct-horse", owner)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewPasswordPolicy(t *testing.T) {
+	t.Run("should reject invalid limits and corpora", func(t *testing.T) {
+		t.Parallel()
+
+		file := filepath.Join(t.TempDir(), "pwned.txt")
+		require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+		for name, cfg := range map[string]domain.PasswordPolicyConfig{
+			"no minimum":        {MaxLength: 16},
+			"maximum too small": {MinLength: 8, MaxLength: 4},
+			"missing corpus":    {MinLength: 8, MaxLength: 16, BreachedCorpus: filepath.Join(t.TempDir(), "missing"), BreachedMinCount: 1},
+			"corpus file":       {MinLength: 8, MaxLength: 16, BreachedCorpus: file, BreachedMinCount: 1},
+			"no minimum count":  {MinLength: 8, MaxLength: 16, BreachedCorpus: t.TempDir()},
+		} {
+			_, err := newPasswordPolicy(cfg)
+			assert.Error(t, err, name)
+		}
+	})
+}
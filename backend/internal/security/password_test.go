@@ -0,0 +1,183 @@
+package security
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+var testArgon2 = domain.PasswordConfig{
+	HashAlgorithm:     domain.PasswordAlgorithmArgon2id,
+	Argon2Memory:      1024,
+	Argon2Iterations:  1,
+	Argon2Parallelism: 1,
+	BcryptCost:        bcrypt.MinCost,
+	HashQueueTimeout:  time.Second,
+}
+
+func TestPasswordHasher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should hash with argon2id and verify the password", func(t *testing.T) {
+		t.Parallel()
+
+		h, err := newPasswordHasher(testArgon2)
+		require.NoError(t, err)
+
+		hash, err := h.Hash(ctx, "password123")
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"), hash)
+		assert.NoError(t, h.Check(ctx, "password123", hash))
+		assert.ErrorIs(t, h.Check(ctx, "password124", hash), ErrPasswordMismatch)
+		assert.False(t, h.NeedsRehash(hash))
+	})
+
+	t.Run("should not truncate long passwords", func(t *testing.T) {
+		t.Parallel()
+
+		h, err := newPasswordHasher(testArgon2)
+		require.NoError(t, err)
+		long := strings.Repeat("a", 100)
+
+		hash, err := h.Hash(ctx, long)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, h.Check(ctx, long[:72], hash), ErrPasswordMismatch)
+	})
+
+	t.Run("should verify bcrypt hashes and ask to rehash them", func(t *testing.T) {
+		t.Parallel()
+
+		h, err := newPasswordHasher(testArgon2)
+		require.NoError(t, err)
+		legacy, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		assert.NoError(t, h.Check(ctx, "password123", string(legacy)))
+		assert.ErrorIs(t, h.Check(ctx, "wrong", string(legacy)), ErrPasswordMismatch)
+		assert.True(t, h.NeedsRehash(string(legacy)))
+	})
+
+	t.Run("should ask to rehash argon2id hashes with other parameters", func(t *testing.T) {
+		t.Parallel()
+
+		old, err := newPasswordHasher(testArgon2)
+		require.NoError(t, err)
+		stronger := testArgon2
+		stronger.Argon2Iterations = 2
+		h, err := newPasswordHasher(stronger)
+		require.NoError(t, err)
+
+		hash, err := old.Hash(ctx, "password123")
+		require.NoError(t, err)
+
+		assert.NoError(t, h.Check(ctx, "password123", hash))
+		assert.True(t, h.NeedsRehash(hash))
+	})
+
+	t.Run("should hash with bcrypt when configured and rehash other costs", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testArgon2
+		cfg.HashAlgorithm = domain.PasswordAlgorithmBcrypt
+		h, err := newPasswordHasher(cfg)
+		require.NoError(t, err)
+		argon2, err := newPasswordHasher(testArgon2)
+		require.NoError(t, err)
+		argon2Hash, err := argon2.Hash(ctx, "password123")
+		require.NoError(t, err)
+
+		hash, err := h.Hash(ctx, "password123")
+		require.NoError(t, err)
+		costlier, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost+1)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(hash, "$2a$"), hash)
+		assert.False(t, h.NeedsRehash(hash))
+		assert.True(t, h.NeedsRehash(string(costlier)))
+		assert.True(t, h.NeedsRehash(argon2Hash))
+		assert.NoError(t, h.Check(ctx, "password123", argon2Hash))
+	})
+
+	t.Run("should reject unknown and malformed hashes", func(t *testing.T) {
+		t.Parallel()
+
+		h, err := newPasswordHasher(testArgon2)
+		require.NoError(t, err)
+
+		assert.Error(t, h.Check(ctx, "password123", "plain-text"))
+		assert.Error(t, h.Check(ctx, "password123", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA"))
+		assert.NotErrorIs(t, h.Check(ctx, "password123", "plain-text"), ErrPasswordMismatch)
+		assert.True(t, h.NeedsRehash("plain-text"))
+	})
+
+	t.Run("should pepper new hashes and rehash those under other pepper keys", func(t *testing.T) {
+		t.Parallel()
+
+		oldKey, err := GenerateKey("2025", minPepperSize)
+		require.NoError(t, err)
+		newKey, err := GenerateKey("2026", minPepperSize)
+		require.NoError(t, err)
+
+		plain, err := newPasswordHasher(testArgon2)
+		require.NoError(t, err)
+		plainHash, err := plain.Hash(ctx, "password123")
+		require.NoError(t, err)
+
+		cfg := testArgon2
+		cfg.PepperKeys = oldKey
+		old, err := newPasswordHasher(cfg)
+		require.NoError(t, err)
+		oldHash, err := old.Hash(ctx, "password123")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(oldHash, "$pepper$2025$argon2id$v=19$"), oldHash)
+
+		cfg.PepperKeys = oldKey + "," + newKey
+		cfg.PepperKeyID = "2026"
+		h, err := newPasswordHasher(cfg)
+		require.NoError(t, err)
+		hash, err := h.Hash(ctx, "password123")
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(hash, "$pepper$2026$"), hash)
+		assert.NoError(t, h.Check(ctx, "password123", hash))
+		assert.ErrorIs(t, h.Check(ctx, "password124", hash), ErrPasswordMismatch)
+		assert.False(t, h.NeedsRehash(hash))
+
+		assert.NoError(t, h.Check(ctx, "password123", oldHash))
+		assert.True(t, h.NeedsRehash(oldHash))
+		assert.NoError(t, h.Check(ctx, "password123", plainHash))
+		assert.True(t, h.NeedsRehash(plainHash))
+
+		assert.Error(t, plain.Check(ctx, "password123", hash))
+		assert.True(t, plain.NeedsRehash(hash))
+	})
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	t.Run("should reject unsupported algorithms and parameters", func(t *testing.T) {
+		t.Parallel()
+
+		for name, cfg := range map[string]domain.PasswordConfig{
+			"unknown algorithm": {HashAlgorithm: "md5"},
+			"no parallelism":    {HashAlgorithm: domain.PasswordAlgorithmArgon2id, Argon2Memory: 1024, Argon2Iterations: 1},
+			"too little memory": {HashAlgorithm: domain.PasswordAlgorithmArgon2id, Argon2Memory: 8, Argon2Iterations: 1, Argon2Parallelism: 2},
+			"bcrypt cost":       {HashAlgorithm: domain.PasswordAlgorithmBcrypt, BcryptCost: 40},
+			"negative workers":  {HashAlgorithm: domain.PasswordAlgorithmBcrypt, BcryptCost: 10, HashWorkers: -1, HashQueueTimeout: time.Second},
+			"no queue timeout":  {HashAlgorithm: domain.PasswordAlgorithmBcrypt, BcryptCost: 10},
+			"short pepper":      {HashAlgorithm: domain.PasswordAlgorithmBcrypt, BcryptCost: 10, HashQueueTimeout: time.Second, PepperKeys: "k1:c2hvcnQ="},
+			"unknown pepper":    {HashAlgorithm: domain.PasswordAlgorithmBcrypt, BcryptCost: 10, HashQueueTimeout: time.Second, PepperKeyID: "k1"},
+		} {
+			_, err := newPasswordHasher(cfg)
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	authmiddleware "github.com/SergioLNeves/migos/internal/middleware"
+	errorpkg "github.com/SergioLNeves/migos/internal/pkg/error"
+	"github.com/SergioLNeves/migos/internal/pkg/openapi"
+	"github.com/SergioLNeves/migos/internal/pkg/validator"
+	"github.com/SergioLNeves/migos/internal/router"
+)
+
+// New builds the public API server: global middleware, central error
+// handling, the routes of internal/router and the OpenAPI endpoints. It is
+// shared by cmd/api and end-to-end tests.
+func New(i *do.Injector, cfg *domain.Config) (*echo.Echo, error) {
+	headers, err := authmiddleware.ParseHeaderPolicy(cfg.Headers, cfg.Env == "production")
+	if err != nil {
+		return nil, fmt.Errorf("invalid security headers: %w", err)
+	}
+
+	e := echo.New()
+	e.Use(authmiddleware.RequestID())
+	e.Use(authmiddleware.SecureHeaders(headers.API))
+	e.Use(authmiddleware.Locale())
+	e.Use(middleware.RequestLogger())
+	e.Use(authmiddleware.Tracing())
+	e.Use(authmiddleware.Metrics())
+	e.Use(middleware.Recover())
+	e.Use(middleware.BodyLimit(cfg.HTTP.BodyLimit))
+	origins, err := authmiddleware.ParseOrigins(cfg.HTTP.AllowedOrigins)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP_ALLOWED_ORIGINS: %w", err)
+	}
+	e.Use(authmiddleware.CORS(origins))
+	e.Use(authmiddleware.OriginCheck(origins))
+	e.Validator = validator.NewValidator()
+	e.HTTPErrorHandler = errorpkg.HTTPErrorHandler
+
+	if err := configureRoutes(e, i, cfg, headers); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func configureRoutes(e *echo.Echo, i *do.Injector, cfg *domain.Config, headers *authmiddleware.HeaderPolicy) error {
+	tokenProvider := do.MustInvoke[domain.TokenProvider](i)
+	sessionRepo := do.MustInvoke[domain.SessionRepository](i)
+	cookies := do.MustInvoke[domain.CookieManager](i)
+	authRepo := do.MustInvoke[domain.AuthRepository](i)
+	apiKeyRepo := do.MustInvoke[domain.APIKeyRepository](i)
+	serviceAccountRepo := do.MustInvoke[domain.ServiceAccountRepository](i)
+	healthCheckHandler, err := do.Invoke[domain.HealthCheckHandler](i)
+	if err != nil {
+		return fmt.Errorf("invoke healthcheck handler: %w", err)
+	}
+	authHandler, err := do.Invoke[domain.AuthHandler](i)
+	if err != nil {
+		return fmt.Errorf("invoke auth handler: %w", err)
+	}
+	keysHandler, err := do.Invoke[domain.KeysHandler](i)
+	if err != nil {
+		return fmt.Errorf("invoke keys handler: %w", err)
+	}
+	oauthHandler, err := do.Invoke[domain.OAuthHandler](i)
+	if err != nil {
+		return fmt.Errorf("invoke oauth handler: %w", err)
+	}
+	oidcHandler, err := do.Invoke[domain.OIDCHandler](i)
+	if err != nil {
+		return fmt.Errorf("invoke oidc handler: %w", err)
+	}
+	socialHandler, err := do.Invoke[domain.SocialHandler](i)
+	if err != nil {
+		return fmt.Errorf("invoke social handler: %w", err)
+	}
+	apiKeyHandler, err := do.Invoke[domain.APIKeyHandler](i)
+	if err != nil {
+		return fmt.Errorf("invoke api key handler: %w", err)
+	}
+	serviceAccountHandler, err := do.Invoke[domain.ServiceAccountHandler](i)
+	if err != nil {
+		return fmt.Errorf("invoke service account handler: %w", err)
+	}
+	sessionAuth := authmiddleware.SessionAuth(tokenProvider, sessionRepo, authRepo, cookies)
+	auth := authmiddleware.APIKeyAuth(apiKeyRepo, authRepo, sessionAuth)
+	clientSessionAuth := authmiddleware.ClientSessionAuth(tokenProvider, sessionRepo, authRepo, cookies)
+	guards := router.Guards{
+		Auth:        auth,
+		ClientAuth:  authmiddleware.APIKeyAuth(apiKeyRepo, authRepo, clientSessionAuth),
+		ServiceAuth: authmiddleware.ServiceAccountAuth(tokenProvider, serviceAccountRepo, auth),
+		RequireScope: func(scopes ...string) echo.MiddlewareFunc {
+			return authmiddleware.RequireScope(authRepo, scopes...)
+		},
+		Page: authmiddleware.SecureHeaders(headers.Page),
+	}
+
+	routes := router.Routes(router.Handlers{
+		Health: healthCheckHandler,
+		Auth:   authHandler,
+		Keys:   keysHandler,
+		OAuth:  oauthHandler,
+		OIDC:   oidcHandler,
+		Social: socialHandler,
+		APIKey: apiKeyHandler,
+
+		ServiceAccount: serviceAccountHandler,
+	})
+	doc := router.Document(routes)
+
+	if cfg.OpenAPI.ValidateRequests {
+		requestValidator, err := openapi.NewValidator(doc)
+		if err != nil {
+			return fmt.Errorf("compile openapi document: %w", err)
+		}
+		e.Use(authmiddleware.RequestValidation(requestValidator))
+	}
+
+	router.Register(e, routes, guards)
+	return configureOpenAPIRoute(e, doc, cfg, headers)
+}
+
+// configureOpenAPIRoute publishes the generated document and, when enabled,
+// an interactive reference for it.
+func configureOpenAPIRoute(e *echo.Echo, doc *openapi.Document, cfg *domain.Config, headers *authmiddleware.HeaderPolicy) error {
+	specHandler, err := openapi.Handler(doc)
+	if err != nil {
+		return err
+	}
+	e.GET("/openapi.json", echo.WrapHandler(specHandler))
+
+	if cfg.OpenAPI.DocsUI {
+		e.GET("/docs", echo.WrapHandler(openapi.DocsHandler(doc.Info.Title, "/openapi.json")), authmiddleware.SecureHeaders(headers.Docs))
+	}
+	return nil
+}
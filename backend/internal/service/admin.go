@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+type AdminServiceImpl struct {
+	authRepository    domain.AuthRepository
+	sessionRepository domain.SessionRepository
+	passwordHasher    domain.PasswordHasher
+	passwordPolicy    domain.PasswordPolicy
+}
+
+func NewAdminService(i *do.Injector) (domain.AdminService, error) {
+	authRepository := do.MustInvoke[domain.AuthRepository](i)
+	sessionRepository := do.MustInvoke[domain.SessionRepository](i)
+	passwordHasher := do.MustInvoke[domain.PasswordHasher](i)
+	passwordPolicy := do.MustInvoke[domain.PasswordPolicy](i)
+	return &AdminServiceImpl{
+		authRepository:    authRepository,
+		sessionRepository: sessionRepository,
+		passwordHasher:    passwordHasher,
+		passwordPolicy:    passwordPolicy,
+	}, nil
+}
+
+func (s *AdminServiceImpl) CreateUser(ctx context.Context, req domain.CreateAccountRequest) (_ *domain.User, err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.CreateUser")
+	defer tracing.End(span, &err)
+
+	owner := domain.PasswordOwner{Email: req.Email, Name: req.Name}
+	if err := enforcePasswordPolicy(ctx, s.passwordPolicy, "password", req.Password, owner); err != nil {
+		return nil, err
+	}
+
+	_, err = s.authRepository.FindUserByEmail(ctx, req.Email)
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing email: %w", err)
+		}
+		return nil, domain.ErrEmailAlreadyExists
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(ctx, req.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &domain.User{
+		ID:       uuid.New(),
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: hashedPassword,
+		Avatar:   req.Avatar,
+	}
+
+	if err := s.authRepository.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	metrics.AccountsCreatedTotal.WithLabelValues("admin").Inc()
+
+	logging.WithContext(ctx, zap.String("service", "AdminService.CreateUser")).
+		Info("user created", zap.String("user_id", user.ID.String()))
+
+	return user, nil
+}
+
+// ResetPassword replaces the user's password, clears any forced expiry and
+// revokes every session so the old credentials stop working immediately.
+func (s *AdminServiceImpl) ResetPassword(ctx context.Context, req domain.ResetPasswordRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.ResetPassword")
+	defer tracing.End(span, &err)
+
+	user, err := s.authRepository.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	owner := domain.PasswordOwner{Email: user.Email, Name: user.Name}
+	if err := enforcePasswordPolicy(ctx, s.passwordPolicy, "password", req.Password, owner); err != nil {
+		return err
+	}
+	if err := checkPasswordReuse(ctx, s.passwordHasher, s.authRepository, user, "password", req.Password); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(ctx, req.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := changePassword(ctx, s.authRepository, user, hashedPassword); err != nil {
+		return err
+	}
+	user.PasswordExpiresAt = nil
+
+	if err := s.authRepository.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	deleted, err := s.sessionRepository.DeleteSessionsByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	metrics.SessionsRevokedTotal.WithLabelValues("password_reset").Add(float64(deleted))
+
+	logging.WithContext(ctx, zap.String("service", "AdminService.ResetPassword")).
+		Info("password reset", zap.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+// ExpirePassword marks the user's password as expired and revokes every
+// session, so the next login is rejected until the password is reset.
+func (s *AdminServiceImpl) ExpirePassword(ctx context.Context, email string) (err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.ExpirePassword")
+	defer tracing.End(span, &err)
+
+	user, err := s.authRepository.FindUserByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	now := time.Now()
+	user.PasswordExpiresAt = &now
+
+	if err := s.authRepository.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to expire password: %w", err)
+	}
+
+	deleted, err := s.sessionRepository.DeleteSessionsByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	metrics.SessionsRevokedTotal.WithLabelValues("password_expired").Add(float64(deleted))
+
+	logging.WithContext(ctx, zap.String("service", "AdminService.ExpirePassword")).
+		Info("password expired", zap.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+func (s *AdminServiceImpl) ListSessions(ctx context.Context, email string) (_ []domain.Session, err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.ListSessions")
+	defer tracing.End(span, &err)
+
+	user, err := s.authRepository.FindUserByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	sessions, err := s.sessionRepository.FindSessionsByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (s *AdminServiceImpl) RevokeSession(ctx context.Context, sessionID uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.RevokeSession")
+	defer tracing.End(span, &err)
+
+	session, err := s.sessionRepository.DeleteSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	metrics.SessionsRevokedTotal.WithLabelValues("admin").Inc()
+
+	logging.WithContext(ctx, zap.String("service", "AdminService.RevokeSession")).
+		Info("session revoked",
+			zap.String("session_id", session.ID.String()),
+			zap.String("user_id", session.UserID.String()),
+		)
+
+	return nil
+}
+
+func (s *AdminServiceImpl) RevokeUserSessions(ctx context.Context, email string) (err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.RevokeUserSessions")
+	defer tracing.End(span, &err)
+
+	user, err := s.authRepository.FindUserByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	deleted, err := s.sessionRepository.DeleteSessionsByUserID(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	metrics.SessionsRevokedTotal.WithLabelValues("admin").Add(float64(deleted))
+
+	logging.WithContext(ctx, zap.String("service", "AdminService.RevokeUserSessions")).
+		Info("user sessions revoked", zap.String("user_id", user.ID.String()))
+
+	return nil
+}
+
+func (s *AdminServiceImpl) GrantRole(ctx context.Context, email, role string) (err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.GrantRole")
+	defer tracing.End(span, &err)
+
+	if !slices.Contains(domain.ValidRoles, role) {
+		return domain.ErrInvalidRole
+	}
+
+	user, err := s.authRepository.FindUserByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err := s.authRepository.GrantRole(ctx, user.ID, role); err != nil {
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "AdminService.GrantRole")).
+		Info("role granted", zap.String("user_id", user.ID.String()), zap.String("role", role))
+
+	return nil
+}
+
+func (s *AdminServiceImpl) RevokeRole(ctx context.Context, email, role string) (err error) {
+	ctx, span := tracing.Start(ctx, "AdminService.RevokeRole")
+	defer tracing.End(span, &err)
+
+	user, err := s.authRepository.FindUserByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err := s.authRepository.RevokeRole(ctx, user.ID, role); err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "AdminService.RevokeRole")).
+		Info("role revoked", zap.String("user_id", user.ID.String()), zap.String("role", role))
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+func newAdminService(t *testing.T) (*AdminServiceImpl, *mockpkg.MockAuthRepository, *mockpkg.MockSessionRepository, *mockpkg.MockPasswordHasher) {
+	t.Helper()
+	authRepo := mockpkg.NewMockAuthRepository(t)
+	sessionRepo := mockpkg.NewMockSessionRepository(t)
+	passwordHasher := mockpkg.NewMockPasswordHasher(t)
+	svc := &AdminServiceImpl{
+		authRepository:    authRepo,
+		sessionRepository: sessionRepo,
+		passwordHasher:    passwordHasher,
+		passwordPolicy:    acceptingPasswordPolicy(t),
+	}
+	return svc, authRepo, sessionRepo, passwordHasher
+}
+
+func TestAdminCreateUser(t *testing.T) {
+	t.Run("should create user without opening a session", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, passwordHasher := newAdminService(t)
+		ctx := context.Background()
+		req := domain.CreateAccountRequest{Name: "Admin", Email: "admin@test.com", Password: "password123"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "admin@test.com").Return(nil, domain.ErrUserNotFound)
+		passwordHasher.On("Hash", mock.Anything, "password123").Return("hashed-password", nil)
+		authRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+
+		user, err := svc.CreateUser(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "admin@test.com", user.Email)
+		assert.Equal(t, "hashed-password", user.Password)
+	})
+
+	t.Run("should return ErrEmailAlreadyExists when email is taken", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, _ := newAdminService(t)
+		ctx := context.Background()
+		req := domain.CreateAccountRequest{Name: "Admin", Email: "admin@test.com", Password: "password123"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "admin@test.com").Return(&domain.User{ID: uuid.New()}, nil)
+
+		user, err := svc.CreateUser(ctx, req)
+
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, domain.ErrEmailAlreadyExists)
+	})
+}
+
+func TestAdminResetPassword(t *testing.T) {
+	t.Run("should replace hash, clear expiry and revoke sessions", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, sessionRepo, passwordHasher := newAdminService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "old-hash"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Hash", mock.Anything, "newpassword").Return("new-hash", nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Password == "new-hash" && u.PasswordExpiresAt == nil
+		})).Return(nil)
+		sessionRepo.On("DeleteSessionsByUserID", mock.Anything, user.ID).Return(int64(1), nil)
+
+		err := svc.ResetPassword(ctx, domain.ResetPasswordRequest{Email: "user@test.com", Password: "newpassword"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject passwords that break the policy", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, _ := newAdminService(t)
+		policy := mockpkg.NewMockPasswordPolicy(t)
+		svc.passwordPolicy = policy
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Name: "Test User", Email: "user@test.com", Password: "old-hash"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		policy.On("Check", mock.Anything, "password", domain.PasswordOwner{Email: "user@test.com", Name: "Test User"}).
+			Return([]domain.PasswordViolation{{Rule: domain.PasswordRuleBreached}}, nil)
+
+		err := svc.ResetPassword(ctx, domain.ResetPasswordRequest{Email: "user@test.com", Password: "password"})
+
+		assert.ErrorIs(t, err, domain.ErrWeakPassword)
+	})
+
+	t.Run("should return error when user not found", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, _ := newAdminService(t)
+		ctx := context.Background()
+
+		authRepo.On("FindUserByEmail", mock.Anything, "nobody@test.com").Return(nil, domain.ErrUserNotFound)
+
+		err := svc.ResetPassword(ctx, domain.ResetPasswordRequest{Email: "nobody@test.com", Password: "newpassword"})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestAdminExpirePassword(t *testing.T) {
+	t.Run("should set expiry and revoke sessions", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, sessionRepo, _ := newAdminService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.PasswordExpiresAt != nil
+		})).Return(nil)
+		sessionRepo.On("DeleteSessionsByUserID", mock.Anything, user.ID).Return(int64(1), nil)
+
+		err := svc.ExpirePassword(ctx, "user@test.com")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when UpdateUser fails", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, _ := newAdminService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
+
+		err := svc.ExpirePassword(ctx, "user@test.com")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to expire password")
+	})
+}
+
+func TestAdminSessions(t *testing.T) {
+	t.Run("should list sessions of the user", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, sessionRepo, _ := newAdminService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com"}
+		sessions := []domain.Session{{ID: uuid.New(), UserID: user.ID}}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		sessionRepo.On("FindSessionsByUserID", mock.Anything, user.ID).Return(sessions, nil)
+
+		result, err := svc.ListSessions(ctx, "user@test.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, sessions, result)
+	})
+
+	t.Run("should revoke a single session", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, sessionRepo, _ := newAdminService(t)
+		ctx := context.Background()
+		session := &domain.Session{ID: uuid.New(), UserID: uuid.New()}
+
+		sessionRepo.On("DeleteSession", mock.Anything, session.ID).Return(session, nil)
+
+		err := svc.RevokeSession(ctx, session.ID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should revoke every session of the user", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, sessionRepo, _ := newAdminService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		sessionRepo.On("DeleteSessionsByUserID", mock.Anything, user.ID).Return(int64(1), nil)
+
+		err := svc.RevokeUserSessions(ctx, "user@test.com")
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestAdminGrantRole(t *testing.T) {
+	t.Run("should grant a known role", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, _ := newAdminService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		authRepo.On("GrantRole", mock.Anything, user.ID, domain.RoleAdmin).Return(nil)
+
+		err := svc.GrantRole(ctx, "user@test.com", domain.RoleAdmin)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return ErrInvalidRole for unknown roles", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, _ := newAdminService(t)
+
+		err := svc.GrantRole(context.Background(), "user@test.com", "superuser")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidRole)
+	})
+}
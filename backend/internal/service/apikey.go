@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+// apiKeyPrefixLength is how much of a key is kept to identify it: the
+// "mig_" marker and 8 random characters.
+const apiKeyPrefixLength = len(domain.APIKeyPrefix) + 8
+
+type APIKeyServiceImpl struct {
+	apiKeyRepository domain.APIKeyRepository
+}
+
+func NewAPIKeyService(i *do.Injector) (domain.APIKeyService, error) {
+	apiKeyRepository := do.MustInvoke[domain.APIKeyRepository](i)
+	return &APIKeyServiceImpl{apiKeyRepository: apiKeyRepository}, nil
+}
+
+// Create mints a key for userID. The key is returned once; only its hash is
+// stored, like OAuth client secrets.
+func (s *APIKeyServiceImpl) Create(ctx context.Context, userID string, req domain.CreateAPIKeyRequest) (_ *domain.CreatedAPIKeyResponse, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Create")
+	defer tracing.End(span, &err)
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	existing, err := s.apiKeyRepository.ListAPIKeysByUserID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	for _, key := range existing {
+		if key.Name == req.Name {
+			return nil, domain.ErrAPIKeyNameConflict
+		}
+	}
+
+	secret := domain.APIKeyPrefix + rand.Text()
+	key := &domain.APIKey{
+		ID:        uuid.New(),
+		UserID:    id,
+		Name:      req.Name,
+		Prefix:    secret[:apiKeyPrefixLength],
+		KeyHash:   hashSecret(secret),
+		Scopes:    strings.Join(normalizeScopes(req.Scopes), " "),
+		ExpiresAt: time.Now().Add(time.Duration(req.ExpiresInDays) * 24 * time.Hour),
+	}
+
+	if err := s.apiKeyRepository.CreateAPIKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "APIKeyService.Create")).
+		Info("api key created", zap.String("api_key_id", key.ID.String()), zap.String("user_id", userID))
+
+	return &domain.CreatedAPIKeyResponse{APIKeyResponse: apiKeyResponse(key), Key: secret}, nil
+}
+
+func (s *APIKeyServiceImpl) List(ctx context.Context, userID string) (_ []domain.APIKeyResponse, err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.List")
+	defer tracing.End(span, &err)
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	keys, err := s.apiKeyRepository.ListAPIKeysByUserID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	response := make([]domain.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		response = append(response, apiKeyResponse(&keys[i]))
+	}
+	return response, nil
+}
+
+func (s *APIKeyServiceImpl) Revoke(ctx context.Context, userID, keyID string) (err error) {
+	ctx, span := tracing.Start(ctx, "APIKeyService.Revoke")
+	defer tracing.End(span, &err)
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	keyUUID, err := uuid.Parse(keyID)
+	if err != nil {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	if err := s.apiKeyRepository.DeleteAPIKey(ctx, id, keyUUID); err != nil {
+		return err
+	}
+
+	logging.WithContext(ctx, zap.String("service", "APIKeyService.Revoke")).
+		Info("api key revoked", zap.String("api_key_id", keyID), zap.String("user_id", userID))
+
+	return nil
+}
+
+func apiKeyResponse(key *domain.APIKey) domain.APIKeyResponse {
+	return domain.APIKeyResponse{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     strings.Fields(key.Scopes),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
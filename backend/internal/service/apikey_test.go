@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+func TestAPIKeyServiceCreate(t *testing.T) {
+	t.Run("should store only the hash and a prefix of the key", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mockpkg.NewMockAPIKeyRepository(t)
+		svc := &APIKeyServiceImpl{apiKeyRepository: repo}
+		userID := uuid.New()
+
+		var stored *domain.APIKey
+		repo.On("ListAPIKeysByUserID", mock.Anything, userID).Return(nil, nil)
+		repo.On("CreateAPIKey", mock.Anything, mock.AnythingOfType("*domain.APIKey")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.APIKey) }).
+			Return(nil)
+
+		response, err := svc.Create(context.Background(), userID.String(), domain.CreateAPIKeyRequest{
+			Name: "ci", Scopes: []string{"write", "read", "read"}, ExpiresInDays: 30,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(response.Key, domain.APIKeyPrefix))
+		assert.Equal(t, response.Key[:12], response.Prefix)
+		assert.Equal(t, hashSecret(response.Key), stored.KeyHash)
+		assert.NotContains(t, stored.KeyHash, response.Key)
+		assert.Equal(t, userID, stored.UserID)
+		assert.Equal(t, "write read", stored.Scopes)
+		assert.Equal(t, []string{"write", "read"}, response.Scopes)
+		assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("should reject a name already used by another key", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mockpkg.NewMockAPIKeyRepository(t)
+		svc := &APIKeyServiceImpl{apiKeyRepository: repo}
+		userID := uuid.New()
+
+		repo.On("ListAPIKeysByUserID", mock.Anything, userID).Return([]domain.APIKey{{Name: "ci"}}, nil)
+
+		_, err := svc.Create(context.Background(), userID.String(), domain.CreateAPIKeyRequest{
+			Name: "ci", Scopes: []string{"read"}, ExpiresInDays: 30,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrAPIKeyNameConflict)
+	})
+}
+
+func TestAPIKeyServiceRevoke(t *testing.T) {
+	t.Run("should return not found for a malformed key id", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mockpkg.NewMockAPIKeyRepository(t)
+		svc := &APIKeyServiceImpl{apiKeyRepository: repo}
+
+		err := svc.Revoke(context.Background(), uuid.NewString(), "not-a-uuid")
+
+		assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	})
+
+	t.Run("should delete only keys of the user", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mockpkg.NewMockAPIKeyRepository(t)
+		svc := &APIKeyServiceImpl{apiKeyRepository: repo}
+		userID, keyID := uuid.New(), uuid.New()
+
+		repo.On("DeleteAPIKey", mock.Anything, userID, keyID).Return(domain.ErrAPIKeyNotFound)
+
+		err := svc.Revoke(context.Background(), userID.String(), keyID.String())
+
+		assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	})
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/SergioLNeves/migos/internal/config"
 	"github.com/SergioLNeves/migos/internal/domain"
 	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
 )
 
 type AuthServiceImpl struct {
@@ -20,6 +22,8 @@ type AuthServiceImpl struct {
 	sessionRepository domain.SessionRepository
 	tokenProvider     domain.TokenProvider
 	passwordHasher    domain.PasswordHasher
+	passwordPolicy    domain.PasswordPolicy
+	clientRepository  domain.OAuthClientRepository
 }
 
 func NewAuthService(i *do.Injector) (domain.AuthService, error) {
@@ -27,16 +31,28 @@ func NewAuthService(i *do.Injector) (domain.AuthService, error) {
 	sessionRepository := do.MustInvoke[domain.SessionRepository](i)
 	tokenProvider := do.MustInvoke[domain.TokenProvider](i)
 	passwordHasher := do.MustInvoke[domain.PasswordHasher](i)
+	passwordPolicy := do.MustInvoke[domain.PasswordPolicy](i)
+	clientRepository := do.MustInvoke[domain.OAuthClientRepository](i)
 	return &AuthServiceImpl{
 		authRepository:    authRepository,
 		sessionRepository: sessionRepository,
 		tokenProvider:     tokenProvider,
 		passwordHasher:    passwordHasher,
+		passwordPolicy:    passwordPolicy,
+		clientRepository:  clientRepository,
 	}, nil
 }
 
-func (s *AuthServiceImpl) CreateAccount(ctx context.Context, req domain.CreateAccountRequest) (*domain.AuthResponse, error) {
-	_, err := s.authRepository.FindUserByEmail(ctx, req.Email)
+func (s *AuthServiceImpl) CreateAccount(ctx context.Context, req domain.CreateAccountRequest) (_ *domain.AuthResponse, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.CreateAccount")
+	defer tracing.End(span, &err)
+
+	owner := domain.PasswordOwner{Email: req.Email, Name: req.Name}
+	if err := enforcePasswordPolicy(ctx, s.passwordPolicy, "password", req.Password, owner); err != nil {
+		return nil, err
+	}
+
+	_, err = s.authRepository.FindUserByEmail(ctx, req.Email)
 	if !errors.Is(err, domain.ErrUserNotFound) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to check existing email: %w", err)
@@ -44,7 +60,7 @@ func (s *AuthServiceImpl) CreateAccount(ctx context.Context, req domain.CreateAc
 		return nil, domain.ErrEmailAlreadyExists
 	}
 
-	hashedPassword, err := s.passwordHasher.Hash(req.Password)
+	hashedPassword, err := s.passwordHasher.Hash(ctx, req.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -60,6 +76,7 @@ func (s *AuthServiceImpl) CreateAccount(ctx context.Context, req domain.CreateAc
 	if err := s.authRepository.CreateUser(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	metrics.AccountsCreatedTotal.WithLabelValues("signup").Inc()
 
 	session := &domain.Session{
 		ID:        uuid.New(),
@@ -71,12 +88,12 @@ func (s *AuthServiceImpl) CreateAccount(ctx context.Context, req domain.CreateAc
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
-	accessToken, err := s.tokenProvider.GenerateAccessToken(session.ID.String())
+	accessToken, err := s.tokenProvider.GenerateAccessToken(ctx, user.ID.String(), session.ID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err := s.tokenProvider.GenerateRefreshToken(user.ID.String(), session.ID.String())
+	refreshToken, err := s.tokenProvider.GenerateRefreshToken(ctx, user.ID.String(), session.ID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
@@ -84,10 +101,80 @@ func (s *AuthServiceImpl) CreateAccount(ctx context.Context, req domain.CreateAc
 	return &domain.AuthResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
+		SessionID:    session.ID.String(),
 	}, nil
 }
 
-func (s *AuthServiceImpl) Login(ctx context.Context, req domain.LoginRequest) (*domain.AuthResponse, error) {
+func (s *AuthServiceImpl) Login(ctx context.Context, req domain.LoginRequest) (_ *domain.AuthResponse, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer tracing.End(span, &err)
+
+	response, err := s.login(ctx, req)
+	if err != nil {
+		metrics.LoginsTotal.WithLabelValues("failure", loginFailureReason(err)).Inc()
+		return nil, err
+	}
+	metrics.LoginsTotal.WithLabelValues("success", "").Inc()
+	return response, nil
+}
+
+// rehashPassword replaces a hash made with an outdated algorithm or
+// parameters while the password is at hand, so hashes migrate as users sign
+// in instead of through forced resets. A failure only delays the migration
+// to the next login.
+func (s *AuthServiceImpl) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	if !s.passwordHasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	logger := logging.WithContext(ctx, zap.String("service", "AuthService.Login"), zap.String("user_id", user.ID.String()))
+	hash, err := s.passwordHasher.Hash(ctx, password)
+	if err == nil {
+		err = s.authRepository.UpdatePasswordHash(ctx, user.ID, user.Password, hash)
+	}
+	if err != nil {
+		logger.Warn("failed to rehash password", zap.Error(err))
+		return
+	}
+
+	metrics.PasswordRehashesTotal.Inc()
+	logger.Info("password rehashed")
+}
+
+// loginFailureReason maps a Login error to a bounded metric label.
+func loginFailureReason(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return "invalid_credentials"
+	case errors.Is(err, domain.ErrUserDeactivated):
+		return "user_deactivated"
+	case errors.Is(err, domain.ErrPasswordExpired):
+		return "password_expired"
+	case errors.Is(err, domain.ErrPasswordChangeRequired):
+		return "password_change_required"
+	case errors.Is(err, domain.ErrServerBusy):
+		return "server_busy"
+	default:
+		return "internal_error"
+	}
+}
+
+// checkPassword verifies password against hash and reports any mismatch as
+// invalid. A saturated hasher is passed through so the client can retry
+// instead of being told the password is wrong.
+func (s *AuthServiceImpl) checkPassword(ctx context.Context, password, hash string, invalid error) error {
+	err := s.passwordHasher.Check(ctx, password, hash)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrServerBusy), ctx.Err() != nil:
+		return err
+	default:
+		return invalid
+	}
+}
+
+func (s *AuthServiceImpl) login(ctx context.Context, req domain.LoginRequest) (*domain.AuthResponse, error) {
 	user, err := s.authRepository.FindUserByEmail(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
@@ -100,10 +187,112 @@ func (s *AuthServiceImpl) Login(ctx context.Context, req domain.LoginRequest) (*
 		return nil, domain.ErrUserDeactivated
 	}
 
-	if checkErr := s.passwordHasher.Check(req.Password, user.Password); checkErr != nil {
-		return nil, domain.ErrInvalidCredentials
+	if checkErr := s.checkPassword(ctx, req.Password, user.Password, domain.ErrInvalidCredentials); checkErr != nil {
+		return nil, checkErr
+	}
+
+	if user.PasswordExpiresAt != nil && !user.PasswordExpiresAt.After(time.Now()) {
+		return nil, domain.ErrPasswordExpired
+	}
+
+	return s.admit(ctx, user, req.Password)
+}
+
+// admit starts a session for a user who just proved their password, unless
+// it is past the maximum age and must be changed first.
+func (s *AuthServiceImpl) admit(ctx context.Context, user *domain.User, password string) (*domain.AuthResponse, error) {
+	if passwordTooOld(user) {
+		return nil, s.challengePasswordChange(ctx, user)
+	}
+
+	s.rehashPassword(ctx, user, password)
+
+	return s.startSession(ctx, user)
+}
+
+// challengePasswordChange stores a challenge letting the user set a new
+// password and returns it as a PasswordChangeRequiredError.
+func (s *AuthServiceImpl) challengePasswordChange(ctx context.Context, user *domain.User) error {
+	token, err := randomString()
+	if err != nil {
+		return err
+	}
+
+	challenge := &domain.PasswordChallenge{
+		ID:        hashSecret(token),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(config.Env.Policy.ChangeChallengeTTL),
+	}
+	if err := s.authRepository.CreatePasswordChallenge(ctx, challenge); err != nil {
+		return fmt.Errorf("failed to create password challenge: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "AuthService.Login")).
+		Info("password change required", zap.String("user_id", user.ID.String()))
+
+	return &domain.PasswordChangeRequiredError{ChallengeToken: token}
+}
+
+func (s *AuthServiceImpl) ChangeExpiredPassword(ctx context.Context, req domain.ChangeExpiredPasswordRequest) (_ *domain.AuthResponse, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.ChangeExpiredPassword")
+	defer tracing.End(span, &err)
+
+	id := hashSecret(req.ChallengeToken)
+	challenge, err := s.authRepository.FindPasswordChallenge(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.ExpiresAt.After(time.Now()) {
+		return nil, domain.ErrInvalidPasswordChallenge
+	}
+
+	user, err := s.authRepository.FindUserByID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidPasswordChallenge
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	// An expiry set by an admin after the challenge was issued still needs
+	// an admin reset.
+	if user.PasswordExpiresAt != nil && !user.PasswordExpiresAt.After(time.Now()) {
+		return nil, domain.ErrPasswordExpired
 	}
 
+	owner := domain.PasswordOwner{Email: user.Email, Name: user.Name}
+	if err := enforcePasswordPolicy(ctx, s.passwordPolicy, "new_password", req.NewPassword, owner); err != nil {
+		return nil, err
+	}
+	if err := checkPasswordReuse(ctx, s.passwordHasher, s.authRepository, user, "new_password", req.NewPassword); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(ctx, req.NewPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// Deleting the challenge only now keeps it usable after a rejected
+	// password, and still lets a single request redeem it.
+	if err := s.authRepository.DeletePasswordChallenge(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := changePassword(ctx, s.authRepository, user, hashedPassword); err != nil {
+		return nil, err
+	}
+	if err := s.authRepository.UpdateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "AuthService.ChangeExpiredPassword")).
+		Info("expired password changed", zap.String("user_id", user.ID.String()))
+
+	return s.startSession(ctx, user)
+}
+
+// startSession opens a session for the user and issues its tokens.
+func (s *AuthServiceImpl) startSession(ctx context.Context, user *domain.User) (*domain.AuthResponse, error) {
 	session := &domain.Session{
 		ID:        uuid.New(),
 		UserID:    user.ID,
@@ -114,12 +303,12 @@ func (s *AuthServiceImpl) Login(ctx context.Context, req domain.LoginRequest) (*
 		return nil, fmt.Errorf("failed to create session: %w", createErr)
 	}
 
-	accessToken, err := s.tokenProvider.GenerateAccessToken(session.ID.String())
+	accessToken, err := s.tokenProvider.GenerateAccessToken(ctx, user.ID.String(), session.ID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err := s.tokenProvider.GenerateRefreshToken(user.ID.String(), session.ID.String())
+	refreshToken, err := s.tokenProvider.GenerateRefreshToken(ctx, user.ID.String(), session.ID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
@@ -127,10 +316,14 @@ func (s *AuthServiceImpl) Login(ctx context.Context, req domain.LoginRequest) (*
 	return &domain.AuthResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
+		SessionID:    session.ID.String(),
 	}, nil
 }
 
-func (s *AuthServiceImpl) Logout(ctx context.Context, sessionID string) error {
+func (s *AuthServiceImpl) Logout(ctx context.Context, sessionID string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Logout")
+	defer tracing.End(span, &err)
+
 	id, err := uuid.Parse(sessionID)
 	if err != nil {
 		return fmt.Errorf("invalid session ID: %w", err)
@@ -140,8 +333,9 @@ func (s *AuthServiceImpl) Logout(ctx context.Context, sessionID string) error {
 	if err != nil {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
+	metrics.SessionsRevokedTotal.WithLabelValues("logout").Inc()
 
-	logging.With(zap.String("service", "AuthService.Logout")).
+	logging.WithContext(ctx, zap.String("service", "AuthService.Logout")).
 		Info("session deleted",
 			zap.String("session_id", session.ID.String()),
 			zap.String("user_id", session.UserID.String()),
@@ -150,7 +344,86 @@ func (s *AuthServiceImpl) Logout(ctx context.Context, sessionID string) error {
 	return nil
 }
 
-func (s *AuthServiceImpl) UpdatePassword(ctx context.Context, userID string, req domain.UpdatePasswordRequest) error {
+// ListSessions returns the sessions of the user, naming the OAuth client of
+// sessions started through OpenID Connect or the device flow.
+func (s *AuthServiceImpl) ListSessions(ctx context.Context, userID, currentSessionID string) (_ []domain.SessionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.ListSessions")
+	defer tracing.End(span, &err)
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	sessions, err := s.sessionRepository.FindSessionsByUserID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	clientNames := map[uuid.UUID]string{}
+	response := make([]domain.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		item := domain.SessionResponse{
+			ID:        session.ID.String(),
+			Scope:     session.Scope,
+			Current:   session.ID.String() == currentSessionID,
+			CreatedAt: session.CreatedAt,
+			ExpiresAt: session.ExpiresAt,
+		}
+		if session.ClientID != nil {
+			item.ClientID = session.ClientID.String()
+			name, ok := clientNames[*session.ClientID]
+			if !ok {
+				client, err := s.clientRepository.FindClientByID(ctx, *session.ClientID)
+				if err != nil && !errors.Is(err, domain.ErrClientNotFound) {
+					return nil, fmt.Errorf("failed to find client: %w", err)
+				}
+				if client != nil {
+					name = client.Name
+				}
+				clientNames[*session.ClientID] = name
+			}
+			item.ClientName = name
+		}
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+// RevokeSession deletes a session of the user, signing out the browser or
+// device that holds it. Sessions of other users are reported as not found.
+func (s *AuthServiceImpl) RevokeSession(ctx context.Context, userID, sessionID string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.RevokeSession")
+	defer tracing.End(span, &err)
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return domain.ErrSessionNotFound
+	}
+
+	session, err := s.sessionRepository.FindSessionByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+	if session.UserID.String() != userID {
+		return domain.ErrSessionNotFound
+	}
+
+	if _, err := s.sessionRepository.DeleteSession(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	metrics.SessionsRevokedTotal.WithLabelValues("user").Inc()
+
+	logging.WithContext(ctx, zap.String("service", "AuthService.RevokeSession")).
+		Info("session revoked", zap.String("session_id", sessionID), zap.String("user_id", userID))
+
+	return nil
+}
+
+func (s *AuthServiceImpl) UpdatePassword(ctx context.Context, userID string, req domain.UpdatePasswordRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.UpdatePassword")
+	defer tracing.End(span, &err)
+
 	id, err := uuid.Parse(userID)
 	if err != nil {
 		return fmt.Errorf("invalid user ID: %w", err)
@@ -161,16 +434,26 @@ func (s *AuthServiceImpl) UpdatePassword(ctx context.Context, userID string, req
 		return fmt.Errorf("failed to find user: %w", err)
 	}
 
-	if checkErr := s.passwordHasher.Check(req.CurrentPassword, user.Password); checkErr != nil {
-		return domain.ErrInvalidCurrentPassword
+	if checkErr := s.checkPassword(ctx, req.CurrentPassword, user.Password, domain.ErrInvalidCurrentPassword); checkErr != nil {
+		return checkErr
+	}
+
+	owner := domain.PasswordOwner{Email: user.Email, Name: user.Name}
+	if err := enforcePasswordPolicy(ctx, s.passwordPolicy, "new_password", req.NewPassword, owner); err != nil {
+		return err
+	}
+	if err := checkPasswordReuse(ctx, s.passwordHasher, s.authRepository, user, "new_password", req.NewPassword); err != nil {
+		return err
 	}
 
-	hashedPassword, err := s.passwordHasher.Hash(req.NewPassword)
+	hashedPassword, err := s.passwordHasher.Hash(ctx, req.NewPassword)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	user.Password = hashedPassword
+	if err := changePassword(ctx, s.authRepository, user, hashedPassword); err != nil {
+		return err
+	}
 
 	if err := s.authRepository.UpdateUser(ctx, user); err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
@@ -179,7 +462,10 @@ func (s *AuthServiceImpl) UpdatePassword(ctx context.Context, userID string, req
 	return nil
 }
 
-func (s *AuthServiceImpl) UpdateUser(ctx context.Context, userID string, req domain.UpdateUserRequest) (*domain.UserResponse, error) {
+func (s *AuthServiceImpl) UpdateUser(ctx context.Context, userID string, req domain.UpdateUserRequest) (_ *domain.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.UpdateUser")
+	defer tracing.End(span, &err)
+
 	id, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
@@ -221,27 +507,35 @@ func (s *AuthServiceImpl) UpdateUser(ctx context.Context, userID string, req dom
 	}, nil
 }
 
-func (s *AuthServiceImpl) DeleteUser(ctx context.Context, userID string) error {
+func (s *AuthServiceImpl) DeleteUser(ctx context.Context, userID string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.DeleteUser")
+	defer tracing.End(span, &err)
+
 	id, err := uuid.Parse(userID)
 	if err != nil {
 		return fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	if err := s.sessionRepository.DeleteSessionsByUserID(ctx, id); err != nil {
+	deleted, err := s.sessionRepository.DeleteSessionsByUserID(ctx, id)
+	if err != nil {
 		return fmt.Errorf("failed to delete user sessions: %w", err)
 	}
+	metrics.SessionsRevokedTotal.WithLabelValues("account_deleted").Add(float64(deleted))
 
 	if err := s.authRepository.DeleteUser(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
-	logging.With(zap.String("service", "AuthService.DeleteUser")).
+	logging.WithContext(ctx, zap.String("service", "AuthService.DeleteUser")).
 		Info("user deactivated", zap.String("user_id", userID))
 
 	return nil
 }
 
-func (s *AuthServiceImpl) ReactivateAccount(ctx context.Context, req domain.LoginRequest) (*domain.AuthResponse, error) {
+func (s *AuthServiceImpl) ReactivateAccount(ctx context.Context, req domain.LoginRequest) (_ *domain.AuthResponse, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.ReactivateAccount")
+	defer tracing.End(span, &err)
+
 	user, err := s.authRepository.FindUserByEmail(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
@@ -254,8 +548,14 @@ func (s *AuthServiceImpl) ReactivateAccount(ctx context.Context, req domain.Logi
 		return nil, domain.ErrUserNotDeactivated
 	}
 
-	if checkErr := s.passwordHasher.Check(req.Password, user.Password); checkErr != nil {
-		return nil, domain.ErrInvalidCredentials
+	if checkErr := s.checkPassword(ctx, req.Password, user.Password, domain.ErrInvalidCredentials); checkErr != nil {
+		return nil, checkErr
+	}
+
+	// An expired password needs an admin reset, so the account stays
+	// deactivated until then.
+	if user.PasswordExpiresAt != nil && !user.PasswordExpiresAt.After(time.Now()) {
+		return nil, domain.ErrPasswordExpired
 	}
 
 	user.DeletedAt = nil
@@ -263,28 +563,68 @@ func (s *AuthServiceImpl) ReactivateAccount(ctx context.Context, req domain.Logi
 		return nil, fmt.Errorf("failed to reactivate user: %w", updateErr)
 	}
 
-	session := &domain.Session{
-		ID:        uuid.New(),
-		UserID:    user.ID,
-		ExpiresAt: time.Now().Add(time.Duration(config.Env.Token.RefreshTokenExpiry) * time.Minute),
+	return s.admit(ctx, user, req.Password)
+}
+
+func (s *AuthServiceImpl) Refresh(ctx context.Context, refreshToken string) (_ *domain.AuthResponse, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Refresh")
+	defer tracing.End(span, &err)
+
+	claims, err := s.tokenProvider.ParseRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, domain.ErrInvalidRefreshToken
 	}
 
-	if createErr := s.sessionRepository.CreateSession(ctx, session); createErr != nil {
-		return nil, fmt.Errorf("failed to create session: %w", createErr)
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, domain.ErrInvalidRefreshToken
 	}
 
-	accessToken, err := s.tokenProvider.GenerateAccessToken(session.ID.String())
+	session, err := s.sessionRepository.FindSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	// Sessions of OpenID Connect clients are refreshed at /token, which
+	// authenticates the client and keeps their tokens bound to it.
+	if session.UserID.String() != claims.UserID || session.ClientID != nil {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	user, err := s.authRepository.FindUserByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user.DeletedAt != nil {
+		return nil, domain.ErrUserDeactivated
+	}
+
+	accessToken, err := s.tokenProvider.GenerateAccessToken(ctx, user.ID.String(), session.ID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, err := s.tokenProvider.GenerateRefreshToken(user.ID.String(), session.ID.String())
+	newRefreshToken, err := s.tokenProvider.GenerateRefreshToken(ctx, user.ID.String(), session.ID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
+	expiresAt := time.Now().Add(time.Duration(config.Env.Token.RefreshTokenExpiry) * time.Minute)
+	if updateErr := s.sessionRepository.UpdateSessionExpiry(ctx, session.ID, expiresAt); updateErr != nil {
+		return nil, fmt.Errorf("failed to update session expiry: %w", updateErr)
+	}
+	metrics.TokensRefreshedTotal.Inc()
+
 	return &domain.AuthResponse{
 		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		RefreshToken: newRefreshToken,
+		SessionID:    session.ID.String(),
 	}, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/SergioLNeves/migos/internal/domain"
 	"github.com/SergioLNeves/migos/internal/pkg/logging"
@@ -32,10 +33,20 @@ func newAuthService(t *testing.T) (*AuthServiceImpl, *mockpkg.MockAuthRepository
 		sessionRepository: sessionRepo,
 		tokenProvider:     tokenProvider,
 		passwordHasher:    passwordHasher,
+		passwordPolicy:    acceptingPasswordPolicy(t),
 	}
 	return svc, authRepo, sessionRepo, tokenProvider, passwordHasher
 }
 
+// acceptingPasswordPolicy accepts every password; tests of the policy
+// replace it with their own mock.
+func acceptingPasswordPolicy(t *testing.T) *mockpkg.MockPasswordPolicy {
+	t.Helper()
+	policy := mockpkg.NewMockPasswordPolicy(t)
+	policy.On("Check", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return policy
+}
+
 func TestCreateAccount(t *testing.T) {
 	t.Run("should create account and return tokens", func(t *testing.T) {
 		t.Parallel()
@@ -44,12 +55,12 @@ func TestCreateAccount(t *testing.T) {
 		ctx := context.Background()
 		req := domain.CreateAccountRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(nil, domain.ErrUserNotFound)
-		passwordHasher.On("Hash", "password123").Return("hashed-password", nil)
-		authRepo.On("CreateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
-		sessionRepo.On("CreateSession", ctx, mock.AnythingOfType("*domain.Session")).Return(nil)
-		tokenProvider.On("GenerateAccessToken", mock.AnythingOfType("string")).Return("access-token", nil)
-		tokenProvider.On("GenerateRefreshToken", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("refresh-token", nil)
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(nil, domain.ErrUserNotFound)
+		passwordHasher.On("Hash", mock.Anything, "password123").Return("hashed-password", nil)
+		authRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("refresh-token", nil)
 
 		result, err := svc.CreateAccount(ctx, req)
 
@@ -67,7 +78,7 @@ func TestCreateAccount(t *testing.T) {
 		req := domain.CreateAccountRequest{Email: "user@test.com", Password: "password123"}
 		existingUser := &domain.User{ID: uuid.New(), Email: "user@test.com"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(existingUser, nil)
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(existingUser, nil)
 
 		result, err := svc.CreateAccount(ctx, req)
 
@@ -82,7 +93,7 @@ func TestCreateAccount(t *testing.T) {
 		ctx := context.Background()
 		req := domain.CreateAccountRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(nil, errors.New("db error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(nil, errors.New("db error"))
 
 		result, err := svc.CreateAccount(ctx, req)
 
@@ -98,8 +109,8 @@ func TestCreateAccount(t *testing.T) {
 		ctx := context.Background()
 		req := domain.CreateAccountRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(nil, domain.ErrUserNotFound)
-		passwordHasher.On("Hash", "password123").Return("", errors.New("hash error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(nil, domain.ErrUserNotFound)
+		passwordHasher.On("Hash", mock.Anything, "password123").Return("", errors.New("hash error"))
 
 		result, err := svc.CreateAccount(ctx, req)
 
@@ -115,9 +126,9 @@ func TestCreateAccount(t *testing.T) {
 		ctx := context.Background()
 		req := domain.CreateAccountRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(nil, domain.ErrUserNotFound)
-		passwordHasher.On("Hash", "password123").Return("hashed-password", nil)
-		authRepo.On("CreateUser", ctx, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(nil, domain.ErrUserNotFound)
+		passwordHasher.On("Hash", mock.Anything, "password123").Return("hashed-password", nil)
+		authRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
 
 		result, err := svc.CreateAccount(ctx, req)
 
@@ -133,10 +144,10 @@ func TestCreateAccount(t *testing.T) {
 		ctx := context.Background()
 		req := domain.CreateAccountRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(nil, domain.ErrUserNotFound)
-		passwordHasher.On("Hash", "password123").Return("hashed-password", nil)
-		authRepo.On("CreateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
-		sessionRepo.On("CreateSession", ctx, mock.AnythingOfType("*domain.Session")).Return(errors.New("db error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(nil, domain.ErrUserNotFound)
+		passwordHasher.On("Hash", mock.Anything, "password123").Return("hashed-password", nil)
+		authRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(errors.New("db error"))
 
 		result, err := svc.CreateAccount(ctx, req)
 
@@ -152,11 +163,11 @@ func TestCreateAccount(t *testing.T) {
 		ctx := context.Background()
 		req := domain.CreateAccountRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(nil, domain.ErrUserNotFound)
-		passwordHasher.On("Hash", "password123").Return("hashed-password", nil)
-		authRepo.On("CreateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
-		sessionRepo.On("CreateSession", ctx, mock.AnythingOfType("*domain.Session")).Return(nil)
-		tokenProvider.On("GenerateAccessToken", mock.AnythingOfType("string")).Return("", errors.New("token error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(nil, domain.ErrUserNotFound)
+		passwordHasher.On("Hash", mock.Anything, "password123").Return("hashed-password", nil)
+		authRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("", errors.New("token error"))
 
 		result, err := svc.CreateAccount(ctx, req)
 
@@ -172,12 +183,12 @@ func TestCreateAccount(t *testing.T) {
 		ctx := context.Background()
 		req := domain.CreateAccountRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(nil, domain.ErrUserNotFound)
-		passwordHasher.On("Hash", "password123").Return("hashed-password", nil)
-		authRepo.On("CreateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
-		sessionRepo.On("CreateSession", ctx, mock.AnythingOfType("*domain.Session")).Return(nil)
-		tokenProvider.On("GenerateAccessToken", mock.AnythingOfType("string")).Return("access-token", nil)
-		tokenProvider.On("GenerateRefreshToken", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("", errors.New("token error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(nil, domain.ErrUserNotFound)
+		passwordHasher.On("Hash", mock.Anything, "password123").Return("hashed-password", nil)
+		authRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("", errors.New("token error"))
 
 		result, err := svc.CreateAccount(ctx, req)
 
@@ -185,6 +196,45 @@ func TestCreateAccount(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to generate refresh token")
 	})
+
+	t.Run("should reject passwords that break the policy before touching the database", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, _, _ := newAuthService(t)
+		policy := mockpkg.NewMockPasswordPolicy(t)
+		svc.passwordPolicy = policy
+		ctx := context.Background()
+		req := domain.CreateAccountRequest{Name: "Test User", Email: "user@test.com", Password: "short"}
+		violations := []domain.PasswordViolation{{Rule: domain.PasswordRuleMinLength, Limit: 8}}
+
+		policy.On("Check", mock.Anything, "short", domain.PasswordOwner{Email: "user@test.com", Name: "Test User"}).Return(violations, nil)
+
+		result, err := svc.CreateAccount(ctx, req)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrWeakPassword)
+		var policyErr *domain.PasswordPolicyError
+		require.ErrorAs(t, err, &policyErr)
+		assert.Equal(t, "password", policyErr.Field)
+		assert.Equal(t, violations, policyErr.Violations)
+	})
+
+	t.Run("should return error when the policy check fails", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, _, _ := newAuthService(t)
+		policy := mockpkg.NewMockPasswordPolicy(t)
+		svc.passwordPolicy = policy
+		ctx := context.Background()
+
+		policy.On("Check", mock.Anything, "password123", mock.Anything).Return(nil, errors.New("corpus unreadable"))
+
+		result, err := svc.CreateAccount(ctx, domain.CreateAccountRequest{Email: "user@test.com", Password: "password123"})
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "failed to check password policy")
+		assert.NotErrorIs(t, err, domain.ErrWeakPassword)
+	})
 }
 
 func TestLogin(t *testing.T) {
@@ -196,11 +246,12 @@ func TestLogin(t *testing.T) {
 		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password"}
 		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(user, nil)
-		passwordHasher.On("Check", "password123", "hashed-password").Return(nil)
-		sessionRepo.On("CreateSession", ctx, mock.AnythingOfType("*domain.Session")).Return(nil)
-		tokenProvider.On("GenerateAccessToken", mock.AnythingOfType("string")).Return("access-token", nil)
-		tokenProvider.On("GenerateRefreshToken", user.ID.String(), mock.AnythingOfType("string")).Return("refresh-token", nil)
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+		passwordHasher.On("NeedsRehash", "hashed-password").Return(false)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, user.ID.String(), mock.AnythingOfType("string")).Return("refresh-token", nil)
 
 		result, err := svc.Login(ctx, req)
 
@@ -210,6 +261,48 @@ func TestLogin(t *testing.T) {
 		assert.Equal(t, "refresh-token", result.RefreshToken)
 	})
 
+	t.Run("should rehash an outdated password hash", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, sessionRepo, tokenProvider, passwordHasher := newAuthService(t)
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "bcrypt-hash"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "bcrypt-hash").Return(nil)
+		passwordHasher.On("NeedsRehash", "bcrypt-hash").Return(true)
+		passwordHasher.On("Hash", mock.Anything, "password123").Return("argon2id-hash", nil)
+		authRepo.On("UpdatePasswordHash", mock.Anything, user.ID, "bcrypt-hash", "argon2id-hash").Return(nil)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, user.ID.String(), mock.AnythingOfType("string")).Return("access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, user.ID.String(), mock.AnythingOfType("string")).Return("refresh-token", nil)
+
+		result, err := svc.Login(context.Background(), domain.LoginRequest{Email: "user@test.com", Password: "password123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "access-token", result.AccessToken)
+	})
+
+	t.Run("should still login when the rehash fails", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, sessionRepo, tokenProvider, passwordHasher := newAuthService(t)
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "bcrypt-hash"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "bcrypt-hash").Return(nil)
+		passwordHasher.On("NeedsRehash", "bcrypt-hash").Return(true)
+		passwordHasher.On("Hash", mock.Anything, "password123").Return("argon2id-hash", nil)
+		authRepo.On("UpdatePasswordHash", mock.Anything, user.ID, "bcrypt-hash", "argon2id-hash").Return(errors.New("db error"))
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, user.ID.String(), mock.AnythingOfType("string")).Return("access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, user.ID.String(), mock.AnythingOfType("string")).Return("refresh-token", nil)
+
+		result, err := svc.Login(context.Background(), domain.LoginRequest{Email: "user@test.com", Password: "password123"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
 	t.Run("should return ErrInvalidCredentials when user not found", func(t *testing.T) {
 		t.Parallel()
 
@@ -217,7 +310,7 @@ func TestLogin(t *testing.T) {
 		ctx := context.Background()
 		req := domain.LoginRequest{Email: "nobody@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "nobody@test.com").Return(nil, domain.ErrUserNotFound)
+		authRepo.On("FindUserByEmail", mock.Anything, "nobody@test.com").Return(nil, domain.ErrUserNotFound)
 
 		result, err := svc.Login(ctx, req)
 
@@ -233,8 +326,8 @@ func TestLogin(t *testing.T) {
 		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password"}
 		req := domain.LoginRequest{Email: "user@test.com", Password: "wrongpassword"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(user, nil)
-		passwordHasher.On("Check", "wrongpassword", "hashed-password").Return(errors.New("mismatch"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "wrongpassword", "hashed-password").Return(errors.New("mismatch"))
 
 		result, err := svc.Login(ctx, req)
 
@@ -242,6 +335,24 @@ func TestLogin(t *testing.T) {
 		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
 	})
 
+	t.Run("should pass a busy hasher through instead of rejecting the password", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, _, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password"}
+		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(domain.ErrServerBusy)
+
+		result, err := svc.Login(ctx, req)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrServerBusy)
+		assert.NotErrorIs(t, err, domain.ErrInvalidCredentials)
+	})
+
 	t.Run("should return error when FindUserByEmail fails", func(t *testing.T) {
 		t.Parallel()
 
@@ -249,7 +360,7 @@ func TestLogin(t *testing.T) {
 		ctx := context.Background()
 		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(nil, errors.New("db error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(nil, errors.New("db error"))
 
 		result, err := svc.Login(ctx, req)
 
@@ -266,9 +377,10 @@ func TestLogin(t *testing.T) {
 		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password"}
 		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(user, nil)
-		passwordHasher.On("Check", "password123", "hashed-password").Return(nil)
-		sessionRepo.On("CreateSession", ctx, mock.AnythingOfType("*domain.Session")).Return(errors.New("db error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+		passwordHasher.On("NeedsRehash", "hashed-password").Return(false)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(errors.New("db error"))
 
 		result, err := svc.Login(ctx, req)
 
@@ -286,13 +398,31 @@ func TestLogin(t *testing.T) {
 		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", DeletedAt: &now}
 		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(user, nil)
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
 
 		result, err := svc.Login(ctx, req)
 
 		assert.Nil(t, result)
 		assert.ErrorIs(t, err, domain.ErrUserDeactivated)
 	})
+
+	t.Run("should return ErrPasswordExpired when password has been expired", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, _, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		expiredAt := time.Now().Add(-time.Minute)
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", PasswordExpiresAt: &expiredAt}
+		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+
+		result, err := svc.Login(ctx, req)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrPasswordExpired)
+	})
 }
 
 func TestLogout(t *testing.T) {
@@ -304,7 +434,7 @@ func TestLogout(t *testing.T) {
 		sessionID := uuid.New()
 		deletedSession := &domain.Session{ID: sessionID, UserID: uuid.New()}
 
-		sessionRepo.On("DeleteSession", ctx, sessionID).Return(deletedSession, nil)
+		sessionRepo.On("DeleteSession", mock.Anything, sessionID).Return(deletedSession, nil)
 
 		err := svc.Logout(ctx, sessionID.String())
 
@@ -330,7 +460,7 @@ func TestLogout(t *testing.T) {
 		ctx := context.Background()
 		sessionID := uuid.New()
 
-		sessionRepo.On("DeleteSession", ctx, sessionID).Return(nil, errors.New("db error"))
+		sessionRepo.On("DeleteSession", mock.Anything, sessionID).Return(nil, errors.New("db error"))
 
 		err := svc.Logout(ctx, sessionID.String())
 
@@ -348,16 +478,39 @@ func TestUpdatePassword(t *testing.T) {
 		userID := uuid.New()
 		user := &domain.User{ID: userID, Email: "user@test.com", Password: "hashed-old"}
 
-		authRepo.On("FindUserByID", ctx, userID).Return(user, nil)
-		passwordHasher.On("Check", "oldpass", "hashed-old").Return(nil)
-		passwordHasher.On("Hash", "newpass123").Return("hashed-new", nil)
-		authRepo.On("UpdateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "oldpass", "hashed-old").Return(nil)
+		passwordHasher.On("Hash", mock.Anything, "newpass123").Return("hashed-new", nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
 
 		err := svc.UpdatePassword(ctx, userID.String(), domain.UpdatePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass123"})
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("should check the new password against the policy with the user as owner", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, _, passwordHasher := newAuthService(t)
+		policy := mockpkg.NewMockPasswordPolicy(t)
+		svc.passwordPolicy = policy
+		ctx := context.Background()
+		userID := uuid.New()
+		user := &domain.User{ID: userID, Name: "Test User", Email: "user@test.com", Password: "hashed-old"}
+
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "oldpass", "hashed-old").Return(nil)
+		policy.On("Check", mock.Anything, "testuser123", domain.PasswordOwner{Email: "user@test.com", Name: "Test User"}).
+			Return([]domain.PasswordViolation{{Rule: domain.PasswordRulePersonalInfo}}, nil)
+
+		err := svc.UpdatePassword(ctx, userID.String(), domain.UpdatePasswordRequest{CurrentPassword: "oldpass", NewPassword: "testuser123"})
+
+		var policyErr *domain.PasswordPolicyError
+		require.ErrorAs(t, err, &policyErr)
+		assert.Equal(t, "new_password", policyErr.Field)
+		passwordHasher.AssertNotCalled(t, "Hash", mock.Anything, mock.Anything)
+	})
+
 	t.Run("should return error when user not found", func(t *testing.T) {
 		t.Parallel()
 
@@ -365,7 +518,7 @@ func TestUpdatePassword(t *testing.T) {
 		ctx := context.Background()
 		userID := uuid.New()
 
-		authRepo.On("FindUserByID", ctx, userID).Return(nil, domain.ErrUserNotFound)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(nil, domain.ErrUserNotFound)
 
 		err := svc.UpdatePassword(ctx, userID.String(), domain.UpdatePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass123"})
 
@@ -381,8 +534,8 @@ func TestUpdatePassword(t *testing.T) {
 		userID := uuid.New()
 		user := &domain.User{ID: userID, Email: "user@test.com", Password: "hashed-old"}
 
-		authRepo.On("FindUserByID", ctx, userID).Return(user, nil)
-		passwordHasher.On("Check", "wrongpass", "hashed-old").Return(errors.New("mismatch"))
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "wrongpass", "hashed-old").Return(errors.New("mismatch"))
 
 		err := svc.UpdatePassword(ctx, userID.String(), domain.UpdatePasswordRequest{CurrentPassword: "wrongpass", NewPassword: "newpass123"})
 
@@ -397,9 +550,9 @@ func TestUpdatePassword(t *testing.T) {
 		userID := uuid.New()
 		user := &domain.User{ID: userID, Email: "user@test.com", Password: "hashed-old"}
 
-		authRepo.On("FindUserByID", ctx, userID).Return(user, nil)
-		passwordHasher.On("Check", "oldpass", "hashed-old").Return(nil)
-		passwordHasher.On("Hash", "newpass123").Return("", errors.New("hash error"))
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "oldpass", "hashed-old").Return(nil)
+		passwordHasher.On("Hash", mock.Anything, "newpass123").Return("", errors.New("hash error"))
 
 		err := svc.UpdatePassword(ctx, userID.String(), domain.UpdatePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass123"})
 
@@ -415,10 +568,10 @@ func TestUpdatePassword(t *testing.T) {
 		userID := uuid.New()
 		user := &domain.User{ID: userID, Email: "user@test.com", Password: "hashed-old"}
 
-		authRepo.On("FindUserByID", ctx, userID).Return(user, nil)
-		passwordHasher.On("Check", "oldpass", "hashed-old").Return(nil)
-		passwordHasher.On("Hash", "newpass123").Return("hashed-new", nil)
-		authRepo.On("UpdateUser", ctx, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "oldpass", "hashed-old").Return(nil)
+		passwordHasher.On("Hash", mock.Anything, "newpass123").Return("hashed-new", nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
 
 		err := svc.UpdatePassword(ctx, userID.String(), domain.UpdatePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass123"})
 
@@ -436,9 +589,9 @@ func TestUpdateUser(t *testing.T) {
 		userID := uuid.New()
 		user := &domain.User{ID: userID, Email: "old@test.com", Name: "Old Name", Avatar: "old-avatar"}
 
-		authRepo.On("FindUserByID", ctx, userID).Return(user, nil)
-		authRepo.On("FindUserByEmail", ctx, "new@test.com").Return(nil, domain.ErrUserNotFound)
-		authRepo.On("UpdateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		authRepo.On("FindUserByEmail", mock.Anything, "new@test.com").Return(nil, domain.ErrUserNotFound)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
 
 		result, err := svc.UpdateUser(ctx, userID.String(), domain.UpdateUserRequest{Name: "New Name", Email: "new@test.com", Avatar: "new-avatar"})
 
@@ -457,8 +610,8 @@ func TestUpdateUser(t *testing.T) {
 		userID := uuid.New()
 		user := &domain.User{ID: userID, Email: "user@test.com", Name: "Old"}
 
-		authRepo.On("FindUserByID", ctx, userID).Return(user, nil)
-		authRepo.On("UpdateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
 
 		result, err := svc.UpdateUser(ctx, userID.String(), domain.UpdateUserRequest{Name: "New Name"})
 
@@ -476,8 +629,8 @@ func TestUpdateUser(t *testing.T) {
 		userID := uuid.New()
 		user := &domain.User{ID: userID, Email: "same@test.com", Name: "User"}
 
-		authRepo.On("FindUserByID", ctx, userID).Return(user, nil)
-		authRepo.On("UpdateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
 
 		result, err := svc.UpdateUser(ctx, userID.String(), domain.UpdateUserRequest{Email: "same@test.com"})
 
@@ -493,8 +646,8 @@ func TestUpdateUser(t *testing.T) {
 		userID := uuid.New()
 		user := &domain.User{ID: userID, Email: "old@test.com", Name: "User"}
 
-		authRepo.On("FindUserByID", ctx, userID).Return(user, nil)
-		authRepo.On("FindUserByEmail", ctx, "taken@test.com").Return(&domain.User{}, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		authRepo.On("FindUserByEmail", mock.Anything, "taken@test.com").Return(&domain.User{}, nil)
 
 		result, err := svc.UpdateUser(ctx, userID.String(), domain.UpdateUserRequest{Email: "taken@test.com"})
 
@@ -509,7 +662,7 @@ func TestUpdateUser(t *testing.T) {
 		ctx := context.Background()
 		userID := uuid.New()
 
-		authRepo.On("FindUserByID", ctx, userID).Return(nil, errors.New("db error"))
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(nil, errors.New("db error"))
 
 		result, err := svc.UpdateUser(ctx, userID.String(), domain.UpdateUserRequest{Name: "New Name"})
 
@@ -526,8 +679,8 @@ func TestUpdateUser(t *testing.T) {
 		userID := uuid.New()
 		user := &domain.User{ID: userID, Email: "user@test.com", Name: "Old"}
 
-		authRepo.On("FindUserByID", ctx, userID).Return(user, nil)
-		authRepo.On("UpdateUser", ctx, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
 
 		result, err := svc.UpdateUser(ctx, userID.String(), domain.UpdateUserRequest{Name: "New Name"})
 
@@ -545,8 +698,8 @@ func TestDeleteUser(t *testing.T) {
 		ctx := context.Background()
 		userID := uuid.New()
 
-		sessionRepo.On("DeleteSessionsByUserID", ctx, userID).Return(nil)
-		authRepo.On("DeleteUser", ctx, userID).Return(nil)
+		sessionRepo.On("DeleteSessionsByUserID", mock.Anything, userID).Return(int64(1), nil)
+		authRepo.On("DeleteUser", mock.Anything, userID).Return(nil)
 
 		err := svc.DeleteUser(ctx, userID.String())
 
@@ -560,7 +713,7 @@ func TestDeleteUser(t *testing.T) {
 		ctx := context.Background()
 		userID := uuid.New()
 
-		sessionRepo.On("DeleteSessionsByUserID", ctx, userID).Return(errors.New("db error"))
+		sessionRepo.On("DeleteSessionsByUserID", mock.Anything, userID).Return(int64(0), errors.New("db error"))
 
 		err := svc.DeleteUser(ctx, userID.String())
 
@@ -575,8 +728,8 @@ func TestDeleteUser(t *testing.T) {
 		ctx := context.Background()
 		userID := uuid.New()
 
-		sessionRepo.On("DeleteSessionsByUserID", ctx, userID).Return(nil)
-		authRepo.On("DeleteUser", ctx, userID).Return(errors.New("db error"))
+		sessionRepo.On("DeleteSessionsByUserID", mock.Anything, userID).Return(int64(1), nil)
+		authRepo.On("DeleteUser", mock.Anything, userID).Return(errors.New("db error"))
 
 		err := svc.DeleteUser(ctx, userID.String())
 
@@ -595,12 +748,13 @@ func TestReactivateAccount(t *testing.T) {
 		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", DeletedAt: &now}
 		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(user, nil)
-		passwordHasher.On("Check", "password123", "hashed-password").Return(nil)
-		authRepo.On("UpdateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
-		sessionRepo.On("CreateSession", ctx, mock.AnythingOfType("*domain.Session")).Return(nil)
-		tokenProvider.On("GenerateAccessToken", mock.AnythingOfType("string")).Return("access-token", nil)
-		tokenProvider.On("GenerateRefreshToken", user.ID.String(), mock.AnythingOfType("string")).Return("refresh-token", nil)
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+		passwordHasher.On("NeedsRehash", "hashed-password").Return(false)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, user.ID.String(), mock.AnythingOfType("string")).Return("refresh-token", nil)
 
 		result, err := svc.ReactivateAccount(ctx, req)
 
@@ -617,7 +771,7 @@ func TestReactivateAccount(t *testing.T) {
 		ctx := context.Background()
 		req := domain.LoginRequest{Email: "nobody@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "nobody@test.com").Return(nil, domain.ErrUserNotFound)
+		authRepo.On("FindUserByEmail", mock.Anything, "nobody@test.com").Return(nil, domain.ErrUserNotFound)
 
 		result, err := svc.ReactivateAccount(ctx, req)
 
@@ -634,8 +788,8 @@ func TestReactivateAccount(t *testing.T) {
 		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", DeletedAt: &now}
 		req := domain.LoginRequest{Email: "user@test.com", Password: "wrongpassword"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(user, nil)
-		passwordHasher.On("Check", "wrongpassword", "hashed-password").Return(errors.New("mismatch"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "wrongpassword", "hashed-password").Return(errors.New("mismatch"))
 
 		result, err := svc.ReactivateAccount(ctx, req)
 
@@ -651,7 +805,7 @@ func TestReactivateAccount(t *testing.T) {
 		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password"}
 		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(user, nil)
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
 
 		result, err := svc.ReactivateAccount(ctx, req)
 
@@ -668,9 +822,9 @@ func TestReactivateAccount(t *testing.T) {
 		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", DeletedAt: &now}
 		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(user, nil)
-		passwordHasher.On("Check", "password123", "hashed-password").Return(nil)
-		authRepo.On("UpdateUser", ctx, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("db error"))
 
 		result, err := svc.ReactivateAccount(ctx, req)
 
@@ -688,10 +842,11 @@ func TestReactivateAccount(t *testing.T) {
 		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", DeletedAt: &now}
 		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
 
-		authRepo.On("FindUserByEmail", ctx, "user@test.com").Return(user, nil)
-		passwordHasher.On("Check", "password123", "hashed-password").Return(nil)
-		authRepo.On("UpdateUser", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
-		sessionRepo.On("CreateSession", ctx, mock.AnythingOfType("*domain.Session")).Return(errors.New("db error"))
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+		passwordHasher.On("NeedsRehash", "hashed-password").Return(false)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(errors.New("db error"))
 
 		result, err := svc.ReactivateAccount(ctx, req)
 
@@ -699,4 +854,208 @@ func TestReactivateAccount(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create session")
 	})
+
+	t.Run("should keep the account deactivated when the password expired", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, _, _, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		now := time.Now()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", DeletedAt: &now, PasswordExpiresAt: &now}
+		req := domain.LoginRequest{Email: "user@test.com", Password: "password123"}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+
+		result, err := svc.ReactivateAccount(ctx, req)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrPasswordExpired)
+		authRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	t.Run("should rotate tokens and extend the session", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, sessionRepo, tokenProvider, _ := newAuthService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New()}
+		session := &domain.Session{ID: uuid.New(), UserID: user.ID}
+
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "refresh-token").
+			Return(&domain.RefreshTokenClaims{UserID: user.ID.String(), SessionID: session.ID.String()}, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, session.ID).Return(session, nil)
+		authRepo.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, user.ID.String(), session.ID.String()).Return("new-access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, user.ID.String(), session.ID.String()).Return("new-refresh-token", nil)
+		sessionRepo.On("UpdateSessionExpiry", mock.Anything, session.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		result, err := svc.Refresh(ctx, "refresh-token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new-access-token", result.AccessToken)
+		assert.Equal(t, "new-refresh-token", result.RefreshToken)
+	})
+
+	t.Run("should return ErrInvalidRefreshToken when the token does not parse", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, tokenProvider, _ := newAuthService(t)
+		ctx := context.Background()
+
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "bad-token").Return(nil, errors.New("invalid"))
+
+		result, err := svc.Refresh(ctx, "bad-token")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+	})
+
+	t.Run("should return ErrInvalidRefreshToken when the session was revoked", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, sessionRepo, tokenProvider, _ := newAuthService(t)
+		ctx := context.Background()
+		sessionID := uuid.New()
+
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "refresh-token").
+			Return(&domain.RefreshTokenClaims{UserID: uuid.NewString(), SessionID: sessionID.String()}, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(nil, domain.ErrSessionNotFound)
+
+		result, err := svc.Refresh(ctx, "refresh-token")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+	})
+
+	t.Run("should return ErrInvalidRefreshToken when the session belongs to another user", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, sessionRepo, tokenProvider, _ := newAuthService(t)
+		ctx := context.Background()
+		session := &domain.Session{ID: uuid.New(), UserID: uuid.New()}
+
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "refresh-token").
+			Return(&domain.RefreshTokenClaims{UserID: uuid.NewString(), SessionID: session.ID.String()}, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, session.ID).Return(session, nil)
+
+		result, err := svc.Refresh(ctx, "refresh-token")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+	})
+
+	t.Run("should return ErrUserDeactivated when user is soft-deleted", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, sessionRepo, tokenProvider, _ := newAuthService(t)
+		ctx := context.Background()
+		now := time.Now()
+		user := &domain.User{ID: uuid.New(), DeletedAt: &now}
+		session := &domain.Session{ID: uuid.New(), UserID: user.ID}
+
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "refresh-token").
+			Return(&domain.RefreshTokenClaims{UserID: user.ID.String(), SessionID: session.ID.String()}, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, session.ID).Return(session, nil)
+		authRepo.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		result, err := svc.Refresh(ctx, "refresh-token")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrUserDeactivated)
+	})
+
+	t.Run("should return error when UpdateSessionExpiry fails", func(t *testing.T) {
+		t.Parallel()
+
+		svc, authRepo, sessionRepo, tokenProvider, _ := newAuthService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New()}
+		session := &domain.Session{ID: uuid.New(), UserID: user.ID}
+
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "refresh-token").
+			Return(&domain.RefreshTokenClaims{UserID: user.ID.String(), SessionID: session.ID.String()}, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, session.ID).Return(session, nil)
+		authRepo.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, user.ID.String(), session.ID.String()).Return("new-access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, user.ID.String(), session.ID.String()).Return("new-refresh-token", nil)
+		sessionRepo.On("UpdateSessionExpiry", mock.Anything, session.ID, mock.AnythingOfType("time.Time")).Return(errors.New("db error"))
+
+		result, err := svc.Refresh(ctx, "refresh-token")
+
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to update session expiry")
+	})
+}
+
+func TestListSessions(t *testing.T) {
+	t.Run("should mark the current session and name the clients", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, sessionRepo, _, _ := newAuthService(t)
+		clientRepo := mockpkg.NewMockOAuthClientRepository(t)
+		svc.clientRepository = clientRepo
+		userID := uuid.New()
+		client := &domain.OAuthClient{ID: uuid.New(), Name: "migos-cli"}
+		browser := domain.Session{ID: uuid.New(), UserID: userID}
+		device := domain.Session{ID: uuid.New(), UserID: userID, ClientID: &client.ID, Scope: "openid"}
+		other := domain.Session{ID: uuid.New(), UserID: userID, ClientID: &client.ID, Scope: "openid"}
+
+		sessionRepo.On("FindSessionsByUserID", mock.Anything, userID).Return([]domain.Session{browser, device, other}, nil)
+		clientRepo.On("FindClientByID", mock.Anything, client.ID).Return(client, nil).Once()
+
+		sessions, err := svc.ListSessions(context.Background(), userID.String(), browser.ID.String())
+
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 3)
+		assert.True(t, sessions[0].Current)
+		assert.Empty(t, sessions[0].ClientName)
+		assert.False(t, sessions[1].Current)
+		assert.Equal(t, "migos-cli", sessions[1].ClientName)
+		assert.Equal(t, "migos-cli", sessions[2].ClientName)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	t.Run("should delete a session of the user", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, sessionRepo, _, _ := newAuthService(t)
+		userID := uuid.New()
+		session := &domain.Session{ID: uuid.New(), UserID: userID}
+
+		sessionRepo.On("FindSessionByID", mock.Anything, session.ID).Return(session, nil)
+		sessionRepo.On("DeleteSession", mock.Anything, session.ID).Return(session, nil)
+
+		err := svc.RevokeSession(context.Background(), userID.String(), session.ID.String())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return ErrSessionNotFound for sessions of other users", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, sessionRepo, _, _ := newAuthService(t)
+		session := &domain.Session{ID: uuid.New(), UserID: uuid.New()}
+
+		sessionRepo.On("FindSessionByID", mock.Anything, session.ID).Return(session, nil)
+
+		err := svc.RevokeSession(context.Background(), uuid.NewString(), session.ID.String())
+
+		assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+		sessionRepo.AssertNotCalled(t, "DeleteSession")
+	})
+
+	t.Run("should return ErrSessionNotFound for malformed IDs", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, sessionRepo, _, _ := newAuthService(t)
+
+		err := svc.RevokeSession(context.Background(), uuid.NewString(), "not-a-uuid")
+
+		assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+		sessionRepo.AssertNotCalled(t, "FindSessionByID")
+	})
 }
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+// userCodeAlphabet has no vowels, so user codes don't spell words, and no
+// characters that are easily confused (RFC 8628 section 6.1).
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+const userCodeLength = 8
+
+// slowDownIncrement is added to the polling interval of a device that polls
+// too fast (RFC 8628 section 3.5).
+const slowDownIncrement = 5
+
+// DeviceAuthorization starts the device flow for the client. Scopes are
+// optional; the ID token is only issued when openid is among them.
+func (s *OIDCServiceImpl) DeviceAuthorization(ctx context.Context, req domain.DeviceCodeRequest) (_ *domain.DeviceCodeResponse, err error) {
+	ctx, span := tracing.Start(ctx, "OIDCService.DeviceAuthorization")
+	defer tracing.End(span, &err)
+
+	client, err := s.authenticateTokenClient(ctx, req.ClientCredentials)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := normalizeScopes(strings.Fields(req.Scope))
+	if !containsAll(domain.SupportedScopes, scopes) {
+		return nil, domain.ErrInvalidScope
+	}
+
+	deviceCode, err := randomString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate device code: %w", err)
+	}
+	userCode, err := newUserCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate user code: %w", err)
+	}
+
+	ttl := config.Env.OIDC.DeviceCodeTTL
+	interval := max(int(config.Env.OIDC.DevicePollInterval/time.Second), 1)
+	if err := s.authorizationRepository.CreateDeviceAuthorization(ctx, &domain.DeviceAuthorization{
+		ID:        hashSecret(deviceCode),
+		UserCode:  hashSecret(userCode),
+		ClientID:  client.ID,
+		Scope:     strings.Join(scopes, " "),
+		Status:    domain.DeviceStatusPending,
+		Interval:  interval,
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create device authorization: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "OIDCService.DeviceAuthorization")).
+		Info("device authorization started", zap.String("client_id", client.ID.String()))
+
+	displayed := formatUserCode(userCode)
+	verificationURI := s.endpoint("/device")
+	return &domain.DeviceCodeResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                displayed,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: withUserCode(verificationURI, displayed),
+		ExpiresIn:               int(ttl / time.Second),
+		Interval:                interval,
+	}, nil
+}
+
+// DevicePrompt describes the pending request of userCode for the
+// verification page.
+func (s *OIDCServiceImpl) DevicePrompt(ctx context.Context, userCode string) (_ *domain.ConsentPrompt, err error) {
+	ctx, span := tracing.Start(ctx, "OIDCService.DevicePrompt")
+	defer tracing.End(span, &err)
+
+	device, client, err := s.pendingDevice(ctx, userCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ConsentPrompt{ClientName: client.Name, Scopes: strings.Fields(device.Scope)}, nil
+}
+
+// ApproveDevice records the user's answer to a device request. The device
+// picks it up on its next poll.
+func (s *OIDCServiceImpl) ApproveDevice(ctx context.Context, userID string, req domain.DeviceApprovalRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "OIDCService.ApproveDevice")
+	defer tracing.End(span, &err)
+
+	device, client, err := s.pendingDevice(ctx, req.UserCode)
+	if err != nil {
+		return err
+	}
+
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	status := domain.DeviceStatusDenied
+	if req.Consent == domain.ConsentApprove {
+		status = domain.DeviceStatusApproved
+	}
+	if err := s.authorizationRepository.ResolveDeviceAuthorization(ctx, device.ID, user, status, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrDeviceAuthorizationNotFound) {
+			return domain.ErrInvalidUserCode
+		}
+		return fmt.Errorf("failed to resolve device authorization: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "OIDCService.ApproveDevice")).
+		Info("device authorization "+status, zap.String("user_id", userID), zap.String("client_id", client.ID.String()))
+
+	return nil
+}
+
+// pendingDevice finds the request of userCode, which users may type in any
+// case and with or without the dash.
+func (s *OIDCServiceImpl) pendingDevice(ctx context.Context, userCode string) (*domain.DeviceAuthorization, *domain.OAuthClient, error) {
+	code := normalizeUserCode(userCode)
+	if len(code) != userCodeLength {
+		return nil, nil, domain.ErrInvalidUserCode
+	}
+
+	device, err := s.authorizationRepository.FindDeviceAuthorizationByUserCode(ctx, hashSecret(code))
+	if err != nil {
+		if errors.Is(err, domain.ErrDeviceAuthorizationNotFound) {
+			return nil, nil, domain.ErrInvalidUserCode
+		}
+		return nil, nil, fmt.Errorf("failed to find device authorization: %w", err)
+	}
+	if device.Status != domain.DeviceStatusPending || !time.Now().Before(device.ExpiresAt) {
+		return nil, nil, domain.ErrInvalidUserCode
+	}
+
+	client, err := s.clientRepository.FindClientByID(ctx, device.ClientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrClientNotFound) {
+			return nil, nil, domain.ErrInvalidUserCode
+		}
+		return nil, nil, fmt.Errorf("failed to find client: %w", err)
+	}
+
+	return device, client, nil
+}
+
+// exchangeDeviceCode answers a device poll. Until the user answers, polls
+// get authorization_pending, or slow_down when they come faster than the
+// interval, which then grows. An approved request is redeemed once for a
+// session of the client.
+func (s *OIDCServiceImpl) exchangeDeviceCode(ctx context.Context, client *domain.OAuthClient, req domain.TokenRequest) (*domain.TokenResponse, error) {
+	if req.DeviceCode == "" {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	id := hashSecret(req.DeviceCode)
+	device, err := s.authorizationRepository.FindDeviceAuthorization(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrDeviceAuthorizationNotFound) {
+			return nil, domain.ErrInvalidGrant
+		}
+		return nil, fmt.Errorf("failed to find device authorization: %w", err)
+	}
+	if device.ClientID != client.ID {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	now := time.Now()
+	if !now.Before(device.ExpiresAt) {
+		return nil, domain.ErrExpiredToken
+	}
+
+	switch device.Status {
+	case domain.DeviceStatusPending:
+		return nil, s.recordDevicePoll(ctx, device, now)
+	case domain.DeviceStatusDenied:
+		if err := s.authorizationRepository.DeleteDeviceAuthorization(ctx, id); err != nil && !errors.Is(err, domain.ErrDeviceAuthorizationNotFound) {
+			return nil, fmt.Errorf("failed to delete device authorization: %w", err)
+		}
+		return nil, domain.ErrAccessDenied
+	}
+
+	if err := s.authorizationRepository.DeleteDeviceAuthorization(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrDeviceAuthorizationNotFound) {
+			return nil, domain.ErrInvalidGrant
+		}
+		return nil, fmt.Errorf("failed to delete device authorization: %w", err)
+	}
+	if device.UserID == nil || device.ApprovedAt == nil {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	user, err := s.activeUser(ctx, *device.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &domain.Session{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		ClientID:  &client.ID,
+		Scope:     device.Scope,
+		ExpiresAt: now.Add(time.Duration(config.Env.Token.RefreshTokenExpiry) * time.Minute),
+	}
+	response, err := s.createSession(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.addIDToken(ctx, response, user, session, client, "", *device.ApprovedAt); err != nil {
+		return nil, err
+	}
+
+	logging.WithContext(ctx, zap.String("service", "OIDCService.exchangeDeviceCode")).
+		Info("device session created", zap.String("user_id", user.ID.String()), zap.String("session_id", session.ID.String()))
+
+	return response, nil
+}
+
+func (s *OIDCServiceImpl) recordDevicePoll(ctx context.Context, device *domain.DeviceAuthorization, now time.Time) error {
+	interval := device.Interval
+	tooFast := device.LastPolledAt != nil && now.Sub(*device.LastPolledAt) < time.Duration(interval)*time.Second
+	if tooFast {
+		interval += slowDownIncrement
+	}
+
+	if err := s.authorizationRepository.RecordDevicePoll(ctx, device.ID, now, interval); err != nil {
+		return fmt.Errorf("failed to record device poll: %w", err)
+	}
+
+	if tooFast {
+		return domain.ErrSlowDown
+	}
+	return domain.ErrAuthorizationPending
+}
+
+func newUserCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(userCodeAlphabet)))
+	code := make([]byte, userCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code[i] = userCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// normalizeUserCode upper-cases code and drops everything outside the
+// alphabet, such as the dash and spaces.
+func normalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if slices.Contains([]rune(userCodeAlphabet), r) {
+			return r
+		}
+		return -1
+	}, strings.ToUpper(code))
+}
+
+// formatUserCode splits code in two halves for readability: WDJB-MJHT.
+func formatUserCode(code string) string {
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
+
+func withUserCode(verificationURI, userCode string) string {
+	return verificationURI + "?" + url.Values{"user_code": {userCode}}.Encode()
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+func TestOIDCDeviceAuthorization(t *testing.T) {
+	t.Run("should store hashes of the codes and return a readable user code", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+
+		var device *domain.DeviceAuthorization
+		m.authorizations.On("CreateDeviceAuthorization", mock.Anything, mock.AnythingOfType("*domain.DeviceAuthorization")).
+			Run(func(args mock.Arguments) { device = args.Get(1).(*domain.DeviceAuthorization) }).
+			Return(nil)
+
+		response, err := svc.DeviceAuthorization(context.Background(), domain.DeviceCodeRequest{
+			Scope:             "profile openid",
+			ClientCredentials: domain.ClientCredentials{ClientID: client.ID.String()},
+		})
+
+		require.NoError(t, err)
+		assert.Regexp(t, `^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$`, response.UserCode)
+		assert.Equal(t, "https://auth.example.com/device", response.VerificationURI)
+		assert.Equal(t, "https://auth.example.com/device?user_code="+response.UserCode, response.VerificationURIComplete)
+		assert.Equal(t, hashSecret(response.DeviceCode), device.ID)
+		assert.Equal(t, hashSecret(normalizeUserCode(response.UserCode)), device.UserCode)
+		assert.Equal(t, "openid profile", device.Scope)
+		assert.Equal(t, domain.DeviceStatusPending, device.Status)
+		assert.Equal(t, response.Interval, device.Interval)
+	})
+
+	t.Run("should reject unknown scopes", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+
+		_, err := svc.DeviceAuthorization(context.Background(), domain.DeviceCodeRequest{
+			Scope:             "openid admin",
+			ClientCredentials: domain.ClientCredentials{ClientID: client.ID.String()},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidScope)
+		m.authorizations.AssertNotCalled(t, "CreateDeviceAuthorization")
+	})
+}
+
+func TestOIDCApproveDevice(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("should accept the user code in any case and without the dash", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		device := &domain.DeviceAuthorization{
+			ID: "device", ClientID: client.ID, Status: domain.DeviceStatusPending, ExpiresAt: time.Now().Add(time.Minute),
+		}
+		m.authorizations.On("FindDeviceAuthorizationByUserCode", mock.Anything, hashSecret("WDJBMJHT")).Return(device, nil)
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("ResolveDeviceAuthorization", mock.Anything, "device", userID, domain.DeviceStatusApproved, mock.Anything).Return(nil)
+
+		err := svc.ApproveDevice(context.Background(), userID.String(), domain.DeviceApprovalRequest{
+			UserCode: "wdjb mjht", Consent: domain.ConsentApprove,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should record a denial", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		device := &domain.DeviceAuthorization{
+			ID: "device", ClientID: client.ID, Status: domain.DeviceStatusPending, ExpiresAt: time.Now().Add(time.Minute),
+		}
+		m.authorizations.On("FindDeviceAuthorizationByUserCode", mock.Anything, hashSecret("WDJBMJHT")).Return(device, nil)
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("ResolveDeviceAuthorization", mock.Anything, "device", userID, domain.DeviceStatusDenied, mock.Anything).Return(nil)
+
+		err := svc.ApproveDevice(context.Background(), userID.String(), domain.DeviceApprovalRequest{
+			UserCode: "WDJB-MJHT", Consent: domain.ConsentDeny,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return ErrInvalidUserCode for expired or answered requests", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		m.authorizations.On("FindDeviceAuthorizationByUserCode", mock.Anything, hashSecret("WDJBMJHT")).
+			Return(&domain.DeviceAuthorization{Status: domain.DeviceStatusPending, ExpiresAt: time.Now().Add(-time.Second)}, nil).Once()
+		m.authorizations.On("FindDeviceAuthorizationByUserCode", mock.Anything, hashSecret("WDJBMJHT")).
+			Return(&domain.DeviceAuthorization{Status: domain.DeviceStatusApproved, ExpiresAt: time.Now().Add(time.Minute)}, nil).Once()
+
+		request := domain.DeviceApprovalRequest{UserCode: "WDJB-MJHT", Consent: domain.ConsentApprove}
+		assert.ErrorIs(t, svc.ApproveDevice(context.Background(), userID.String(), request), domain.ErrInvalidUserCode)
+		assert.ErrorIs(t, svc.ApproveDevice(context.Background(), userID.String(), request), domain.ErrInvalidUserCode)
+	})
+
+	t.Run("should return ErrInvalidUserCode without a lookup for malformed codes", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+
+		_, err := svc.DevicePrompt(context.Background(), "WDJB-MJ")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidUserCode)
+		m.authorizations.AssertNotCalled(t, "FindDeviceAuthorizationByUserCode")
+	})
+}
+
+func TestOIDCDeviceToken(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Name: "Ada", Email: "ada@example.com"}
+	deviceRequest := func(client *domain.OAuthClient) domain.TokenRequest {
+		return domain.TokenRequest{
+			GrantType:         domain.GrantTypeDeviceCode,
+			DeviceCode:        "device-code",
+			ClientCredentials: domain.ClientCredentials{ClientID: client.ID.String()},
+		}
+	}
+	pending := func(client *domain.OAuthClient, lastPolledAt *time.Time) *domain.DeviceAuthorization {
+		return &domain.DeviceAuthorization{
+			ID: hashSecret("device-code"), ClientID: client.ID, Scope: "openid email", Status: domain.DeviceStatusPending,
+			Interval: 5, LastPolledAt: lastPolledAt, ExpiresAt: time.Now().Add(time.Minute),
+		}
+	}
+
+	t.Run("should return ErrAuthorizationPending until the user answers", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindDeviceAuthorization", mock.Anything, hashSecret("device-code")).Return(pending(client, nil), nil)
+		m.authorizations.On("RecordDevicePoll", mock.Anything, hashSecret("device-code"), mock.Anything, 5).Return(nil)
+
+		_, err := svc.Token(context.Background(), deviceRequest(client))
+
+		assert.ErrorIs(t, err, domain.ErrAuthorizationPending)
+	})
+
+	t.Run("should return ErrSlowDown and grow the interval when polled too fast", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		lastPolledAt := time.Now().Add(-time.Second)
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindDeviceAuthorization", mock.Anything, hashSecret("device-code")).Return(pending(client, &lastPolledAt), nil)
+		m.authorizations.On("RecordDevicePoll", mock.Anything, hashSecret("device-code"), mock.Anything, 10).Return(nil)
+
+		_, err := svc.Token(context.Background(), deviceRequest(client))
+
+		assert.ErrorIs(t, err, domain.ErrSlowDown)
+	})
+
+	t.Run("should return ErrExpiredToken once the device code expires", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		device := pending(client, nil)
+		device.ExpiresAt = time.Now().Add(-time.Second)
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindDeviceAuthorization", mock.Anything, hashSecret("device-code")).Return(device, nil)
+
+		_, err := svc.Token(context.Background(), deviceRequest(client))
+
+		assert.ErrorIs(t, err, domain.ErrExpiredToken)
+	})
+
+	t.Run("should reject a device code issued to another client", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindDeviceAuthorization", mock.Anything, hashSecret("device-code")).
+			Return(pending(newOIDCClient(""), nil), nil)
+
+		_, err := svc.Token(context.Background(), deviceRequest(client))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+	})
+
+	t.Run("should return ErrAccessDenied and forget the request when the user denies", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		device := pending(client, nil)
+		device.Status = domain.DeviceStatusDenied
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindDeviceAuthorization", mock.Anything, hashSecret("device-code")).Return(device, nil)
+		m.authorizations.On("DeleteDeviceAuthorization", mock.Anything, hashSecret("device-code")).Return(nil)
+
+		_, err := svc.Token(context.Background(), deviceRequest(client))
+
+		assert.ErrorIs(t, err, domain.ErrAccessDenied)
+	})
+
+	t.Run("should create a session of the client once approved", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		approvedAt := time.Now().Add(-time.Second)
+		device := pending(client, nil)
+		device.Status = domain.DeviceStatusApproved
+		device.UserID = &user.ID
+		device.ApprovedAt = &approvedAt
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindDeviceAuthorization", mock.Anything, hashSecret("device-code")).Return(device, nil)
+		m.authorizations.On("DeleteDeviceAuthorization", mock.Anything, hashSecret("device-code")).Return(nil)
+		m.users.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		var session *domain.Session
+		m.sessions.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).
+			Run(func(args mock.Arguments) { session = args.Get(1).(*domain.Session) }).
+			Return(nil)
+		m.tokens.On("GenerateClientAccessToken", mock.Anything, user.ID.String(), mock.Anything, client.ID.String(), "openid email").Return("access", nil)
+		m.tokens.On("GenerateRefreshToken", mock.Anything, user.ID.String(), mock.Anything).Return("refresh", nil)
+		m.tokens.On("GenerateIDToken", mock.Anything, mock.MatchedBy(func(claims domain.IDTokenClaims) bool {
+			return claims.Subject == user.ID.String() && claims.Email == user.Email && claims.AuthTime.Equal(approvedAt)
+		})).Return("id", nil)
+
+		response, err := svc.Token(context.Background(), deviceRequest(client))
+
+		require.NoError(t, err)
+		assert.Equal(t, "access", response.AccessToken)
+		assert.Equal(t, "id", response.IDToken)
+		assert.Equal(t, "openid email", response.Scope)
+		require.NotNil(t, session.ClientID)
+		assert.Equal(t, client.ID, *session.ClientID)
+		assert.Equal(t, "openid email", session.Scope)
+	})
+
+	t.Run("should redeem an approved device code only once", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		approvedAt := time.Now()
+		device := pending(client, nil)
+		device.Status = domain.DeviceStatusApproved
+		device.UserID = &user.ID
+		device.ApprovedAt = &approvedAt
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindDeviceAuthorization", mock.Anything, hashSecret("device-code")).Return(device, nil)
+		m.authorizations.On("DeleteDeviceAuthorization", mock.Anything, hashSecret("device-code")).
+			Return(domain.ErrDeviceAuthorizationNotFound)
+
+		_, err := svc.Token(context.Background(), deviceRequest(client))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+		m.sessions.AssertNotCalled(t, "CreateSession")
+	})
+}
@@ -2,42 +2,177 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/config"
 	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
 	"github.com/SergioLNeves/migos/internal/storage"
-	"github.com/samber/do"
 )
 
+// signingKeyProbeSubject is the subject of the throwaway token readiness signs
+// and verifies to prove both halves of the key pair are usable.
+const signingKeyProbeSubject = "readiness-probe"
+
+// Errors /health/ready reports for a failing component. The probe is
+// unauthenticated, so the cause is only logged.
 const (
-	WorkingStatus   = "WORKING"
-	DatabaseHealthy = "healthy"
-	DatabaseError   = "error"
+	componentFailed   = "check failed"
+	componentTimedOut = "timed out"
 )
 
+// HealthComponent is a named readiness check. Check should honour ctx, which
+// carries the per-check timeout.
+type HealthComponent struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
 type HealthCheckServiceImpl struct {
-	db storage.Storage
+	components []HealthComponent
+	timeout    time.Duration
+	draining   atomic.Bool
 }
 
 func NewHealthCheckService(i *do.Injector) (domain.HealthCheckerService, error) {
 	db := do.MustInvoke[storage.Storage](i)
-	return &HealthCheckServiceImpl{db: db}, nil
+	tokenProvider := do.MustInvoke[domain.TokenProvider](i)
+	scheduler := do.MustInvoke[domain.JobScheduler](i)
+
+	// There is no mail sender to check: the application sends no email
+	// yet. Whatever sends it should add a HealthComponent here.
+	return newHealthCheckService(config.Env.Health.CheckTimeout,
+		DatabaseComponent(db),
+		MigrationsComponent(db),
+		SigningKeyComponent(tokenProvider),
+		SchedulerComponent(scheduler),
+	), nil
+}
+
+func newHealthCheckService(timeout time.Duration, components ...HealthComponent) *HealthCheckServiceImpl {
+	return &HealthCheckServiceImpl{
+		components: components,
+		timeout:    timeout,
+	}
 }
 
-func (h *HealthCheckServiceImpl) Check() (domain.HealthCheck, []error) {
-	var errs []error
+// DatabaseComponent pings the database.
+func DatabaseComponent(db storage.Storage) HealthComponent {
+	return HealthComponent{Name: "database", Check: db.Ping}
+}
 
-	dbStatus := DatabaseHealthy
-	if h.db != nil {
-		if err := h.db.Ping(context.Background()); err != nil {
-			dbStatus = DatabaseError
-			errs = append(errs, err)
-		}
+// MigrationsComponent fails while the schema is behind the models.
+func MigrationsComponent(db storage.Storage) HealthComponent {
+	return HealthComponent{
+		Name: "migrations",
+		Check: func(ctx context.Context) error {
+			pending, err := db.PendingMigrations(ctx)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d pending migrations: %s", len(pending), strings.Join(pending, ", "))
+			}
+			return nil
+		},
 	}
+}
 
-	healthCheck := domain.HealthCheck{
-		Status:   WorkingStatus,
-		Database: dbStatus,
+// SigningKeyComponent signs and parses a probe token.
+func SigningKeyComponent(tokenProvider domain.TokenProvider) HealthComponent {
+	return HealthComponent{
+		Name: "signing_key",
+		Check: func(ctx context.Context) error {
+			token, err := tokenProvider.GenerateAccessToken(ctx, signingKeyProbeSubject, signingKeyProbeSubject)
+			if err != nil {
+				return err
+			}
+			_, err = tokenProvider.ParseAccessToken(ctx, token)
+			return err
+		},
 	}
+}
 
-	return healthCheck, errs
+// SchedulerComponent fails when the cleanup jobs are not running.
+func SchedulerComponent(scheduler domain.JobScheduler) HealthComponent {
+	return HealthComponent{
+		Name: "job_scheduler",
+		Check: func(_ context.Context) error {
+			if !scheduler.Running() {
+				return fmt.Errorf("job scheduler is not running")
+			}
+			return nil
+		},
+	}
+}
+
+// Live only reports that the process is serving requests; dependencies are
+// left to Ready so a database outage doesn't get the pod restarted.
+func (h *HealthCheckServiceImpl) Live(_ context.Context) domain.HealthCheck {
+	return domain.HealthCheck{Status: domain.HealthStatusUp}
+}
+
+// Ready runs every component concurrently, each under its own timeout.
+func (h *HealthCheckServiceImpl) Ready(ctx context.Context) domain.HealthCheck {
+	results := make([]domain.ComponentHealth, len(h.components))
+
+	var wg sync.WaitGroup
+	for idx, component := range h.components {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[idx] = h.run(ctx, component)
+		}()
+	}
+	wg.Wait()
+
+	status := domain.HealthStatusUp
+	for _, result := range results {
+		if result.Status != domain.HealthStatusUp {
+			status = domain.HealthStatusDown
+		}
+	}
+	if h.draining.Load() {
+		status = domain.HealthStatusDraining
+	}
+
+	return domain.HealthCheck{Status: status, Components: results}
+}
+
+// Drain makes Ready report not-ready from now on, so load balancers stop
+// routing new traffic while in-flight requests finish.
+func (h *HealthCheckServiceImpl) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *HealthCheckServiceImpl) run(ctx context.Context, component HealthComponent) domain.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := component.Check(ctx)
+
+	result := domain.ComponentHealth{
+		Name:      component.Name,
+		Status:    domain.HealthStatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = domain.HealthStatusDown
+		result.Error = componentFailed
+		if errors.Is(err, context.DeadlineExceeded) {
+			result.Error = componentTimedOut
+		}
+		logging.WithContext(ctx, zap.String("service", "HealthCheckService.Ready")).
+			Warn("readiness check failed", zap.String("component", component.Name), zap.Error(err))
+	}
+	return result
 }
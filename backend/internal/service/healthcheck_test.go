@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+type readyMocks struct {
+	storage       *mockpkg.MockStorage
+	tokenProvider *mockpkg.MockTokenProvider
+	scheduler     *mockpkg.MockJobScheduler
+}
+
+func newReadyService(t *testing.T) (*HealthCheckServiceImpl, readyMocks) {
+	t.Helper()
+	m := readyMocks{
+		storage:       mockpkg.NewMockStorage(t),
+		tokenProvider: mockpkg.NewMockTokenProvider(t),
+		scheduler:     mockpkg.NewMockJobScheduler(t),
+	}
+	svc := newHealthCheckService(time.Second,
+		DatabaseComponent(m.storage),
+		MigrationsComponent(m.storage),
+		SigningKeyComponent(m.tokenProvider),
+		SchedulerComponent(m.scheduler),
+	)
+	return svc, m
+}
+
+func (m readyMocks) allHealthy() {
+	m.storage.On("Ping", mock.Anything).Return(nil)
+	m.storage.On("PendingMigrations", mock.Anything).Return([]string(nil), nil)
+	m.tokenProvider.On("GenerateAccessToken", mock.Anything, signingKeyProbeSubject, signingKeyProbeSubject).Return("probe-token", nil)
+	m.tokenProvider.On("ParseAccessToken", mock.Anything, "probe-token").Return(&domain.AccessTokenClaims{SessionID: signingKeyProbeSubject}, nil)
+	m.scheduler.On("Running").Return(true)
+}
+
+func componentByName(check domain.HealthCheck, name string) domain.ComponentHealth {
+	for _, component := range check.Components {
+		if component.Name == name {
+			return component
+		}
+	}
+	return domain.ComponentHealth{}
+}
+
+func TestHealthCheckLive(t *testing.T) {
+	t.Run("should report up without touching dependencies", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _ := newReadyService(t)
+
+		check := svc.Live(context.Background())
+
+		assert.Equal(t, domain.HealthStatusUp, check.Status)
+		assert.Empty(t, check.Components)
+	})
+}
+
+func TestHealthCheckReady(t *testing.T) {
+	t.Run("should report up with every component when all checks pass", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newReadyService(t)
+		m.allHealthy()
+
+		check := svc.Ready(context.Background())
+
+		assert.Equal(t, domain.HealthStatusUp, check.Status)
+		assert.Len(t, check.Components, 4)
+		for _, component := range check.Components {
+			assert.Equal(t, domain.HealthStatusUp, component.Status, component.Name)
+			assert.Empty(t, component.Error)
+		}
+	})
+
+	t.Run("should report down and the failing component when database ping fails", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newReadyService(t)
+		m.storage.On("Ping", mock.Anything).Return(errors.New("database is locked"))
+		m.storage.On("PendingMigrations", mock.Anything).Return([]string(nil), nil)
+		m.tokenProvider.On("GenerateAccessToken", mock.Anything, signingKeyProbeSubject, signingKeyProbeSubject).Return("probe-token", nil)
+		m.tokenProvider.On("ParseAccessToken", mock.Anything, "probe-token").Return(&domain.AccessTokenClaims{}, nil)
+		m.scheduler.On("Running").Return(true)
+
+		check := svc.Ready(context.Background())
+
+		assert.Equal(t, domain.HealthStatusDown, check.Status)
+		database := componentByName(check, "database")
+		assert.Equal(t, domain.HealthStatusDown, database.Status)
+		assert.Equal(t, componentFailed, database.Error)
+		assert.Equal(t, domain.HealthStatusUp, componentByName(check, "signing_key").Status)
+	})
+
+	t.Run("should report pending migrations", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newReadyService(t)
+		m.storage.On("Ping", mock.Anything).Return(nil)
+		m.storage.On("PendingMigrations", mock.Anything).Return([]string{"create table user_role"}, nil)
+		m.tokenProvider.On("GenerateAccessToken", mock.Anything, signingKeyProbeSubject, signingKeyProbeSubject).Return("probe-token", nil)
+		m.tokenProvider.On("ParseAccessToken", mock.Anything, "probe-token").Return(&domain.AccessTokenClaims{}, nil)
+		m.scheduler.On("Running").Return(true)
+
+		check := svc.Ready(context.Background())
+
+		assert.Equal(t, domain.HealthStatusDown, check.Status)
+		assert.Equal(t, componentFailed, componentByName(check, "migrations").Error)
+	})
+
+	t.Run("should report down when signing key cannot sign", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newReadyService(t)
+		m.storage.On("Ping", mock.Anything).Return(nil)
+		m.storage.On("PendingMigrations", mock.Anything).Return([]string(nil), nil)
+		m.tokenProvider.On("GenerateAccessToken", mock.Anything, signingKeyProbeSubject, signingKeyProbeSubject).Return("", errors.New("failed to sign access token"))
+		m.scheduler.On("Running").Return(true)
+
+		check := svc.Ready(context.Background())
+
+		assert.Equal(t, domain.HealthStatusDown, check.Status)
+		assert.Equal(t, domain.HealthStatusDown, componentByName(check, "signing_key").Status)
+	})
+
+	t.Run("should report down when scheduler is stopped", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newReadyService(t)
+		m.storage.On("Ping", mock.Anything).Return(nil)
+		m.storage.On("PendingMigrations", mock.Anything).Return([]string(nil), nil)
+		m.tokenProvider.On("GenerateAccessToken", mock.Anything, signingKeyProbeSubject, signingKeyProbeSubject).Return("probe-token", nil)
+		m.tokenProvider.On("ParseAccessToken", mock.Anything, "probe-token").Return(&domain.AccessTokenClaims{}, nil)
+		m.scheduler.On("Running").Return(false)
+
+		check := svc.Ready(context.Background())
+
+		assert.Equal(t, domain.HealthStatusDown, check.Status)
+		assert.Equal(t, componentFailed, componentByName(check, "job_scheduler").Error)
+	})
+
+	t.Run("should time out a slow component", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newHealthCheckService(10*time.Millisecond, HealthComponent{
+			Name: "slow",
+			Check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		})
+
+		check := svc.Ready(context.Background())
+
+		assert.Equal(t, domain.HealthStatusDown, check.Status)
+		assert.Equal(t, componentTimedOut, componentByName(check, "slow").Error)
+	})
+
+	t.Run("should report draining after Drain even when components are up", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newReadyService(t)
+		m.allHealthy()
+
+		svc.Drain()
+		check := svc.Ready(context.Background())
+
+		assert.Equal(t, domain.HealthStatusDraining, check.Status)
+		assert.Equal(t, domain.HealthStatusUp, componentByName(check, "database").Status)
+	})
+}
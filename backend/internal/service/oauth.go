@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+type OAuthServiceImpl struct {
+	clientRepository         domain.OAuthClientRepository
+	authRepository           domain.AuthRepository
+	sessionRepository        domain.SessionRepository
+	serviceAccountRepository domain.ServiceAccountRepository
+	tokenProvider            domain.TokenProvider
+}
+
+func NewOAuthService(i *do.Injector) (domain.OAuthService, error) {
+	clientRepository := do.MustInvoke[domain.OAuthClientRepository](i)
+	authRepository := do.MustInvoke[domain.AuthRepository](i)
+	sessionRepository := do.MustInvoke[domain.SessionRepository](i)
+	serviceAccountRepository := do.MustInvoke[domain.ServiceAccountRepository](i)
+	tokenProvider := do.MustInvoke[domain.TokenProvider](i)
+	return &OAuthServiceImpl{
+		clientRepository:         clientRepository,
+		authRepository:           authRepository,
+		sessionRepository:        sessionRepository,
+		serviceAccountRepository: serviceAccountRepository,
+		tokenProvider:            tokenProvider,
+	}, nil
+}
+
+// CreateClient registers a new client and returns its secret, which is only
+// available at this point: the database keeps a hash of it. Public clients
+// get no secret.
+func (s *OAuthServiceImpl) CreateClient(ctx context.Context, req domain.NewOAuthClientRequest) (_ *domain.OAuthClient, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "OAuthService.CreateClient")
+	defer tracing.End(span, &err)
+
+	for _, redirectURI := range req.RedirectURIs {
+		if !validRedirectURI(redirectURI) {
+			return nil, "", fmt.Errorf("%w: %s", domain.ErrInvalidRedirectURI, redirectURI)
+		}
+	}
+	if req.Public && len(req.RedirectURIs) == 0 {
+		return nil, "", fmt.Errorf("%w: public clients need a redirect URI", domain.ErrInvalidRedirectURI)
+	}
+
+	client := &domain.OAuthClient{
+		ID:           uuid.New(),
+		Name:         req.Name,
+		RedirectURIs: req.RedirectURIs,
+	}
+
+	var secret string
+	if !req.Public {
+		raw := make([]byte, 32)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, "", fmt.Errorf("failed to generate client secret: %w", err)
+		}
+		secret = base64.RawURLEncoding.EncodeToString(raw)
+		client.SecretHash = hashSecret(secret)
+	}
+
+	if err := s.clientRepository.CreateClient(ctx, client); err != nil {
+		return nil, "", fmt.Errorf("failed to create client: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "OAuthService.CreateClient")).
+		Info("oauth client created", zap.String("client_id", client.ID.String()))
+
+	return client, secret, nil
+}
+
+func (s *OAuthServiceImpl) ListClients(ctx context.Context) (_ []domain.OAuthClient, err error) {
+	ctx, span := tracing.Start(ctx, "OAuthService.ListClients")
+	defer tracing.End(span, &err)
+
+	clients, err := s.clientRepository.ListClients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	return clients, nil
+}
+
+func (s *OAuthServiceImpl) DeleteClient(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "OAuthService.DeleteClient")
+	defer tracing.End(span, &err)
+
+	if err := s.clientRepository.DeleteClient(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete client: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "OAuthService.DeleteClient")).
+		Info("oauth client deleted", zap.String("client_id", id.String()))
+
+	return nil
+}
+
+// AuthenticateClient checks the credentials of a confidential client.
+// Unknown clients, public clients and wrong secrets are all reported as
+// ErrInvalidClient.
+func (s *OAuthServiceImpl) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (_ *domain.OAuthClient, err error) {
+	ctx, span := tracing.Start(ctx, "OAuthService.AuthenticateClient")
+	defer tracing.End(span, &err)
+
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return nil, domain.ErrInvalidClient
+	}
+
+	client, err := s.clientRepository.FindClientByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrClientNotFound) {
+			return nil, domain.ErrInvalidClient
+		}
+		return nil, fmt.Errorf("failed to find client: %w", err)
+	}
+
+	if client.Public() || !secretMatches(client, clientSecret) {
+		return nil, domain.ErrInvalidClient
+	}
+
+	return client, nil
+}
+
+// Introspect reports whether a token is active. A token is active when its
+// signature and expiry are valid and its session still exists, belongs to
+// the token's user and that user is not deactivated. Tokens of an OpenID
+// Connect client's session are only active for that client, with the scopes
+// it was granted; first-party sessions report the user's roles. Service
+// account tokens are active while the account exists.
+func (s *OAuthServiceImpl) Introspect(ctx context.Context, client *domain.OAuthClient, req domain.IntrospectRequest) (_ *domain.IntrospectionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "OAuthService.Introspect")
+	defer tracing.End(span, &err)
+
+	inactive := &domain.IntrospectionResponse{Active: false}
+
+	token, ok := s.parseToken(ctx, req.Token, req.TokenTypeHint)
+	if !ok {
+		return inactive, nil
+	}
+	if token.clientID != uuid.Nil {
+		return s.introspectServiceAccount(ctx, token)
+	}
+
+	session, err := s.sessionRepository.FindSessionByID(ctx, token.sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return inactive, nil
+		}
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if !token.ownedBy(session) || time.Now().After(session.ExpiresAt) {
+		return inactive, nil
+	}
+	if session.ClientID != nil && *session.ClientID != client.ID {
+		return inactive, nil
+	}
+
+	user, err := s.authRepository.FindUserByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return inactive, nil
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user.DeletedAt != nil {
+		return inactive, nil
+	}
+
+	resp := &domain.IntrospectionResponse{
+		Active:    true,
+		TokenType: token.tokenType,
+		Subject:   user.ID.String(),
+		SessionID: session.ID.String(),
+		ExpiresAt: token.expiresAt.Unix(),
+	}
+	if session.ClientID != nil {
+		resp.ClientID = session.ClientID.String()
+		resp.Scope = session.Scope
+	} else {
+		roles, err := s.authRepository.FindRolesByUserID(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find roles: %w", err)
+		}
+		resp.Scope = strings.Join(roles, " ")
+	}
+	if !token.issuedAt.IsZero() {
+		resp.IssuedAt = token.issuedAt.Unix()
+	}
+
+	return resp, nil
+}
+
+// Revoke deletes the session behind an access or refresh token issued to
+// client. As required by RFC 7009, invalid or already revoked tokens are not
+// an error, while tokens issued to another client, including first-party
+// sessions, are refused with ErrUnauthorizedClient. Service account tokens
+// have no session and are left to expire; deleting the account revokes them
+// all.
+func (s *OAuthServiceImpl) Revoke(ctx context.Context, client *domain.OAuthClient, req domain.RevokeRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "OAuthService.Revoke")
+	defer tracing.End(span, &err)
+
+	token, ok := s.parseToken(ctx, req.Token, req.TokenTypeHint)
+	if !ok || token.clientID != uuid.Nil {
+		return nil
+	}
+
+	session, err := s.sessionRepository.FindSessionByID(ctx, token.sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if !token.ownedBy(session) {
+		return nil
+	}
+	if session.ClientID == nil || *session.ClientID != client.ID {
+		return domain.ErrUnauthorizedClient
+	}
+
+	if _, err := s.sessionRepository.DeleteSession(ctx, session.ID); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	metrics.SessionsRevokedTotal.WithLabelValues("oauth_revoke").Inc()
+
+	logging.WithContext(ctx, zap.String("service", "OAuthService.Revoke")).
+		Info("session revoked",
+			zap.String("session_id", session.ID.String()),
+			zap.String("user_id", session.UserID.String()),
+			zap.String("client_id", client.ID.String()),
+		)
+
+	return nil
+}
+
+func (s *OAuthServiceImpl) introspectServiceAccount(ctx context.Context, token *parsedToken) (*domain.IntrospectionResponse, error) {
+	account, err := s.serviceAccountRepository.FindServiceAccountByID(ctx, token.clientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceAccountNotFound) {
+			return &domain.IntrospectionResponse{Active: false}, nil
+		}
+		return nil, fmt.Errorf("failed to find service account: %w", err)
+	}
+
+	resp := &domain.IntrospectionResponse{
+		Active:    true,
+		TokenType: token.tokenType,
+		Subject:   account.ID.String(),
+		ClientID:  account.ID.String(),
+		Scope:     strings.Join(token.scopes, " "),
+		ExpiresAt: token.expiresAt.Unix(),
+	}
+	if !token.issuedAt.IsZero() {
+		resp.IssuedAt = token.issuedAt.Unix()
+	}
+
+	return resp, nil
+}
+
+// parsedToken is a user token, with a session, or a service account token,
+// with clientID and scopes.
+type parsedToken struct {
+	tokenType string
+	userID    uuid.UUID
+	sessionID uuid.UUID
+	clientID  uuid.UUID
+	scopes    []string
+	issuedAt  time.Time
+	expiresAt time.Time
+}
+
+// parseToken tries the hinted token type first and falls back to the other
+// one, as RFC 7662 and RFC 7009 treat the hint as advisory.
+func (s *OAuthServiceImpl) parseToken(ctx context.Context, token, hint string) (*parsedToken, bool) {
+	parsers := []func(context.Context, string) (*parsedToken, bool){s.parseAccessToken, s.parseRefreshToken}
+	if hint == domain.TokenTypeRefresh {
+		parsers[0], parsers[1] = parsers[1], parsers[0]
+	}
+
+	for _, parse := range parsers {
+		if parsed, ok := parse(ctx, token); ok {
+			return parsed, true
+		}
+	}
+	return nil, false
+}
+
+func (s *OAuthServiceImpl) parseAccessToken(ctx context.Context, token string) (*parsedToken, bool) {
+	claims, err := s.tokenProvider.ParseAccessToken(ctx, token)
+	if err != nil || time.Now().After(claims.ExpiresAt) {
+		return nil, false
+	}
+	if claims.ClientID != "" {
+		clientID, err := uuid.Parse(claims.ClientID)
+		if err != nil {
+			return nil, false
+		}
+		return &parsedToken{
+			tokenType: domain.TokenTypeAccess,
+			clientID:  clientID,
+			scopes:    claims.Scopes,
+			issuedAt:  claims.IssuedAt,
+			expiresAt: claims.ExpiresAt,
+		}, true
+	}
+	return newParsedToken(domain.TokenTypeAccess, claims.UserID, claims.SessionID, claims.IssuedAt, claims.ExpiresAt)
+}
+
+func (s *OAuthServiceImpl) parseRefreshToken(ctx context.Context, token string) (*parsedToken, bool) {
+	claims, err := s.tokenProvider.ParseRefreshToken(ctx, token)
+	if err != nil {
+		return nil, false
+	}
+	return newParsedToken(domain.TokenTypeRefresh, claims.UserID, claims.SessionID, claims.IssuedAt, claims.ExpiresAt)
+}
+
+// ownedBy reports whether the session belongs to the token's user. Legacy
+// access tokens carry no user and are matched by session alone.
+func (t *parsedToken) ownedBy(session *domain.Session) bool {
+	return t.userID == uuid.Nil || t.userID == session.UserID
+}
+
+func newParsedToken(tokenType, userID, sessionID string, issuedAt, expiresAt time.Time) (*parsedToken, bool) {
+	var user uuid.UUID
+	if userID != "" {
+		var err error
+		if user, err = uuid.Parse(userID); err != nil {
+			return nil, false
+		}
+	}
+	session, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, false
+	}
+	return &parsedToken{
+		tokenType: tokenType,
+		userID:    user,
+		sessionID: session,
+		issuedAt:  issuedAt,
+		expiresAt: expiresAt,
+	}, true
+}
+
+func secretMatches(client *domain.OAuthClient, secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(client.SecretHash)) == 1
+}
+
+// validRedirectURI accepts absolute https URIs without a fragment, and plain
+// http only for loopback addresses used by native apps and development.
+func validRedirectURI(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.Fragment != "" {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		return host == "localhost" || net.ParseIP(host).IsLoopback()
+	default:
+		return false
+	}
+}
+
+// hashSecret hashes client secrets and authorization codes with a plain
+// SHA-256: both are 256 random bits, so a slow password hash would only add
+// latency to every call.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+func newOAuthService(t *testing.T) (*OAuthServiceImpl, *mockpkg.MockOAuthClientRepository, *mockpkg.MockAuthRepository, *mockpkg.MockSessionRepository, *mockpkg.MockTokenProvider) {
+	t.Helper()
+	clientRepo := mockpkg.NewMockOAuthClientRepository(t)
+	authRepo := mockpkg.NewMockAuthRepository(t)
+	sessionRepo := mockpkg.NewMockSessionRepository(t)
+	tokenProvider := mockpkg.NewMockTokenProvider(t)
+	svc := &OAuthServiceImpl{
+		clientRepository:  clientRepo,
+		authRepository:    authRepo,
+		sessionRepository: sessionRepo,
+		tokenProvider:     tokenProvider,
+	}
+	return svc, clientRepo, authRepo, sessionRepo, tokenProvider
+}
+
+func TestOAuthCreateClient(t *testing.T) {
+	t.Run("should store only the hash of the returned secret", func(t *testing.T) {
+		t.Parallel()
+
+		svc, clientRepo, _, _, _ := newOAuthService(t)
+		ctx := context.Background()
+
+		var stored *domain.OAuthClient
+		clientRepo.On("CreateClient", mock.Anything, mock.AnythingOfType("*domain.OAuthClient")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.OAuthClient) }).
+			Return(nil)
+
+		client, secret, err := svc.CreateClient(ctx, domain.NewOAuthClientRequest{Name: "gateway"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "gateway", client.Name)
+		assert.NotEmpty(t, secret)
+		assert.NotEqual(t, secret, stored.SecretHash)
+		assert.Equal(t, hashSecret(secret), stored.SecretHash)
+	})
+}
+
+func TestOAuthAuthenticateClient(t *testing.T) {
+	clientID := uuid.New()
+	client := &domain.OAuthClient{ID: clientID, Name: "gateway", SecretHash: hashSecret("secret")}
+
+	t.Run("should return the client for a matching secret", func(t *testing.T) {
+		t.Parallel()
+
+		svc, clientRepo, _, _, _ := newOAuthService(t)
+		clientRepo.On("FindClientByID", mock.Anything, clientID).Return(client, nil)
+
+		got, err := svc.AuthenticateClient(context.Background(), clientID.String(), "secret")
+
+		assert.NoError(t, err)
+		assert.Equal(t, client, got)
+	})
+
+	t.Run("should return ErrInvalidClient for a wrong secret", func(t *testing.T) {
+		t.Parallel()
+
+		svc, clientRepo, _, _, _ := newOAuthService(t)
+		clientRepo.On("FindClientByID", mock.Anything, clientID).Return(client, nil)
+
+		_, err := svc.AuthenticateClient(context.Background(), clientID.String(), "wrong")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+	})
+
+	t.Run("should return ErrInvalidClient for an unknown client", func(t *testing.T) {
+		t.Parallel()
+
+		svc, clientRepo, _, _, _ := newOAuthService(t)
+		clientRepo.On("FindClientByID", mock.Anything, clientID).Return(nil, domain.ErrClientNotFound)
+
+		_, err := svc.AuthenticateClient(context.Background(), clientID.String(), "secret")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+	})
+
+	t.Run("should return ErrInvalidClient for a malformed client ID", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, _, _ := newOAuthService(t)
+
+		_, err := svc.AuthenticateClient(context.Background(), "not-a-uuid", "secret")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+	})
+}
+
+// resourceServer is the confidential client calling introspection and
+// revocation in the tests.
+var resourceServer = &domain.OAuthClient{ID: uuid.New(), Name: "orders"}
+
+func TestOAuthIntrospect(t *testing.T) {
+	userID := uuid.New()
+	sessionID := uuid.New()
+	issuedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	expiresAt := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	accessClaims := &domain.AccessTokenClaims{UserID: userID.String(), SessionID: sessionID.String(), IssuedAt: issuedAt, ExpiresAt: expiresAt}
+	activeSession := &domain.Session{ID: sessionID, UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("should report an active access token with its roles as scope", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, authRepo, sessionRepo, tokenProvider := newOAuthService(t)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "at").Return(accessClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(activeSession, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil)
+		authRepo.On("FindRolesByUserID", mock.Anything, userID).Return([]string{"admin"}, nil)
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "at"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.IntrospectionResponse{
+			Active:    true,
+			TokenType: domain.TokenTypeAccess,
+			Subject:   userID.String(),
+			SessionID: sessionID.String(),
+			Scope:     "admin",
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		}, resp)
+	})
+
+	t.Run("should report the client and granted scopes of a client session", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, authRepo, sessionRepo, tokenProvider := newOAuthService(t)
+		clientSession := &domain.Session{ID: sessionID, UserID: userID, ClientID: &resourceServer.ID, Scope: "openid email", ExpiresAt: time.Now().Add(time.Hour)}
+		tokenProvider.On("ParseAccessToken", mock.Anything, "at").Return(accessClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(clientSession, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil)
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "at"})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Active)
+		assert.Equal(t, resourceServer.ID.String(), resp.ClientID)
+		assert.Equal(t, "openid email", resp.Scope)
+		authRepo.AssertNotCalled(t, "FindRolesByUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should report a token of another client's session as inactive", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, sessionRepo, tokenProvider := newOAuthService(t)
+		otherClient := uuid.New()
+		tokenProvider.On("ParseAccessToken", mock.Anything, "at").Return(accessClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).
+			Return(&domain.Session{ID: sessionID, UserID: userID, ClientID: &otherClient, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "at"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.IntrospectionResponse{Active: false}, resp)
+	})
+
+	t.Run("should report a service account token with its scopes", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, _, tokenProvider := newOAuthService(t)
+		serviceAccountRepo := mockpkg.NewMockServiceAccountRepository(t)
+		svc.serviceAccountRepository = serviceAccountRepo
+		accountID := uuid.New()
+		tokenProvider.On("ParseAccessToken", mock.Anything, "st").Return(&domain.AccessTokenClaims{
+			ClientID: accountID.String(), Scopes: []string{"orders:read"}, IssuedAt: issuedAt, ExpiresAt: expiresAt,
+		}, nil)
+		serviceAccountRepo.On("FindServiceAccountByID", mock.Anything, accountID).Return(&domain.ServiceAccount{ID: accountID}, nil)
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "st"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.IntrospectionResponse{
+			Active:    true,
+			TokenType: domain.TokenTypeAccess,
+			Subject:   accountID.String(),
+			ClientID:  accountID.String(),
+			Scope:     "orders:read",
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		}, resp)
+	})
+
+	t.Run("should report a token of a deleted service account as inactive", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, _, tokenProvider := newOAuthService(t)
+		serviceAccountRepo := mockpkg.NewMockServiceAccountRepository(t)
+		svc.serviceAccountRepository = serviceAccountRepo
+		accountID := uuid.New()
+		tokenProvider.On("ParseAccessToken", mock.Anything, "st").Return(&domain.AccessTokenClaims{
+			ClientID: accountID.String(), IssuedAt: issuedAt, ExpiresAt: expiresAt,
+		}, nil)
+		serviceAccountRepo.On("FindServiceAccountByID", mock.Anything, accountID).Return(nil, domain.ErrServiceAccountNotFound)
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "st"})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Active)
+	})
+
+	t.Run("should try the refresh token first when hinted", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, authRepo, sessionRepo, tokenProvider := newOAuthService(t)
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "rt").
+			Return(&domain.RefreshTokenClaims{UserID: userID.String(), SessionID: sessionID.String(), ExpiresAt: expiresAt}, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(activeSession, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil)
+		authRepo.On("FindRolesByUserID", mock.Anything, userID).Return(nil, nil)
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "rt", TokenTypeHint: domain.TokenTypeRefresh})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Active)
+		assert.Equal(t, domain.TokenTypeRefresh, resp.TokenType)
+		assert.Zero(t, resp.IssuedAt)
+		tokenProvider.AssertNotCalled(t, "ParseAccessToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should report an unparseable token as inactive", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, _, tokenProvider := newOAuthService(t)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "garbage").Return(nil, errors.New("invalid"))
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "garbage").Return(nil, errors.New("invalid"))
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "garbage"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.IntrospectionResponse{Active: false}, resp)
+	})
+
+	t.Run("should report an expired access token as inactive", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, _, tokenProvider := newOAuthService(t)
+		expired := *accessClaims
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "at").Return(&expired, nil)
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "at").Return(nil, errors.New("not a refresh token"))
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "at"})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Active)
+	})
+
+	t.Run("should report a token whose session was revoked as inactive", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, sessionRepo, tokenProvider := newOAuthService(t)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "at").Return(accessClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(nil, domain.ErrSessionNotFound)
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "at"})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Active)
+	})
+
+	t.Run("should report a token of another user's session as inactive", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, sessionRepo, tokenProvider := newOAuthService(t)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "at").Return(accessClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).
+			Return(&domain.Session{ID: sessionID, UserID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "at"})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Active)
+	})
+
+	t.Run("should report a token of a deactivated user as inactive", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, authRepo, sessionRepo, tokenProvider := newOAuthService(t)
+		deletedAt := time.Now()
+		tokenProvider.On("ParseAccessToken", mock.Anything, "at").Return(accessClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(activeSession, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(&domain.User{ID: userID, DeletedAt: &deletedAt}, nil)
+
+		resp, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "at"})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Active)
+	})
+
+	t.Run("should return an error when the session lookup fails", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, sessionRepo, tokenProvider := newOAuthService(t)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "at").Return(accessClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(nil, errors.New("db error"))
+
+		_, err := svc.Introspect(context.Background(), resourceServer, domain.IntrospectRequest{Token: "at"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestOAuthRevoke(t *testing.T) {
+	userID := uuid.New()
+	sessionID := uuid.New()
+	session := &domain.Session{ID: sessionID, UserID: userID, ClientID: &resourceServer.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	refreshClaims := &domain.RefreshTokenClaims{UserID: userID.String(), SessionID: sessionID.String(), ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("should delete the session of a refresh token issued to the client", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, sessionRepo, tokenProvider := newOAuthService(t)
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "rt").Return(refreshClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(session, nil)
+		sessionRepo.On("DeleteSession", mock.Anything, sessionID).Return(session, nil)
+
+		err := svc.Revoke(context.Background(), resourceServer, domain.RevokeRequest{Token: "rt", TokenTypeHint: domain.TokenTypeRefresh})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should refuse tokens of other clients and of first-party sessions", func(t *testing.T) {
+		t.Parallel()
+
+		otherClient := uuid.New()
+		for name, clientID := range map[string]*uuid.UUID{"other client": &otherClient, "first party": nil} {
+			svc, _, _, sessionRepo, tokenProvider := newOAuthService(t)
+			tokenProvider.On("ParseRefreshToken", mock.Anything, "rt").Return(refreshClaims, nil)
+			sessionRepo.On("FindSessionByID", mock.Anything, sessionID).
+				Return(&domain.Session{ID: sessionID, UserID: userID, ClientID: clientID, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+			err := svc.Revoke(context.Background(), resourceServer, domain.RevokeRequest{Token: "rt", TokenTypeHint: domain.TokenTypeRefresh})
+
+			assert.ErrorIs(t, err, domain.ErrUnauthorizedClient, name)
+			sessionRepo.AssertNotCalled(t, "DeleteSession", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("should succeed without deleting anything for an invalid token", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, sessionRepo, tokenProvider := newOAuthService(t)
+		tokenProvider.On("ParseAccessToken", mock.Anything, "garbage").Return(nil, errors.New("invalid"))
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "garbage").Return(nil, errors.New("invalid"))
+
+		err := svc.Revoke(context.Background(), resourceServer, domain.RevokeRequest{Token: "garbage"})
+
+		assert.NoError(t, err)
+		sessionRepo.AssertNotCalled(t, "DeleteSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should succeed for a session that is already gone", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _, _, sessionRepo, tokenProvider := newOAuthService(t)
+		tokenProvider.On("ParseRefreshToken", mock.Anything, "rt").Return(refreshClaims, nil)
+		sessionRepo.On("FindSessionByID", mock.Anything, sessionID).Return(nil, domain.ErrSessionNotFound)
+
+		err := svc.Revoke(context.Background(), resourceServer, domain.RevokeRequest{Token: "rt", TokenTypeHint: domain.TokenTypeRefresh})
+
+		assert.NoError(t, err)
+	})
+}
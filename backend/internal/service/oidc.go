@@ -0,0 +1,594 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+type OIDCServiceImpl struct {
+	// issuer is TOKEN_ISSUER, the iss of every token and the base of the
+	// endpoints in the discovery document.
+	issuer                  string
+	clientRepository        domain.OAuthClientRepository
+	authorizationRepository domain.AuthorizationRepository
+	authRepository          domain.AuthRepository
+	sessionRepository       domain.SessionRepository
+	tokenProvider           domain.TokenProvider
+}
+
+func NewOIDCService(i *do.Injector) (domain.OIDCService, error) {
+	issuer, err := oidcIssuer(config.Env.Token.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	clientRepository := do.MustInvoke[domain.OAuthClientRepository](i)
+	authorizationRepository := do.MustInvoke[domain.AuthorizationRepository](i)
+	authRepository := do.MustInvoke[domain.AuthRepository](i)
+	sessionRepository := do.MustInvoke[domain.SessionRepository](i)
+	tokenProvider := do.MustInvoke[domain.TokenProvider](i)
+	return &OIDCServiceImpl{
+		issuer:                  issuer,
+		clientRepository:        clientRepository,
+		authorizationRepository: authorizationRepository,
+		authRepository:          authRepository,
+		sessionRepository:       sessionRepository,
+		tokenProvider:           tokenProvider,
+	}, nil
+}
+
+// Discovery describes the provider. The issuer and the endpoints come from
+// TOKEN_ISSUER alone, never from the request, so they match the iss of the
+// tokens and can't be steered by a Host header.
+func (s *OIDCServiceImpl) Discovery() domain.DiscoveryDocument {
+	return domain.DiscoveryDocument{
+		Issuer:                            s.issuer,
+		AuthorizationEndpoint:             s.endpoint("/authorize"),
+		TokenEndpoint:                     s.endpoint("/token"),
+		UserInfoEndpoint:                  s.endpoint("/userinfo"),
+		JWKSURI:                           s.endpoint("/.well-known/jwks.json"),
+		IntrospectionEndpoint:             s.endpoint("/oauth/introspect"),
+		RevocationEndpoint:                s.endpoint("/oauth/revoke"),
+		DeviceAuthorizationEndpoint:       s.endpoint("/oauth/device/code"),
+		ScopesSupported:                   domain.SupportedScopes,
+		ResponseTypesSupported:            []string{domain.ResponseTypeCode},
+		GrantTypesSupported:               []string{domain.GrantTypeAuthorizationCode, domain.GrantTypeRefreshToken, domain.GrantTypeDeviceCode},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{domain.CodeChallengeMethodS256},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "sid", "name", "picture", "email"},
+	}
+}
+
+// Authorize validates an authorization request for the signed in user. It
+// returns a code right away when the user already granted the requested
+// scopes to the client, and a consent prompt otherwise.
+//
+// ErrInvalidClient and ErrInvalidRedirectURI mean the redirect URI cannot
+// be trusted; every other domain error is meant to be sent back to it.
+func (s *OIDCServiceImpl) Authorize(ctx context.Context, userID string, req domain.AuthorizeRequest) (_ *domain.Authorization, err error) {
+	ctx, span := tracing.Start(ctx, "OIDCService.Authorize")
+	defer tracing.End(span, &err)
+
+	client, scopes, err := s.validateAuthorizeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	grant, err := s.authorizationRepository.FindGrant(ctx, user, client.ID)
+	if err != nil && !errors.Is(err, domain.ErrGrantNotFound) {
+		return nil, fmt.Errorf("failed to find grant: %w", err)
+	}
+	if grant == nil || !containsAll(strings.Fields(grant.Scope), scopes) {
+		return &domain.Authorization{Consent: &domain.ConsentPrompt{ClientName: client.Name, Scopes: scopes}}, nil
+	}
+
+	code, err := s.issueCode(ctx, client, user, req, scopes)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Authorization{Code: code}, nil
+}
+
+// Consent records the user's answer to the consent prompt. Approval is
+// remembered for the client, so later requests for the same scopes skip
+// the prompt.
+func (s *OIDCServiceImpl) Consent(ctx context.Context, userID string, req domain.ConsentRequest) (_ *domain.Authorization, err error) {
+	ctx, span := tracing.Start(ctx, "OIDCService.Consent")
+	defer tracing.End(span, &err)
+
+	client, scopes, err := s.validateAuthorizeRequest(ctx, req.AuthorizeRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Consent != domain.ConsentApprove {
+		return nil, domain.ErrAccessDenied
+	}
+
+	user, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	granted := scopes
+	grant, err := s.authorizationRepository.FindGrant(ctx, user, client.ID)
+	if err != nil && !errors.Is(err, domain.ErrGrantNotFound) {
+		return nil, fmt.Errorf("failed to find grant: %w", err)
+	}
+	if grant != nil {
+		granted = normalizeScopes(append(strings.Fields(grant.Scope), scopes...))
+	}
+
+	if err := s.authorizationRepository.SaveGrant(ctx, &domain.OAuthGrant{
+		UserID:   user,
+		ClientID: client.ID,
+		Scope:    strings.Join(granted, " "),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save grant: %w", err)
+	}
+
+	logging.WithContext(ctx, zap.String("service", "OIDCService.Consent")).
+		Info("consent granted", zap.String("user_id", userID), zap.String("client_id", client.ID.String()))
+
+	code, err := s.issueCode(ctx, client, user, req.AuthorizeRequest, scopes)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Authorization{Code: code}, nil
+}
+
+// Token authenticates the client and redeems an authorization code, a
+// refresh token or an approved device code it was issued.
+func (s *OIDCServiceImpl) Token(ctx context.Context, req domain.TokenRequest) (_ *domain.TokenResponse, err error) {
+	ctx, span := tracing.Start(ctx, "OIDCService.Token")
+	defer tracing.End(span, &err)
+
+	client, err := s.authenticateTokenClient(ctx, req.ClientCredentials)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *domain.TokenResponse
+	switch req.GrantType {
+	case domain.GrantTypeAuthorizationCode:
+		response, err = s.exchangeCode(ctx, client, req)
+	case domain.GrantTypeRefreshToken:
+		response, err = s.refresh(ctx, client, req)
+	case domain.GrantTypeDeviceCode:
+		response, err = s.exchangeDeviceCode(ctx, client, req)
+	default:
+		return nil, domain.ErrUnsupportedGrantType
+	}
+	if err != nil {
+		return nil, err
+	}
+	metrics.OAuthTokensIssuedTotal.WithLabelValues(req.GrantType).Inc()
+
+	return response, nil
+}
+
+// UserInfo returns the claims the session's scopes allow. Sessions of the
+// first-party login carry no scope and see every claim.
+func (s *OIDCServiceImpl) UserInfo(ctx context.Context, userID, sessionID string) (_ *domain.UserInfo, err error) {
+	ctx, span := tracing.Start(ctx, "OIDCService.UserInfo")
+	defer tracing.End(span, &err)
+
+	sid, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, domain.ErrUnauthorized
+	}
+	session, err := s.sessionRepository.FindSessionByID(ctx, sid)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	user, err := s.authRepository.FindUserByID(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user.ID.String() != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	scopes := domain.SupportedScopes
+	if session.ClientID != nil {
+		scopes = strings.Fields(session.Scope)
+	}
+
+	info := &domain.UserInfo{Subject: user.ID.String()}
+	if slices.Contains(scopes, domain.ScopeProfile) {
+		info.Name = user.Name
+		info.Picture = user.Avatar
+	}
+	if slices.Contains(scopes, domain.ScopeEmail) {
+		info.Email = user.Email
+	}
+	return info, nil
+}
+
+// validateAuthorizeRequest checks the client and redirect URI first, so the
+// caller knows whether later errors may be reported through the redirect.
+func (s *OIDCServiceImpl) validateAuthorizeRequest(ctx context.Context, req domain.AuthorizeRequest) (*domain.OAuthClient, []string, error) {
+	client, err := s.findClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.RedirectURI == "" || !slices.Contains(client.RedirectURIs, req.RedirectURI) {
+		return nil, nil, domain.ErrInvalidRedirectURI
+	}
+
+	if req.ResponseType != domain.ResponseTypeCode {
+		return nil, nil, domain.ErrUnsupportedResponseType
+	}
+
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != domain.CodeChallengeMethodS256 {
+		return nil, nil, fmt.Errorf("%w: PKCE with code_challenge_method=S256 is required", domain.ErrInvalidAuthorizeRequest)
+	}
+
+	scopes := normalizeScopes(strings.Fields(req.Scope))
+	if !slices.Contains(scopes, domain.ScopeOpenID) || !containsAll(domain.SupportedScopes, scopes) {
+		return nil, nil, domain.ErrInvalidScope
+	}
+
+	return client, scopes, nil
+}
+
+func (s *OIDCServiceImpl) findClient(ctx context.Context, clientID string) (*domain.OAuthClient, error) {
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return nil, domain.ErrInvalidClient
+	}
+
+	client, err := s.clientRepository.FindClientByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrClientNotFound) {
+			return nil, domain.ErrInvalidClient
+		}
+		return nil, fmt.Errorf("failed to find client: %w", err)
+	}
+
+	return client, nil
+}
+
+// authenticateTokenClient accepts public clients by client_id alone, since
+// PKCE binds the code to the app that requested it, and requires the secret
+// of confidential ones.
+func (s *OIDCServiceImpl) authenticateTokenClient(ctx context.Context, creds domain.ClientCredentials) (*domain.OAuthClient, error) {
+	client, err := s.findClient(ctx, creds.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.Public() {
+		if creds.ClientSecret != "" {
+			return nil, domain.ErrInvalidClient
+		}
+		return client, nil
+	}
+
+	if !secretMatches(client, creds.ClientSecret) {
+		return nil, domain.ErrInvalidClient
+	}
+	return client, nil
+}
+
+func (s *OIDCServiceImpl) issueCode(ctx context.Context, client *domain.OAuthClient, userID uuid.UUID, req domain.AuthorizeRequest, scopes []string) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate authorization code: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now()
+	if err := s.authorizationRepository.CreateAuthorizationCode(ctx, &domain.AuthorizationCode{
+		ID:            hashSecret(code),
+		ClientID:      client.ID,
+		UserID:        userID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         strings.Join(scopes, " "),
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      now,
+		ExpiresAt:     now.Add(config.Env.OIDC.CodeTTL),
+	}); err != nil {
+		return "", fmt.Errorf("failed to create authorization code: %w", err)
+	}
+
+	return code, nil
+}
+
+func (s *OIDCServiceImpl) exchangeCode(ctx context.Context, client *domain.OAuthClient, req domain.TokenRequest) (*domain.TokenResponse, error) {
+	if req.Code == "" {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	code, err := s.authorizationRepository.ConsumeAuthorizationCode(ctx, hashSecret(req.Code))
+	if err != nil {
+		if errors.Is(err, domain.ErrAuthorizationCodeNotFound) {
+			return nil, domain.ErrInvalidGrant
+		}
+		return nil, fmt.Errorf("failed to consume authorization code: %w", err)
+	}
+
+	if code.ClientID != client.ID ||
+		code.RedirectURI != req.RedirectURI ||
+		!time.Now().Before(code.ExpiresAt) ||
+		!verifyCodeChallenge(req.CodeVerifier, code.CodeChallenge) {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	user, err := s.activeUser(ctx, code.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &domain.Session{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		ClientID:  &client.ID,
+		Scope:     code.Scope,
+		ExpiresAt: time.Now().Add(time.Duration(config.Env.Token.RefreshTokenExpiry) * time.Minute),
+	}
+	response, err := s.createSession(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.addIDToken(ctx, response, user, session, client, code.Nonce, code.AuthTime); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *OIDCServiceImpl) refresh(ctx context.Context, client *domain.OAuthClient, req domain.TokenRequest) (*domain.TokenResponse, error) {
+	claims, err := s.tokenProvider.ParseRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	session, err := s.sessionRepository.FindSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, domain.ErrInvalidGrant
+		}
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	if session.ClientID == nil || *session.ClientID != client.ID || session.UserID.String() != claims.UserID {
+		return nil, domain.ErrInvalidGrant
+	}
+
+	// Each refresh token is redeemed once. Presenting one that was already
+	// rotated means it leaked or the client is replaying it, so the session
+	// ends for whoever holds its current token too. Sessions created before
+	// rotation have no hash yet and start rotating on their next refresh.
+	presented := hashSecret(req.RefreshToken)
+	if session.RefreshTokenHash != "" && subtle.ConstantTimeCompare([]byte(session.RefreshTokenHash), []byte(presented)) != 1 {
+		return nil, s.revokeReplayedSession(ctx, session)
+	}
+
+	user, err := s.activeUser(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.tokens(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(time.Duration(config.Env.Token.RefreshTokenExpiry) * time.Minute)
+	if err := s.sessionRepository.RotateRefreshToken(ctx, session.ID, session.RefreshTokenHash, hashSecret(response.RefreshToken), expiresAt); err != nil {
+		// A concurrent redemption of the same token rotated it first.
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, s.revokeReplayedSession(ctx, session)
+		}
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return response, nil
+}
+
+// createSession stores a new session of an OpenID Connect client together
+// with the hash of the refresh token it is issued, and returns its tokens.
+func (s *OIDCServiceImpl) createSession(ctx context.Context, user *domain.User, session *domain.Session) (*domain.TokenResponse, error) {
+	response, err := s.tokens(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	session.RefreshTokenHash = hashSecret(response.RefreshToken)
+	if err := s.sessionRepository.CreateSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return response, nil
+}
+
+// revokeReplayedSession ends a session whose refresh token was presented
+// after it had been rotated, and returns the error the client gets.
+func (s *OIDCServiceImpl) revokeReplayedSession(ctx context.Context, session *domain.Session) error {
+	if _, err := s.sessionRepository.DeleteSession(ctx, session.ID); err != nil && !errors.Is(err, domain.ErrSessionNotFound) {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	metrics.SessionsRevokedTotal.WithLabelValues("refresh_replay").Inc()
+
+	logging.WithContext(ctx, zap.String("service", "OIDCService.refresh")).
+		Warn("refresh token replayed, session revoked",
+			zap.String("user_id", session.UserID.String()),
+			zap.String("session_id", session.ID.String()))
+
+	return domain.ErrInvalidGrant
+}
+
+func (s *OIDCServiceImpl) activeUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.authRepository.FindUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidGrant
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user.DeletedAt != nil {
+		return nil, domain.ErrInvalidGrant
+	}
+	return user, nil
+}
+
+// tokens issues the tokens of a session of an OpenID Connect client. The
+// access token is bound to the client and its scopes, so it only serves
+// /userinfo here and is refused by the first-party routes.
+func (s *OIDCServiceImpl) tokens(ctx context.Context, user *domain.User, session *domain.Session) (*domain.TokenResponse, error) {
+	if session.ClientID == nil {
+		return nil, fmt.Errorf("session %s has no client", session.ID)
+	}
+	accessToken, err := s.tokenProvider.GenerateClientAccessToken(ctx, user.ID.String(), session.ID.String(), session.ClientID.String(), session.Scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := s.tokenProvider.GenerateRefreshToken(ctx, user.ID.String(), session.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &domain.TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    config.Env.Token.AccessTokenExpiry * 60,
+		RefreshToken: refreshToken,
+		Scope:        session.Scope,
+	}, nil
+}
+
+// addIDToken adds an ID token to response when the session was granted the
+// openid scope.
+func (s *OIDCServiceImpl) addIDToken(ctx context.Context, response *domain.TokenResponse, user *domain.User, session *domain.Session, client *domain.OAuthClient, nonce string, authTime time.Time) error {
+	if !slices.Contains(strings.Fields(session.Scope), domain.ScopeOpenID) {
+		return nil
+	}
+
+	idToken, err := s.tokenProvider.GenerateIDToken(ctx, idTokenClaims(user, session, client, nonce, authTime))
+	if err != nil {
+		return fmt.Errorf("failed to generate id token: %w", err)
+	}
+	response.IDToken = idToken
+	return nil
+}
+
+func idTokenClaims(user *domain.User, session *domain.Session, client *domain.OAuthClient, nonce string, authTime time.Time) domain.IDTokenClaims {
+	scopes := strings.Fields(session.Scope)
+	claims := domain.IDTokenClaims{
+		Subject:   user.ID.String(),
+		Audience:  client.ID.String(),
+		SessionID: session.ID.String(),
+		Nonce:     nonce,
+		AuthTime:  authTime,
+	}
+	if slices.Contains(scopes, domain.ScopeProfile) {
+		claims.Name = user.Name
+		claims.Picture = user.Avatar
+	}
+	if slices.Contains(scopes, domain.ScopeEmail) {
+		claims.Email = user.Email
+	}
+	return claims
+}
+
+// endpoint is the URL of path under the issuer.
+func (s *OIDCServiceImpl) endpoint(path string) string {
+	return strings.TrimSuffix(s.issuer, "/") + path
+}
+
+// oidcIssuer checks that TOKEN_ISSUER can be the issuer of an OpenID
+// provider: an https URL without query or fragment. Plain http is only
+// accepted on a loopback host, for local development.
+func oidcIssuer(issuer string) (string, error) {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Host == "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", fmt.Errorf("invalid TOKEN_ISSUER %q: the OpenID provider needs an absolute https URL", issuer)
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if host := u.Hostname(); host != "localhost" && !net.ParseIP(host).IsLoopback() {
+			return "", fmt.Errorf("invalid TOKEN_ISSUER %q: http is only accepted on a loopback host", issuer)
+		}
+	default:
+		return "", fmt.Errorf("invalid TOKEN_ISSUER %q: the OpenID provider needs an absolute https URL", issuer)
+	}
+	return issuer, nil
+}
+
+// verifyCodeChallenge checks a PKCE verifier against an S256 challenge
+// (RFC 7636 section 4.6).
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// normalizeScopes sorts scopes in SupportedScopes order and drops
+// duplicates; unknown scopes are kept at the end so validation sees them.
+func normalizeScopes(scopes []string) []string {
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range domain.SupportedScopes {
+		if slices.Contains(scopes, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized
+}
+
+func containsAll(set, values []string) bool {
+	for _, value := range values {
+		if !slices.Contains(set, value) {
+			return false
+		}
+	}
+	return true
+}
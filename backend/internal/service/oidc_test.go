@@ -0,0 +1,481 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+const (
+	testCodeVerifier  = "dBjftJeZ4CVP-mJ92K1zhqBxZomB3UEuyoEP0iD1IL0"
+	testCodeChallenge = "tOjSOuMkpKiuAygo1dzbdjpgvEZWqbRVdCoIgNaZQJ4"
+	testRedirectURI   = "https://app.example.com/callback"
+)
+
+type oidcMocks struct {
+	clients        *mockpkg.MockOAuthClientRepository
+	authorizations *mockpkg.MockAuthorizationRepository
+	users          *mockpkg.MockAuthRepository
+	sessions       *mockpkg.MockSessionRepository
+	tokens         *mockpkg.MockTokenProvider
+}
+
+func newOIDCService(t *testing.T) (*OIDCServiceImpl, oidcMocks) {
+	t.Helper()
+	m := oidcMocks{
+		clients:        mockpkg.NewMockOAuthClientRepository(t),
+		authorizations: mockpkg.NewMockAuthorizationRepository(t),
+		users:          mockpkg.NewMockAuthRepository(t),
+		sessions:       mockpkg.NewMockSessionRepository(t),
+		tokens:         mockpkg.NewMockTokenProvider(t),
+	}
+	svc := &OIDCServiceImpl{
+		issuer:                  "https://auth.example.com",
+		clientRepository:        m.clients,
+		authorizationRepository: m.authorizations,
+		authRepository:          m.users,
+		sessionRepository:       m.sessions,
+		tokenProvider:           m.tokens,
+	}
+	return svc, m
+}
+
+func newOIDCClient(secret string) *domain.OAuthClient {
+	client := &domain.OAuthClient{ID: uuid.New(), Name: "webapp", RedirectURIs: []string{testRedirectURI}}
+	if secret != "" {
+		client.SecretHash = hashSecret(secret)
+	}
+	return client
+}
+
+func authorizeRequest(client *domain.OAuthClient) domain.AuthorizeRequest {
+	return domain.AuthorizeRequest{
+		ResponseType:        domain.ResponseTypeCode,
+		ClientID:            client.ID.String(),
+		RedirectURI:         testRedirectURI,
+		Scope:               "email openid",
+		State:               "xyz",
+		Nonce:               "n-0S6",
+		CodeChallenge:       testCodeChallenge,
+		CodeChallengeMethod: domain.CodeChallengeMethodS256,
+	}
+}
+
+func TestOIDCDiscovery(t *testing.T) {
+	t.Run("should derive every endpoint from the issuer", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _ := newOIDCService(t)
+
+		doc := svc.Discovery()
+
+		assert.Equal(t, "https://auth.example.com", doc.Issuer)
+		assert.Equal(t, "https://auth.example.com/authorize", doc.AuthorizationEndpoint)
+		assert.Equal(t, "https://auth.example.com/token", doc.TokenEndpoint)
+		assert.Equal(t, "https://auth.example.com/.well-known/jwks.json", doc.JWKSURI)
+	})
+
+	t.Run("should only accept https issuers, or http on a loopback host", func(t *testing.T) {
+		t.Parallel()
+
+		for issuer, valid := range map[string]bool{
+			"https://auth.example.com":      true,
+			"https://example.com/auth":      true,
+			"http://localhost:8080":         true,
+			"http://127.0.0.1:8080":         true,
+			"migos":                         false,
+			"/auth":                         false,
+			"http://auth.example.com":       false,
+			"https://auth.example.com?a=b":  false,
+			"https://auth.example.com#frag": false,
+			"ftp://auth.example.com":        false,
+		} {
+			_, err := oidcIssuer(issuer)
+			assert.Equal(t, valid, err == nil, issuer)
+		}
+	})
+}
+
+func TestOIDCAuthorize(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("should reject redirect URIs that are not registered", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+
+		req := authorizeRequest(client)
+		req.RedirectURI = "https://evil.example.com/callback"
+		_, err := svc.Authorize(context.Background(), userID.String(), req)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidRedirectURI)
+	})
+
+	t.Run("should require an S256 code challenge", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+
+		req := authorizeRequest(client)
+		req.CodeChallengeMethod = "plain"
+		_, err := svc.Authorize(context.Background(), userID.String(), req)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAuthorizeRequest)
+	})
+
+	t.Run("should require the openid scope", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+
+		req := authorizeRequest(client)
+		req.Scope = "email"
+		_, err := svc.Authorize(context.Background(), userID.String(), req)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidScope)
+	})
+
+	t.Run("should ask for consent when the scopes were not granted", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindGrant", mock.Anything, userID, client.ID).
+			Return(&domain.OAuthGrant{Scope: "openid"}, nil)
+
+		authorization, err := svc.Authorize(context.Background(), userID.String(), authorizeRequest(client))
+
+		require.NoError(t, err)
+		assert.Empty(t, authorization.Code)
+		assert.Equal(t, &domain.ConsentPrompt{ClientName: "webapp", Scopes: []string{"openid", "email"}}, authorization.Consent)
+	})
+
+	t.Run("should issue a code bound to the request when the scopes were granted", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindGrant", mock.Anything, userID, client.ID).
+			Return(&domain.OAuthGrant{Scope: "openid profile email"}, nil)
+
+		var stored *domain.AuthorizationCode
+		m.authorizations.On("CreateAuthorizationCode", mock.Anything, mock.AnythingOfType("*domain.AuthorizationCode")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.AuthorizationCode) }).
+			Return(nil)
+
+		authorization, err := svc.Authorize(context.Background(), userID.String(), authorizeRequest(client))
+
+		require.NoError(t, err)
+		assert.Nil(t, authorization.Consent)
+		assert.Equal(t, hashSecret(authorization.Code), stored.ID)
+		assert.Equal(t, client.ID, stored.ClientID)
+		assert.Equal(t, userID, stored.UserID)
+		assert.Equal(t, "openid email", stored.Scope)
+		assert.Equal(t, "n-0S6", stored.Nonce)
+		assert.Equal(t, testCodeChallenge, stored.CodeChallenge)
+	})
+}
+
+func TestOIDCConsent(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("should return ErrAccessDenied when the user denies", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+
+		_, err := svc.Consent(context.Background(), userID.String(), domain.ConsentRequest{
+			AuthorizeRequest: authorizeRequest(client),
+			Consent:          domain.ConsentDeny,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrAccessDenied)
+	})
+
+	t.Run("should add the approved scopes to the existing grant", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("FindGrant", mock.Anything, userID, client.ID).
+			Return(&domain.OAuthGrant{Scope: "openid profile"}, nil)
+		m.authorizations.On("SaveGrant", mock.Anything, &domain.OAuthGrant{
+			UserID: userID, ClientID: client.ID, Scope: "openid profile email",
+		}).Return(nil)
+		m.authorizations.On("CreateAuthorizationCode", mock.Anything, mock.AnythingOfType("*domain.AuthorizationCode")).Return(nil)
+
+		authorization, err := svc.Consent(context.Background(), userID.String(), domain.ConsentRequest{
+			AuthorizeRequest: authorizeRequest(client),
+			Consent:          domain.ConsentApprove,
+		})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, authorization.Code)
+	})
+}
+
+func TestOIDCToken(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Name: "Ada", Email: "ada@example.com"}
+
+	authorizationCode := func(client *domain.OAuthClient) *domain.AuthorizationCode {
+		return &domain.AuthorizationCode{
+			ClientID:      client.ID,
+			UserID:        user.ID,
+			RedirectURI:   testRedirectURI,
+			Scope:         "openid email",
+			Nonce:         "n-0S6",
+			CodeChallenge: testCodeChallenge,
+			AuthTime:      time.Now(),
+			ExpiresAt:     time.Now().Add(time.Minute),
+		}
+	}
+	codeRequest := func(client *domain.OAuthClient) domain.TokenRequest {
+		return domain.TokenRequest{
+			GrantType:         domain.GrantTypeAuthorizationCode,
+			Code:              "code",
+			RedirectURI:       testRedirectURI,
+			CodeVerifier:      testCodeVerifier,
+			ClientCredentials: domain.ClientCredentials{ClientID: client.ID.String()},
+		}
+	}
+
+	t.Run("should exchange a code for tokens and an ID token", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("ConsumeAuthorizationCode", mock.Anything, hashSecret("code")).Return(authorizationCode(client), nil)
+		m.users.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		var session *domain.Session
+		m.sessions.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).
+			Run(func(args mock.Arguments) { session = args.Get(1).(*domain.Session) }).
+			Return(nil)
+		m.tokens.On("GenerateClientAccessToken", mock.Anything, user.ID.String(), mock.Anything, client.ID.String(), "openid email").Return("access", nil)
+		m.tokens.On("GenerateRefreshToken", mock.Anything, user.ID.String(), mock.Anything).Return("refresh", nil)
+		m.tokens.On("GenerateIDToken", mock.Anything, mock.MatchedBy(func(claims domain.IDTokenClaims) bool {
+			return claims.Subject == user.ID.String() &&
+				claims.Audience == client.ID.String() &&
+				claims.Nonce == "n-0S6" &&
+				claims.Email == user.Email &&
+				claims.Name == ""
+		})).Return("id", nil)
+
+		response, err := svc.Token(context.Background(), codeRequest(client))
+
+		require.NoError(t, err)
+		assert.Equal(t, "access", response.AccessToken)
+		assert.Equal(t, "refresh", response.RefreshToken)
+		assert.Equal(t, "id", response.IDToken)
+		assert.Equal(t, "Bearer", response.TokenType)
+		assert.Equal(t, "openid email", response.Scope)
+		require.NotNil(t, session.ClientID)
+		assert.Equal(t, client.ID, *session.ClientID)
+		assert.Equal(t, hashSecret("refresh"), session.RefreshTokenHash)
+	})
+
+	t.Run("should reject a code verifier that does not match the challenge", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("ConsumeAuthorizationCode", mock.Anything, hashSecret("code")).Return(authorizationCode(client), nil)
+
+		req := codeRequest(client)
+		req.CodeVerifier = "Xd0TKXtF7ZBnYHzEnThNJSPMhzXZmiCkrOHvWWqWB0Ek"
+		_, err := svc.Token(context.Background(), req)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+	})
+
+	t.Run("should reject a code issued to another client", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("ConsumeAuthorizationCode", mock.Anything, hashSecret("code")).
+			Return(authorizationCode(newOIDCClient("")), nil)
+
+		_, err := svc.Token(context.Background(), codeRequest(client))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+	})
+
+	t.Run("should reject a code that was already redeemed", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.authorizations.On("ConsumeAuthorizationCode", mock.Anything, hashSecret("code")).
+			Return(nil, domain.ErrAuthorizationCodeNotFound)
+
+		_, err := svc.Token(context.Background(), codeRequest(client))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+	})
+
+	t.Run("should require the secret of confidential clients", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("secret")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+
+		_, err := svc.Token(context.Background(), codeRequest(client))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+	})
+
+	t.Run("should return ErrUnsupportedGrantType for unknown grants", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+
+		req := codeRequest(client)
+		req.GrantType = "password"
+		_, err := svc.Token(context.Background(), req)
+
+		assert.ErrorIs(t, err, domain.ErrUnsupportedGrantType)
+	})
+
+	t.Run("should not refresh sessions of another client", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		otherClientID := uuid.New()
+		sessionID := uuid.New()
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.tokens.On("ParseRefreshToken", mock.Anything, "refresh").
+			Return(&domain.RefreshTokenClaims{UserID: user.ID.String(), SessionID: sessionID.String()}, nil)
+		m.sessions.On("FindSessionByID", mock.Anything, sessionID).
+			Return(&domain.Session{ID: sessionID, UserID: user.ID, ClientID: &otherClientID}, nil)
+
+		_, err := svc.Token(context.Background(), domain.TokenRequest{
+			GrantType:         domain.GrantTypeRefreshToken,
+			RefreshToken:      "refresh",
+			ClientCredentials: domain.ClientCredentials{ClientID: client.ID.String()},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+	})
+
+	refreshRequest := func(client *domain.OAuthClient, token string) domain.TokenRequest {
+		return domain.TokenRequest{
+			GrantType:         domain.GrantTypeRefreshToken,
+			RefreshToken:      token,
+			ClientCredentials: domain.ClientCredentials{ClientID: client.ID.String()},
+		}
+	}
+
+	t.Run("should rotate the refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		sessionID := uuid.New()
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.tokens.On("ParseRefreshToken", mock.Anything, "refresh").
+			Return(&domain.RefreshTokenClaims{UserID: user.ID.String(), SessionID: sessionID.String()}, nil)
+		m.sessions.On("FindSessionByID", mock.Anything, sessionID).
+			Return(&domain.Session{ID: sessionID, UserID: user.ID, ClientID: &client.ID, Scope: "email", RefreshTokenHash: hashSecret("refresh")}, nil)
+		m.users.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+		m.tokens.On("GenerateClientAccessToken", mock.Anything, user.ID.String(), sessionID.String(), client.ID.String(), "email").Return("access", nil)
+		m.tokens.On("GenerateRefreshToken", mock.Anything, user.ID.String(), sessionID.String()).Return("refresh-2", nil)
+		m.sessions.On("RotateRefreshToken", mock.Anything, sessionID, hashSecret("refresh"), hashSecret("refresh-2"), mock.AnythingOfType("time.Time")).Return(nil)
+
+		response, err := svc.Token(context.Background(), refreshRequest(client, "refresh"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "refresh-2", response.RefreshToken)
+		m.sessions.AssertNotCalled(t, "DeleteSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should revoke the session when a rotated refresh token is replayed", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		sessionID := uuid.New()
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.tokens.On("ParseRefreshToken", mock.Anything, "refresh").
+			Return(&domain.RefreshTokenClaims{UserID: user.ID.String(), SessionID: sessionID.String()}, nil)
+		m.sessions.On("FindSessionByID", mock.Anything, sessionID).
+			Return(&domain.Session{ID: sessionID, UserID: user.ID, ClientID: &client.ID, RefreshTokenHash: hashSecret("refresh-2")}, nil)
+		m.sessions.On("DeleteSession", mock.Anything, sessionID).Return(&domain.Session{ID: sessionID}, nil)
+
+		_, err := svc.Token(context.Background(), refreshRequest(client, "refresh"))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+		m.sessions.AssertCalled(t, "DeleteSession", mock.Anything, sessionID)
+		m.tokens.AssertNotCalled(t, "GenerateClientAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should revoke the session when a concurrent refresh rotated the token first", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		client := newOIDCClient("")
+		sessionID := uuid.New()
+		m.clients.On("FindClientByID", mock.Anything, client.ID).Return(client, nil)
+		m.tokens.On("ParseRefreshToken", mock.Anything, "refresh").
+			Return(&domain.RefreshTokenClaims{UserID: user.ID.String(), SessionID: sessionID.String()}, nil)
+		m.sessions.On("FindSessionByID", mock.Anything, sessionID).
+			Return(&domain.Session{ID: sessionID, UserID: user.ID, ClientID: &client.ID, RefreshTokenHash: hashSecret("refresh")}, nil)
+		m.users.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+		m.tokens.On("GenerateClientAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("access", nil)
+		m.tokens.On("GenerateRefreshToken", mock.Anything, mock.Anything, mock.Anything).Return("refresh-3", nil)
+		m.sessions.On("RotateRefreshToken", mock.Anything, sessionID, hashSecret("refresh"), hashSecret("refresh-3"), mock.AnythingOfType("time.Time")).
+			Return(domain.ErrSessionNotFound)
+		m.sessions.On("DeleteSession", mock.Anything, sessionID).Return(&domain.Session{ID: sessionID}, nil)
+
+		_, err := svc.Token(context.Background(), refreshRequest(client, "refresh"))
+
+		assert.ErrorIs(t, err, domain.ErrInvalidGrant)
+		m.sessions.AssertCalled(t, "DeleteSession", mock.Anything, sessionID)
+	})
+}
+
+func TestOIDCUserInfo(t *testing.T) {
+	user := &domain.User{ID: uuid.New(), Name: "Ada", Email: "ada@example.com", Avatar: "https://example.com/ada.png"}
+
+	t.Run("should only return the claims of the granted scopes", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newOIDCService(t)
+		clientID, sessionID := uuid.New(), uuid.New()
+		m.sessions.On("FindSessionByID", mock.Anything, sessionID).
+			Return(&domain.Session{ID: sessionID, UserID: user.ID, ClientID: &clientID, Scope: "openid profile"}, nil)
+		m.users.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		info, err := svc.UserInfo(context.Background(), user.ID.String(), sessionID.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.UserInfo{Subject: user.ID.String(), Name: "Ada", Picture: user.Avatar}, info)
+	})
+}
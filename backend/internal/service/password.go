@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+// enforcePasswordPolicy returns a PasswordPolicyError naming the request
+// field when password breaks the policy.
+func enforcePasswordPolicy(ctx context.Context, policy domain.PasswordPolicy, field, password string, owner domain.PasswordOwner) error {
+	violations, err := policy.Check(ctx, password, owner)
+	if err != nil {
+		return fmt.Errorf("failed to check password policy: %w", err)
+	}
+	if len(violations) > 0 {
+		return &domain.PasswordPolicyError{Field: field, Violations: violations}
+	}
+	return nil
+}
+
+// checkPasswordReuse returns a PasswordPolicyError naming the request field
+// when password is the user's current one or one of the previous ones kept
+// in their history, PASSWORD_HISTORY_SIZE in all.
+func checkPasswordReuse(ctx context.Context, hasher domain.PasswordHasher, authRepository domain.AuthRepository, user *domain.User, field, password string) error {
+	size := config.Env.Policy.HistorySize
+	if size < 1 {
+		return nil
+	}
+
+	hashes := []string{user.Password}
+	if size > 1 {
+		history, err := authRepository.ListPasswordHistory(ctx, user.ID, size-1)
+		if err != nil {
+			return fmt.Errorf("failed to list password history: %w", err)
+		}
+		for _, entry := range history {
+			hashes = append(hashes, entry.Hash)
+		}
+	}
+
+	for _, hash := range hashes {
+		err := hasher.Check(ctx, password, hash)
+		switch {
+		case err == nil:
+			return &domain.PasswordPolicyError{Field: field, Violations: []domain.PasswordViolation{{Rule: domain.PasswordRuleReused, Limit: size}}}
+		case errors.Is(err, domain.ErrServerBusy), ctx.Err() != nil:
+			return err
+		}
+	}
+	return nil
+}
+
+// changePassword moves the user's current hash to the history and sets
+// hash in its place, restarting the password's age. The caller saves user.
+func changePassword(ctx context.Context, authRepository domain.AuthRepository, user *domain.User, hash string) error {
+	if keep := config.Env.Policy.HistorySize - 1; keep > 0 {
+		entry := &domain.PasswordHistory{ID: uuid.New(), UserID: user.ID, Hash: user.Password}
+		if err := authRepository.AddPasswordHistory(ctx, entry, keep); err != nil {
+			return fmt.Errorf("failed to add password history: %w", err)
+		}
+	}
+
+	now := time.Now()
+	user.Password = hash
+	user.PasswordChangedAt = &now
+	return nil
+}
+
+// passwordTooOld reports whether the user's password is past
+// PASSWORD_MAX_AGE.
+func passwordTooOld(user *domain.User) bool {
+	maxAge := config.Env.Policy.MaxAge
+	if maxAge <= 0 {
+		return false
+	}
+
+	changedAt := user.CreatedAt
+	if user.PasswordChangedAt != nil {
+		changedAt = *user.PasswordChangedAt
+	}
+	return time.Since(changedAt) >= maxAge
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+// withPasswordPolicy sets the history and max-age settings for one test.
+// config.Env is shared, so callers must not run in parallel.
+func withPasswordPolicy(t *testing.T, historySize int, maxAge time.Duration) {
+	t.Helper()
+	previous := config.Env.Policy
+	config.Env.Policy.HistorySize = historySize
+	config.Env.Policy.MaxAge = maxAge
+	config.Env.Policy.ChangeChallengeTTL = 5 * time.Minute
+	t.Cleanup(func() { config.Env.Policy = previous })
+}
+
+func TestPasswordHistory(t *testing.T) {
+	withPasswordPolicy(t, 3, 0)
+
+	t.Run("should reject a password kept in the history", func(t *testing.T) {
+		svc, authRepo, _, _, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		userID := uuid.New()
+		user := &domain.User{ID: userID, Email: "user@test.com", Password: "hashed-old"}
+
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "oldpass", "hashed-old").Return(nil)
+		passwordHasher.On("Check", mock.Anything, "newpass123", "hashed-old").Return(errors.New("mismatch"))
+		authRepo.On("ListPasswordHistory", mock.Anything, userID, 2).
+			Return([]domain.PasswordHistory{{Hash: "hashed-older"}, {Hash: "hashed-oldest"}}, nil)
+		passwordHasher.On("Check", mock.Anything, "newpass123", "hashed-older").Return(nil)
+
+		err := svc.UpdatePassword(ctx, userID.String(), domain.UpdatePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass123"})
+
+		var policyErr *domain.PasswordPolicyError
+		require.ErrorAs(t, err, &policyErr)
+		assert.Equal(t, "new_password", policyErr.Field)
+		assert.Equal(t, []domain.PasswordViolation{{Rule: domain.PasswordRuleReused, Limit: 3}}, policyErr.Violations)
+		passwordHasher.AssertNotCalled(t, "Hash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should move the current hash to the history", func(t *testing.T) {
+		svc, authRepo, _, _, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		userID := uuid.New()
+		user := &domain.User{ID: userID, Email: "user@test.com", Password: "hashed-old"}
+
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "oldpass", "hashed-old").Return(nil)
+		passwordHasher.On("Check", mock.Anything, "newpass123", mock.Anything).Return(errors.New("mismatch"))
+		authRepo.On("ListPasswordHistory", mock.Anything, userID, 2).Return([]domain.PasswordHistory{{Hash: "hashed-older"}}, nil)
+		passwordHasher.On("Hash", mock.Anything, "newpass123").Return("hashed-new", nil)
+		authRepo.On("AddPasswordHistory", mock.Anything, mock.MatchedBy(func(entry *domain.PasswordHistory) bool {
+			return entry.UserID == userID && entry.Hash == "hashed-old"
+		}), 2).Return(nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Password == "hashed-new" && u.PasswordChangedAt != nil
+		})).Return(nil)
+
+		err := svc.UpdatePassword(ctx, userID.String(), domain.UpdatePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass123"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should pass a busy hasher through", func(t *testing.T) {
+		svc, authRepo, _, _, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		userID := uuid.New()
+		user := &domain.User{ID: userID, Email: "user@test.com", Password: "hashed-old"}
+
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "oldpass", "hashed-old").Return(nil)
+		authRepo.On("ListPasswordHistory", mock.Anything, userID, 2).Return(nil, nil)
+		passwordHasher.On("Check", mock.Anything, "newpass123", "hashed-old").Return(domain.ErrServerBusy)
+
+		err := svc.UpdatePassword(ctx, userID.String(), domain.UpdatePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass123"})
+
+		assert.ErrorIs(t, err, domain.ErrServerBusy)
+	})
+}
+
+func TestPasswordMaxAge(t *testing.T) {
+	withPasswordPolicy(t, 0, 24*time.Hour)
+
+	t.Run("should return a change challenge instead of a session", func(t *testing.T) {
+		svc, authRepo, sessionRepo, _, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		changedAt := time.Now().Add(-48 * time.Hour)
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", PasswordChangedAt: &changedAt}
+
+		var challenge *domain.PasswordChallenge
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+		authRepo.On("CreatePasswordChallenge", mock.Anything, mock.AnythingOfType("*domain.PasswordChallenge")).
+			Run(func(args mock.Arguments) { challenge = args.Get(1).(*domain.PasswordChallenge) }).
+			Return(nil)
+
+		result, err := svc.Login(ctx, domain.LoginRequest{Email: "user@test.com", Password: "password123"})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrPasswordChangeRequired)
+		var changeErr *domain.PasswordChangeRequiredError
+		require.ErrorAs(t, err, &changeErr)
+		require.NotNil(t, challenge)
+		assert.Equal(t, hashSecret(changeErr.ChallengeToken), challenge.ID)
+		assert.Equal(t, user.ID, challenge.UserID)
+		sessionRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should challenge a reactivation like a login", func(t *testing.T) {
+		svc, authRepo, sessionRepo, _, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		changedAt := time.Now().Add(-48 * time.Hour)
+		deletedAt := time.Now().Add(-time.Hour)
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", PasswordChangedAt: &changedAt, DeletedAt: &deletedAt}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+		authRepo.On("UpdateUser", mock.Anything, user).Return(nil)
+		authRepo.On("CreatePasswordChallenge", mock.Anything, mock.AnythingOfType("*domain.PasswordChallenge")).Return(nil)
+
+		result, err := svc.ReactivateAccount(ctx, domain.LoginRequest{Email: "user@test.com", Password: "password123"})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrPasswordChangeRequired)
+		assert.Nil(t, user.DeletedAt)
+		sessionRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should age passwords never changed from account creation", func(t *testing.T) {
+		svc, authRepo, sessionRepo, tokenProvider, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-password", CreatedAt: time.Now().Add(-time.Hour)}
+
+		authRepo.On("FindUserByEmail", mock.Anything, "user@test.com").Return(user, nil)
+		passwordHasher.On("Check", mock.Anything, "password123", "hashed-password").Return(nil)
+		passwordHasher.On("NeedsRehash", "hashed-password").Return(false)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, user.ID.String(), mock.AnythingOfType("string")).Return("refresh-token", nil)
+
+		result, err := svc.Login(ctx, domain.LoginRequest{Email: "user@test.com", Password: "password123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "access-token", result.AccessToken)
+	})
+}
+
+func TestChangeExpiredPassword(t *testing.T) {
+	withPasswordPolicy(t, 2, 24*time.Hour)
+
+	t.Run("should change the password and start a session", func(t *testing.T) {
+		svc, authRepo, sessionRepo, tokenProvider, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-old"}
+		id := hashSecret("challenge-token")
+
+		authRepo.On("FindPasswordChallenge", mock.Anything, id).
+			Return(&domain.PasswordChallenge{ID: id, UserID: user.ID, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+		authRepo.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+		authRepo.On("ListPasswordHistory", mock.Anything, user.ID, 1).Return(nil, nil)
+		passwordHasher.On("Check", mock.Anything, "newpass123", "hashed-old").Return(errors.New("mismatch"))
+		passwordHasher.On("Hash", mock.Anything, "newpass123").Return("hashed-new", nil)
+		authRepo.On("DeletePasswordChallenge", mock.Anything, id).Return(nil)
+		authRepo.On("AddPasswordHistory", mock.Anything, mock.AnythingOfType("*domain.PasswordHistory"), 1).Return(nil)
+		authRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Password == "hashed-new" && u.PasswordChangedAt != nil
+		})).Return(nil)
+		sessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
+		tokenProvider.On("GenerateAccessToken", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("access-token", nil)
+		tokenProvider.On("GenerateRefreshToken", mock.Anything, user.ID.String(), mock.AnythingOfType("string")).Return("refresh-token", nil)
+
+		result, err := svc.ChangeExpiredPassword(ctx, domain.ChangeExpiredPasswordRequest{ChallengeToken: "challenge-token", NewPassword: "newpass123"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "access-token", result.AccessToken)
+		assert.Equal(t, "refresh-token", result.RefreshToken)
+	})
+
+	t.Run("should keep the challenge when the password is reused", func(t *testing.T) {
+		svc, authRepo, _, _, passwordHasher := newAuthService(t)
+		ctx := context.Background()
+		user := &domain.User{ID: uuid.New(), Email: "user@test.com", Password: "hashed-old"}
+		id := hashSecret("challenge-token")
+
+		authRepo.On("FindPasswordChallenge", mock.Anything, id).
+			Return(&domain.PasswordChallenge{ID: id, UserID: user.ID, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+		authRepo.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+		authRepo.On("ListPasswordHistory", mock.Anything, user.ID, 1).Return(nil, nil)
+		passwordHasher.On("Check", mock.Anything, "oldpass", "hashed-old").Return(nil)
+
+		_, err := svc.ChangeExpiredPassword(ctx, domain.ChangeExpiredPasswordRequest{ChallengeToken: "challenge-token", NewPassword: "oldpass"})
+
+		assert.ErrorIs(t, err, domain.ErrWeakPassword)
+		authRepo.AssertNotCalled(t, "DeletePasswordChallenge", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrInvalidPasswordChallenge when the challenge expired", func(t *testing.T) {
+		svc, authRepo, _, _, _ := newAuthService(t)
+		ctx := context.Background()
+		id := hashSecret("challenge-token")
+
+		authRepo.On("FindPasswordChallenge", mock.Anything, id).
+			Return(&domain.PasswordChallenge{ID: id, UserID: uuid.New(), ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+		_, err := svc.ChangeExpiredPassword(ctx, domain.ChangeExpiredPasswordRequest{ChallengeToken: "challenge-token", NewPassword: "newpass123"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPasswordChallenge)
+	})
+
+	t.Run("should return ErrInvalidPasswordChallenge when the user is gone", func(t *testing.T) {
+		svc, authRepo, _, _, _ := newAuthService(t)
+		ctx := context.Background()
+		id := hashSecret("challenge-token")
+		userID := uuid.New()
+
+		authRepo.On("FindPasswordChallenge", mock.Anything, id).
+			Return(&domain.PasswordChallenge{ID: id, UserID: userID, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+		authRepo.On("FindUserByID", mock.Anything, userID).Return(nil, domain.ErrUserNotFound)
+
+		_, err := svc.ChangeExpiredPassword(ctx, domain.ChangeExpiredPasswordRequest{ChallengeToken: "challenge-token", NewPassword: "newpass123"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPasswordChallenge)
+	})
+}
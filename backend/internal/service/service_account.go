@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+type ServiceAccountServiceImpl struct {
+	serviceAccountRepository domain.ServiceAccountRepository
+	tokenProvider            domain.TokenProvider
+}
+
+func NewServiceAccountService(i *do.Injector) (domain.ServiceAccountService, error) {
+	serviceAccountRepository := do.MustInvoke[domain.ServiceAccountRepository](i)
+	tokenProvider := do.MustInvoke[domain.TokenProvider](i)
+	return &ServiceAccountServiceImpl{
+		serviceAccountRepository: serviceAccountRepository,
+		tokenProvider:            tokenProvider,
+	}, nil
+}
+
+// Create registers a service account with a first secret, which is only
+// returned here: the database keeps a hash of it.
+func (s *ServiceAccountServiceImpl) Create(ctx context.Context, req domain.CreateServiceAccountRequest) (_ *domain.CreatedServiceAccountResponse, err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountService.Create")
+	defer tracing.End(span, &err)
+
+	scopes := uniqueScopes(req.Scopes)
+	for _, scope := range scopes {
+		if !validScopeToken(scope) {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidScope, scope)
+		}
+	}
+
+	account := &domain.ServiceAccount{
+		ID:     uuid.New(),
+		Name:   req.Name,
+		Scopes: strings.Join(scopes, " "),
+	}
+	if err := s.serviceAccountRepository.CreateServiceAccount(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	secret, plain, err := s.createSecret(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.WithContext(ctx, zap.String("service", "ServiceAccountService.Create")).
+		Info("service account created", zap.String("client_id", account.ID.String()))
+
+	response := serviceAccountResponse(account, []domain.ServiceAccountSecret{*secret})
+	return &domain.CreatedServiceAccountResponse{ServiceAccountResponse: response, ClientSecret: plain}, nil
+}
+
+func (s *ServiceAccountServiceImpl) List(ctx context.Context) (_ []domain.ServiceAccountResponse, err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountService.List")
+	defer tracing.End(span, &err)
+
+	accounts, err := s.serviceAccountRepository.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	response := make([]domain.ServiceAccountResponse, 0, len(accounts))
+	for i := range accounts {
+		secrets, err := s.serviceAccountRepository.ListSecrets(ctx, accounts[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list service account secrets: %w", err)
+		}
+		response = append(response, serviceAccountResponse(&accounts[i], secrets))
+	}
+	return response, nil
+}
+
+// Delete removes the account and its secrets. Tokens it was issued stop
+// being accepted by this service right away; other services accept them
+// until they expire unless they introspect.
+func (s *ServiceAccountServiceImpl) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountService.Delete")
+	defer tracing.End(span, &err)
+
+	accountID, err := uuid.Parse(id)
+	if err != nil {
+		return domain.ErrServiceAccountNotFound
+	}
+
+	if err := s.serviceAccountRepository.DeleteServiceAccount(ctx, accountID); err != nil {
+		return err
+	}
+
+	logging.WithContext(ctx, zap.String("service", "ServiceAccountService.Delete")).
+		Info("service account deleted", zap.String("client_id", id))
+
+	return nil
+}
+
+// CreateSecret adds a secret to the account, so a new secret can be rolled
+// out before the old one is deleted.
+func (s *ServiceAccountServiceImpl) CreateSecret(ctx context.Context, id string) (_ *domain.CreatedServiceAccountSecretResponse, err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountService.CreateSecret")
+	defer tracing.End(span, &err)
+
+	accountID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrServiceAccountNotFound
+	}
+
+	if _, err := s.serviceAccountRepository.FindServiceAccountByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	secret, plain, err := s.createSecret(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.WithContext(ctx, zap.String("service", "ServiceAccountService.CreateSecret")).
+		Info("service account secret created", zap.String("client_id", id), zap.String("secret_id", secret.ID.String()))
+
+	return &domain.CreatedServiceAccountSecretResponse{
+		ServiceAccountSecretResponse: serviceAccountSecretResponse(secret),
+		ClientSecret:                 plain,
+	}, nil
+}
+
+func (s *ServiceAccountServiceImpl) DeleteSecret(ctx context.Context, id, secretID string) (err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountService.DeleteSecret")
+	defer tracing.End(span, &err)
+
+	accountID, err := uuid.Parse(id)
+	if err != nil {
+		return domain.ErrServiceAccountSecretNotFound
+	}
+	secretUUID, err := uuid.Parse(secretID)
+	if err != nil {
+		return domain.ErrServiceAccountSecretNotFound
+	}
+
+	if err := s.serviceAccountRepository.DeleteSecret(ctx, accountID, secretUUID); err != nil {
+		return err
+	}
+
+	logging.WithContext(ctx, zap.String("service", "ServiceAccountService.DeleteSecret")).
+		Info("service account secret deleted", zap.String("client_id", id), zap.String("secret_id", secretID))
+
+	return nil
+}
+
+// Authenticate matches clientSecret against every secret of the account.
+func (s *ServiceAccountServiceImpl) Authenticate(ctx context.Context, clientID, clientSecret string) (_ *domain.ServiceAccount, err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountService.Authenticate")
+	defer tracing.End(span, &err)
+
+	id, err := uuid.Parse(clientID)
+	if err != nil || clientSecret == "" {
+		return nil, domain.ErrInvalidClient
+	}
+
+	account, err := s.serviceAccountRepository.FindServiceAccountByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceAccountNotFound) {
+			return nil, domain.ErrInvalidClient
+		}
+		return nil, fmt.Errorf("failed to find service account: %w", err)
+	}
+
+	secrets, err := s.serviceAccountRepository.ListSecrets(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service account secrets: %w", err)
+	}
+
+	hash := []byte(hashSecret(clientSecret))
+	for _, secret := range secrets {
+		if subtle.ConstantTimeCompare(hash, []byte(secret.SecretHash)) != 1 {
+			continue
+		}
+		if err := s.serviceAccountRepository.TouchSecret(ctx, secret.ID, time.Now()); err != nil {
+			logging.WithContext(ctx, zap.String("service", "ServiceAccountService.Authenticate")).
+				Error("failed to record secret use", zap.Error(err))
+		}
+		return account, nil
+	}
+
+	return nil, domain.ErrInvalidClient
+}
+
+// Token issues an access token for the client_credentials grant. Without a
+// requested scope the token carries every scope of the account.
+func (s *ServiceAccountServiceImpl) Token(ctx context.Context, account *domain.ServiceAccount, req domain.ClientCredentialsRequest) (_ *domain.ClientCredentialsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "ServiceAccountService.Token")
+	defer tracing.End(span, &err)
+
+	if req.GrantType != domain.GrantTypeClientCredentials {
+		return nil, domain.ErrUnsupportedGrantType
+	}
+
+	allowed := strings.Fields(account.Scopes)
+	scopes := uniqueScopes(strings.Fields(req.Scope))
+	if len(scopes) == 0 {
+		scopes = allowed
+	}
+	if !containsAll(allowed, scopes) {
+		return nil, domain.ErrInvalidScope
+	}
+
+	accessToken, err := s.tokenProvider.GenerateServiceAccountToken(ctx, account.ID.String(), scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	metrics.OAuthTokensIssuedTotal.WithLabelValues(domain.GrantTypeClientCredentials).Inc()
+
+	return &domain.ClientCredentialsResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   config.Env.Token.AccessTokenExpiry * 60,
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+func (s *ServiceAccountServiceImpl) createSecret(ctx context.Context, accountID uuid.UUID) (*domain.ServiceAccountSecret, string, error) {
+	plain, err := randomString()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate client secret: %w", err)
+	}
+
+	secret := &domain.ServiceAccountSecret{
+		ID:               uuid.New(),
+		ServiceAccountID: accountID,
+		SecretHash:       hashSecret(plain),
+	}
+	if err := s.serviceAccountRepository.CreateSecret(ctx, secret); err != nil {
+		return nil, "", fmt.Errorf("failed to create service account secret: %w", err)
+	}
+
+	return secret, plain, nil
+}
+
+// uniqueScopes drops repeated scopes, keeping their order.
+func uniqueScopes(scopes []string) []string {
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(unique, scope) {
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
+
+// validScopeToken reports whether scope is a scope-token of RFC 6749
+// section 3.3: printable ASCII without spaces, quotes or backslashes.
+func validScopeToken(scope string) bool {
+	if scope == "" {
+		return false
+	}
+	for _, r := range scope {
+		if r < 0x21 || r > 0x7e || r == '"' || r == '\\' {
+			return false
+		}
+	}
+	return true
+}
+
+func serviceAccountResponse(account *domain.ServiceAccount, secrets []domain.ServiceAccountSecret) domain.ServiceAccountResponse {
+	response := domain.ServiceAccountResponse{
+		ID:        account.ID.String(),
+		Name:      account.Name,
+		Scopes:    strings.Fields(account.Scopes),
+		Secrets:   make([]domain.ServiceAccountSecretResponse, 0, len(secrets)),
+		CreatedAt: account.CreatedAt,
+	}
+	for i := range secrets {
+		response.Secrets = append(response.Secrets, serviceAccountSecretResponse(&secrets[i]))
+	}
+	return response
+}
+
+func serviceAccountSecretResponse(secret *domain.ServiceAccountSecret) domain.ServiceAccountSecretResponse {
+	return domain.ServiceAccountSecretResponse{
+		ID:         secret.ID.String(),
+		LastUsedAt: secret.LastUsedAt,
+		CreatedAt:  secret.CreatedAt,
+	}
+}
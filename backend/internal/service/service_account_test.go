@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+func TestServiceAccountServiceCreate(t *testing.T) {
+	t.Run("should store the account with a hashed first secret", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mockpkg.NewMockServiceAccountRepository(t)
+		svc := &ServiceAccountServiceImpl{serviceAccountRepository: repo}
+
+		var account *domain.ServiceAccount
+		var secret *domain.ServiceAccountSecret
+		repo.On("CreateServiceAccount", mock.Anything, mock.AnythingOfType("*domain.ServiceAccount")).
+			Run(func(args mock.Arguments) { account = args.Get(1).(*domain.ServiceAccount) }).
+			Return(nil)
+		repo.On("CreateSecret", mock.Anything, mock.AnythingOfType("*domain.ServiceAccountSecret")).
+			Run(func(args mock.Arguments) { secret = args.Get(1).(*domain.ServiceAccountSecret) }).
+			Return(nil)
+
+		response, err := svc.Create(context.Background(), domain.CreateServiceAccountRequest{
+			Name: "billing", Scopes: []string{"admin", "orders:read", "admin"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "admin orders:read", account.Scopes)
+		assert.Equal(t, account.ID, secret.ServiceAccountID)
+		assert.Equal(t, hashSecret(response.ClientSecret), secret.SecretHash)
+		assert.Equal(t, account.ID.String(), response.ID)
+		assert.Equal(t, []string{"admin", "orders:read"}, response.Scopes)
+		assert.Len(t, response.Secrets, 1)
+	})
+
+	t.Run("should reject a scope with spaces or quotes", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mockpkg.NewMockServiceAccountRepository(t)
+		svc := &ServiceAccountServiceImpl{serviceAccountRepository: repo}
+
+		_, err := svc.Create(context.Background(), domain.CreateServiceAccountRequest{
+			Name: "billing", Scopes: []string{`orders "read"`},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidScope)
+	})
+}
+
+func TestServiceAccountServiceAuthenticate(t *testing.T) {
+	t.Run("should accept any of the account's secrets", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mockpkg.NewMockServiceAccountRepository(t)
+		svc := &ServiceAccountServiceImpl{serviceAccountRepository: repo}
+		account := &domain.ServiceAccount{ID: uuid.New(), Scopes: "admin"}
+		current := domain.ServiceAccountSecret{ID: uuid.New(), SecretHash: hashSecret("new-secret")}
+
+		repo.On("FindServiceAccountByID", mock.Anything, account.ID).Return(account, nil)
+		repo.On("ListSecrets", mock.Anything, account.ID).Return([]domain.ServiceAccountSecret{
+			{ID: uuid.New(), SecretHash: hashSecret("old-secret")}, current,
+		}, nil)
+		repo.On("TouchSecret", mock.Anything, current.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+		got, err := svc.Authenticate(context.Background(), account.ID.String(), "new-secret")
+
+		require.NoError(t, err)
+		assert.Equal(t, account, got)
+	})
+
+	t.Run("should reject a wrong secret", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mockpkg.NewMockServiceAccountRepository(t)
+		svc := &ServiceAccountServiceImpl{serviceAccountRepository: repo}
+		account := &domain.ServiceAccount{ID: uuid.New()}
+
+		repo.On("FindServiceAccountByID", mock.Anything, account.ID).Return(account, nil)
+		repo.On("ListSecrets", mock.Anything, account.ID).Return([]domain.ServiceAccountSecret{
+			{ID: uuid.New(), SecretHash: hashSecret("secret")},
+		}, nil)
+
+		_, err := svc.Authenticate(context.Background(), account.ID.String(), "guess")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+	})
+
+	t.Run("should reject an unknown client", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mockpkg.NewMockServiceAccountRepository(t)
+		svc := &ServiceAccountServiceImpl{serviceAccountRepository: repo}
+		id := uuid.New()
+
+		repo.On("FindServiceAccountByID", mock.Anything, id).Return(nil, domain.ErrServiceAccountNotFound)
+
+		_, err := svc.Authenticate(context.Background(), id.String(), "secret")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidClient)
+	})
+}
+
+func TestServiceAccountServiceToken(t *testing.T) {
+	account := &domain.ServiceAccount{ID: uuid.New(), Scopes: "orders:read orders:write"}
+
+	t.Run("should grant every scope of the account by default", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		svc := &ServiceAccountServiceImpl{tokenProvider: tokenProvider}
+
+		tokenProvider.On("GenerateServiceAccountToken", mock.Anything, account.ID.String(), []string{"orders:read", "orders:write"}).
+			Return("token", nil)
+
+		response, err := svc.Token(context.Background(), account, domain.ClientCredentialsRequest{
+			GrantType: domain.GrantTypeClientCredentials,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "token", response.AccessToken)
+		assert.Equal(t, "Bearer", response.TokenType)
+		assert.Equal(t, "orders:read orders:write", response.Scope)
+	})
+
+	t.Run("should narrow the token to the requested scopes", func(t *testing.T) {
+		t.Parallel()
+
+		tokenProvider := mockpkg.NewMockTokenProvider(t)
+		svc := &ServiceAccountServiceImpl{tokenProvider: tokenProvider}
+
+		tokenProvider.On("GenerateServiceAccountToken", mock.Anything, account.ID.String(), []string{"orders:read"}).
+			Return("token", nil)
+
+		response, err := svc.Token(context.Background(), account, domain.ClientCredentialsRequest{
+			GrantType: domain.GrantTypeClientCredentials, Scope: "orders:read",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "orders:read", response.Scope)
+	})
+
+	t.Run("should reject a scope the account was not granted", func(t *testing.T) {
+		t.Parallel()
+
+		svc := &ServiceAccountServiceImpl{tokenProvider: mockpkg.NewMockTokenProvider(t)}
+
+		_, err := svc.Token(context.Background(), account, domain.ClientCredentialsRequest{
+			GrantType: domain.GrantTypeClientCredentials, Scope: "orders:read admin",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidScope)
+	})
+
+	t.Run("should reject other grant types", func(t *testing.T) {
+		t.Parallel()
+
+		svc := &ServiceAccountServiceImpl{tokenProvider: mockpkg.NewMockTokenProvider(t)}
+
+		_, err := svc.Token(context.Background(), account, domain.ClientCredentialsRequest{GrantType: "password"})
+
+		assert.ErrorIs(t, err, domain.ErrUnsupportedGrantType)
+	})
+}
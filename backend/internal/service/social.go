@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/logging"
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+)
+
+type SocialServiceImpl struct {
+	providers          domain.SocialProviders
+	identityRepository domain.IdentityRepository
+	authRepository     domain.AuthRepository
+	sessionRepository  domain.SessionRepository
+	tokenProvider      domain.TokenProvider
+	passwordHasher     domain.PasswordHasher
+}
+
+func NewSocialService(i *do.Injector) (domain.SocialService, error) {
+	providers := do.MustInvoke[domain.SocialProviders](i)
+	identityRepository := do.MustInvoke[domain.IdentityRepository](i)
+	authRepository := do.MustInvoke[domain.AuthRepository](i)
+	sessionRepository := do.MustInvoke[domain.SessionRepository](i)
+	tokenProvider := do.MustInvoke[domain.TokenProvider](i)
+	passwordHasher := do.MustInvoke[domain.PasswordHasher](i)
+	return &SocialServiceImpl{
+		providers:          providers,
+		identityRepository: identityRepository,
+		authRepository:     authRepository,
+		sessionRepository:  sessionRepository,
+		tokenProvider:      tokenProvider,
+		passwordHasher:     passwordHasher,
+	}, nil
+}
+
+func (s *SocialServiceImpl) Providers() []domain.SocialProviderInfo {
+	infos := make([]domain.SocialProviderInfo, 0, len(s.providers))
+	for _, provider := range s.providers {
+		cfg := provider.Config()
+		infos = append(infos, domain.SocialProviderInfo{Name: cfg.Name, DisplayName: cfg.DisplayName})
+	}
+	return infos
+}
+
+// Begin starts a login with provider. The state, nonce and PKCE verifier
+// are stored server side under the hash of the state, which the browser
+// carries through the provider and back to the callback.
+func (s *SocialServiceImpl) Begin(ctx context.Context, name, redirectURI, returnTo string) (_ *domain.SocialLogin, err error) {
+	ctx, span := tracing.Start(ctx, "SocialService.Begin")
+	defer tracing.End(span, &err)
+
+	provider, err := s.provider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authorizationURL, err := provider.AuthorizationURL(ctx, redirectURI, state, nonce,
+		base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.identityRepository.CreateLoginState(ctx, &domain.SocialLoginState{
+		ID:           hashSecret(state),
+		Provider:     name,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		RedirectURI:  redirectURI,
+		ReturnTo:     safeReturnTo(returnTo),
+		ExpiresAt:    time.Now().Add(config.Env.Social.StateTTL),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create login state: %w", err)
+	}
+
+	return &domain.SocialLogin{AuthorizationURL: authorizationURL, State: state}, nil
+}
+
+// Complete finishes a login on the callback. The user is the one linked to
+// the provider subject; on the first login the identity is linked to the
+// user with the same verified email, or to a new user.
+func (s *SocialServiceImpl) Complete(ctx context.Context, name string, req domain.SocialCallbackRequest) (_ *domain.SocialLoginResult, err error) {
+	ctx, span := tracing.Start(ctx, "SocialService.Complete")
+	defer tracing.End(span, &err)
+
+	provider, err := s.provider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.complete(ctx, provider, req)
+	if err != nil {
+		metrics.SocialLoginsTotal.WithLabelValues(name, socialFailureReason(err)).Inc()
+		return nil, err
+	}
+	metrics.SocialLoginsTotal.WithLabelValues(name, "success").Inc()
+	return result, nil
+}
+
+// socialFailureReason maps a Complete error to a bounded metric label.
+func socialFailureReason(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrSocialLoginStateInvalid):
+		return "invalid_state"
+	case errors.Is(err, domain.ErrSocialLoginFailed):
+		return "provider_error"
+	case errors.Is(err, domain.ErrSocialEmailNotVerified):
+		return "email_not_verified"
+	case errors.Is(err, domain.ErrUserDeactivated):
+		return "user_deactivated"
+	default:
+		return "internal_error"
+	}
+}
+
+func (s *SocialServiceImpl) complete(ctx context.Context, provider domain.SocialProvider, req domain.SocialCallbackRequest) (*domain.SocialLoginResult, error) {
+	name := provider.Config().Name
+
+	if req.State == "" {
+		return nil, domain.ErrSocialLoginStateInvalid
+	}
+	state, err := s.identityRepository.ConsumeLoginState(ctx, hashSecret(req.State))
+	if err != nil {
+		if errors.Is(err, domain.ErrSocialLoginStateInvalid) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to consume login state: %w", err)
+	}
+	if state.Provider != name || !state.ExpiresAt.After(time.Now()) {
+		return nil, domain.ErrSocialLoginStateInvalid
+	}
+
+	if req.Error != "" {
+		return nil, fmt.Errorf("%w: %s %s", domain.ErrSocialLoginFailed, req.Error, req.ErrorDescription)
+	}
+	if req.Code == "" {
+		return nil, fmt.Errorf("%w: callback has no code", domain.ErrSocialLoginFailed)
+	}
+
+	external, err := provider.Exchange(ctx, state.RedirectURI, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if external.Subject == "" {
+		return nil, fmt.Errorf("%w: identity has no subject", domain.ErrSocialLoginFailed)
+	}
+
+	user, err := s.resolveUser(ctx, external)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.identityRepository.SaveIdentity(ctx, &domain.Identity{
+		Provider: name,
+		Subject:  external.Subject,
+		UserID:   user.ID,
+		Email:    external.Email,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save identity: %w", err)
+	}
+
+	session := &domain.Session{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(time.Duration(config.Env.Token.RefreshTokenExpiry) * time.Minute),
+	}
+
+	if err := s.sessionRepository.CreateSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	accessToken, err := s.tokenProvider.GenerateAccessToken(ctx, user.ID.String(), session.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := s.tokenProvider.GenerateRefreshToken(ctx, user.ID.String(), session.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &domain.SocialLoginResult{
+		Tokens: &domain.AuthResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			SessionID:    session.ID.String(),
+		},
+		ReturnTo: state.ReturnTo,
+	}, nil
+}
+
+func (s *SocialServiceImpl) resolveUser(ctx context.Context, external *domain.SocialIdentity) (*domain.User, error) {
+	identity, err := s.identityRepository.FindIdentity(ctx, external.Provider, external.Subject)
+	switch {
+	case err == nil:
+		user, err := s.authRepository.FindUserByID(ctx, identity.UserID)
+		if err == nil {
+			if user.DeletedAt != nil {
+				return nil, domain.ErrUserDeactivated
+			}
+			return user, nil
+		}
+		// The linked user was purged; link the identity again.
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+	case !errors.Is(err, domain.ErrIdentityNotFound):
+		return nil, fmt.Errorf("failed to find identity: %w", err)
+	}
+
+	// Linking trusts the email, so it must be verified by the provider.
+	if external.Email == "" || !external.EmailVerified {
+		return nil, domain.ErrSocialEmailNotVerified
+	}
+
+	user, err := s.authRepository.FindUserByEmail(ctx, external.Email)
+	if err == nil {
+		if user.DeletedAt != nil {
+			return nil, domain.ErrUserDeactivated
+		}
+		logging.WithContext(ctx, zap.String("service", "SocialService.Complete")).
+			Info("social identity linked to existing user",
+				zap.String("provider", external.Provider), zap.String("user_id", user.ID.String()))
+		return user, nil
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return s.provision(ctx, external)
+}
+
+// provision creates the user of a first social login. Its password is a
+// hash of random bytes, so the account can only sign in through providers
+// until a password is set.
+func (s *SocialServiceImpl) provision(ctx context.Context, external *domain.SocialIdentity) (*domain.User, error) {
+	password, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := s.passwordHasher.Hash(ctx, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	name := external.Name
+	if name == "" {
+		name, _, _ = strings.Cut(external.Email, "@")
+	}
+
+	user := &domain.User{
+		ID:       uuid.New(),
+		Name:     name,
+		Email:    external.Email,
+		Password: hashedPassword,
+		Avatar:   external.Picture,
+	}
+
+	if err := s.authRepository.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	metrics.AccountsCreatedTotal.WithLabelValues("social").Inc()
+
+	return user, nil
+}
+
+func (s *SocialServiceImpl) provider(name string) (domain.SocialProvider, error) {
+	for _, provider := range s.providers {
+		if provider.Config().Name == name {
+			return provider, nil
+		}
+	}
+	return nil, domain.ErrSocialProviderNotFound
+}
+
+// safeReturnTo keeps return_to on this origin: only absolute paths are
+// kept. Browsers drop tabs and newlines and read a backslash as "/", so a
+// tab or backslash after the first slash would leave it like "//host" does.
+func safeReturnTo(returnTo string) string {
+	if strings.ContainsFunc(returnTo, func(r rune) bool { return unicode.IsControl(r) || unicode.IsSpace(r) || r == '\\' }) ||
+		!strings.HasPrefix(returnTo, "/") || strings.HasPrefix(returnTo, "//") {
+		return "/"
+	}
+	u, err := url.Parse(returnTo)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.User != nil || !strings.HasPrefix(u.Path, "/") || strings.HasPrefix(u.Path, "//") {
+		return "/"
+	}
+	return returnTo
+}
+
+func randomString() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
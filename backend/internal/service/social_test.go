@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	mockpkg "github.com/SergioLNeves/migos/mock"
+)
+
+const socialRedirectURI = "https://auth.example.com/v1/auth/social/google/callback"
+
+type socialMocks struct {
+	provider   *mockpkg.MockSocialProvider
+	identities *mockpkg.MockIdentityRepository
+	users      *mockpkg.MockAuthRepository
+	sessions   *mockpkg.MockSessionRepository
+	tokens     *mockpkg.MockTokenProvider
+	hasher     *mockpkg.MockPasswordHasher
+}
+
+func newSocialService(t *testing.T) (*SocialServiceImpl, socialMocks) {
+	t.Helper()
+	m := socialMocks{
+		provider:   mockpkg.NewMockSocialProvider(t),
+		identities: mockpkg.NewMockIdentityRepository(t),
+		users:      mockpkg.NewMockAuthRepository(t),
+		sessions:   mockpkg.NewMockSessionRepository(t),
+		tokens:     mockpkg.NewMockTokenProvider(t),
+		hasher:     mockpkg.NewMockPasswordHasher(t),
+	}
+	m.provider.On("Config").Return(domain.SocialProviderConfig{Name: "google", DisplayName: "Google"}).Maybe()
+	svc := &SocialServiceImpl{
+		providers:          domain.SocialProviders{m.provider},
+		identityRepository: m.identities,
+		authRepository:     m.users,
+		sessionRepository:  m.sessions,
+		tokenProvider:      m.tokens,
+		passwordHasher:     m.hasher,
+	}
+	return svc, m
+}
+
+// expectLoginState makes the callback state st4te valid for google.
+func (m socialMocks) expectLoginState() {
+	m.identities.On("ConsumeLoginState", mock.Anything, hashSecret("st4te")).Return(&domain.SocialLoginState{
+		Provider:     "google",
+		Nonce:        "n0nce",
+		CodeVerifier: "v3rifier",
+		RedirectURI:  socialRedirectURI,
+		ReturnTo:     "/settings",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}, nil)
+}
+
+func (m socialMocks) expectExchange(identity *domain.SocialIdentity) {
+	m.provider.On("Exchange", mock.Anything, socialRedirectURI, "c0de", "v3rifier", "n0nce").Return(identity, nil)
+}
+
+// expectSession expects the session of a completed login for userID.
+func (m socialMocks) expectSession(userID uuid.UUID) {
+	m.identities.On("SaveIdentity", mock.Anything, mock.MatchedBy(func(identity *domain.Identity) bool {
+		return identity.Provider == "google" && identity.Subject == "g-1" && identity.UserID == userID
+	})).Return(nil)
+	m.sessions.On("CreateSession", mock.Anything, mock.MatchedBy(func(session *domain.Session) bool {
+		return session.UserID == userID
+	})).Return(nil)
+	m.tokens.On("GenerateAccessToken", mock.Anything, userID.String(), mock.Anything).Return("access", nil)
+	m.tokens.On("GenerateRefreshToken", mock.Anything, userID.String(), mock.Anything).Return("refresh", nil)
+}
+
+var callback = domain.SocialCallbackRequest{Code: "c0de", State: "st4te"}
+
+func verifiedIdentity() *domain.SocialIdentity {
+	return &domain.SocialIdentity{Provider: "google", Subject: "g-1", Email: "ada@example.com", EmailVerified: true, Name: "Ada"}
+}
+
+func TestSocialBegin(t *testing.T) {
+	t.Run("should store the state hashed with the PKCE verifier", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newSocialService(t)
+		var stored *domain.SocialLoginState
+		var challenge string
+		m.provider.On("AuthorizationURL", mock.Anything, socialRedirectURI, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { challenge = args.String(4) }).
+			Return("https://accounts.example.com/authorize", nil)
+		m.identities.On("CreateLoginState", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.SocialLoginState) }).
+			Return(nil)
+
+		login, err := svc.Begin(context.Background(), "google", socialRedirectURI, "/settings")
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://accounts.example.com/authorize", login.AuthorizationURL)
+		assert.Equal(t, hashSecret(login.State), stored.ID)
+		assert.Equal(t, "/settings", stored.ReturnTo)
+		sum := sha256.Sum256([]byte(stored.CodeVerifier))
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(sum[:]), challenge)
+	})
+
+	t.Run("should not return to other origins", func(t *testing.T) {
+		t.Parallel()
+
+		for _, returnTo := range []string{
+			"https://evil.example.com", "//evil.example.com", "/\\evil.example.com", "",
+			"/\t/evil.example.com", "/\n/evil.example.com", "/\r//evil.example.com", "/ /evil.example.com",
+			"/%2F/evil.example.com", "javascript:alert(1)",
+		} {
+			assert.Equal(t, "/", safeReturnTo(returnTo), returnTo)
+		}
+		assert.Equal(t, "/settings?tab=security#2fa", safeReturnTo("/settings?tab=security#2fa"))
+	})
+
+	t.Run("should reject unknown providers", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _ := newSocialService(t)
+
+		_, err := svc.Begin(context.Background(), "myspace", socialRedirectURI, "/")
+
+		assert.ErrorIs(t, err, domain.ErrSocialProviderNotFound)
+	})
+}
+
+func TestSocialComplete(t *testing.T) {
+	t.Run("should sign in the user linked to the identity", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newSocialService(t)
+		user := &domain.User{ID: uuid.New(), Email: "old@example.com"}
+		m.expectLoginState()
+		m.expectExchange(verifiedIdentity())
+		m.identities.On("FindIdentity", mock.Anything, "google", "g-1").Return(&domain.Identity{UserID: user.ID}, nil)
+		m.users.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+		m.expectSession(user.ID)
+
+		result, err := svc.Complete(context.Background(), "google", callback)
+
+		require.NoError(t, err)
+		assert.Equal(t, "access", result.Tokens.AccessToken)
+		assert.Equal(t, "refresh", result.Tokens.RefreshToken)
+		assert.NotEmpty(t, result.Tokens.SessionID)
+		assert.Equal(t, "/settings", result.ReturnTo)
+	})
+
+	t.Run("should link the account with the same verified email", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newSocialService(t)
+		user := &domain.User{ID: uuid.New(), Email: "ada@example.com"}
+		m.expectLoginState()
+		m.expectExchange(verifiedIdentity())
+		m.identities.On("FindIdentity", mock.Anything, "google", "g-1").Return(nil, domain.ErrIdentityNotFound)
+		m.users.On("FindUserByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+		m.expectSession(user.ID)
+
+		_, err := svc.Complete(context.Background(), "google", callback)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should provision a user on the first login", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newSocialService(t)
+		var created *domain.User
+		m.expectLoginState()
+		m.expectExchange(verifiedIdentity())
+		m.identities.On("FindIdentity", mock.Anything, "google", "g-1").Return(nil, domain.ErrIdentityNotFound)
+		m.users.On("FindUserByEmail", mock.Anything, "ada@example.com").Return(nil, domain.ErrUserNotFound)
+		m.hasher.On("Hash", mock.Anything, mock.Anything).Return("hashed", nil)
+		m.users.On("CreateUser", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				created = args.Get(1).(*domain.User)
+				m.expectSession(created.ID)
+			}).
+			Return(nil)
+
+		_, err := svc.Complete(context.Background(), "google", callback)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Ada", created.Name)
+		assert.Equal(t, "ada@example.com", created.Email)
+		assert.Equal(t, "hashed", created.Password)
+	})
+
+	t.Run("should not link identities without a verified email", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newSocialService(t)
+		identity := verifiedIdentity()
+		identity.EmailVerified = false
+		m.expectLoginState()
+		m.expectExchange(identity)
+		m.identities.On("FindIdentity", mock.Anything, "google", "g-1").Return(nil, domain.ErrIdentityNotFound)
+
+		_, err := svc.Complete(context.Background(), "google", callback)
+
+		assert.ErrorIs(t, err, domain.ErrSocialEmailNotVerified)
+	})
+
+	t.Run("should reject deactivated users", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newSocialService(t)
+		now := time.Now()
+		user := &domain.User{ID: uuid.New(), DeletedAt: &now}
+		m.expectLoginState()
+		m.expectExchange(verifiedIdentity())
+		m.identities.On("FindIdentity", mock.Anything, "google", "g-1").Return(&domain.Identity{UserID: user.ID}, nil)
+		m.users.On("FindUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		_, err := svc.Complete(context.Background(), "google", callback)
+
+		assert.ErrorIs(t, err, domain.ErrUserDeactivated)
+	})
+
+	t.Run("should reject states that are unknown, expired or of another provider", func(t *testing.T) {
+		t.Parallel()
+
+		states := map[string]*domain.SocialLoginState{
+			"expired": {Provider: "google", ExpiresAt: time.Now().Add(-time.Second)},
+			"other":   {Provider: "github", ExpiresAt: time.Now().Add(time.Minute)},
+		}
+		for state, stored := range states {
+			svc, m := newSocialService(t)
+			m.identities.On("ConsumeLoginState", mock.Anything, hashSecret(state)).Return(stored, nil)
+
+			_, err := svc.Complete(context.Background(), "google", domain.SocialCallbackRequest{Code: "c0de", State: state})
+
+			assert.ErrorIs(t, err, domain.ErrSocialLoginStateInvalid, state)
+		}
+
+		svc, m := newSocialService(t)
+		m.identities.On("ConsumeLoginState", mock.Anything, hashSecret("unknown")).Return(nil, domain.ErrSocialLoginStateInvalid)
+
+		_, err := svc.Complete(context.Background(), "google", domain.SocialCallbackRequest{Code: "c0de", State: "unknown"})
+
+		assert.ErrorIs(t, err, domain.ErrSocialLoginStateInvalid)
+	})
+
+	t.Run("should fail when the provider returns an error", func(t *testing.T) {
+		t.Parallel()
+
+		svc, m := newSocialService(t)
+		m.expectLoginState()
+
+		_, err := svc.Complete(context.Background(), "google", domain.SocialCallbackRequest{State: "st4te", Error: "access_denied"})
+
+		assert.ErrorIs(t, err, domain.ErrSocialLoginFailed)
+	})
+}
@@ -0,0 +1,361 @@
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/pkg/tracing"
+	"github.com/SergioLNeves/migos/pkg/jwks"
+)
+
+// defaultOIDCScopes are requested from OpenID Connect providers configured
+// without scopes.
+var defaultOIDCScopes = []string{"openid", "email", "profile"}
+
+// maxResponseSize bounds what is read from a provider.
+const maxResponseSize = 1 << 20
+
+// endpoints are the provider URLs, configured or discovered.
+type endpoints struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+
+	// keys caches the signing keys at JWKSURI.
+	keys *jwks.KeySet
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Provider is a domain.SocialProvider for an OpenID Connect or OAuth2
+// provider. Discovery metadata and signing keys are fetched on first use
+// and cached; keys are fetched again when a token names an unknown key, at
+// most once a minute.
+type Provider struct {
+	cfg        domain.SocialProviderConfig
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	endpoints *endpoints
+}
+
+func NewProvider(cfg domain.SocialProviderConfig, httpClient *http.Client) *Provider {
+	return &Provider{cfg: cfg, httpClient: httpClient}
+}
+
+func (p *Provider) Config() domain.SocialProviderConfig {
+	return p.cfg
+}
+
+func (p *Provider) oidc() bool {
+	return p.cfg.Issuer != ""
+}
+
+// AuthorizationURL builds the URL that starts the authorization code flow,
+// always with PKCE and, for OpenID Connect, with the nonce.
+func (p *Provider) AuthorizationURL(ctx context.Context, redirectURI, state, nonce, codeChallenge string) (string, error) {
+	ep, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(ep.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid authorization endpoint: %v", domain.ErrSocialLoginFailed, err)
+	}
+
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 && p.oidc() {
+		scopes = defaultOIDCScopes
+	}
+
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("state", state)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	if len(scopes) > 0 {
+		query.Set("scope", strings.Join(scopes, " "))
+	}
+	if p.oidc() {
+		query.Set("nonce", nonce)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// Exchange redeems the code. OpenID Connect identities come from the
+// verified ID token, completed by the user info endpoint when the token
+// carries no email; OAuth2 identities come from the user info endpoint.
+func (p *Provider) Exchange(ctx context.Context, redirectURI, code, codeVerifier, nonce string) (_ *domain.SocialIdentity, err error) {
+	ctx, span := tracing.Start(ctx, "SocialProvider.Exchange")
+	defer tracing.End(span, &err)
+
+	ep, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.redeem(ctx, ep, redirectURI, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.oidc() {
+		claims, err := p.userInfo(ctx, ep.UserInfoEndpoint, token.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		identity := p.identity(claims)
+		if p.cfg.EmailsURL != "" {
+			identity.Email, identity.EmailVerified, err = p.primaryEmail(ctx, token.AccessToken)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return identity, nil
+	}
+
+	claims, err := p.verifyIDToken(ctx, ep, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+	identity := p.identity(claims)
+
+	if identity.Email == "" && ep.UserInfoEndpoint != "" {
+		info, err := p.userInfo(ctx, ep.UserInfoEndpoint, token.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		fromInfo := p.identity(info)
+		if fromInfo.Subject != identity.Subject {
+			return nil, fmt.Errorf("%w: user info subject does not match the ID token", domain.ErrSocialLoginFailed)
+		}
+		identity.Email, identity.EmailVerified = fromInfo.Email, fromInfo.EmailVerified
+		if identity.Name == "" {
+			identity.Name = fromInfo.Name
+		}
+		if identity.Picture == "" {
+			identity.Picture = fromInfo.Picture
+		}
+	}
+
+	return identity, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*endpoints, error) {
+	if !p.oidc() {
+		return &endpoints{
+			AuthorizationEndpoint: p.cfg.AuthorizationURL,
+			TokenEndpoint:         p.cfg.TokenURL,
+			UserInfoEndpoint:      p.cfg.UserInfoURL,
+		}, nil
+	}
+
+	p.mu.Lock()
+	cached := p.endpoints
+	p.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	var ep endpoints
+	discoveryURL := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, discoveryURL, "", &ep); err != nil {
+		return nil, err
+	}
+	if ep.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("%w: discovery issuer %q does not match %q", domain.ErrSocialLoginFailed, ep.Issuer, p.cfg.Issuer)
+	}
+	if ep.AuthorizationEndpoint == "" || ep.TokenEndpoint == "" || ep.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: discovery document is incomplete", domain.ErrSocialLoginFailed)
+	}
+
+	ep.keys = jwks.New(ep.JWKSURI, jwks.Config{HTTPClient: p.httpClient})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.endpoints == nil {
+		p.endpoints = &ep
+	}
+	return p.endpoints, nil
+}
+
+// redeem posts the code to the token endpoint, authenticating with
+// client_secret_post, which every provider in use accepts.
+func (p *Provider) redeem(ctx context.Context, ep *endpoints, redirectURI, code, codeVerifier string) (*tokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {codeVerifier},
+		"client_id":     {p.cfg.ClientID},
+	}
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token tokenResponse
+	status, err := p.do(req, &token)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("%w: token endpoint answered %d %s %s", domain.ErrSocialLoginFailed, status, token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" || (p.oidc() && token.IDToken == "") {
+		return nil, fmt.Errorf("%w: token response is missing tokens", domain.ErrSocialLoginFailed)
+	}
+
+	return &token, nil
+}
+
+func (p *Provider) verifyIDToken(ctx context.Context, ep *endpoints, idToken, nonce string) (map[string]any, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return ep.keys.Key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid ID token: %v", domain.ErrSocialLoginFailed, err)
+	}
+
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return nil, fmt.Errorf("%w: ID token nonce does not match", domain.ErrSocialLoginFailed)
+	}
+
+	return claims, nil
+}
+
+func (p *Provider) userInfo(ctx context.Context, userInfoURL, accessToken string) (map[string]any, error) {
+	claims := map[string]any{}
+	if err := p.getJSON(ctx, userInfoURL, accessToken, &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// primaryEmail reads the verified primary address from providers that list
+// email addresses separately, as GitHub does.
+func (p *Provider) primaryEmail(ctx context.Context, accessToken string) (string, bool, error) {
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := p.getJSON(ctx, p.cfg.EmailsURL, accessToken, &emails); err != nil {
+		return "", false, err
+	}
+
+	for _, email := range emails {
+		if email.Primary {
+			return email.Email, email.Verified, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (p *Provider) identity(claims map[string]any) *domain.SocialIdentity {
+	names := p.cfg.Claims
+	return &domain.SocialIdentity{
+		Provider:      p.cfg.Name,
+		Subject:       claim(claims, names.Subject, "sub"),
+		Email:         claim(claims, names.Email, "email"),
+		EmailVerified: claim(claims, names.EmailVerified, "email_verified") == "true",
+		Name:          claim(claims, names.Name, "name"),
+		Picture:       claim(claims, names.Picture, "picture"),
+	}
+}
+
+// claim reads a string, number or boolean claim as a string.
+func claim(claims map[string]any, name, fallback string) string {
+	if name == "" {
+		name = fallback
+	}
+	switch value := claims[name].(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case float64:
+		return big.NewFloat(value).Text('f', -1)
+	case bool:
+		if value {
+			return "true"
+		}
+		return "false"
+	default:
+		return ""
+	}
+}
+
+func (p *Provider) getJSON(ctx context.Context, target, accessToken string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	status, err := p.do(req, v)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("%w: %s answered %d", domain.ErrSocialLoginFailed, target, status)
+	}
+	return nil
+}
+
+// do sends req and decodes a JSON body into v, keeping numbers exact so
+// numeric subjects are not rounded.
+func (p *Provider) do(req *http.Request, v any) (int, error) {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", domain.ErrSocialLoginFailed, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // body is fully read
+
+	decoder := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil && resp.StatusCode == http.StatusOK {
+		return 0, fmt.Errorf("%w: invalid response from %s: %v", domain.ErrSocialLoginFailed, req.URL.Redacted(), err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,124 @@
+package social
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/social/socialtest"
+)
+
+const (
+	redirectURI = "http://127.0.0.1/v1/auth/social/test/callback"
+	verifier    = "dBjftJeZ4CVP-mJ92K1zhqBxZomB3UEuyoEP0iD1IL0"
+)
+
+var ada = socialtest.User{Subject: "1001", Email: "ada@example.com", EmailVerified: true, Name: "Ada"}
+
+// signIn runs the authorization step for user and returns the code.
+func signIn(t *testing.T, p *Provider, user socialtest.User, nonce string) string {
+	t.Helper()
+	sum := sha256.Sum256([]byte(verifier))
+
+	authorizationURL, err := p.AuthorizationURL(context.Background(), redirectURI, "st4te", nonce,
+		base64.RawURLEncoding.EncodeToString(sum[:]))
+	require.NoError(t, err)
+
+	location, err := socialtest.SignIn(authorizationURL, user)
+	require.NoError(t, err)
+	callback, err := url.Parse(location)
+	require.NoError(t, err)
+	require.Equal(t, "st4te", callback.Query().Get("state"))
+	return callback.Query().Get("code")
+}
+
+func TestProvider(t *testing.T) {
+	idp := socialtest.NewServer()
+	t.Cleanup(idp.Close)
+
+	t.Run("should read the identity from the verified ID token", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewProvider(idp.OIDCConfig("test"), http.DefaultClient)
+		code := signIn(t, p, ada, "n0nce")
+
+		identity, err := p.Exchange(context.Background(), redirectURI, code, verifier, "n0nce")
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.SocialIdentity{
+			Provider: "test", Subject: "1001", Email: "ada@example.com", EmailVerified: true, Name: "Ada",
+		}, identity)
+	})
+
+	t.Run("should request the default scopes, nonce and PKCE", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewProvider(idp.OIDCConfig("test"), http.DefaultClient)
+
+		raw, err := p.AuthorizationURL(context.Background(), redirectURI, "st4te", "n0nce", "ch4llenge")
+
+		require.NoError(t, err)
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		assert.Equal(t, idp.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+		assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+		assert.Equal(t, "n0nce", u.Query().Get("nonce"))
+		assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	})
+
+	t.Run("should reject an ID token issued for another nonce", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewProvider(idp.OIDCConfig("test"), http.DefaultClient)
+		code := signIn(t, p, ada, "n0nce")
+
+		_, err := p.Exchange(context.Background(), redirectURI, code, verifier, "other")
+
+		assert.ErrorIs(t, err, domain.ErrSocialLoginFailed)
+	})
+
+	t.Run("should reject a discovery document of another issuer", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := idp.OIDCConfig("test")
+		cfg.Issuer += "/"
+		p := NewProvider(cfg, http.DefaultClient)
+
+		_, err := p.AuthorizationURL(context.Background(), redirectURI, "st4te", "n0nce", "ch4llenge")
+
+		assert.ErrorIs(t, err, domain.ErrSocialLoginFailed)
+	})
+
+	t.Run("should fail when the provider rejects the code verifier", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewProvider(idp.OIDCConfig("test"), http.DefaultClient)
+		code := signIn(t, p, ada, "n0nce")
+
+		_, err := p.Exchange(context.Background(), redirectURI, code, "wrong-verifier", "n0nce")
+
+		assert.ErrorIs(t, err, domain.ErrSocialLoginFailed)
+	})
+
+	t.Run("should read OAuth2 users with mapped claims and the primary email", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewProvider(idp.OAuth2Config("github"), http.DefaultClient)
+		code := signIn(t, p, ada, "")
+
+		identity, err := p.Exchange(context.Background(), redirectURI, code, verifier, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.SocialIdentity{
+			Provider: "github", Subject: "1001", Email: "ada@example.com", EmailVerified: true, Name: "Ada",
+			Picture: "https://avatars.example.com/1001",
+		}, identity)
+	})
+}
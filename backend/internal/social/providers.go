@@ -0,0 +1,117 @@
+// Package social signs users in with upstream OpenID Connect and OAuth2
+// identity providers.
+package social
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/samber/do"
+
+	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+var providerName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// NewProviders builds the providers of SOCIAL_PROVIDERS_FILE. Without the
+// file social login is disabled.
+func NewProviders(_ *do.Injector) (domain.SocialProviders, error) {
+	configs, err := LoadProviders(config.Env.Social.ProvidersFile)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	providers := make(domain.SocialProviders, 0, len(configs))
+	for _, cfg := range configs {
+		providers = append(providers, NewProvider(cfg, httpClient))
+	}
+	return providers, nil
+}
+
+// LoadProviders reads and validates the provider configurations of path,
+// expanding ${VAR} references so secrets can stay in the environment.
+func LoadProviders(path string) ([]domain.SocialProviderConfig, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read social providers: %w", err)
+	}
+
+	var configs []domain.SocialProviderConfig
+	decoder := json.NewDecoder(bytes.NewReader([]byte(os.ExpandEnv(string(raw)))))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&configs); err != nil {
+		return nil, fmt.Errorf("failed to parse social providers: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for i, cfg := range configs {
+		if err := validate(cfg); err != nil {
+			return nil, fmt.Errorf("invalid social provider %d: %w", i, err)
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("duplicated social provider %q", cfg.Name)
+		}
+		seen[cfg.Name] = true
+		if configs[i].DisplayName == "" {
+			configs[i].DisplayName = cfg.Name
+		}
+	}
+
+	return configs, nil
+}
+
+func validate(cfg domain.SocialProviderConfig) error {
+	if !providerName.MatchString(cfg.Name) {
+		return fmt.Errorf("name %q must be lowercase letters, digits and dashes", cfg.Name)
+	}
+	if cfg.ClientID == "" {
+		return fmt.Errorf("%s: client_id is required", cfg.Name)
+	}
+
+	urls := []string{cfg.Issuer}
+	if cfg.Issuer == "" {
+		if cfg.AuthorizationURL == "" || cfg.TokenURL == "" || cfg.UserInfoURL == "" {
+			return fmt.Errorf("%s: issuer or authorization_url, token_url and userinfo_url are required", cfg.Name)
+		}
+		urls = []string{cfg.AuthorizationURL, cfg.TokenURL, cfg.UserInfoURL}
+	}
+	if cfg.EmailsURL != "" {
+		urls = append(urls, cfg.EmailsURL)
+	}
+
+	for _, raw := range urls {
+		if !secureURL(raw) {
+			return fmt.Errorf("%s: %q must be an https URL", cfg.Name, raw)
+		}
+	}
+	return nil
+}
+
+// secureURL accepts https URLs, and http URLs on loopback for development.
+func secureURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		return host == "localhost" || host == "127.0.0.1" || host == "::1"
+	default:
+		return false
+	}
+}
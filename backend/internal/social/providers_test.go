@@ -0,0 +1,59 @@
+package social
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProviders(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "providers.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadProviders(t *testing.T) {
+	t.Run("should expand environment variables in the file", func(t *testing.T) {
+		t.Setenv("SOCIAL_TEST_SECRET", "from-env")
+		path := writeProviders(t, `[{"name":"google","issuer":"https://accounts.google.com","client_id":"id","client_secret":"${SOCIAL_TEST_SECRET}"}]`)
+
+		configs, err := LoadProviders(path)
+
+		require.NoError(t, err)
+		require.Len(t, configs, 1)
+		assert.Equal(t, "from-env", configs[0].ClientSecret)
+		assert.Equal(t, "google", configs[0].DisplayName)
+	})
+
+	t.Run("should disable social login without a file", func(t *testing.T) {
+		t.Parallel()
+
+		configs, err := LoadProviders("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, configs)
+	})
+
+	invalid := map[string]string{
+		"an invalid name":     `[{"name":"Google","issuer":"https://accounts.google.com","client_id":"id"}]`,
+		"a missing client id": `[{"name":"google","issuer":"https://accounts.google.com"}]`,
+		"missing endpoints":   `[{"name":"github","authorization_url":"https://github.com/login/oauth/authorize","client_id":"id"}]`,
+		"a plain http issuer": `[{"name":"google","issuer":"http://accounts.google.com","client_id":"id"}]`,
+		"an unknown field":    `[{"name":"google","issuer":"https://accounts.google.com","client_id":"id","secret":"x"}]`,
+		"a duplicated name": `[{"name":"google","issuer":"https://accounts.google.com","client_id":"id"},
+			{"name":"google","issuer":"https://accounts.google.com","client_id":"id"}]`,
+	}
+	for name, content := range invalid {
+		t.Run("should reject "+name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := LoadProviders(writeProviders(t, content))
+
+			assert.Error(t, err)
+		})
+	}
+}
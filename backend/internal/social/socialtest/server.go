@@ -0,0 +1,283 @@
+// Package socialtest provides a fake identity provider for tests of social
+// login.
+package socialtest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+)
+
+const (
+	ClientID     = "migos"
+	ClientSecret = "provider-secret"
+	keyID        = "socialtest"
+)
+
+// User is an account at the fake provider.
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type grant struct {
+	user          User
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// Server is an OpenID Connect provider that signs in whichever user the
+// authorization request names in its "user" parameter, standing in for the
+// login page. It also serves the user as a plain OAuth2 provider would, with
+// a numeric id and a separate list of emails.
+type Server struct {
+	*httptest.Server
+
+	key *rsa.PrivateKey
+
+	mu     sync.Mutex
+	codes  map[string]grant
+	tokens map[string]User
+}
+
+func NewServer() *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	s := &Server{key: key, codes: map[string]grant{}, tokens: map[string]User{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("GET /jwks", s.jwks)
+	mux.HandleFunc("GET /authorize", s.authorize)
+	mux.HandleFunc("POST /token", s.token)
+	mux.HandleFunc("GET /userinfo", s.userInfo)
+	mux.HandleFunc("GET /oauth2/user", s.oauth2User)
+	mux.HandleFunc("GET /oauth2/emails", s.oauth2Emails)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// OIDCConfig configures the server as an OpenID Connect provider.
+func (s *Server) OIDCConfig(name string) domain.SocialProviderConfig {
+	return domain.SocialProviderConfig{
+		Name:         name,
+		Issuer:       s.URL,
+		ClientID:     ClientID,
+		ClientSecret: ClientSecret,
+	}
+}
+
+// OAuth2Config configures the server as a plain OAuth2 provider.
+func (s *Server) OAuth2Config(name string) domain.SocialProviderConfig {
+	return domain.SocialProviderConfig{
+		Name:             name,
+		AuthorizationURL: s.URL + "/authorize",
+		TokenURL:         s.URL + "/token",
+		UserInfoURL:      s.URL + "/oauth2/user",
+		EmailsURL:        s.URL + "/oauth2/emails",
+		ClientID:         ClientID,
+		ClientSecret:     ClientSecret,
+		Claims:           domain.SocialClaims{Subject: "id", Picture: "avatar_url"},
+	}
+}
+
+// SignIn completes an authorization URL as user would at the provider,
+// returning the URL the provider sends the browser back to.
+func SignIn(authorizationURL string, user User) (string, error) {
+	u, err := url.Parse(authorizationURL)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	raw, err := json.Marshal(user)
+	if err != nil {
+		return "", err
+	}
+	query.Set("user", string(raw))
+	u.RawQuery = query.Encode()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() //nolint:errcheck // no body
+	return resp.Header.Get("Location"), nil
+}
+
+func (s *Server) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                 s.URL,
+		"authorization_endpoint": s.URL + "/authorize",
+		"token_endpoint":         s.URL + "/token",
+		"userinfo_endpoint":      s.URL + "/userinfo",
+		"jwks_uri":               s.URL + "/jwks",
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, domain.JWKS{Keys: []domain.JWK{{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: "RS256",
+		Kid: keyID,
+		N:   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+	}}})
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || query.Get("client_id") != ClientID || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	params := url.Values{"state": {query.Get("state")}}
+	var user User
+	if err := json.Unmarshal([]byte(query.Get("user")), &user); err != nil {
+		params.Set("error", "access_denied")
+	} else {
+		code := rand.Text()
+		s.mu.Lock()
+		s.codes[code] = grant{
+			user:          user,
+			redirectURI:   redirect.String(),
+			nonce:         query.Get("nonce"),
+			codeChallenge: query.Get("code_challenge"),
+		}
+		s.mu.Unlock()
+		params.Set("code", code)
+	}
+
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.PostFormValue("client_id") != ClientID || r.PostFormValue("client_secret") != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	code, ok := s.codes[r.PostFormValue("code")]
+	delete(s.codes, r.PostFormValue("code"))
+	s.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok || r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != code.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(sum[:]) != code.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	accessToken := rand.Text()
+	s.mu.Lock()
+	s.tokens[accessToken] = code.user
+	s.mu.Unlock()
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            s.URL,
+		"sub":            code.user.Subject,
+		"aud":            ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Minute).Unix(),
+		"nonce":          code.nonce,
+		"email":          code.user.Email,
+		"email_verified": code.user.EmailVerified,
+		"name":           code.user.Name,
+	})
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(s.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"id_token":     signed,
+	})
+}
+
+func (s *Server) userInfo(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.bearer(r)
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"sub":            user.Subject,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"name":           user.Name,
+	})
+}
+
+// oauth2User answers like GitHub's /user: a numeric id and no verified
+// email, which comes from oauth2Emails instead.
+func (s *Server) oauth2User(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.bearer(r)
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":         json.Number(user.Subject),
+		"name":       user.Name,
+		"email":      user.Email,
+		"avatar_url": "https://avatars.example.com/" + user.Subject,
+	})
+}
+
+func (s *Server) oauth2Emails(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.bearer(r)
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, []map[string]any{
+		{"email": "secondary-" + user.Email, "primary": false, "verified": true},
+		{"email": user.Email, "primary": true, "verified": user.EmailVerified},
+	})
+}
+
+func (s *Server) bearer(r *http.Request) (User, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return User{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.tokens[token]
+	return user, ok
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck,errchkjson // test server
+}
@@ -0,0 +1,250 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/SergioLNeves/migos/internal/domain"
+	"github.com/SergioLNeves/migos/internal/storage"
+)
+
+// Serializers of the string columns sealed by the domain.FieldCipher, set
+// with `gorm:"serializer:secret"` or `gorm:"serializer:pii"`.
+const (
+	// SerializerSecret seals the column whenever encryption keys are
+	// configured.
+	SerializerSecret = "secret"
+	// SerializerPII seals the column only with ENCRYPTION_PII.
+	SerializerPII = "pii"
+)
+
+// reencryptBatchSize is how many rows ReencryptColumns rewrites per
+// transaction.
+const reencryptBatchSize = 500
+
+// GORM keeps serializers in a global registry, so the cipher they use is
+// global too; newSQLite sets it. Until then values are stored as they are.
+var fieldCipher atomic.Pointer[cipherRef]
+
+type cipherRef struct {
+	domain.FieldCipher
+}
+
+func init() {
+	schema.RegisterSerializer(SerializerSecret, encryptedSerializer{})
+	schema.RegisterSerializer(SerializerPII, encryptedSerializer{pii: true})
+}
+
+// encryptedModels are the domain structs with sealed columns, by table.
+var encryptedModels = []struct {
+	table string
+	model any
+}{
+	{UserTable{}.TableName(), &domain.User{}},
+	{IdentityTable{}.TableName(), &domain.Identity{}},
+	{SocialLoginStateTable{}.TableName(), &domain.SocialLoginState{}},
+}
+
+type encryptedSerializer struct {
+	pii bool
+}
+
+func (s encryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("unsupported type %T for encrypted column %s", dbValue, field.DBName)
+	}
+
+	if ref := fieldCipher.Load(); ref != nil {
+		sealed, err := sealedField(ctx, field, dst)
+		if err != nil {
+			return err
+		}
+		opened, err := ref.Open(value, sealed)
+		if err != nil {
+			return err
+		}
+		value = opened
+	}
+	return field.Set(ctx, dst, value)
+}
+
+func (s encryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %T for encrypted column %s", fieldValue, field.DBName)
+	}
+
+	ref := fieldCipher.Load()
+	if ref == nil {
+		return value, nil
+	}
+	sealed, err := sealedField(ctx, field, dst)
+	if err != nil {
+		return nil, err
+	}
+	return ref.Seal(value, sealed, !s.pii || ref.EncryptPII())
+}
+
+// sealedField locates field of the row in dst. GORM scans columns in table
+// order, which puts the primary key before the sealed columns; a row read
+// or written without its primary key leaves Row empty and fails to seal or
+// open.
+func sealedField(ctx context.Context, field *schema.Field, dst reflect.Value) (domain.SealedField, error) {
+	table := ""
+	for _, m := range encryptedModels {
+		if reflect.TypeOf(m.model).Elem() == field.Schema.ModelType {
+			table = m.table
+		}
+	}
+	if table == "" {
+		return domain.SealedField{}, fmt.Errorf("encrypted column %s.%s is missing from encryptedModels", field.Schema.Name, field.DBName)
+	}
+
+	sealed := domain.SealedField{Table: table, Column: field.DBName}
+	for _, pk := range field.Schema.PrimaryFields {
+		value, zero := pk.ValueOf(ctx, dst)
+		if zero {
+			return domain.SealedField{Table: table, Column: field.DBName}, nil
+		}
+		if valuer, ok := value.(driver.Valuer); ok {
+			var err error
+			if value, err = valuer.Value(); err != nil {
+				return domain.SealedField{}, fmt.Errorf("failed to read the primary key of %s: %w", table, err)
+			}
+		}
+		sealed.Row = append(sealed.Row, fmt.Sprint(value))
+	}
+	return sealed, nil
+}
+
+// ReencryptColumns rewrites the sealed columns whose values don't match the
+// current configuration: sealed under another key or in the v1 envelope,
+// still in plaintext, or sealed PII after ENCRYPTION_PII was turned off.
+// Rows are matched by rowid, so tables with composite keys work too.
+func (s *SQLiteStorage) ReencryptColumns(ctx context.Context) ([]storage.ReencryptedColumn, error) {
+	var results []storage.ReencryptedColumn
+	for _, m := range encryptedModels {
+		stmt := &gorm.Statement{DB: s.db}
+		if err := stmt.Parse(m.model); err != nil {
+			return nil, fmt.Errorf("failed to parse model: %w", err)
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			serializer := strings.ToLower(field.TagSettings["SERIALIZER"])
+			if serializer != SerializerSecret && serializer != SerializerPII {
+				continue
+			}
+
+			target := ""
+			if serializer == SerializerSecret || s.cipher.EncryptPII() {
+				target = s.cipher.PrimaryKeyID()
+			}
+			primaryKey := make([]string, len(stmt.Schema.PrimaryFields))
+			for i, pk := range stmt.Schema.PrimaryFields {
+				primaryKey[i] = pk.DBName
+			}
+			rows, err := s.reencryptColumn(ctx, m.table, field.DBName, primaryKey, target)
+			if err != nil {
+				return results, fmt.Errorf("failed to re-encrypt %s.%s: %w", m.table, field.DBName, err)
+			}
+			results = append(results, storage.ReencryptedColumn{Column: m.table + "." + field.DBName, Rows: rows})
+		}
+	}
+	return results, nil
+}
+
+// reencryptColumn seals every value of column under the target key, or
+// opens it when target is "". primaryKey names the key columns the values
+// are bound to.
+func (s *SQLiteStorage) reencryptColumn(ctx context.Context, table, column string, primaryKey []string, target string) (int, error) {
+	rewritten := 0
+	var lastRowID int64
+	for {
+		batch, err := s.sealedValues(ctx, table, column, primaryKey, lastRowID)
+		if err != nil {
+			return rewritten, err
+		}
+		if len(batch) == 0 {
+			return rewritten, nil
+		}
+		lastRowID = batch[len(batch)-1].rowID
+
+		batchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		count := 0
+		err = s.db.WithContext(batchCtx).Transaction(func(tx *gorm.DB) error {
+			for _, r := range batch {
+				if s.cipher.Current(r.value, target) {
+					continue
+				}
+				field := domain.SealedField{Table: table, Column: column, Row: r.key}
+				plaintext, err := s.cipher.Open(r.value, field)
+				if err != nil {
+					return err
+				}
+				sealed, err := s.cipher.Seal(plaintext, field, target != "")
+				if err != nil {
+					return err
+				}
+				if err := tx.Table(table).Where("rowid = ?", r.rowID).Update(column, sealed).Error; err != nil {
+					return err
+				}
+				count++
+			}
+			return nil
+		})
+		cancel()
+		if err != nil {
+			return rewritten, err
+		}
+		rewritten += count
+	}
+}
+
+type sealedRow struct {
+	rowID int64
+	key   []string
+	value string
+}
+
+// sealedValues reads the next batch of column after rowid afterRowID, with
+// the primary key of each row.
+func (s *SQLiteStorage) sealedValues(ctx context.Context, table, column string, primaryKey []string, afterRowID int64) ([]sealedRow, error) {
+	rows, err := s.db.WithContext(ctx).Table(table).
+		Select(append([]string{"rowid", column}, primaryKey...)).
+		Where(column+" IS NOT NULL AND rowid > ?", afterRowID).
+		Order("rowid").Limit(reencryptBatchSize).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck // rows.Err reports read errors
+
+	var batch []sealedRow
+	for rows.Next() {
+		r := sealedRow{key: make([]string, len(primaryKey))}
+		dest := []any{&r.rowID, &r.value}
+		for i := range r.key {
+			dest = append(dest, &r.key[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		batch = append(batch, r)
+	}
+	return batch, rows.Err()
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SergioLNeves/migos/internal/pkg/metrics"
+)
+
+const metricsStartKey = "metrics:start"
+
+// metricsPlugin times every statement through GORM callbacks, so queries
+// issued by repositories via GetDB are measured alongside Storage methods.
+type metricsPlugin struct{}
+
+func (metricsPlugin) Name() string { return "metrics" }
+
+func (metricsPlugin) Initialize(db *gorm.DB) error {
+	callbacks := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", db.Callback().Create().Before("gorm:create").Register, db.Callback().Create().After("gorm:create").Register},
+		{"query", db.Callback().Query().Before("gorm:query").Register, db.Callback().Query().After("gorm:query").Register},
+		{"update", db.Callback().Update().Before("gorm:update").Register, db.Callback().Update().After("gorm:update").Register},
+		{"delete", db.Callback().Delete().Before("gorm:delete").Register, db.Callback().Delete().After("gorm:delete").Register},
+		{"row", db.Callback().Row().Before("gorm:row").Register, db.Callback().Row().After("gorm:row").Register},
+		{"raw", db.Callback().Raw().Before("gorm:raw").Register, db.Callback().Raw().After("gorm:raw").Register},
+	}
+
+	for _, cb := range callbacks {
+		if err := cb.before("metrics:before_"+cb.operation, startTimer); err != nil {
+			return err
+		}
+		if err := cb.after("metrics:after_"+cb.operation, observe(cb.operation)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func startTimer(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		metrics.StorageOperationDuration.
+			WithLabelValues(operation, db.Statement.Table).
+			Observe(time.Since(start).Seconds())
+	}
+}
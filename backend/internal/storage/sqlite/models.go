@@ -7,31 +7,184 @@ import (
 )
 
 type UserTable struct {
-	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
-	Name      string    `gorm:"not null"`
-	Email     string    `gorm:"uniqueIndex;not null"`
-	Password  string    `gorm:"not null"`
-	Avatar    string
-	DeletedAt *time.Time `gorm:"index"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name              string    `gorm:"not null"`
+	Email             string    `gorm:"uniqueIndex;not null"`
+	Password          string    `gorm:"not null"`
+	Avatar            string
+	PasswordExpiresAt *time.Time
+	PasswordChangedAt *time.Time
+	DeletedAt         *time.Time `gorm:"index"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (UserTable) TableName() string { return "user" }
 
-type SessionTable struct {
+type UserRoleTable struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Role      string    `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+func (UserRoleTable) TableName() string { return "user_role" }
+
+type PasswordHistoryTable struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
 	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Hash      string    `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+func (PasswordHistoryTable) TableName() string { return "password_history" }
+
+type PasswordChallengeTable struct {
+	ID        string    `gorm:"primary_key"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null"`
 	ExpiresAt time.Time `gorm:"not null;index"`
 	CreatedAt time.Time
-	UpdatedAt time.Time
+}
+
+func (PasswordChallengeTable) TableName() string { return "password_change_challenge" }
+
+type SessionTable struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primary_key"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ClientID         *uuid.UUID `gorm:"type:uuid"`
+	Scope            string
+	RefreshTokenHash string
+	ExpiresAt        time.Time `gorm:"not null;index"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 func (SessionTable) TableName() string { return "session" }
 
+type OAuthClientTable struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name         string    `gorm:"not null"`
+	SecretHash   string
+	RedirectURIs string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (OAuthClientTable) TableName() string { return "oauth_client" }
+
+type AuthorizationCodeTable struct {
+	ID            string    `gorm:"primary_key"`
+	ClientID      uuid.UUID `gorm:"type:uuid;not null"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null"`
+	RedirectURI   string    `gorm:"not null"`
+	Scope         string
+	Nonce         string
+	CodeChallenge string    `gorm:"not null"`
+	AuthTime      time.Time `gorm:"not null"`
+	ExpiresAt     time.Time `gorm:"not null;index"`
+	CreatedAt     time.Time
+}
+
+func (AuthorizationCodeTable) TableName() string { return "oauth_authorization_code" }
+
+type OAuthGrantTable struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ClientID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Scope     string    `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (OAuthGrantTable) TableName() string { return "oauth_grant" }
+
+type DeviceAuthorizationTable struct {
+	ID           string    `gorm:"primary_key"`
+	UserCode     string    `gorm:"not null;uniqueIndex"`
+	ClientID     uuid.UUID `gorm:"type:uuid;not null"`
+	Scope        string
+	Status       string     `gorm:"not null"`
+	UserID       *uuid.UUID `gorm:"type:uuid"`
+	ApprovedAt   *time.Time
+	Interval     int `gorm:"not null"`
+	LastPolledAt *time.Time
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+func (DeviceAuthorizationTable) TableName() string { return "oauth_device_authorization" }
+
+type IdentityTable struct {
+	Provider  string    `gorm:"primaryKey"`
+	Subject   string    `gorm:"primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Email     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (IdentityTable) TableName() string { return "identity" }
+
+type SocialLoginStateTable struct {
+	ID           string `gorm:"primary_key"`
+	Provider     string `gorm:"not null"`
+	Nonce        string `gorm:"not null"`
+	CodeVerifier string `gorm:"not null"`
+	RedirectURI  string `gorm:"not null"`
+	ReturnTo     string
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+func (SocialLoginStateTable) TableName() string { return "social_login_state" }
+
+type APIKeyTable struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_api_key_user_name"`
+	Name       string    `gorm:"not null;uniqueIndex:idx_api_key_user_name"`
+	Prefix     string    `gorm:"not null"`
+	KeyHash    string    `gorm:"not null;uniqueIndex"`
+	Scopes     string    `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+func (APIKeyTable) TableName() string { return "api_key" }
+
+type ServiceAccountTable struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name      string    `gorm:"not null"`
+	Scopes    string    `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (ServiceAccountTable) TableName() string { return "service_account" }
+
+type ServiceAccountSecretTable struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key"`
+	ServiceAccountID uuid.UUID `gorm:"type:uuid;not null;index"`
+	SecretHash       string    `gorm:"not null;uniqueIndex"`
+	LastUsedAt       *time.Time
+	CreatedAt        time.Time
+}
+
+func (ServiceAccountSecretTable) TableName() string { return "service_account_secret" }
+
 func GetModelsToMigrate() []any {
 	return []any{
 		&UserTable{},
 		&SessionTable{},
+		&UserRoleTable{},
+		&PasswordHistoryTable{},
+		&PasswordChallengeTable{},
+		&OAuthClientTable{},
+		&AuthorizationCodeTable{},
+		&OAuthGrantTable{},
+		&DeviceAuthorizationTable{},
+		&IdentityTable{},
+		&SocialLoginStateTable{},
+		&APIKeyTable{},
+		&ServiceAccountTable{},
+		&ServiceAccountSecretTable{},
 	}
 }
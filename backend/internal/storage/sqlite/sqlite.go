@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/SergioLNeves/migos/internal/config"
+	"github.com/SergioLNeves/migos/internal/domain"
 	"github.com/SergioLNeves/migos/internal/storage"
 	"github.com/samber/do"
 	"gorm.io/driver/sqlite"
@@ -24,20 +25,26 @@ type Config struct {
 }
 
 type SQLiteStorage struct {
-	db *gorm.DB
+	db     *gorm.DB
+	cipher domain.FieldCipher
 }
 
 func NewSQLite(i *do.Injector) (storage.Storage, error) {
+	cipher, err := do.Invoke[domain.FieldCipher](i)
+	if err != nil {
+		return nil, err
+	}
+
 	return newSQLite(&Config{
 		DBPath:      config.Env.SQL.DBPath,
 		Environment: config.Env.Env,
 		MaxConn:     config.Env.SQL.MaxConn,
 		MaxIdle:     config.Env.SQL.MaxIdle,
 		MaxLifeTime: config.Env.SQL.MaxLifeTime,
-	})
+	}, cipher)
 }
 
-func newSQLite(cfg *Config) (storage.Storage, error) {
+func newSQLite(cfg *Config, cipher domain.FieldCipher) (storage.Storage, error) {
 	dbDir := filepath.Dir(cfg.DBPath)
 	if err := os.MkdirAll(dbDir, 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create database directory: %w", err)
@@ -56,6 +63,14 @@ func newSQLite(cfg *Config) (storage.Storage, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if err := db.Use(metricsPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register metrics plugin: %w", err)
+	}
+
+	if err := db.Use(tracingPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get underlying SQL DB: %w", err)
@@ -69,13 +84,8 @@ func newSQLite(cfg *Config) (storage.Storage, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	sqliteDB := &SQLiteStorage{db: db}
-
-	if err := sqliteDB.AutoMigrate(GetModelsToMigrate()...); err != nil {
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
-	}
-
-	return sqliteDB, nil
+	fieldCipher.Store(&cipherRef{cipher})
+	return &SQLiteStorage{db: db, cipher: cipher}, nil
 }
 
 func (s *SQLiteStorage) Ping(ctx context.Context) error {
@@ -111,6 +121,46 @@ func (s *SQLiteStorage) AutoMigrate(models ...any) error {
 	return nil
 }
 
+// Migrate brings the schema up to date with the models in GetModelsToMigrate.
+func (s *SQLiteStorage) Migrate(ctx context.Context) error {
+	if err := s.db.WithContext(ctx).AutoMigrate(GetModelsToMigrate()...); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return nil
+}
+
+// PendingMigrations lists the tables and columns from GetModelsToMigrate
+// that are missing from the database, i.e. what Migrate would still create.
+func (s *SQLiteStorage) PendingMigrations(ctx context.Context) ([]string, error) {
+	db := s.db.WithContext(ctx)
+	migrator := db.Migrator()
+
+	var pending []string
+	for _, model := range GetModelsToMigrate() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model: %w", err)
+		}
+
+		table := stmt.Schema.Table
+		if !migrator.HasTable(model) {
+			pending = append(pending, "create table "+table)
+			continue
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !migrator.HasColumn(model, field.DBName) {
+				pending = append(pending, "add column "+table+"."+field.DBName)
+			}
+		}
+	}
+
+	return pending, nil
+}
+
 func (s *SQLiteStorage) Insert(ctx context.Context, table string, data any) error {
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Second)
 	defer cancel()
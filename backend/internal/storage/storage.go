@@ -4,6 +4,8 @@ import "context"
 
 type Storage interface {
 	Ping(ctx context.Context) error
+	Migrator
+	Reencrypter
 	Writer
 	Reader
 	Querier
@@ -23,3 +25,21 @@ type Querier interface {
 	FindByEmail(ctx context.Context, table, email string, dest any) error
 	FindByID(ctx context.Context, table string, id any, dest any) error
 }
+
+type Migrator interface {
+	Migrate(ctx context.Context) error
+	PendingMigrations(ctx context.Context) ([]string, error)
+}
+
+// ReencryptedColumn counts the rows rewritten in a sealed column, named
+// "table.column".
+type ReencryptedColumn struct {
+	Column string
+	Rows   int
+}
+
+type Reencrypter interface {
+	// ReencryptColumns brings every sealed column in line with the current
+	// encryption keys and settings.
+	ReencryptColumns(ctx context.Context) ([]ReencryptedColumn, error)
+}
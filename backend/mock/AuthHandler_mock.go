@@ -36,6 +36,57 @@ func (_m *MockAuthHandler) EXPECT() *MockAuthHandler_Expecter {
 	return &MockAuthHandler_Expecter{mock: &_m.Mock}
 }
 
+// ChangeExpiredPassword provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) ChangeExpiredPassword(c echo.Context) error {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeExpiredPassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthHandler_ChangeExpiredPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeExpiredPassword'
+type MockAuthHandler_ChangeExpiredPassword_Call struct {
+	*mock.Call
+}
+
+// ChangeExpiredPassword is a helper method to define mock.On call
+//   - c echo.Context
+func (_e *MockAuthHandler_Expecter) ChangeExpiredPassword(c interface{}) *MockAuthHandler_ChangeExpiredPassword_Call {
+	return &MockAuthHandler_ChangeExpiredPassword_Call{Call: _e.mock.On("ChangeExpiredPassword", c)}
+}
+
+func (_c *MockAuthHandler_ChangeExpiredPassword_Call) Run(run func(c echo.Context)) *MockAuthHandler_ChangeExpiredPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 echo.Context
+		if args[0] != nil {
+			arg0 = args[0].(echo.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_ChangeExpiredPassword_Call) Return(err error) *MockAuthHandler_ChangeExpiredPassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthHandler_ChangeExpiredPassword_Call) RunAndReturn(run func(c echo.Context) error) *MockAuthHandler_ChangeExpiredPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAccount provides a mock function for the type MockAuthHandler
 func (_mock *MockAuthHandler) CreateAccount(c echo.Context) error {
 	ret := _mock.Called(c)
@@ -138,6 +189,57 @@ func (_c *MockAuthHandler_DeleteUser_Call) RunAndReturn(run func(c echo.Context)
 	return _c
 }
 
+// ListSessions provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) ListSessions(c echo.Context) error {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthHandler_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type MockAuthHandler_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - c echo.Context
+func (_e *MockAuthHandler_Expecter) ListSessions(c interface{}) *MockAuthHandler_ListSessions_Call {
+	return &MockAuthHandler_ListSessions_Call{Call: _e.mock.On("ListSessions", c)}
+}
+
+func (_c *MockAuthHandler_ListSessions_Call) Run(run func(c echo.Context)) *MockAuthHandler_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 echo.Context
+		if args[0] != nil {
+			arg0 = args[0].(echo.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_ListSessions_Call) Return(err error) *MockAuthHandler_ListSessions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthHandler_ListSessions_Call) RunAndReturn(run func(c echo.Context) error) *MockAuthHandler_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function for the type MockAuthHandler
 func (_mock *MockAuthHandler) Login(c echo.Context) error {
 	ret := _mock.Called(c)
@@ -342,6 +444,108 @@ func (_c *MockAuthHandler_ReactivateAccount_Call) RunAndReturn(run func(c echo.C
 	return _c
 }
 
+// Refresh provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) Refresh(c echo.Context) error {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthHandler_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockAuthHandler_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - c echo.Context
+func (_e *MockAuthHandler_Expecter) Refresh(c interface{}) *MockAuthHandler_Refresh_Call {
+	return &MockAuthHandler_Refresh_Call{Call: _e.mock.On("Refresh", c)}
+}
+
+func (_c *MockAuthHandler_Refresh_Call) Run(run func(c echo.Context)) *MockAuthHandler_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 echo.Context
+		if args[0] != nil {
+			arg0 = args[0].(echo.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_Refresh_Call) Return(err error) *MockAuthHandler_Refresh_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthHandler_Refresh_Call) RunAndReturn(run func(c echo.Context) error) *MockAuthHandler_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function for the type MockAuthHandler
+func (_mock *MockAuthHandler) RevokeSession(c echo.Context) error {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthHandler_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockAuthHandler_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - c echo.Context
+func (_e *MockAuthHandler_Expecter) RevokeSession(c interface{}) *MockAuthHandler_RevokeSession_Call {
+	return &MockAuthHandler_RevokeSession_Call{Call: _e.mock.On("RevokeSession", c)}
+}
+
+func (_c *MockAuthHandler_RevokeSession_Call) Run(run func(c echo.Context)) *MockAuthHandler_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 echo.Context
+		if args[0] != nil {
+			arg0 = args[0].(echo.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthHandler_RevokeSession_Call) Return(err error) *MockAuthHandler_RevokeSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthHandler_RevokeSession_Call) RunAndReturn(run func(c echo.Context) error) *MockAuthHandler_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePassword provides a mock function for the type MockAuthHandler
 func (_mock *MockAuthHandler) UpdatePassword(c echo.Context) error {
 	ret := _mock.Called(c)
@@ -39,6 +39,126 @@ func (_m *MockAuthRepository) EXPECT() *MockAuthRepository_Expecter {
 	return &MockAuthRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddPasswordHistory provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) AddPasswordHistory(ctx context.Context, entry *domain.PasswordHistory, keep int) error {
+	ret := _mock.Called(ctx, entry, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPasswordHistory")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.PasswordHistory, int) error); ok {
+		r0 = returnFunc(ctx, entry, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthRepository_AddPasswordHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPasswordHistory'
+type MockAuthRepository_AddPasswordHistory_Call struct {
+	*mock.Call
+}
+
+// AddPasswordHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *domain.PasswordHistory
+//   - keep int
+func (_e *MockAuthRepository_Expecter) AddPasswordHistory(ctx interface{}, entry interface{}, keep interface{}) *MockAuthRepository_AddPasswordHistory_Call {
+	return &MockAuthRepository_AddPasswordHistory_Call{Call: _e.mock.On("AddPasswordHistory", ctx, entry, keep)}
+}
+
+func (_c *MockAuthRepository_AddPasswordHistory_Call) Run(run func(ctx context.Context, entry *domain.PasswordHistory, keep int)) *MockAuthRepository_AddPasswordHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.PasswordHistory
+		if args[1] != nil {
+			arg1 = args[1].(*domain.PasswordHistory)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_AddPasswordHistory_Call) Return(err error) *MockAuthRepository_AddPasswordHistory_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthRepository_AddPasswordHistory_Call) RunAndReturn(run func(ctx context.Context, entry *domain.PasswordHistory, keep int) error) *MockAuthRepository_AddPasswordHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePasswordChallenge provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) CreatePasswordChallenge(ctx context.Context, challenge *domain.PasswordChallenge) error {
+	ret := _mock.Called(ctx, challenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordChallenge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.PasswordChallenge) error); ok {
+		r0 = returnFunc(ctx, challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthRepository_CreatePasswordChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePasswordChallenge'
+type MockAuthRepository_CreatePasswordChallenge_Call struct {
+	*mock.Call
+}
+
+// CreatePasswordChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challenge *domain.PasswordChallenge
+func (_e *MockAuthRepository_Expecter) CreatePasswordChallenge(ctx interface{}, challenge interface{}) *MockAuthRepository_CreatePasswordChallenge_Call {
+	return &MockAuthRepository_CreatePasswordChallenge_Call{Call: _e.mock.On("CreatePasswordChallenge", ctx, challenge)}
+}
+
+func (_c *MockAuthRepository_CreatePasswordChallenge_Call) Run(run func(ctx context.Context, challenge *domain.PasswordChallenge)) *MockAuthRepository_CreatePasswordChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.PasswordChallenge
+		if args[1] != nil {
+			arg1 = args[1].(*domain.PasswordChallenge)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_CreatePasswordChallenge_Call) Return(err error) *MockAuthRepository_CreatePasswordChallenge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthRepository_CreatePasswordChallenge_Call) RunAndReturn(run func(ctx context.Context, challenge *domain.PasswordChallenge) error) *MockAuthRepository_CreatePasswordChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function for the type MockAuthRepository
 func (_mock *MockAuthRepository) CreateUser(ctx context.Context, user *domain.User) error {
 	ret := _mock.Called(ctx, user)
@@ -156,6 +276,123 @@ func (_c *MockAuthRepository_DeleteDeactivatedUsers_Call) RunAndReturn(run func(
 	return _c
 }
 
+// DeleteExpiredPasswordChallenges provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) DeleteExpiredPasswordChallenges(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredPasswordChallenges")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthRepository_DeleteExpiredPasswordChallenges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredPasswordChallenges'
+type MockAuthRepository_DeleteExpiredPasswordChallenges_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredPasswordChallenges is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuthRepository_Expecter) DeleteExpiredPasswordChallenges(ctx interface{}) *MockAuthRepository_DeleteExpiredPasswordChallenges_Call {
+	return &MockAuthRepository_DeleteExpiredPasswordChallenges_Call{Call: _e.mock.On("DeleteExpiredPasswordChallenges", ctx)}
+}
+
+func (_c *MockAuthRepository_DeleteExpiredPasswordChallenges_Call) Run(run func(ctx context.Context)) *MockAuthRepository_DeleteExpiredPasswordChallenges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_DeleteExpiredPasswordChallenges_Call) Return(n int64, err error) *MockAuthRepository_DeleteExpiredPasswordChallenges_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_DeleteExpiredPasswordChallenges_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockAuthRepository_DeleteExpiredPasswordChallenges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePasswordChallenge provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) DeletePasswordChallenge(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePasswordChallenge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthRepository_DeletePasswordChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePasswordChallenge'
+type MockAuthRepository_DeletePasswordChallenge_Call struct {
+	*mock.Call
+}
+
+// DeletePasswordChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAuthRepository_Expecter) DeletePasswordChallenge(ctx interface{}, id interface{}) *MockAuthRepository_DeletePasswordChallenge_Call {
+	return &MockAuthRepository_DeletePasswordChallenge_Call{Call: _e.mock.On("DeletePasswordChallenge", ctx, id)}
+}
+
+func (_c *MockAuthRepository_DeletePasswordChallenge_Call) Run(run func(ctx context.Context, id string)) *MockAuthRepository_DeletePasswordChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_DeletePasswordChallenge_Call) Return(err error) *MockAuthRepository_DeletePasswordChallenge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthRepository_DeletePasswordChallenge_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockAuthRepository_DeletePasswordChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteUser provides a mock function for the type MockAuthRepository
 func (_mock *MockAuthRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	ret := _mock.Called(ctx, id)
@@ -213,6 +450,142 @@ func (_c *MockAuthRepository_DeleteUser_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// FindPasswordChallenge provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) FindPasswordChallenge(ctx context.Context, id string) (*domain.PasswordChallenge, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPasswordChallenge")
+	}
+
+	var r0 *domain.PasswordChallenge
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.PasswordChallenge, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.PasswordChallenge); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PasswordChallenge)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthRepository_FindPasswordChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPasswordChallenge'
+type MockAuthRepository_FindPasswordChallenge_Call struct {
+	*mock.Call
+}
+
+// FindPasswordChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAuthRepository_Expecter) FindPasswordChallenge(ctx interface{}, id interface{}) *MockAuthRepository_FindPasswordChallenge_Call {
+	return &MockAuthRepository_FindPasswordChallenge_Call{Call: _e.mock.On("FindPasswordChallenge", ctx, id)}
+}
+
+func (_c *MockAuthRepository_FindPasswordChallenge_Call) Run(run func(ctx context.Context, id string)) *MockAuthRepository_FindPasswordChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_FindPasswordChallenge_Call) Return(passwordChallenge *domain.PasswordChallenge, err error) *MockAuthRepository_FindPasswordChallenge_Call {
+	_c.Call.Return(passwordChallenge, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_FindPasswordChallenge_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.PasswordChallenge, error)) *MockAuthRepository_FindPasswordChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRolesByUserID provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) FindRolesByUserID(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRolesByUserID")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]string, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []string); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthRepository_FindRolesByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRolesByUserID'
+type MockAuthRepository_FindRolesByUserID_Call struct {
+	*mock.Call
+}
+
+// FindRolesByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockAuthRepository_Expecter) FindRolesByUserID(ctx interface{}, userID interface{}) *MockAuthRepository_FindRolesByUserID_Call {
+	return &MockAuthRepository_FindRolesByUserID_Call{Call: _e.mock.On("FindRolesByUserID", ctx, userID)}
+}
+
+func (_c *MockAuthRepository_FindRolesByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockAuthRepository_FindRolesByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_FindRolesByUserID_Call) Return(ss []string, err error) *MockAuthRepository_FindRolesByUserID_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_FindRolesByUserID_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]string, error)) *MockAuthRepository_FindRolesByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindUserByEmail provides a mock function for the type MockAuthRepository
 func (_mock *MockAuthRepository) FindUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	ret := _mock.Called(ctx, email)
@@ -349,6 +722,275 @@ func (_c *MockAuthRepository_FindUserByID_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// GrantRole provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) GrantRole(ctx context.Context, userID uuid.UUID, role string) error {
+	ret := _mock.Called(ctx, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthRepository_GrantRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GrantRole'
+type MockAuthRepository_GrantRole_Call struct {
+	*mock.Call
+}
+
+// GrantRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - role string
+func (_e *MockAuthRepository_Expecter) GrantRole(ctx interface{}, userID interface{}, role interface{}) *MockAuthRepository_GrantRole_Call {
+	return &MockAuthRepository_GrantRole_Call{Call: _e.mock.On("GrantRole", ctx, userID, role)}
+}
+
+func (_c *MockAuthRepository_GrantRole_Call) Run(run func(ctx context.Context, userID uuid.UUID, role string)) *MockAuthRepository_GrantRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_GrantRole_Call) Return(err error) *MockAuthRepository_GrantRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthRepository_GrantRole_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, role string) error) *MockAuthRepository_GrantRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPasswordHistory provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) ListPasswordHistory(ctx context.Context, userID uuid.UUID, limit int) ([]domain.PasswordHistory, error) {
+	ret := _mock.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPasswordHistory")
+	}
+
+	var r0 []domain.PasswordHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) ([]domain.PasswordHistory, error)); ok {
+		return returnFunc(ctx, userID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) []domain.PasswordHistory); ok {
+		r0 = returnFunc(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PasswordHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = returnFunc(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthRepository_ListPasswordHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPasswordHistory'
+type MockAuthRepository_ListPasswordHistory_Call struct {
+	*mock.Call
+}
+
+// ListPasswordHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - limit int
+func (_e *MockAuthRepository_Expecter) ListPasswordHistory(ctx interface{}, userID interface{}, limit interface{}) *MockAuthRepository_ListPasswordHistory_Call {
+	return &MockAuthRepository_ListPasswordHistory_Call{Call: _e.mock.On("ListPasswordHistory", ctx, userID, limit)}
+}
+
+func (_c *MockAuthRepository_ListPasswordHistory_Call) Run(run func(ctx context.Context, userID uuid.UUID, limit int)) *MockAuthRepository_ListPasswordHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_ListPasswordHistory_Call) Return(passwordHistorys []domain.PasswordHistory, err error) *MockAuthRepository_ListPasswordHistory_Call {
+	_c.Call.Return(passwordHistorys, err)
+	return _c
+}
+
+func (_c *MockAuthRepository_ListPasswordHistory_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, limit int) ([]domain.PasswordHistory, error)) *MockAuthRepository_ListPasswordHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeRole provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role string) error {
+	ret := _mock.Called(ctx, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthRepository_RevokeRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeRole'
+type MockAuthRepository_RevokeRole_Call struct {
+	*mock.Call
+}
+
+// RevokeRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - role string
+func (_e *MockAuthRepository_Expecter) RevokeRole(ctx interface{}, userID interface{}, role interface{}) *MockAuthRepository_RevokeRole_Call {
+	return &MockAuthRepository_RevokeRole_Call{Call: _e.mock.On("RevokeRole", ctx, userID, role)}
+}
+
+func (_c *MockAuthRepository_RevokeRole_Call) Run(run func(ctx context.Context, userID uuid.UUID, role string)) *MockAuthRepository_RevokeRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_RevokeRole_Call) Return(err error) *MockAuthRepository_RevokeRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthRepository_RevokeRole_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, role string) error) *MockAuthRepository_RevokeRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePasswordHash provides a mock function for the type MockAuthRepository
+func (_mock *MockAuthRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash string, newHash string) error {
+	ret := _mock.Called(ctx, id, oldHash, newHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePasswordHash")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r0 = returnFunc(ctx, id, oldHash, newHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthRepository_UpdatePasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePasswordHash'
+type MockAuthRepository_UpdatePasswordHash_Call struct {
+	*mock.Call
+}
+
+// UpdatePasswordHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - oldHash string
+//   - newHash string
+func (_e *MockAuthRepository_Expecter) UpdatePasswordHash(ctx interface{}, id interface{}, oldHash interface{}, newHash interface{}) *MockAuthRepository_UpdatePasswordHash_Call {
+	return &MockAuthRepository_UpdatePasswordHash_Call{Call: _e.mock.On("UpdatePasswordHash", ctx, id, oldHash, newHash)}
+}
+
+func (_c *MockAuthRepository_UpdatePasswordHash_Call) Run(run func(ctx context.Context, id uuid.UUID, oldHash string, newHash string)) *MockAuthRepository_UpdatePasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthRepository_UpdatePasswordHash_Call) Return(err error) *MockAuthRepository_UpdatePasswordHash_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthRepository_UpdatePasswordHash_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, oldHash string, newHash string) error) *MockAuthRepository_UpdatePasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateUser provides a mock function for the type MockAuthRepository
 func (_mock *MockAuthRepository) UpdateUser(ctx context.Context, user *domain.User) error {
 	ret := _mock.Called(ctx, user)
@@ -38,6 +38,74 @@ func (_m *MockAuthService) EXPECT() *MockAuthService_Expecter {
 	return &MockAuthService_Expecter{mock: &_m.Mock}
 }
 
+// ChangeExpiredPassword provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) ChangeExpiredPassword(ctx context.Context, req domain.ChangeExpiredPasswordRequest) (*domain.AuthResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeExpiredPassword")
+	}
+
+	var r0 *domain.AuthResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ChangeExpiredPasswordRequest) (*domain.AuthResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ChangeExpiredPasswordRequest) *domain.AuthResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ChangeExpiredPasswordRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_ChangeExpiredPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeExpiredPassword'
+type MockAuthService_ChangeExpiredPassword_Call struct {
+	*mock.Call
+}
+
+// ChangeExpiredPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.ChangeExpiredPasswordRequest
+func (_e *MockAuthService_Expecter) ChangeExpiredPassword(ctx interface{}, req interface{}) *MockAuthService_ChangeExpiredPassword_Call {
+	return &MockAuthService_ChangeExpiredPassword_Call{Call: _e.mock.On("ChangeExpiredPassword", ctx, req)}
+}
+
+func (_c *MockAuthService_ChangeExpiredPassword_Call) Run(run func(ctx context.Context, req domain.ChangeExpiredPasswordRequest)) *MockAuthService_ChangeExpiredPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ChangeExpiredPasswordRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.ChangeExpiredPasswordRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ChangeExpiredPassword_Call) Return(authResponse *domain.AuthResponse, err error) *MockAuthService_ChangeExpiredPassword_Call {
+	_c.Call.Return(authResponse, err)
+	return _c
+}
+
+func (_c *MockAuthService_ChangeExpiredPassword_Call) RunAndReturn(run func(ctx context.Context, req domain.ChangeExpiredPasswordRequest) (*domain.AuthResponse, error)) *MockAuthService_ChangeExpiredPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAccount provides a mock function for the type MockAuthService
 func (_mock *MockAuthService) CreateAccount(ctx context.Context, req domain.CreateAccountRequest) (*domain.AuthResponse, error) {
 	ret := _mock.Called(ctx, req)
@@ -163,6 +231,80 @@ func (_c *MockAuthService_DeleteUser_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// ListSessions provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) ListSessions(ctx context.Context, userID string, currentSessionID string) ([]domain.SessionResponse, error) {
+	ret := _mock.Called(ctx, userID, currentSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []domain.SessionResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.SessionResponse, error)); ok {
+		return returnFunc(ctx, userID, currentSessionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.SessionResponse); ok {
+		r0 = returnFunc(ctx, userID, currentSessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SessionResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, currentSessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type MockAuthService_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - currentSessionID string
+func (_e *MockAuthService_Expecter) ListSessions(ctx interface{}, userID interface{}, currentSessionID interface{}) *MockAuthService_ListSessions_Call {
+	return &MockAuthService_ListSessions_Call{Call: _e.mock.On("ListSessions", ctx, userID, currentSessionID)}
+}
+
+func (_c *MockAuthService_ListSessions_Call) Run(run func(ctx context.Context, userID string, currentSessionID string)) *MockAuthService_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_ListSessions_Call) Return(sessionResponses []domain.SessionResponse, err error) *MockAuthService_ListSessions_Call {
+	_c.Call.Return(sessionResponses, err)
+	return _c
+}
+
+func (_c *MockAuthService_ListSessions_Call) RunAndReturn(run func(ctx context.Context, userID string, currentSessionID string) ([]domain.SessionResponse, error)) *MockAuthService_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Login provides a mock function for the type MockAuthService
 func (_mock *MockAuthService) Login(ctx context.Context, req domain.LoginRequest) (*domain.AuthResponse, error) {
 	ret := _mock.Called(ctx, req)
@@ -356,6 +498,137 @@ func (_c *MockAuthService_ReactivateAccount_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// Refresh provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) Refresh(ctx context.Context, refreshToken string) (*domain.AuthResponse, error) {
+	ret := _mock.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *domain.AuthResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.AuthResponse, error)); ok {
+		return returnFunc(ctx, refreshToken)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.AuthResponse); ok {
+		r0 = returnFunc(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuthResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthService_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockAuthService_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+func (_e *MockAuthService_Expecter) Refresh(ctx interface{}, refreshToken interface{}) *MockAuthService_Refresh_Call {
+	return &MockAuthService_Refresh_Call{Call: _e.mock.On("Refresh", ctx, refreshToken)}
+}
+
+func (_c *MockAuthService_Refresh_Call) Run(run func(ctx context.Context, refreshToken string)) *MockAuthService_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_Refresh_Call) Return(authResponse *domain.AuthResponse, err error) *MockAuthService_Refresh_Call {
+	_c.Call.Return(authResponse, err)
+	return _c
+}
+
+func (_c *MockAuthService_Refresh_Call) RunAndReturn(run func(ctx context.Context, refreshToken string) (*domain.AuthResponse, error)) *MockAuthService_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	ret := _mock.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuthService_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type MockAuthService_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+func (_e *MockAuthService_Expecter) RevokeSession(ctx interface{}, userID interface{}, sessionID interface{}) *MockAuthService_RevokeSession_Call {
+	return &MockAuthService_RevokeSession_Call{Call: _e.mock.On("RevokeSession", ctx, userID, sessionID)}
+}
+
+func (_c *MockAuthService_RevokeSession_Call) Run(run func(ctx context.Context, userID string, sessionID string)) *MockAuthService_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthService_RevokeSession_Call) Return(err error) *MockAuthService_RevokeSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuthService_RevokeSession_Call) RunAndReturn(run func(ctx context.Context, userID string, sessionID string) error) *MockAuthService_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePassword provides a mock function for the type MockAuthService
 func (_mock *MockAuthService) UpdatePassword(ctx context.Context, userID string, req domain.UpdatePasswordRequest) error {
 	ret := _mock.Called(ctx, userID, req)
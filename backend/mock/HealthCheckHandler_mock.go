@@ -36,12 +36,12 @@ func (_m *MockHealthCheckHandler) EXPECT() *MockHealthCheckHandler_Expecter {
 	return &MockHealthCheckHandler_Expecter{mock: &_m.Mock}
 }
 
-// Check provides a mock function for the type MockHealthCheckHandler
-func (_mock *MockHealthCheckHandler) Check(ctx echo.Context) error {
+// Live provides a mock function for the type MockHealthCheckHandler
+func (_mock *MockHealthCheckHandler) Live(ctx echo.Context) error {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Check")
+		panic("no return value specified for Live")
 	}
 
 	var r0 error
@@ -53,18 +53,18 @@ func (_mock *MockHealthCheckHandler) Check(ctx echo.Context) error {
 	return r0
 }
 
-// MockHealthCheckHandler_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
-type MockHealthCheckHandler_Check_Call struct {
+// MockHealthCheckHandler_Live_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Live'
+type MockHealthCheckHandler_Live_Call struct {
 	*mock.Call
 }
 
-// Check is a helper method to define mock.On call
+// Live is a helper method to define mock.On call
 //   - ctx echo.Context
-func (_e *MockHealthCheckHandler_Expecter) Check(ctx interface{}) *MockHealthCheckHandler_Check_Call {
-	return &MockHealthCheckHandler_Check_Call{Call: _e.mock.On("Check", ctx)}
+func (_e *MockHealthCheckHandler_Expecter) Live(ctx interface{}) *MockHealthCheckHandler_Live_Call {
+	return &MockHealthCheckHandler_Live_Call{Call: _e.mock.On("Live", ctx)}
 }
 
-func (_c *MockHealthCheckHandler_Check_Call) Run(run func(ctx echo.Context)) *MockHealthCheckHandler_Check_Call {
+func (_c *MockHealthCheckHandler_Live_Call) Run(run func(ctx echo.Context)) *MockHealthCheckHandler_Live_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 echo.Context
 		if args[0] != nil {
@@ -77,12 +77,63 @@ func (_c *MockHealthCheckHandler_Check_Call) Run(run func(ctx echo.Context)) *Mo
 	return _c
 }
 
-func (_c *MockHealthCheckHandler_Check_Call) Return(err error) *MockHealthCheckHandler_Check_Call {
+func (_c *MockHealthCheckHandler_Live_Call) Return(err error) *MockHealthCheckHandler_Live_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockHealthCheckHandler_Check_Call) RunAndReturn(run func(ctx echo.Context) error) *MockHealthCheckHandler_Check_Call {
+func (_c *MockHealthCheckHandler_Live_Call) RunAndReturn(run func(ctx echo.Context) error) *MockHealthCheckHandler_Live_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ready provides a mock function for the type MockHealthCheckHandler
+func (_mock *MockHealthCheckHandler) Ready(ctx echo.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockHealthCheckHandler_Ready_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ready'
+type MockHealthCheckHandler_Ready_Call struct {
+	*mock.Call
+}
+
+// Ready is a helper method to define mock.On call
+//   - ctx echo.Context
+func (_e *MockHealthCheckHandler_Expecter) Ready(ctx interface{}) *MockHealthCheckHandler_Ready_Call {
+	return &MockHealthCheckHandler_Ready_Call{Call: _e.mock.On("Ready", ctx)}
+}
+
+func (_c *MockHealthCheckHandler_Ready_Call) Run(run func(ctx echo.Context)) *MockHealthCheckHandler_Ready_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 echo.Context
+		if args[0] != nil {
+			arg0 = args[0].(echo.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthCheckHandler_Ready_Call) Return(err error) *MockHealthCheckHandler_Ready_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockHealthCheckHandler_Ready_Call) RunAndReturn(run func(ctx echo.Context) error) *MockHealthCheckHandler_Ready_Call {
 	_c.Call.Return(run)
 	return _c
 }
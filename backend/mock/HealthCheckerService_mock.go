@@ -5,6 +5,8 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/SergioLNeves/migos/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -36,57 +38,137 @@ func (_m *MockHealthCheckerService) EXPECT() *MockHealthCheckerService_Expecter
 	return &MockHealthCheckerService_Expecter{mock: &_m.Mock}
 }
 
-// Check provides a mock function for the type MockHealthCheckerService
-func (_mock *MockHealthCheckerService) Check() (domain.HealthCheck, []error) {
-	ret := _mock.Called()
+// Drain provides a mock function for the type MockHealthCheckerService
+func (_mock *MockHealthCheckerService) Drain() {
+	_mock.Called()
+	return
+}
+
+// MockHealthCheckerService_Drain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Drain'
+type MockHealthCheckerService_Drain_Call struct {
+	*mock.Call
+}
+
+// Drain is a helper method to define mock.On call
+func (_e *MockHealthCheckerService_Expecter) Drain() *MockHealthCheckerService_Drain_Call {
+	return &MockHealthCheckerService_Drain_Call{Call: _e.mock.On("Drain")}
+}
+
+func (_c *MockHealthCheckerService_Drain_Call) Run(run func()) *MockHealthCheckerService_Drain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockHealthCheckerService_Drain_Call) Return() *MockHealthCheckerService_Drain_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHealthCheckerService_Drain_Call) RunAndReturn(run func()) *MockHealthCheckerService_Drain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Live provides a mock function for the type MockHealthCheckerService
+func (_mock *MockHealthCheckerService) Live(ctx context.Context) domain.HealthCheck {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Check")
+		panic("no return value specified for Live")
 	}
 
 	var r0 domain.HealthCheck
-	var r1 []error
-	if returnFunc, ok := ret.Get(0).(func() (domain.HealthCheck, []error)); ok {
-		return returnFunc()
-	}
-	if returnFunc, ok := ret.Get(0).(func() domain.HealthCheck); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) domain.HealthCheck); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		r0 = ret.Get(0).(domain.HealthCheck)
 	}
-	if returnFunc, ok := ret.Get(1).(func() []error); ok {
-		r1 = returnFunc()
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]error)
+	return r0
+}
+
+// MockHealthCheckerService_Live_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Live'
+type MockHealthCheckerService_Live_Call struct {
+	*mock.Call
+}
+
+// Live is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHealthCheckerService_Expecter) Live(ctx interface{}) *MockHealthCheckerService_Live_Call {
+	return &MockHealthCheckerService_Live_Call{Call: _e.mock.On("Live", ctx)}
+}
+
+func (_c *MockHealthCheckerService_Live_Call) Run(run func(ctx context.Context)) *MockHealthCheckerService_Live_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
 		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthCheckerService_Live_Call) Return(healthCheck domain.HealthCheck) *MockHealthCheckerService_Live_Call {
+	_c.Call.Return(healthCheck)
+	return _c
+}
+
+func (_c *MockHealthCheckerService_Live_Call) RunAndReturn(run func(ctx context.Context) domain.HealthCheck) *MockHealthCheckerService_Live_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ready provides a mock function for the type MockHealthCheckerService
+func (_mock *MockHealthCheckerService) Ready(ctx context.Context) domain.HealthCheck {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 domain.HealthCheck
+	if returnFunc, ok := ret.Get(0).(func(context.Context) domain.HealthCheck); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.HealthCheck)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockHealthCheckerService_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
-type MockHealthCheckerService_Check_Call struct {
+// MockHealthCheckerService_Ready_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ready'
+type MockHealthCheckerService_Ready_Call struct {
 	*mock.Call
 }
 
-// Check is a helper method to define mock.On call
-func (_e *MockHealthCheckerService_Expecter) Check() *MockHealthCheckerService_Check_Call {
-	return &MockHealthCheckerService_Check_Call{Call: _e.mock.On("Check")}
+// Ready is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHealthCheckerService_Expecter) Ready(ctx interface{}) *MockHealthCheckerService_Ready_Call {
+	return &MockHealthCheckerService_Ready_Call{Call: _e.mock.On("Ready", ctx)}
 }
 
-func (_c *MockHealthCheckerService_Check_Call) Run(run func()) *MockHealthCheckerService_Check_Call {
+func (_c *MockHealthCheckerService_Ready_Call) Run(run func(ctx context.Context)) *MockHealthCheckerService_Ready_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
 
-func (_c *MockHealthCheckerService_Check_Call) Return(healthCheck domain.HealthCheck, errs []error) *MockHealthCheckerService_Check_Call {
-	_c.Call.Return(healthCheck, errs)
+func (_c *MockHealthCheckerService_Ready_Call) Return(healthCheck domain.HealthCheck) *MockHealthCheckerService_Ready_Call {
+	_c.Call.Return(healthCheck)
 	return _c
 }
 
-func (_c *MockHealthCheckerService_Check_Call) RunAndReturn(run func() (domain.HealthCheck, []error)) *MockHealthCheckerService_Check_Call {
+func (_c *MockHealthCheckerService_Ready_Call) RunAndReturn(run func(ctx context.Context) domain.HealthCheck) *MockHealthCheckerService_Ready_Call {
 	_c.Call.Return(run)
 	return _c
 }
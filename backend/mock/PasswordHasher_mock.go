@@ -5,6 +5,8 @@
 package mock
 
 import (
+	"context"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -36,16 +38,16 @@ func (_m *MockPasswordHasher) EXPECT() *MockPasswordHasher_Expecter {
 }
 
 // Check provides a mock function for the type MockPasswordHasher
-func (_mock *MockPasswordHasher) Check(password string, hash string) error {
-	ret := _mock.Called(password, hash)
+func (_mock *MockPasswordHasher) Check(ctx context.Context, password string, hash string) error {
+	ret := _mock.Called(ctx, password, hash)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Check")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = returnFunc(password, hash)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, password, hash)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -58,25 +60,31 @@ type MockPasswordHasher_Check_Call struct {
 }
 
 // Check is a helper method to define mock.On call
+//   - ctx context.Context
 //   - password string
 //   - hash string
-func (_e *MockPasswordHasher_Expecter) Check(password interface{}, hash interface{}) *MockPasswordHasher_Check_Call {
-	return &MockPasswordHasher_Check_Call{Call: _e.mock.On("Check", password, hash)}
+func (_e *MockPasswordHasher_Expecter) Check(ctx interface{}, password interface{}, hash interface{}) *MockPasswordHasher_Check_Call {
+	return &MockPasswordHasher_Check_Call{Call: _e.mock.On("Check", ctx, password, hash)}
 }
 
-func (_c *MockPasswordHasher_Check_Call) Run(run func(password string, hash string)) *MockPasswordHasher_Check_Call {
+func (_c *MockPasswordHasher_Check_Call) Run(run func(ctx context.Context, password string, hash string)) *MockPasswordHasher_Check_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -87,14 +95,14 @@ func (_c *MockPasswordHasher_Check_Call) Return(err error) *MockPasswordHasher_C
 	return _c
 }
 
-func (_c *MockPasswordHasher_Check_Call) RunAndReturn(run func(password string, hash string) error) *MockPasswordHasher_Check_Call {
+func (_c *MockPasswordHasher_Check_Call) RunAndReturn(run func(ctx context.Context, password string, hash string) error) *MockPasswordHasher_Check_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Hash provides a mock function for the type MockPasswordHasher
-func (_mock *MockPasswordHasher) Hash(password string) (string, error) {
-	ret := _mock.Called(password)
+func (_mock *MockPasswordHasher) Hash(ctx context.Context, password string) (string, error) {
+	ret := _mock.Called(ctx, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Hash")
@@ -102,16 +110,16 @@ func (_mock *MockPasswordHasher) Hash(password string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return returnFunc(password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, password)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
-		r0 = returnFunc(password)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, password)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(password)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -124,19 +132,25 @@ type MockPasswordHasher_Hash_Call struct {
 }
 
 // Hash is a helper method to define mock.On call
+//   - ctx context.Context
 //   - password string
-func (_e *MockPasswordHasher_Expecter) Hash(password interface{}) *MockPasswordHasher_Hash_Call {
-	return &MockPasswordHasher_Hash_Call{Call: _e.mock.On("Hash", password)}
+func (_e *MockPasswordHasher_Expecter) Hash(ctx interface{}, password interface{}) *MockPasswordHasher_Hash_Call {
+	return &MockPasswordHasher_Hash_Call{Call: _e.mock.On("Hash", ctx, password)}
 }
 
-func (_c *MockPasswordHasher_Hash_Call) Run(run func(password string)) *MockPasswordHasher_Hash_Call {
+func (_c *MockPasswordHasher_Hash_Call) Run(run func(ctx context.Context, password string)) *MockPasswordHasher_Hash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -147,7 +161,58 @@ func (_c *MockPasswordHasher_Hash_Call) Return(s string, err error) *MockPasswor
 	return _c
 }
 
-func (_c *MockPasswordHasher_Hash_Call) RunAndReturn(run func(password string) (string, error)) *MockPasswordHasher_Hash_Call {
+func (_c *MockPasswordHasher_Hash_Call) RunAndReturn(run func(ctx context.Context, password string) (string, error)) *MockPasswordHasher_Hash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NeedsRehash provides a mock function for the type MockPasswordHasher
+func (_mock *MockPasswordHasher) NeedsRehash(hash string) bool {
+	ret := _mock.Called(hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NeedsRehash")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = returnFunc(hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockPasswordHasher_NeedsRehash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NeedsRehash'
+type MockPasswordHasher_NeedsRehash_Call struct {
+	*mock.Call
+}
+
+// NeedsRehash is a helper method to define mock.On call
+//   - hash string
+func (_e *MockPasswordHasher_Expecter) NeedsRehash(hash interface{}) *MockPasswordHasher_NeedsRehash_Call {
+	return &MockPasswordHasher_NeedsRehash_Call{Call: _e.mock.On("NeedsRehash", hash)}
+}
+
+func (_c *MockPasswordHasher_NeedsRehash_Call) Run(run func(hash string)) *MockPasswordHasher_NeedsRehash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPasswordHasher_NeedsRehash_Call) Return(b bool) *MockPasswordHasher_NeedsRehash_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockPasswordHasher_NeedsRehash_Call) RunAndReturn(run func(hash string) bool) *MockPasswordHasher_NeedsRehash_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -226,20 +226,29 @@ func (_c *MockSessionRepository_DeleteSession_Call) RunAndReturn(run func(ctx co
 }
 
 // DeleteSessionsByUserID provides a mock function for the type MockSessionRepository
-func (_mock *MockSessionRepository) DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) error {
+func (_mock *MockSessionRepository) DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
 	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteSessionsByUserID")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
 		r0 = returnFunc(ctx, userID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockSessionRepository_DeleteSessionsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSessionsByUserID'
@@ -272,12 +281,12 @@ func (_c *MockSessionRepository_DeleteSessionsByUserID_Call) Run(run func(ctx co
 	return _c
 }
 
-func (_c *MockSessionRepository_DeleteSessionsByUserID_Call) Return(err error) *MockSessionRepository_DeleteSessionsByUserID_Call {
-	_c.Call.Return(err)
+func (_c *MockSessionRepository_DeleteSessionsByUserID_Call) Return(n int64, err error) *MockSessionRepository_DeleteSessionsByUserID_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockSessionRepository_DeleteSessionsByUserID_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockSessionRepository_DeleteSessionsByUserID_Call {
+func (_c *MockSessionRepository_DeleteSessionsByUserID_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (int64, error)) *MockSessionRepository_DeleteSessionsByUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -350,6 +359,149 @@ func (_c *MockSessionRepository_FindSessionByID_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// FindSessionsByUserID provides a mock function for the type MockSessionRepository
+func (_mock *MockSessionRepository) FindSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSessionsByUserID")
+	}
+
+	var r0 []domain.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Session, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Session); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionRepository_FindSessionsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSessionsByUserID'
+type MockSessionRepository_FindSessionsByUserID_Call struct {
+	*mock.Call
+}
+
+// FindSessionsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockSessionRepository_Expecter) FindSessionsByUserID(ctx interface{}, userID interface{}) *MockSessionRepository_FindSessionsByUserID_Call {
+	return &MockSessionRepository_FindSessionsByUserID_Call{Call: _e.mock.On("FindSessionsByUserID", ctx, userID)}
+}
+
+func (_c *MockSessionRepository_FindSessionsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSessionRepository_FindSessionsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_FindSessionsByUserID_Call) Return(sessions []domain.Session, err error) *MockSessionRepository_FindSessionsByUserID_Call {
+	_c.Call.Return(sessions, err)
+	return _c
+}
+
+func (_c *MockSessionRepository_FindSessionsByUserID_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]domain.Session, error)) *MockSessionRepository_FindSessionsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateRefreshToken provides a mock function for the type MockSessionRepository
+func (_mock *MockSessionRepository) RotateRefreshToken(ctx context.Context, sessionID uuid.UUID, current string, next string, expiresAt time.Time) error {
+	ret := _mock.Called(ctx, sessionID, current, next, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateRefreshToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, sessionID, current, next, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionRepository_RotateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateRefreshToken'
+type MockSessionRepository_RotateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// RotateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sessionID uuid.UUID
+//   - current string
+//   - next string
+//   - expiresAt time.Time
+func (_e *MockSessionRepository_Expecter) RotateRefreshToken(ctx interface{}, sessionID interface{}, current interface{}, next interface{}, expiresAt interface{}) *MockSessionRepository_RotateRefreshToken_Call {
+	return &MockSessionRepository_RotateRefreshToken_Call{Call: _e.mock.On("RotateRefreshToken", ctx, sessionID, current, next, expiresAt)}
+}
+
+func (_c *MockSessionRepository_RotateRefreshToken_Call) Run(run func(ctx context.Context, sessionID uuid.UUID, current string, next string, expiresAt time.Time)) *MockSessionRepository_RotateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_RotateRefreshToken_Call) Return(err error) *MockSessionRepository_RotateRefreshToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionRepository_RotateRefreshToken_Call) RunAndReturn(run func(ctx context.Context, sessionID uuid.UUID, current string, next string, expiresAt time.Time) error) *MockSessionRepository_RotateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateSessionExpiry provides a mock function for the type MockSessionRepository
 func (_mock *MockSessionRepository) UpdateSessionExpiry(ctx context.Context, sessionID uuid.UUID, expiresAt time.Time) error {
 	ret := _mock.Called(ctx, sessionID, expiresAt)
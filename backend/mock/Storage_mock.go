@@ -7,6 +7,7 @@ package mock
 import (
 	"context"
 
+	"github.com/SergioLNeves/migos/internal/storage"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -353,6 +354,119 @@ func (_c *MockStorage_Insert_Call) RunAndReturn(run func(ctx context.Context, ta
 	return _c
 }
 
+// Migrate provides a mock function for the type MockStorage
+func (_mock *MockStorage) Migrate(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Migrate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_Migrate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Migrate'
+type MockStorage_Migrate_Call struct {
+	*mock.Call
+}
+
+// Migrate is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) Migrate(ctx interface{}) *MockStorage_Migrate_Call {
+	return &MockStorage_Migrate_Call{Call: _e.mock.On("Migrate", ctx)}
+}
+
+func (_c *MockStorage_Migrate_Call) Run(run func(ctx context.Context)) *MockStorage_Migrate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Migrate_Call) Return(err error) *MockStorage_Migrate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_Migrate_Call) RunAndReturn(run func(ctx context.Context) error) *MockStorage_Migrate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PendingMigrations provides a mock function for the type MockStorage
+func (_mock *MockStorage) PendingMigrations(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingMigrations")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_PendingMigrations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PendingMigrations'
+type MockStorage_PendingMigrations_Call struct {
+	*mock.Call
+}
+
+// PendingMigrations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) PendingMigrations(ctx interface{}) *MockStorage_PendingMigrations_Call {
+	return &MockStorage_PendingMigrations_Call{Call: _e.mock.On("PendingMigrations", ctx)}
+}
+
+func (_c *MockStorage_PendingMigrations_Call) Run(run func(ctx context.Context)) *MockStorage_PendingMigrations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_PendingMigrations_Call) Return(ss []string, err error) *MockStorage_PendingMigrations_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockStorage_PendingMigrations_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockStorage_PendingMigrations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Ping provides a mock function for the type MockStorage
 func (_mock *MockStorage) Ping(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -404,6 +518,68 @@ func (_c *MockStorage_Ping_Call) RunAndReturn(run func(ctx context.Context) erro
 	return _c
 }
 
+// ReencryptColumns provides a mock function for the type MockStorage
+func (_mock *MockStorage) ReencryptColumns(ctx context.Context) ([]storage.ReencryptedColumn, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReencryptColumns")
+	}
+
+	var r0 []storage.ReencryptedColumn
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]storage.ReencryptedColumn, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []storage.ReencryptedColumn); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]storage.ReencryptedColumn)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_ReencryptColumns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReencryptColumns'
+type MockStorage_ReencryptColumns_Call struct {
+	*mock.Call
+}
+
+// ReencryptColumns is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) ReencryptColumns(ctx interface{}) *MockStorage_ReencryptColumns_Call {
+	return &MockStorage_ReencryptColumns_Call{Call: _e.mock.On("ReencryptColumns", ctx)}
+}
+
+func (_c *MockStorage_ReencryptColumns_Call) Run(run func(ctx context.Context)) *MockStorage_ReencryptColumns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ReencryptColumns_Call) Return(reencryptedColumns []storage.ReencryptedColumn, err error) *MockStorage_ReencryptColumns_Call {
+	_c.Call.Return(reencryptedColumns, err)
+	return _c
+}
+
+func (_c *MockStorage_ReencryptColumns_Call) RunAndReturn(run func(ctx context.Context) ([]storage.ReencryptedColumn, error)) *MockStorage_ReencryptColumns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type MockStorage
 func (_mock *MockStorage) Update(ctx context.Context, table string, data any) error {
 	ret := _mock.Called(ctx, table, data)
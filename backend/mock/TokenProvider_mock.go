@@ -5,6 +5,8 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/SergioLNeves/migos/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,8 +39,8 @@ func (_m *MockTokenProvider) EXPECT() *MockTokenProvider_Expecter {
 }
 
 // GenerateAccessToken provides a mock function for the type MockTokenProvider
-func (_mock *MockTokenProvider) GenerateAccessToken(sessionID string) (string, error) {
-	ret := _mock.Called(sessionID)
+func (_mock *MockTokenProvider) GenerateAccessToken(ctx context.Context, userID string, sessionID string) (string, error) {
+	ret := _mock.Called(ctx, userID, sessionID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateAccessToken")
@@ -46,16 +48,16 @@ func (_mock *MockTokenProvider) GenerateAccessToken(sessionID string) (string, e
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return returnFunc(sessionID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, userID, sessionID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
-		r0 = returnFunc(sessionID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, userID, sessionID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(sessionID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, sessionID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -68,19 +70,31 @@ type MockTokenProvider_GenerateAccessToken_Call struct {
 }
 
 // GenerateAccessToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
 //   - sessionID string
-func (_e *MockTokenProvider_Expecter) GenerateAccessToken(sessionID interface{}) *MockTokenProvider_GenerateAccessToken_Call {
-	return &MockTokenProvider_GenerateAccessToken_Call{Call: _e.mock.On("GenerateAccessToken", sessionID)}
+func (_e *MockTokenProvider_Expecter) GenerateAccessToken(ctx interface{}, userID interface{}, sessionID interface{}) *MockTokenProvider_GenerateAccessToken_Call {
+	return &MockTokenProvider_GenerateAccessToken_Call{Call: _e.mock.On("GenerateAccessToken", ctx, userID, sessionID)}
 }
 
-func (_c *MockTokenProvider_GenerateAccessToken_Call) Run(run func(sessionID string)) *MockTokenProvider_GenerateAccessToken_Call {
+func (_c *MockTokenProvider_GenerateAccessToken_Call) Run(run func(ctx context.Context, userID string, sessionID string)) *MockTokenProvider_GenerateAccessToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -91,14 +105,164 @@ func (_c *MockTokenProvider_GenerateAccessToken_Call) Return(s string, err error
 	return _c
 }
 
-func (_c *MockTokenProvider_GenerateAccessToken_Call) RunAndReturn(run func(sessionID string) (string, error)) *MockTokenProvider_GenerateAccessToken_Call {
+func (_c *MockTokenProvider_GenerateAccessToken_Call) RunAndReturn(run func(ctx context.Context, userID string, sessionID string) (string, error)) *MockTokenProvider_GenerateAccessToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateClientAccessToken provides a mock function for the type MockTokenProvider
+func (_mock *MockTokenProvider) GenerateClientAccessToken(ctx context.Context, userID string, sessionID string, clientID string, scope string) (string, error) {
+	ret := _mock.Called(ctx, userID, sessionID, clientID, scope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateClientAccessToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (string, error)); ok {
+		return returnFunc(ctx, userID, sessionID, clientID, scope)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) string); ok {
+		r0 = returnFunc(ctx, userID, sessionID, clientID, scope)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, sessionID, clientID, scope)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTokenProvider_GenerateClientAccessToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateClientAccessToken'
+type MockTokenProvider_GenerateClientAccessToken_Call struct {
+	*mock.Call
+}
+
+// GenerateClientAccessToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - sessionID string
+//   - clientID string
+//   - scope string
+func (_e *MockTokenProvider_Expecter) GenerateClientAccessToken(ctx interface{}, userID interface{}, sessionID interface{}, clientID interface{}, scope interface{}) *MockTokenProvider_GenerateClientAccessToken_Call {
+	return &MockTokenProvider_GenerateClientAccessToken_Call{Call: _e.mock.On("GenerateClientAccessToken", ctx, userID, sessionID, clientID, scope)}
+}
+
+func (_c *MockTokenProvider_GenerateClientAccessToken_Call) Run(run func(ctx context.Context, userID string, sessionID string, clientID string, scope string)) *MockTokenProvider_GenerateClientAccessToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTokenProvider_GenerateClientAccessToken_Call) Return(s string, err error) *MockTokenProvider_GenerateClientAccessToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockTokenProvider_GenerateClientAccessToken_Call) RunAndReturn(run func(ctx context.Context, userID string, sessionID string, clientID string, scope string) (string, error)) *MockTokenProvider_GenerateClientAccessToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateIDToken provides a mock function for the type MockTokenProvider
+func (_mock *MockTokenProvider) GenerateIDToken(ctx context.Context, claims domain.IDTokenClaims) (string, error) {
+	ret := _mock.Called(ctx, claims)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateIDToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.IDTokenClaims) (string, error)); ok {
+		return returnFunc(ctx, claims)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.IDTokenClaims) string); ok {
+		r0 = returnFunc(ctx, claims)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.IDTokenClaims) error); ok {
+		r1 = returnFunc(ctx, claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTokenProvider_GenerateIDToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateIDToken'
+type MockTokenProvider_GenerateIDToken_Call struct {
+	*mock.Call
+}
+
+// GenerateIDToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - claims domain.IDTokenClaims
+func (_e *MockTokenProvider_Expecter) GenerateIDToken(ctx interface{}, claims interface{}) *MockTokenProvider_GenerateIDToken_Call {
+	return &MockTokenProvider_GenerateIDToken_Call{Call: _e.mock.On("GenerateIDToken", ctx, claims)}
+}
+
+func (_c *MockTokenProvider_GenerateIDToken_Call) Run(run func(ctx context.Context, claims domain.IDTokenClaims)) *MockTokenProvider_GenerateIDToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.IDTokenClaims
+		if args[1] != nil {
+			arg1 = args[1].(domain.IDTokenClaims)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTokenProvider_GenerateIDToken_Call) Return(s string, err error) *MockTokenProvider_GenerateIDToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockTokenProvider_GenerateIDToken_Call) RunAndReturn(run func(ctx context.Context, claims domain.IDTokenClaims) (string, error)) *MockTokenProvider_GenerateIDToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GenerateRefreshToken provides a mock function for the type MockTokenProvider
-func (_mock *MockTokenProvider) GenerateRefreshToken(userID string, sessionID string) (string, error) {
-	ret := _mock.Called(userID, sessionID)
+func (_mock *MockTokenProvider) GenerateRefreshToken(ctx context.Context, userID string, sessionID string) (string, error) {
+	ret := _mock.Called(ctx, userID, sessionID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateRefreshToken")
@@ -106,16 +270,16 @@ func (_mock *MockTokenProvider) GenerateRefreshToken(userID string, sessionID st
 
 	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return returnFunc(userID, sessionID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, userID, sessionID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = returnFunc(userID, sessionID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, userID, sessionID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = returnFunc(userID, sessionID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, sessionID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -128,25 +292,31 @@ type MockTokenProvider_GenerateRefreshToken_Call struct {
 }
 
 // GenerateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
 //   - userID string
 //   - sessionID string
-func (_e *MockTokenProvider_Expecter) GenerateRefreshToken(userID interface{}, sessionID interface{}) *MockTokenProvider_GenerateRefreshToken_Call {
-	return &MockTokenProvider_GenerateRefreshToken_Call{Call: _e.mock.On("GenerateRefreshToken", userID, sessionID)}
+func (_e *MockTokenProvider_Expecter) GenerateRefreshToken(ctx interface{}, userID interface{}, sessionID interface{}) *MockTokenProvider_GenerateRefreshToken_Call {
+	return &MockTokenProvider_GenerateRefreshToken_Call{Call: _e.mock.On("GenerateRefreshToken", ctx, userID, sessionID)}
 }
 
-func (_c *MockTokenProvider_GenerateRefreshToken_Call) Run(run func(userID string, sessionID string)) *MockTokenProvider_GenerateRefreshToken_Call {
+func (_c *MockTokenProvider_GenerateRefreshToken_Call) Run(run func(ctx context.Context, userID string, sessionID string)) *MockTokenProvider_GenerateRefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -157,14 +327,130 @@ func (_c *MockTokenProvider_GenerateRefreshToken_Call) Return(s string, err erro
 	return _c
 }
 
-func (_c *MockTokenProvider_GenerateRefreshToken_Call) RunAndReturn(run func(userID string, sessionID string) (string, error)) *MockTokenProvider_GenerateRefreshToken_Call {
+func (_c *MockTokenProvider_GenerateRefreshToken_Call) RunAndReturn(run func(ctx context.Context, userID string, sessionID string) (string, error)) *MockTokenProvider_GenerateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateServiceAccountToken provides a mock function for the type MockTokenProvider
+func (_mock *MockTokenProvider) GenerateServiceAccountToken(ctx context.Context, serviceAccountID string, scopes []string) (string, error) {
+	ret := _mock.Called(ctx, serviceAccountID, scopes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateServiceAccountToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (string, error)); ok {
+		return returnFunc(ctx, serviceAccountID, scopes)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) string); ok {
+		r0 = returnFunc(ctx, serviceAccountID, scopes)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, serviceAccountID, scopes)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTokenProvider_GenerateServiceAccountToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateServiceAccountToken'
+type MockTokenProvider_GenerateServiceAccountToken_Call struct {
+	*mock.Call
+}
+
+// GenerateServiceAccountToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceAccountID string
+//   - scopes []string
+func (_e *MockTokenProvider_Expecter) GenerateServiceAccountToken(ctx interface{}, serviceAccountID interface{}, scopes interface{}) *MockTokenProvider_GenerateServiceAccountToken_Call {
+	return &MockTokenProvider_GenerateServiceAccountToken_Call{Call: _e.mock.On("GenerateServiceAccountToken", ctx, serviceAccountID, scopes)}
+}
+
+func (_c *MockTokenProvider_GenerateServiceAccountToken_Call) Run(run func(ctx context.Context, serviceAccountID string, scopes []string)) *MockTokenProvider_GenerateServiceAccountToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTokenProvider_GenerateServiceAccountToken_Call) Return(s string, err error) *MockTokenProvider_GenerateServiceAccountToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockTokenProvider_GenerateServiceAccountToken_Call) RunAndReturn(run func(ctx context.Context, serviceAccountID string, scopes []string) (string, error)) *MockTokenProvider_GenerateServiceAccountToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JWKS provides a mock function for the type MockTokenProvider
+func (_mock *MockTokenProvider) JWKS() domain.JWKS {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 domain.JWKS
+	if returnFunc, ok := ret.Get(0).(func() domain.JWKS); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(domain.JWKS)
+	}
+	return r0
+}
+
+// MockTokenProvider_JWKS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JWKS'
+type MockTokenProvider_JWKS_Call struct {
+	*mock.Call
+}
+
+// JWKS is a helper method to define mock.On call
+func (_e *MockTokenProvider_Expecter) JWKS() *MockTokenProvider_JWKS_Call {
+	return &MockTokenProvider_JWKS_Call{Call: _e.mock.On("JWKS")}
+}
+
+func (_c *MockTokenProvider_JWKS_Call) Run(run func()) *MockTokenProvider_JWKS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTokenProvider_JWKS_Call) Return(jwks domain.JWKS) *MockTokenProvider_JWKS_Call {
+	_c.Call.Return(jwks)
+	return _c
+}
+
+func (_c *MockTokenProvider_JWKS_Call) RunAndReturn(run func() domain.JWKS) *MockTokenProvider_JWKS_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ParseAccessToken provides a mock function for the type MockTokenProvider
-func (_mock *MockTokenProvider) ParseAccessToken(tokenString string) (*domain.AccessTokenClaims, error) {
-	ret := _mock.Called(tokenString)
+func (_mock *MockTokenProvider) ParseAccessToken(ctx context.Context, tokenString string) (*domain.AccessTokenClaims, error) {
+	ret := _mock.Called(ctx, tokenString)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ParseAccessToken")
@@ -172,18 +458,18 @@ func (_mock *MockTokenProvider) ParseAccessToken(tokenString string) (*domain.Ac
 
 	var r0 *domain.AccessTokenClaims
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*domain.AccessTokenClaims, error)); ok {
-		return returnFunc(tokenString)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.AccessTokenClaims, error)); ok {
+		return returnFunc(ctx, tokenString)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *domain.AccessTokenClaims); ok {
-		r0 = returnFunc(tokenString)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.AccessTokenClaims); ok {
+		r0 = returnFunc(ctx, tokenString)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.AccessTokenClaims)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(tokenString)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenString)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -196,19 +482,25 @@ type MockTokenProvider_ParseAccessToken_Call struct {
 }
 
 // ParseAccessToken is a helper method to define mock.On call
+//   - ctx context.Context
 //   - tokenString string
-func (_e *MockTokenProvider_Expecter) ParseAccessToken(tokenString interface{}) *MockTokenProvider_ParseAccessToken_Call {
-	return &MockTokenProvider_ParseAccessToken_Call{Call: _e.mock.On("ParseAccessToken", tokenString)}
+func (_e *MockTokenProvider_Expecter) ParseAccessToken(ctx interface{}, tokenString interface{}) *MockTokenProvider_ParseAccessToken_Call {
+	return &MockTokenProvider_ParseAccessToken_Call{Call: _e.mock.On("ParseAccessToken", ctx, tokenString)}
 }
 
-func (_c *MockTokenProvider_ParseAccessToken_Call) Run(run func(tokenString string)) *MockTokenProvider_ParseAccessToken_Call {
+func (_c *MockTokenProvider_ParseAccessToken_Call) Run(run func(ctx context.Context, tokenString string)) *MockTokenProvider_ParseAccessToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -219,14 +511,14 @@ func (_c *MockTokenProvider_ParseAccessToken_Call) Return(accessTokenClaims *dom
 	return _c
 }
 
-func (_c *MockTokenProvider_ParseAccessToken_Call) RunAndReturn(run func(tokenString string) (*domain.AccessTokenClaims, error)) *MockTokenProvider_ParseAccessToken_Call {
+func (_c *MockTokenProvider_ParseAccessToken_Call) RunAndReturn(run func(ctx context.Context, tokenString string) (*domain.AccessTokenClaims, error)) *MockTokenProvider_ParseAccessToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ParseRefreshToken provides a mock function for the type MockTokenProvider
-func (_mock *MockTokenProvider) ParseRefreshToken(tokenString string) (*domain.RefreshTokenClaims, error) {
-	ret := _mock.Called(tokenString)
+func (_mock *MockTokenProvider) ParseRefreshToken(ctx context.Context, tokenString string) (*domain.RefreshTokenClaims, error) {
+	ret := _mock.Called(ctx, tokenString)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ParseRefreshToken")
@@ -234,18 +526,18 @@ func (_mock *MockTokenProvider) ParseRefreshToken(tokenString string) (*domain.R
 
 	var r0 *domain.RefreshTokenClaims
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*domain.RefreshTokenClaims, error)); ok {
-		return returnFunc(tokenString)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.RefreshTokenClaims, error)); ok {
+		return returnFunc(ctx, tokenString)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *domain.RefreshTokenClaims); ok {
-		r0 = returnFunc(tokenString)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.RefreshTokenClaims); ok {
+		r0 = returnFunc(ctx, tokenString)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.RefreshTokenClaims)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(tokenString)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenString)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -258,19 +550,25 @@ type MockTokenProvider_ParseRefreshToken_Call struct {
 }
 
 // ParseRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
 //   - tokenString string
-func (_e *MockTokenProvider_Expecter) ParseRefreshToken(tokenString interface{}) *MockTokenProvider_ParseRefreshToken_Call {
-	return &MockTokenProvider_ParseRefreshToken_Call{Call: _e.mock.On("ParseRefreshToken", tokenString)}
+func (_e *MockTokenProvider_Expecter) ParseRefreshToken(ctx interface{}, tokenString interface{}) *MockTokenProvider_ParseRefreshToken_Call {
+	return &MockTokenProvider_ParseRefreshToken_Call{Call: _e.mock.On("ParseRefreshToken", ctx, tokenString)}
 }
 
-func (_c *MockTokenProvider_ParseRefreshToken_Call) Run(run func(tokenString string)) *MockTokenProvider_ParseRefreshToken_Call {
+func (_c *MockTokenProvider_ParseRefreshToken_Call) Run(run func(ctx context.Context, tokenString string)) *MockTokenProvider_ParseRefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -281,7 +579,7 @@ func (_c *MockTokenProvider_ParseRefreshToken_Call) Return(refreshTokenClaims *d
 	return _c
 }
 
-func (_c *MockTokenProvider_ParseRefreshToken_Call) RunAndReturn(run func(tokenString string) (*domain.RefreshTokenClaims, error)) *MockTokenProvider_ParseRefreshToken_Call {
+func (_c *MockTokenProvider_ParseRefreshToken_Call) RunAndReturn(run func(ctx context.Context, tokenString string) (*domain.RefreshTokenClaims, error)) *MockTokenProvider_ParseRefreshToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
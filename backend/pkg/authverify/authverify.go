@@ -0,0 +1,186 @@
+// Package authverify verifies migos access tokens in other services.
+//
+// Tokens are checked offline against the keys published at
+// /.well-known/jwks.json, which are cached and refetched when a token names
+// an unknown key. Services that must see a logout or revocation immediately
+// can additionally ask the introspection endpoint about every token.
+//
+//	verifier, err := authverify.New(authverify.Config{
+//		JWKSURL:  "https://auth.example.com/.well-known/jwks.json",
+//		Issuer:   "https://auth.example.com",
+//		Audience: "orders-api",
+//	})
+//	mux.Handle("/orders", verifier.Middleware(ordersHandler))
+package authverify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/SergioLNeves/migos/pkg/jwks"
+)
+
+var (
+	ErrMissingToken = errors.New("authverify: missing bearer token")
+	ErrInvalidToken = errors.New("authverify: invalid token")
+	ErrTokenRevoked = errors.New("authverify: token is no longer active")
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+)
+
+// Config configures a Verifier. JWKSURL, Issuer and Audience are required
+// and must match TOKEN_ISSUER and TOKEN_AUDIENCE of the migos deployment.
+type Config struct {
+	JWKSURL  string
+	Issuer   string
+	Audience string
+
+	// HTTPClient fetches the JWKS and calls the introspection endpoint.
+	// Defaults to a client with a 10s timeout.
+	HTTPClient *http.Client
+	// CacheTTL is how long fetched keys are used before being refetched.
+	// Defaults to one hour.
+	CacheTTL time.Duration
+	// MinRefreshInterval limits refetches triggered by unknown key IDs, so
+	// forged tokens cannot make every request hit the JWKS endpoint.
+	// Defaults to one minute.
+	MinRefreshInterval time.Duration
+	// Leeway tolerates clock skew when checking exp, iat and nbf.
+	Leeway time.Duration
+
+	// Introspection, when set, is called for every token that passes the
+	// offline checks.
+	Introspection *IntrospectionConfig
+
+	// CookieName, when set, makes the middleware fall back to this cookie
+	// when the request has no Authorization header.
+	CookieName string
+}
+
+// Claims are the verified claims of an access token. Tokens of users carry
+// UserID and SessionID; tokens issued to service accounts by the
+// client_credentials grant carry ClientID and Scopes instead. Tokens issued
+// to OpenID Connect clients for their users carry both, and are only
+// accepted with the client as the Audience.
+type Claims struct {
+	UserID    string
+	SessionID string
+	ClientID  string
+	Scopes    []string
+	Issuer    string
+	Audience  []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// HasScope reports whether the token was granted scope.
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+type accessClaims struct {
+	jwt.RegisteredClaims
+	SessionID string `json:"session_id"`
+	ClientID  string `json:"client_id"`
+	Scope     string `json:"scope"`
+}
+
+// Verifier verifies access tokens. It is safe for concurrent use.
+type Verifier struct {
+	cfg           Config
+	keys          *jwks.KeySet
+	parser        *jwt.Parser
+	introspection *introspector
+}
+
+// New returns a Verifier. Keys are fetched on the first verification, so
+// New does not fail when the auth service is briefly unavailable.
+func New(cfg Config) (*Verifier, error) {
+	if cfg.JWKSURL == "" || cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, fmt.Errorf("authverify: JWKSURL, Issuer and Audience are required")
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	v := &Verifier{
+		cfg: cfg,
+		keys: jwks.New(cfg.JWKSURL, jwks.Config{
+			HTTPClient:         cfg.HTTPClient,
+			CacheTTL:           cfg.CacheTTL,
+			MinRefreshInterval: cfg.MinRefreshInterval,
+			Timeout:            defaultTimeout,
+		}),
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithIssuedAt(),
+			jwt.WithLeeway(cfg.Leeway),
+		),
+	}
+	if cfg.Introspection != nil {
+		if cfg.Introspection.URL == "" {
+			return nil, fmt.Errorf("authverify: Introspection.URL is required")
+		}
+		v.introspection = &introspector{cfg: *cfg.Introspection, httpClient: cfg.HTTPClient}
+	}
+
+	return v, nil
+}
+
+// Verify checks the signature, issuer, audience and lifetime of token and,
+// when configured, asks the introspection endpoint whether it is still
+// active. Rejected tokens return an error wrapping ErrInvalidToken or
+// ErrTokenRevoked; other errors mean the check could not be made.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	var claims accessClaims
+	_, err := v.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		var fetchErr *jwks.FetchError
+		if errors.As(err, &fetchErr) {
+			return nil, fmt.Errorf("authverify: %w", fetchErr)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" || (claims.SessionID == "" && claims.ClientID == "") {
+		return nil, fmt.Errorf("%w: missing sub, session_id or client_id", ErrInvalidToken)
+	}
+
+	if v.introspection != nil {
+		if err := v.introspection.check(ctx, token); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &Claims{
+		Issuer:    claims.Issuer,
+		Audience:  claims.Audience,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+	if claims.ClientID != "" {
+		result.ClientID = claims.ClientID
+		result.Scopes = strings.Fields(claims.Scope)
+	}
+	if claims.SessionID != "" {
+		result.UserID = claims.Subject
+		result.SessionID = claims.SessionID
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
+	}
+	return result, nil
+}
@@ -0,0 +1,462 @@
+package authverify
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SergioLNeves/migos/pkg/jwks"
+)
+
+const (
+	issuer   = "https://auth.test"
+	audience = "orders-api"
+)
+
+// testIssuer serves a JWKS and signs tokens the way migos does.
+type testIssuer struct {
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	fetches atomic.Int32
+	down    atomic.Bool
+	delay   atomic.Int64
+	server  *httptest.Server
+}
+
+func newIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	iss := &testIssuer{keys: map[string]*rsa.PrivateKey{}}
+	iss.addKey(t, "key-1")
+	iss.server = httptest.NewServer(http.HandlerFunc(iss.serveJWKS))
+	t.Cleanup(iss.server.Close)
+	return iss
+}
+
+func (iss *testIssuer) addKey(t *testing.T, kid string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	iss.mu.Lock()
+	iss.keys[kid] = key
+	iss.mu.Unlock()
+}
+
+func (iss *testIssuer) serveJWKS(w http.ResponseWriter, _ *http.Request) {
+	iss.fetches.Add(1)
+	time.Sleep(time.Duration(iss.delay.Load()))
+	if iss.down.Load() {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+	set := struct {
+		Keys []jwks.JWK `json:"keys"`
+	}{}
+	for kid, key := range iss.keys {
+		set.Keys = append(set.Keys, jwks.JWK{
+			Kty: "RSA", Use: "sig", Kid: kid,
+			N: base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	_ = json.NewEncoder(w).Encode(set)
+}
+
+func (iss *testIssuer) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	iss.mu.Lock()
+	key := iss.keys[kid]
+	iss.mu.Unlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func accessClaimsFor(userID string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"sub":        userID,
+		"session_id": "session-1",
+		"iss":        issuer,
+		"aud":        audience,
+		"iat":        now.Unix(),
+		"exp":        now.Add(time.Hour).Unix(),
+	}
+}
+
+func (iss *testIssuer) verifier(t *testing.T, mutate ...func(*Config)) *Verifier {
+	t.Helper()
+	cfg := Config{JWKSURL: iss.server.URL, Issuer: issuer, Audience: audience}
+	for _, fn := range mutate {
+		fn(&cfg)
+	}
+	v, err := New(cfg)
+	require.NoError(t, err)
+	return v
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("should return the claims of a valid token", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		v := iss.verifier(t)
+
+		claims, err := v.Verify(context.Background(), iss.sign(t, "key-1", accessClaimsFor("user-1")))
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.UserID)
+		assert.Equal(t, "session-1", claims.SessionID)
+		assert.Equal(t, issuer, claims.Issuer)
+		assert.Equal(t, []string{audience}, claims.Audience)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt, time.Minute)
+	})
+
+	t.Run("should return the client and scopes of a service account token", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		v := iss.verifier(t)
+		token := accessClaimsFor("client-1")
+		delete(token, "session_id")
+		token["client_id"] = "client-1"
+		token["scope"] = "orders:read orders:write"
+
+		claims, err := v.Verify(context.Background(), iss.sign(t, "key-1", token))
+
+		require.NoError(t, err)
+		assert.Equal(t, "client-1", claims.ClientID)
+		assert.Empty(t, claims.UserID)
+		assert.Equal(t, []string{"orders:read", "orders:write"}, claims.Scopes)
+		assert.True(t, claims.HasScope("orders:write"))
+		assert.False(t, claims.HasScope("admin"))
+	})
+
+	t.Run("should reject tokens that fail the claim checks", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		v := iss.verifier(t)
+
+		cases := map[string]func(jwt.MapClaims){
+			"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://other.test" },
+			"wrong audience": func(c jwt.MapClaims) { c["aud"] = "billing-api" },
+			"no audience":    func(c jwt.MapClaims) { delete(c, "aud") },
+			"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+			"no expiry":      func(c jwt.MapClaims) { delete(c, "exp") },
+			"no session":     func(c jwt.MapClaims) { delete(c, "session_id") },
+		}
+		for name, mutate := range cases {
+			claims := accessClaimsFor("user-1")
+			mutate(claims)
+
+			_, err := v.Verify(context.Background(), iss.sign(t, "key-1", claims))
+
+			assert.ErrorIs(t, err, ErrInvalidToken, name)
+		}
+	})
+
+	t.Run("should reject tokens not signed with RS256", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		v := iss.verifier(t)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaimsFor("user-1")).SignedString([]byte("secret"))
+		require.NoError(t, err)
+
+		_, err = v.Verify(context.Background(), token)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("should cache the keys between verifications", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		v := iss.verifier(t)
+		token := iss.sign(t, "key-1", accessClaimsFor("user-1"))
+
+		for range 3 {
+			_, err := v.Verify(context.Background(), token)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(1), iss.fetches.Load())
+	})
+
+	t.Run("should refetch the keys when a token names an unknown key", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		v := iss.verifier(t, func(c *Config) { c.MinRefreshInterval = time.Nanosecond })
+		_, err := v.Verify(context.Background(), iss.sign(t, "key-1", accessClaimsFor("user-1")))
+		require.NoError(t, err)
+
+		iss.addKey(t, "key-2")
+		claims, err := v.Verify(context.Background(), iss.sign(t, "key-2", accessClaimsFor("user-2")))
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-2", claims.UserID)
+		assert.Equal(t, int32(2), iss.fetches.Load())
+	})
+
+	t.Run("should rate limit refetches for unknown keys", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		v := iss.verifier(t)
+		iss.addKey(t, "key-2")
+		_, err := v.Verify(context.Background(), iss.sign(t, "key-1", accessClaimsFor("user-1")))
+		require.NoError(t, err)
+
+		forged := accessClaimsFor("user-1")
+		for range 3 {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, forged)
+			token.Header["kid"] = "unknown"
+			signed, signErr := token.SignedString(iss.keys["key-2"])
+			require.NoError(t, signErr)
+
+			_, err = v.Verify(context.Background(), signed)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		}
+
+		assert.Equal(t, int32(1), iss.fetches.Load())
+	})
+
+	t.Run("should keep using cached keys when the JWKS is unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		v := iss.verifier(t, func(c *Config) { c.CacheTTL = time.Nanosecond; c.MinRefreshInterval = time.Nanosecond })
+		token := iss.sign(t, "key-1", accessClaimsFor("user-1"))
+		_, err := v.Verify(context.Background(), token)
+		require.NoError(t, err)
+
+		iss.down.Store(true)
+		_, err = v.Verify(context.Background(), token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), iss.fetches.Load())
+	})
+
+	t.Run("should share one fetch between concurrent verifications", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		iss.delay.Store(int64(50 * time.Millisecond))
+		v := iss.verifier(t)
+		token := iss.sign(t, "key-1", accessClaimsFor("user-1"))
+
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Go(func() {
+				_, err := v.Verify(context.Background(), token)
+				assert.NoError(t, err)
+			})
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), iss.fetches.Load())
+	})
+
+	t.Run("should not fail other verifications when a caller gives up", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		iss.delay.Store(int64(100 * time.Millisecond))
+		v := iss.verifier(t)
+		token := iss.sign(t, "key-1", accessClaimsFor("user-1"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := v.Verify(ctx, token)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidToken)
+
+		_, err = v.Verify(context.Background(), token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), iss.fetches.Load())
+	})
+
+	t.Run("should not report an unreachable JWKS as an invalid token", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		iss.down.Store(true)
+		v := iss.verifier(t)
+
+		_, err := v.Verify(context.Background(), iss.sign(t, "key-1", accessClaimsFor("user-1")))
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestIntrospection(t *testing.T) {
+	newIntrospection := func(t *testing.T, active bool) (*httptest.Server, *atomic.Value) {
+		t.Helper()
+		var seen atomic.Value
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientID, secret, _ := r.BasicAuth()
+			seen.Store([]string{clientID, secret, r.PostFormValue("token"), r.PostFormValue("token_type_hint")})
+			_ = json.NewEncoder(w).Encode(map[string]bool{"active": active})
+		}))
+		t.Cleanup(server.Close)
+		return server, &seen
+	}
+
+	t.Run("should accept tokens the endpoint reports as active", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		server, seen := newIntrospection(t, true)
+		v := iss.verifier(t, func(c *Config) {
+			c.Introspection = &IntrospectionConfig{URL: server.URL, ClientID: "orders", ClientSecret: "s3cret"}
+		})
+		token := iss.sign(t, "key-1", accessClaimsFor("user-1"))
+
+		_, err := v.Verify(context.Background(), token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"orders", "s3cret", token, "access_token"}, seen.Load())
+	})
+
+	t.Run("should reject tokens the endpoint reports as inactive", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		server, _ := newIntrospection(t, false)
+		v := iss.verifier(t, func(c *Config) { c.Introspection = &IntrospectionConfig{URL: server.URL} })
+
+		_, err := v.Verify(context.Background(), iss.sign(t, "key-1", accessClaimsFor("user-1")))
+
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := FromContext(r.Context())
+		_, _ = w.Write([]byte(claims.UserID))
+	})
+
+	t.Run("should store the claims of a valid bearer token", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Authorization", "Bearer "+iss.sign(t, "key-1", accessClaimsFor("user-1")))
+		rec := httptest.NewRecorder()
+
+		iss.verifier(t).Middleware(ok).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "user-1", rec.Body.String())
+	})
+
+	t.Run("should fall back to the configured cookie", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.AddCookie(&http.Cookie{Name: "access_token", Value: iss.sign(t, "key-1", accessClaimsFor("user-1"))})
+		rec := httptest.NewRecorder()
+
+		iss.verifier(t, func(c *Config) { c.CookieName = "access_token" }).Middleware(ok).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("should answer 401 with a challenge", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		v := iss.verifier(t)
+
+		rec := httptest.NewRecorder()
+		v.Middleware(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		rec = httptest.NewRecorder()
+		v.Middleware(ok).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("should answer 503 when the keys cannot be fetched", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		iss.down.Store(true)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Authorization", "Bearer "+iss.sign(t, "key-1", accessClaimsFor("user-1")))
+		rec := httptest.NewRecorder()
+
+		iss.verifier(t).Middleware(ok).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
+
+func TestEchoMiddleware(t *testing.T) {
+	t.Run("should store the claims of a valid token", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Authorization", "Bearer "+iss.sign(t, "key-1", accessClaimsFor("user-1")))
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+
+		var userID string
+		err := iss.verifier(t).EchoMiddleware()(func(c echo.Context) error {
+			claims, _ := FromContext(c.Request().Context())
+			userID = claims.UserID
+			return nil
+		})(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", userID)
+	})
+
+	t.Run("should return a 401 HTTPError for an invalid token", func(t *testing.T) {
+		t.Parallel()
+
+		iss := newIssuer(t)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+
+		err := iss.verifier(t).EchoMiddleware()(func(echo.Context) error { return nil })(c)
+
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		assert.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get("WWW-Authenticate"))
+	})
+}
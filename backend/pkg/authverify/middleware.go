@@ -0,0 +1,109 @@
+package authverify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying claims.
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// FromContext returns the claims stored by the middleware.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok
+}
+
+// Middleware rejects requests without a valid access token and stores the
+// claims of accepted ones in the request context. Rejections are answered
+// with 401 and an RFC 7807 body; failures to reach the auth service with 503.
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := v.authenticate(r)
+		if err != nil {
+			status, challenge := classify(err)
+			if challenge != "" {
+				w.Header().Set("WWW-Authenticate", challenge)
+			}
+			writeProblem(w, status)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), claims)))
+	})
+}
+
+// EchoMiddleware is Middleware for Echo. Rejections are returned as
+// *echo.HTTPError so the service's own error handler renders them.
+func (v *Verifier) EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, err := v.authenticate(c.Request())
+			if err != nil {
+				status, challenge := classify(err)
+				if challenge != "" {
+					c.Response().Header().Set("WWW-Authenticate", challenge)
+				}
+				return echo.NewHTTPError(status, http.StatusText(status)).SetInternal(err)
+			}
+			c.SetRequest(c.Request().WithContext(NewContext(c.Request().Context(), claims)))
+			return next(c)
+		}
+	}
+}
+
+func (v *Verifier) authenticate(r *http.Request) (*Claims, error) {
+	token := bearerToken(r)
+	if token == "" && v.cfg.CookieName != "" {
+		if cookie, err := r.Cookie(v.cfg.CookieName); err == nil {
+			token = cookie.Value
+		}
+	}
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+	return v.Verify(r.Context(), token)
+}
+
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// classify maps a verification error to a status and RFC 6750 challenge.
+func classify(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrMissingToken):
+		return http.StatusUnauthorized, "Bearer"
+	case errors.Is(err, ErrInvalidToken), errors.Is(err, ErrTokenRevoked):
+		return http.StatusUnauthorized, `Bearer error="invalid_token"`
+	default:
+		return http.StatusServiceUnavailable, ""
+	}
+}
+
+func writeProblem(w http.ResponseWriter, status int) {
+	problem := struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+	}{"about:blank", http.StatusText(status), status}
+	if status == http.StatusUnauthorized {
+		problem.Type = "urn:auth-session-api/auth/unauthorized"
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
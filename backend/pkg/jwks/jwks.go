@@ -0,0 +1,256 @@
+// Package jwks fetches and caches the RSA signing keys of a JSON Web Key
+// Set (RFC 7517). It backs pkg/authverify and the verification of ID
+// tokens from social login providers, and imports nothing from internal/.
+package jwks
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheTTL           = time.Hour
+	defaultMinRefreshInterval = time.Minute
+	defaultTimeout            = 10 * time.Second
+	maxSetSize                = 1 << 20
+)
+
+// FetchError means the keys could not be loaded, as opposed to the token
+// naming a key the set does not have.
+type FetchError struct {
+	Err error
+}
+
+func (e *FetchError) Error() string {
+	return "failed to fetch JWKS: " + e.Err.Error()
+}
+
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
+// Config configures a KeySet. Zero values take the defaults.
+type Config struct {
+	// HTTPClient fetches the set. Defaults to http.DefaultClient; each
+	// fetch is bounded by Timeout either way.
+	HTTPClient *http.Client
+	// CacheTTL is how long fetched keys are used before being refetched.
+	// Defaults to one hour.
+	CacheTTL time.Duration
+	// MinRefreshInterval limits refetches triggered by unknown key IDs, so
+	// forged tokens cannot make every request hit the JWKS endpoint.
+	// Defaults to one minute.
+	MinRefreshInterval time.Duration
+	// Timeout bounds a fetch. Defaults to 10s.
+	Timeout time.Duration
+}
+
+// JWK is an RSA public key in JSON Web Key form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// KeySet caches the RSA keys of a JWKS by key ID. It is safe for
+// concurrent use.
+type KeySet struct {
+	httpClient *http.Client
+	url        string
+	ttl        time.Duration
+	minRefresh time.Duration
+	timeout    time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	lastErr     error
+	// refreshing is closed when the fetch in flight ends. Callers needing
+	// new keys wait on it instead of fetching again.
+	refreshing chan struct{}
+}
+
+// New returns a KeySet for the JWKS at url. Keys are fetched on first use.
+func New(url string, cfg Config) *KeySet {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = defaultCacheTTL
+	}
+	if cfg.MinRefreshInterval <= 0 {
+		cfg.MinRefreshInterval = defaultMinRefreshInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &KeySet{
+		httpClient: cfg.HTTPClient,
+		url:        url,
+		ttl:        cfg.CacheTTL,
+		minRefresh: cfg.MinRefreshInterval,
+		timeout:    cfg.Timeout,
+	}
+}
+
+// Key returns the key with the given ID, refetching the set when it is
+// older than the TTL or does not contain kid. When a refetch fails, keys
+// already cached keep being used.
+//
+// The fetch runs without the lock and outside the context of the caller,
+// so one request giving up doesn't fail it for the others waiting on it.
+func (s *KeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	key, ok := s.lookup(kid)
+	if ok && time.Since(s.fetchedAt) < s.ttl {
+		s.mu.Unlock()
+		return key, nil
+	}
+	done := s.refreshing
+	if done == nil {
+		if time.Since(s.attemptedAt) < s.minRefresh {
+			err := s.lastErr
+			s.mu.Unlock()
+			if ok {
+				return key, nil
+			}
+			if err != nil {
+				return nil, &FetchError{Err: err}
+			}
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		done = make(chan struct{})
+		s.refreshing = done
+		s.attemptedAt = time.Now()
+		go s.refresh(context.WithoutCancel(ctx), done)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		if ok {
+			return key, nil
+		}
+		return nil, &FetchError{Err: ctx.Err()}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	if s.lastErr != nil {
+		return nil, &FetchError{Err: s.lastErr}
+	}
+	return nil, fmt.Errorf("unknown key ID %q", kid)
+}
+
+// refresh fetches the set and closes done. A failed fetch keeps the cached
+// keys; its error is kept for the callers throttled by minRefresh, unless
+// the fetch was cancelled rather than failing.
+func (s *KeySet) refresh(ctx context.Context, done chan struct{}) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	keys, err := s.fetch(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer close(done)
+	s.refreshing = nil
+
+	if errors.Is(err, context.Canceled) {
+		s.attemptedAt = time.Time{}
+		return
+	}
+	s.lastErr = err
+	if err == nil {
+		s.keys = keys
+		s.fetchedAt = time.Now()
+	}
+}
+
+// lookup finds kid in the cached set. Tokens without a key ID are accepted
+// only while the set has a single key.
+func (s *KeySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *KeySet) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // nothing to do about a failed close
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []JWK `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSetSize)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		key, err := k.RSAPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS has no RSA signing keys")
+	}
+
+	return keys, nil
+}
+
+// RSAPublicKey decodes the modulus and exponent of k.
+func (k JWK) RSAPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("unsupported exponent")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
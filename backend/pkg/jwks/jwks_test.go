@@ -0,0 +1,71 @@
+package jwks
+
+import (
+	"context"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func TestJWKRSAPublicKey(t *testing.T) {
+	t.Run("should decode the modulus and exponent", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := JWK{Kty: "RSA", N: encode([]byte{0xc3, 0x01}), E: "AQAB"}.RSAPublicKey()
+
+		require.NoError(t, err)
+		assert.Equal(t, 65537, key.E)
+		assert.Equal(t, big.NewInt(0xc301), key.N)
+	})
+
+	t.Run("should reject exponents that don't fit an int", func(t *testing.T) {
+		t.Parallel()
+
+		for name, e := range map[string][]byte{
+			"too small": {1},
+			"too large": new(big.Int).Lsh(big.NewInt(1), 31).Bytes(),
+			"truncated": new(big.Int).Lsh(big.NewInt(1), 64).Bytes(),
+		} {
+			_, err := JWK{Kty: "RSA", N: "wwE", E: encode(e)}.RSAPublicKey()
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestKeySetKey(t *testing.T) {
+	t.Run("should not cache a failure for callers that gave up", func(t *testing.T) {
+		t.Parallel()
+
+		var fetches atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			fetches.Add(1)
+			_, _ = w.Write([]byte(`{"keys":[{"kty":"RSA","kid":"k1","n":"wwE","e":"AQAB"}]}`))
+		}))
+		t.Cleanup(server.Close)
+		s := New(server.URL, Config{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := s.Key(ctx, "k1")
+
+		var fetchErr *FetchError
+		require.ErrorAs(t, err, &fetchErr)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		key, err := s.Key(context.Background(), "k1")
+
+		require.NoError(t, err)
+		assert.Equal(t, 65537, key.E)
+		assert.Equal(t, int32(1), fetches.Load())
+	})
+}
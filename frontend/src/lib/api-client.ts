@@ -18,6 +18,13 @@ interface RequestOptions {
 
 const fetchWithCookies = createFetchWithCookies(env.API_URL);
 
+/**
+ * Token CSRF da sessão, repetido pelo backend no header X-CSRF-Token sempre
+ * que define os cookies. Requisições autenticadas que alteram estado o
+ * devolvem no mesmo header.
+ */
+let csrfToken: string | null = null;
+
 function parseCookieAttributes(setCookieValue: string) {
   const parts = setCookieValue.split(';').map((p) => p.trim());
   const [nameValue, ...attrs] = parts;
@@ -100,6 +107,10 @@ async function request<T>(method: Method, path: string, options?: RequestOptions
     headers['Content-Type'] = 'application/x-www-form-urlencoded';
   }
 
+  if (options?.authenticated && method !== 'GET' && csrfToken) {
+    headers['X-CSRF-Token'] = csrfToken;
+  }
+
   const fetchFn = options?.authenticated ? fetchWithCookies : fetch;
 
   const fetchOptions: RequestInit = { method, headers };
@@ -111,6 +122,7 @@ async function request<T>(method: Method, path: string, options?: RequestOptions
   const response = await fetchFn(`${env.API_URL}${path}`, fetchOptions);
 
   await saveCookiesFromResponse(response);
+  csrfToken = response.headers.get('x-csrf-token') ?? csrfToken;
 
   if (!response.ok) {
     const problem: ProblemDetails = await response.json();